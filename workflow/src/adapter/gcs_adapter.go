@@ -84,13 +84,18 @@ func (g *GCSAdapter) Upload(ctx context.Context, key string, reader io.Reader, s
 	}
 
 	// Generate a signed URL valid for 24 hours
+	return g.PresignedURL(ctx, key, time.Duration(constants.StorageURLExpiration)*time.Hour)
+}
+
+// PresignedURL generates a V4 signed GET URL for a file in GCS
+func (g *GCSAdapter) PresignedURL(_ context.Context, key string, expiry time.Duration) (string, error) {
 	signOpts := &storage.SignedURLOptions{
 		Method:  constants.HTTPMethodGET,
-		Expires: time.Now().Add(time.Duration(constants.StorageURLExpiration) * time.Hour),
+		Expires: time.Now().Add(expiry),
 		Scheme:  storage.SigningSchemeV4,
 	}
 
-	url, err := bucket.SignedURL(key, signOpts)
+	url, err := g.client.Bucket(g.bucket).SignedURL(key, signOpts)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", constants.ErrFailedToGenerateSignedURL, err)
 	}
@@ -98,6 +103,16 @@ func (g *GCSAdapter) Upload(ctx context.Context, key string, reader io.Reader, s
 	return url, nil
 }
 
+// Download opens a file in GCS for reading
+func (g *GCSAdapter) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := g.client.Bucket(g.bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", constants.ErrFailedToReadFileFromGCS, err)
+	}
+
+	return reader, nil
+}
+
 // Delete removes a file from GCS
 func (g *GCSAdapter) Delete(ctx context.Context, key string) error {
 	bucket := g.client.Bucket(g.bucket)
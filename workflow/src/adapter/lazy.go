@@ -0,0 +1,35 @@
+package adapter
+
+import (
+	"app/src/constants"
+	"fmt"
+	"sync"
+)
+
+// LazyStorage creates the configured storage provider on first use and shares it between
+// services, so the application starts even when the storage backend is unavailable
+type LazyStorage struct {
+	factory  *StorageFactory
+	once     sync.Once
+	provider StorageProvider
+	err      error
+}
+
+// NewLazyStorage creates a lazy storage provider backed by the given factory
+func NewLazyStorage(factory *StorageFactory) *LazyStorage {
+	return &LazyStorage{
+		factory: factory,
+	}
+}
+
+// Provider returns the storage provider, creating it on the first call. A creation error is
+// returned on every call.
+func (l *LazyStorage) Provider() (StorageProvider, error) {
+	l.once.Do(func() {
+		l.provider, l.err = l.factory.NewProvider()
+		if l.err != nil {
+			l.err = fmt.Errorf("%s: %w", constants.ErrFailedToCreateStorageProvider, l.err)
+		}
+	})
+	return l.provider, l.err
+}
@@ -79,7 +79,12 @@ func (m *MinIOAdapter) Upload(ctx context.Context, key string, reader io.Reader,
 	}
 
 	// Generate a pre-signed URL valid for 24 hours
-	url, err := m.client.PresignedGetObject(ctx, m.bucket, key, time.Duration(constants.StorageURLExpiration)*time.Hour, nil)
+	return m.PresignedURL(ctx, key, time.Duration(constants.StorageURLExpiration)*time.Hour)
+}
+
+// PresignedURL generates a pre-signed GET URL for a file in MinIO
+func (m *MinIOAdapter) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	url, err := m.client.PresignedGetObject(ctx, m.bucket, key, expiry, nil)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", constants.ErrFailedToGeneratePreSignedURL, err)
 	}
@@ -87,6 +92,22 @@ func (m *MinIOAdapter) Upload(ctx context.Context, key string, reader io.Reader,
 	return url.String(), nil
 }
 
+// Download opens a file in MinIO for reading
+func (m *MinIOAdapter) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := m.client.GetObject(ctx, m.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", constants.ErrFailedToDownloadFromMinIO, err)
+	}
+
+	// GetObject is lazy; stat the object so a missing key fails here rather than on the first read
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, fmt.Errorf("%s: %w", constants.ErrFailedToDownloadFromMinIO, err)
+	}
+
+	return object, nil
+}
+
 // Delete removes a file from MinIO
 func (m *MinIOAdapter) Delete(ctx context.Context, key string) error {
 	err := m.client.RemoveObject(ctx, m.bucket, key, minio.RemoveObjectOptions{})
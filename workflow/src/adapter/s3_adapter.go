@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -79,11 +80,16 @@ func (s *S3Adapter) Upload(ctx context.Context, key string, reader io.Reader, si
 	}
 
 	// Generate a pre-signed URL for accessing the file
+	return s.PresignedURL(ctx, key, time.Duration(constants.StorageURLExpiration)*time.Hour)
+}
+
+// PresignedURL generates a pre-signed GET URL for a file in S3
+func (s *S3Adapter) PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
 	presignClient := s3.NewPresignClient(s.client)
 	request, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(key),
-	})
+	}, s3.WithPresignExpires(expiry))
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", constants.ErrFailedToGeneratePreSignedURL, err)
 	}
@@ -91,6 +97,19 @@ func (s *S3Adapter) Upload(ctx context.Context, key string, reader io.Reader, si
 	return request.URL, nil
 }
 
+// Download opens a file in S3 for reading
+func (s *S3Adapter) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", constants.ErrFailedToDownloadFileFromS3, err)
+	}
+
+	return output.Body, nil
+}
+
 // Delete removes a file from S3
 func (s *S3Adapter) Delete(ctx context.Context, key string) error {
 	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -0,0 +1,29 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SMSSender defines the interface for delivering text messages to phone numbers
+type SMSSender interface {
+	// Send delivers a text message to a phone number in E.164 format
+	Send(ctx context.Context, to, message string) error
+}
+
+// LogSMSSender writes messages to the application log instead of delivering them. It is meant for
+// development, where the verification codes can be read from the log.
+type LogSMSSender struct {
+	log *logrus.Logger
+}
+
+// NewLogSMSSender creates an SMS sender that only logs messages
+func NewLogSMSSender(log *logrus.Logger) *LogSMSSender {
+	return &LogSMSSender{log: log}
+}
+
+func (s *LogSMSSender) Send(_ context.Context, to, message string) error {
+	s.log.WithField("to", to).Infof("SMS: %s", message)
+	return nil
+}
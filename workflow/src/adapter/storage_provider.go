@@ -3,6 +3,7 @@ package adapter
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // UploadOptions contains optional parameters for file upload
@@ -17,9 +18,15 @@ type StorageProvider interface {
 	// Returns the accessible URL (pre-signed for private storage) and any error
 	Upload(ctx context.Context, key string, reader io.Reader, size int64, opts *UploadOptions) (string, error)
 
+	// Download opens a stored file for reading. The caller must close the returned reader.
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+
 	// Delete removes a file from storage
 	Delete(ctx context.Context, key string) error
 
 	// Exists checks if a file exists in storage
 	Exists(ctx context.Context, key string) (bool, error)
+
+	// PresignedURL returns a time-limited URL for downloading a stored file
+	PresignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
 }
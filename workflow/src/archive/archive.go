@@ -0,0 +1,190 @@
+// Package archive reads and writes zip archives whose contents are described by a manifest of
+// SHA-256 hashes. The manifest itself is stored in the archive by the caller, which signs it.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalid is returned for archives that cannot be read or break the configured limits
+	ErrInvalid = errors.New("invalid archive")
+
+	// ErrIntegrity is returned when the archive contents do not match the manifest
+	ErrIntegrity = errors.New("archive contents do not match manifest")
+)
+
+// Entry describes one file listed in a manifest
+type Entry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Writer writes a zip archive, recording an entry for every file added with Add
+type Writer struct {
+	zw       *zip.Writer
+	modified time.Time
+	entries  []Entry
+}
+
+// NewWriter creates a writer whose files carry the given modification time
+func NewWriter(w io.Writer, modified time.Time) *Writer {
+	return &Writer{zw: zip.NewWriter(w), modified: modified}
+}
+
+// Add writes a file and records its hash for the manifest
+func (w *Writer) Add(name string, data []byte) error {
+	if err := w.write(name, data); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	w.entries = append(w.entries, Entry{Path: name, SHA256: hex.EncodeToString(sum[:]), Size: int64(len(data))})
+	return nil
+}
+
+// AddUnlisted writes a file that is not recorded in the manifest, such as the manifest itself
+func (w *Writer) AddUnlisted(name string, data []byte) error {
+	return w.write(name, data)
+}
+
+// Entries returns the entries of the files added so far
+func (w *Writer) Entries() []Entry {
+	return w.entries
+}
+
+// Close finishes the archive
+func (w *Writer) Close() error {
+	return w.zw.Close()
+}
+
+func (w *Writer) write(name string, data []byte) error {
+	file, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: w.modified})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// Limits bound the resources spent reading an untrusted archive
+type Limits struct {
+	MaxFiles int
+	MaxBytes int64
+}
+
+// Archive is a zip archive read fully into memory
+type Archive struct {
+	files map[string][]byte
+}
+
+// Read decompresses every file of a zip archive, rejecting unsafe or duplicate paths and
+// archives exceeding the limits. Declared sizes are not trusted; decompression stops at the limit.
+func Read(data []byte, limits Limits) (*Archive, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(zr.File) > limits.MaxFiles {
+		return nil, fmt.Errorf("%w: more than %d files", ErrInvalid, limits.MaxFiles)
+	}
+
+	archive := &Archive{files: make(map[string][]byte, len(zr.File))}
+	remaining := limits.MaxBytes
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if !safePath(file.Name) {
+			return nil, fmt.Errorf("%w: unsafe path %q", ErrInvalid, file.Name)
+		}
+		if _, ok := archive.files[file.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate path %q", ErrInvalid, file.Name)
+		}
+
+		content, err := readFile(file, remaining)
+		if err != nil {
+			return nil, err
+		}
+		remaining -= int64(len(content))
+		archive.files[file.Name] = content
+	}
+
+	return archive, nil
+}
+
+// readFile decompresses one file, failing once more than limit bytes have been read
+func readFile(file *zip.File, limit int64) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalid, file.Name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalid, file.Name, err)
+	}
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: uncompressed size exceeds limit", ErrInvalid)
+	}
+	return content, nil
+}
+
+// safePath reports whether name is a clean relative path that cannot escape the archive root
+func safePath(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, `\`) {
+		return false
+	}
+	return path.Clean(name) == name && name != ".." && !strings.HasPrefix(name, "../")
+}
+
+// File returns the content of a file and whether it exists
+func (a *Archive) File(name string) ([]byte, bool) {
+	content, ok := a.files[name]
+	return content, ok
+}
+
+// Verify checks that the archive holds exactly the listed entries plus the unlisted files
+// named by the caller, and that every entry matches its recorded size and hash
+func (a *Archive) Verify(entries []Entry, unlisted ...string) error {
+	expected := make(map[string]bool, len(entries)+len(unlisted))
+	for _, name := range unlisted {
+		expected[name] = true
+	}
+
+	for _, entry := range entries {
+		if expected[entry.Path] {
+			return fmt.Errorf("%w: %s listed twice", ErrIntegrity, entry.Path)
+		}
+		expected[entry.Path] = true
+
+		content, ok := a.files[entry.Path]
+		if !ok {
+			return fmt.Errorf("%w: %s is missing", ErrIntegrity, entry.Path)
+		}
+		sum := sha256.Sum256(content)
+		if int64(len(content)) != entry.Size || !strings.EqualFold(hex.EncodeToString(sum[:]), entry.SHA256) {
+			return fmt.Errorf("%w: %s was modified", ErrIntegrity, entry.Path)
+		}
+	}
+
+	for name := range a.files {
+		if !expected[name] {
+			return fmt.Errorf("%w: %s is not listed", ErrIntegrity, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Command backfill-identifiers canonicalizes the universal identifiers stored before identifier
+// namespaces were introduced: each is case folded, qualified with the configured default domain
+// and given its confusable skeleton. Once every identifier has a skeleton of its own, identifiers
+// are made unique by it. It is safe to run repeatedly; identifiers that are up to date are left alone.
+package main
+
+import (
+	"app/src/container"
+	"app/src/service"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the identifiers that would be converted without updating them")
+	flag.Parse()
+
+	c, err := container.NewContainer()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create container: %v\n", err)
+		os.Exit(1)
+	}
+
+	err = c.Invoke(func(log *logrus.Logger, namespaceService service.NamespaceService) error {
+		result, err := namespaceService.BackfillIdentifiers(context.Background(), *dryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, failure := range result.Failed {
+			log.Warnf("Identifier %q was left as it is: %s", failure.Identifier, failure.Reason)
+		}
+		log.Infof("Identifier backfill finished (dry run: %t): %d scanned, %d converted, %d unchanged, %d failed",
+			*dryRun, result.Scanned, result.Converted, result.Unchanged, len(result.Failed))
+		if !result.Enforced && !*dryRun {
+			log.Warn("Identifiers are not unique by skeleton yet; rename the failed identifiers and run the backfill again")
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Identifier backfill failed: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,44 @@
+// Command backfill-master-keys normalizes the master public keys of existing actors: it stores
+// each key in canonical PEM form with its JWK thumbprint and replaces the DID with the did:key
+// derived from the key. It is safe to run repeatedly; actors that are up to date are left alone.
+package main
+
+import (
+	"app/src/container"
+	"app/src/service"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the actors that would be converted without updating them")
+	flag.Parse()
+
+	c, err := container.NewContainer()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create container: %v\n", err)
+		os.Exit(1)
+	}
+
+	err = c.Invoke(func(log *logrus.Logger, actorService service.ActorService) error {
+		result, err := actorService.BackfillMasterKeys(context.Background(), *dryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, failure := range result.Failed {
+			log.Warnf("Actor %s kept its master key: %s", failure.ActorID, failure.Reason)
+		}
+		log.Infof("Master key backfill finished (dry run: %t): %d scanned, %d converted, %d unchanged, %d failed",
+			*dryRun, result.Scanned, result.Converted, result.Unchanged, len(result.Failed))
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Master key backfill failed: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"app/src/adapter"
 	"app/src/constants"
+	"app/src/namespace"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -25,6 +28,27 @@ type Config struct {
 	AuthSecret        string
 	AuthAdminUser     string
 	AuthAdminPassword string
+	AuthAdminRole     string
+	TrustPolicy       string
+	TrustListKeyFile  string
+	RulesFile         string
+	IssuerURL         string
+	IssuerKeyFile     string
+	IssuerName        string
+	JobWorkers        int
+	JobMaxAttempts    int
+	OrphanedDocuments string
+	KeyRecoveryDelay  int
+	ReleaseCoolingOff int
+	IdentifierDomain  string
+	IdentifierPattern string
+	ResolveCacheSize  int
+	ResolveCacheTTL   int
+	ErasureGraceDays  int
+	ExportKeyFile     string
+	ExportLinkTTL     int
+	ExportRetention   int
+	SMSProvider       string
 	StorageConfig     adapter.StorageConfig
 }
 
@@ -49,9 +73,34 @@ func NewConfig() (*Config, error) {
 		AuthSecret:        viper.GetString(constants.EnvKeycloakClientSecret),
 		AuthAdminUser:     viper.GetString(constants.EnvKeycloakAdminUser),
 		AuthAdminPassword: viper.GetString(constants.EnvKeycloakAdminPassword),
+		AuthAdminRole:     viper.GetString(constants.EnvKeycloakAdminRole),
+		TrustPolicy:       viper.GetString(constants.EnvTrustPolicy),
+		TrustListKeyFile:  viper.GetString(constants.EnvTrustListKeyFile),
+		RulesFile:         viper.GetString(constants.EnvVerificationRulesFile),
+		IssuerURL:         strings.TrimSuffix(viper.GetString(constants.EnvIssuerURL), "/"),
+		IssuerKeyFile:     viper.GetString(constants.EnvIssuerSigningKeyFile),
+		IssuerName:        viper.GetString(constants.EnvIssuerDisplayName),
+		JobWorkers:        viper.GetInt(constants.EnvJobWorkers),
+		JobMaxAttempts:    viper.GetInt(constants.EnvJobMaxAttempts),
+		OrphanedDocuments: viper.GetString(constants.EnvOrphanedDocumentPolicy),
+		KeyRecoveryDelay:  viper.GetInt(constants.EnvKeyRecoveryDelay),
+		ReleaseCoolingOff: viper.GetInt(constants.EnvIdentifierCoolingOff),
+		IdentifierDomain:  viper.GetString(constants.EnvIdentifierDomain),
+		IdentifierPattern: viper.GetString(constants.EnvIdentifierPattern),
+		ResolveCacheSize:  viper.GetInt(constants.EnvResolveCacheSize),
+		ResolveCacheTTL:   viper.GetInt(constants.EnvResolveCacheTTL),
+		ErasureGraceDays:  viper.GetInt(constants.EnvErasureGraceDays),
+		ExportKeyFile:     viper.GetString(constants.EnvExportKeyFile),
+		ExportLinkTTL:     viper.GetInt(constants.EnvExportLinkTTL),
+		ExportRetention:   viper.GetInt(constants.EnvExportRetention),
+		SMSProvider:       viper.GetString(constants.EnvSMSProvider),
 		StorageConfig:     loadStorageConfig(),
 	}
 
+	if cfg.IssuerURL == "" {
+		cfg.IssuerURL = fmt.Sprintf("http://localhost:%d", cfg.AppPort)
+	}
+
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
@@ -63,6 +112,23 @@ func loadConfig() error {
 	// Enable automatic environment variable reading
 	// This reads all environment variables automatically, so no config file is needed
 	viper.AutomaticEnv()
+
+	viper.SetDefault(constants.EnvKeycloakAdminRole, constants.DefaultAdminRole)
+	viper.SetDefault(constants.EnvTrustPolicy, constants.TrustPolicyFlag)
+	viper.SetDefault(constants.EnvIssuerDisplayName, constants.DefaultIssuerDisplayName)
+	viper.SetDefault(constants.EnvJobWorkers, constants.DefaultJobWorkers)
+	viper.SetDefault(constants.EnvJobMaxAttempts, constants.DefaultJobMaxAttempts)
+	viper.SetDefault(constants.EnvOrphanedDocumentPolicy, constants.OrphanedDocumentPolicyRetain)
+	viper.SetDefault(constants.EnvKeyRecoveryDelay, constants.DefaultKeyRecoveryDelay)
+	viper.SetDefault(constants.EnvIdentifierCoolingOff, constants.DefaultIdentifierCoolingOffDays)
+	viper.SetDefault(constants.EnvIdentifierDomain, constants.DefaultIdentifierDomain)
+	viper.SetDefault(constants.EnvIdentifierPattern, constants.DefaultIdentifierPattern)
+	viper.SetDefault(constants.EnvResolveCacheSize, constants.DefaultResolveCacheSize)
+	viper.SetDefault(constants.EnvResolveCacheTTL, constants.DefaultResolveCacheTTL)
+	viper.SetDefault(constants.EnvErasureGraceDays, constants.DefaultErasureGraceDays)
+	viper.SetDefault(constants.EnvExportLinkTTL, constants.DefaultExportLinkTTL)
+	viper.SetDefault(constants.EnvExportRetention, constants.DefaultExportRetention)
+	viper.SetDefault(constants.EnvSMSProvider, constants.SMSProviderLog)
 	return nil
 }
 
@@ -92,6 +158,70 @@ func (c *Config) validate() error {
 		return fmt.Errorf("missing required configuration: %s", strings.Join(missing, ", "))
 	}
 
+	if c.TrustPolicy != constants.TrustPolicyReject && c.TrustPolicy != constants.TrustPolicyFlag {
+		return fmt.Errorf("invalid %s: must be %s or %s", constants.EnvTrustPolicy, constants.TrustPolicyReject, constants.TrustPolicyFlag)
+	}
+
+	if issuerURL, err := url.Parse(c.IssuerURL); err != nil || issuerURL.Host == "" ||
+		(issuerURL.Scheme != "https" && issuerURL.Scheme != "http") {
+		return fmt.Errorf("invalid %s: must be an absolute http(s) URL", constants.EnvIssuerURL)
+	}
+
+	if c.JobWorkers < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", constants.EnvJobWorkers)
+	}
+
+	if c.JobMaxAttempts < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", constants.EnvJobMaxAttempts)
+	}
+
+	if c.OrphanedDocuments != constants.OrphanedDocumentPolicyRetain && c.OrphanedDocuments != constants.OrphanedDocumentPolicyDelete {
+		return fmt.Errorf("invalid %s: must be %s or %s", constants.EnvOrphanedDocumentPolicy,
+			constants.OrphanedDocumentPolicyRetain, constants.OrphanedDocumentPolicyDelete)
+	}
+
+	if c.KeyRecoveryDelay < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", constants.EnvKeyRecoveryDelay)
+	}
+
+	if c.ReleaseCoolingOff < 0 {
+		return fmt.Errorf("invalid %s: must not be negative", constants.EnvIdentifierCoolingOff)
+	}
+
+	if !namespace.ValidDomain(c.IdentifierDomain) {
+		return fmt.Errorf("invalid %s: must be a lowercase DNS-style name", constants.EnvIdentifierDomain)
+	}
+
+	if _, err := regexp.Compile(c.IdentifierPattern); err != nil {
+		return fmt.Errorf("invalid %s: %w", constants.EnvIdentifierPattern, err)
+	}
+
+	if c.ResolveCacheSize < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", constants.EnvResolveCacheSize)
+	}
+
+	// Cached resolutions are served with the proof signed when they were cached, which must
+	// still be valid when the entry expires
+	if c.ResolveCacheTTL < 1 || c.ResolveCacheTTL > constants.ResolutionProofValidity {
+		return fmt.Errorf("invalid %s: must be between 1 and %d", constants.EnvResolveCacheTTL, constants.ResolutionProofValidity)
+	}
+
+	if c.ErasureGraceDays < 0 {
+		return fmt.Errorf("invalid %s: must not be negative", constants.EnvErasureGraceDays)
+	}
+
+	if c.ExportLinkTTL < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", constants.EnvExportLinkTTL)
+	}
+
+	if c.ExportRetention < 1 {
+		return fmt.Errorf("invalid %s: must be at least 1", constants.EnvExportRetention)
+	}
+
+	if c.SMSProvider != constants.SMSProviderLog {
+		return fmt.Errorf("invalid %s: must be %s", constants.EnvSMSProvider, constants.SMSProviderLog)
+	}
+
 	return nil
 }
 
@@ -18,6 +18,7 @@ func FiberConfig(cfg *Config) fiber.Config {
 		ServerHeader:  constants.ServerHeaderName,
 		AppName:       constants.AppName,
 		ErrorHandler:  utils.ErrorHandler,
+		BodyLimit:     constants.RequestBodyLimit,
 		JSONEncoder:   json.Marshal,
 		JSONDecoder:   json.Unmarshal,
 	}
@@ -11,6 +11,8 @@ const (
 	ErrActorNotFound                             = "Actor not found"
 	ErrEmailAlreadyInUse                         = "Email is already in use"
 	ErrMasterPublicKeyAlreadyInUse               = "Master public key is already in use"
+	ErrInvalidMasterPublicKey                    = "masterPublicKey must be a PEM, JWK or multibase RSA, P-256, secp256k1 or Ed25519 public key"
+	ErrWeakMasterPublicKey                       = "masterPublicKey is too weak: RSA keys must be at least 2048 bits"
 	ErrPhoneNumberAlreadyInUse                   = "Phone number is already in use"
 	ErrUniversalIdentifierAlreadyInUse           = "Universal identifier is already in use"
 	ErrNationalityRequiredForIndividual          = "nationality is required for Individual entity type"
@@ -28,14 +30,111 @@ const (
 	ErrFailedToCreateStorageProvider             = "failed to initialize storage provider"
 	ErrFailedToCheckFileExistence                = "failed to check file existence"
 	ErrFailedToDeleteFile                        = "failed to delete file"
+	ErrInvalidCursor                             = "Invalid pagination cursor"
+	ErrInvalidDateRange                          = "createdFrom must be before createdTo"
+	ErrForbidden                                 = "You do not have permission to perform this action"
+	ErrTrustedIssuerNotFound                     = "Trusted issuer not found"
+	ErrTrustedIssuerAlreadyExists                = "Trusted issuer already exists"
+	ErrUntrustedIssuer                           = "Issuer is not trusted for this verification type"
+	ErrInvalidValidityWindow                     = "validFrom must be before validUntil"
+	ErrInvalidTrustList                          = "Trust list signature or content is invalid"
+	ErrTrustListKeyNotConfigured                 = "Trust list signing key is not configured"
+	ErrInvalidStatusTransition                   = "Credential cannot move to the requested status"
+	ErrInvalidCredentialJWT                      = "Credential JWT could not be verified"
+	ErrCredentialSubjectNotHolder                = "Credential subject is not the holder"
+	ErrUnsupportedCredentialConfiguration        = "Credential configuration is not supported by this issuer"
+	ErrPresentationRequestNotFound               = "Presentation request not found"
+	ErrInvalidPresentationDefinition             = "Invalid presentation definition"
+	ErrShareGrantNotFound                        = "Share grant not found"
+	ErrShareGrantAlreadyRevoked                  = "Share grant is already revoked"
+	ErrGranteeNotFound                           = "Grantee not found"
+	ErrCannotShareWithSelf                       = "Credentials cannot be shared with yourself"
+	ErrJobNotFound                               = "Job not found"
+	ErrJobLeaseExpired                           = "Job lease expired on its last attempt"
+	ErrWebhookSubscriptionNotFound               = "Webhook subscription not found"
+	ErrWebhookDeliveryNotFound                   = "Webhook delivery not found"
+	ErrInsecureWebhookURL                        = "Webhook URL must use https"
+	ErrWebhookURLNotPublic                       = "Webhook URL must resolve to a public address"
+	ErrCallbackURLNotPublic                      = "Callback URL must resolve to a public address"
+	ErrInvalidWalletArchive                      = "Invalid wallet archive"
+	ErrUnsupportedWalletArchive                  = "Unsupported wallet archive format"
+	ErrInvalidWalletArchiveSignature             = "Wallet archive signature is invalid"
+	ErrWalletArchiveIntegrity                    = "Wallet archive contents do not match its manifest"
+	ErrWalletArchiveTooLarge                     = "Wallet archive is too large"
+	ErrIssuerDIDResolutionFailed                 = "Issuer DID could not be resolved"
+	ErrInvalidShareExpiry                        = "expiresAt must be in the future and within the maximum sharing period"
+	ErrDocumentRequired                          = "At least one document is required"
+	ErrInvalidRotationProof                      = "Rotation proof must be a fresh JWT signed by the current master key for the new key"
+	ErrKeyRecoveryPending                        = "A key recovery is already pending for this actor"
+	ErrNoKeyValidAt                              = "The actor had no master key at the requested time"
+	ErrInvalidChallenge                          = "Challenge is unknown, expired or already used"
+	ErrInvalidRegistrationProof                  = "Registration proof must be a JWT signed by the master key over an issued challenge and the registration payload"
+	ErrInvalidLoginProof                         = "Login proof must be a fresh JWT signed by the actor's master key over an issued challenge"
+	ErrDIDServiceExists                          = "A service with this ID is already registered"
+	ErrDIDServiceNotFound                        = "DID service not found"
+	ErrInsecureDIDServiceEndpoint                = "Service endpoint must be an https URL"
+	ErrUniversalIdentifierCoolingOff             = "Universal identifier was recently released and cannot be claimed yet"
+	ErrIdentifierNotFound                        = "Universal identifier not found for this actor"
+	ErrPrimaryIdentifierRemoval                  = "The primary universal identifier cannot be removed; make another identifier primary first"
+	ErrTooManyIdentifiers                        = "Maximum number of universal identifiers reached"
+	ErrInvalidUniversalIdentifier                = "Universal identifier must be local@domain, with a local part of letters and digits of one script separated by '.', '-' or '_'"
+	ErrIdentifierReserved                        = "Universal identifier is reserved"
+	ErrIdentifierBlocked                         = "Universal identifier contains a blocked word"
+	ErrUnknownIdentifierDomain                   = "Universal identifier domain is not registered"
+	ErrIdentifierDomainNotOwned                  = "Universal identifier domain belongs to another organization"
+	ErrInvalidNamespaceDomain                    = "Domain must be a lowercase DNS-style name"
+	ErrNamespaceDomainExists                     = "Domain is already registered"
+	ErrNamespaceDomainNotFound                   = "Domain not found"
+	ErrReservedWordExists                        = "Word or a look-alike of it is already listed"
+	ErrReservedWordNotFound                      = "Word not found"
+	ErrAccountDeletionPending                    = "An account deletion is already pending for this actor"
+	ErrAccountDeletionNotFound                   = "Account deletion not found"
+	ErrErasureCertificateNotFound                = "No erasure certificate has been issued for this deletion"
+	ErrRetentionHoldNotFound                     = "Retention hold not found"
+	ErrInvalidRetentionHoldExpiry                = "expiresAt must be in the future"
+	ErrDataExportPending                         = "A data export is already being prepared for this actor"
+	ErrDataExportNotFound                        = "Data export not found"
+	ErrDataExportNotReady                        = "Data export is not ready for download"
+	ErrDataExportLinkInvalid                     = "Download link is invalid, expired or already used"
+	ErrEmailAlreadyVerified                      = "Email address is already verified"
+	ErrPhoneNumberNotSet                         = "No phone number is set for this actor"
+	ErrPhoneNumberAlreadyVerified                = "Phone number is already verified"
+	ErrVerificationRateLimited                   = "Too many verification messages requested; try again later"
+	ErrInvalidVerificationCode                   = "Verification code is invalid or expired"
+	ErrVerificationCodeNotFound                  = "Verification code not found"
+	ErrVerificationAttemptsExceeded              = "Too many incorrect codes; request a new code"
+	ErrNotOrganization                           = "Only Business actors can have members"
+	ErrInviteeNotIndividual                      = "Only Individual actors can be members of an organization"
+	ErrInviteeNotFound                           = "No actor holds this universal identifier"
+	ErrAlreadyOrganizationMember                 = "Actor is already a member of this organization"
+	ErrOrganizationInvitationPending             = "An invitation is already pending for this actor"
+	ErrOrganizationInvitationNotFound            = "Invitation not found"
+	ErrOrganizationInvitationClosed              = "Invitation has expired, was revoked or was already answered"
+	ErrOrganizationMemberNotFound                = "Member not found"
+	ErrOrganizationRoleNotAllowed                = "Your role in the organization does not allow managing this role"
+	ErrOrganizationRoleInsufficient              = "Your role in the organization does not allow this operation"
+	ErrLastOrganizationOwner                     = "The last owner of an organization cannot be demoted or removed"
+	ErrNotOrganizationMember                     = "You are not a member of this organization"
+	ErrInvalidOrganizationHeader                 = "X-Organization-ID must be the UUID of an organization"
+	ErrDelegationGrantNotFound                   = "Delegation grant not found"
+	ErrDelegationGrantAlreadyRevoked             = "Delegation grant is already revoked"
+	ErrDelegateNotFound                          = "Delegate not found"
+	ErrCannotDelegateToSelf                      = "Access cannot be delegated to yourself"
+	ErrInvalidDelegationWindow                   = "expiresAt must be in the future, after validFrom and within the maximum delegation period"
+	ErrInvalidOnBehalfOfHeader                   = "X-On-Behalf-Of must be the UUID of an actor"
+	ErrNoActiveDelegation                        = "No active delegation grant allows this operation for the actor"
+	ErrConflictingActingHeaders                  = "X-On-Behalf-Of and X-Organization-ID cannot be combined"
 )
 
 // Error Codes
 const (
 	ErrCodeBadRequest          = "BAD_REQUEST"
 	ErrCodeUnauthorized        = "UNAUTHORIZED"
+	ErrCodeForbidden           = "FORBIDDEN"
 	ErrCodeNotFound            = "RESOURCE_NOT_FOUND"
 	ErrCodeConflict            = "CONFLICT"
+	ErrCodeUnprocessableEntity = "UNPROCESSABLE_ENTITY"
+	ErrCodeTooManyRequests     = "TOO_MANY_REQUESTS"
 	ErrCodeInternalServerError = "INTERNAL_SERVER_ERROR"
 	ErrCodeValidationFailed    = "VALIDATION_FAILED"
 )
@@ -48,16 +147,378 @@ const (
 	StatusActive     = "active"
 )
 
+// Credential Token Status Constants
+const (
+	TokenStatusPending   = StatusPending
+	TokenStatusUntrusted = "Untrusted"
+	TokenStatusVerified  = "Verified"
+	TokenStatusRejected  = "Rejected"
+	TokenStatusRevoked   = "Revoked"
+)
+
+// Credential Evidence Document Constants
+const (
+	DocumentRoleFront          = "front"
+	DocumentRoleBack           = "back"
+	DocumentRoleSelfie         = "selfie"
+	DocumentRoleProofOfAddress = "proof_of_address"
+
+	// Handling of documents left without any credential after a credential is deleted
+	OrphanedDocumentPolicyRetain = "retain"
+	OrphanedDocumentPolicyDelete = "delete"
+)
+
+// Trusted Issuer Constants
+const (
+	TrustedIssuerStatusActive    = "active"
+	TrustedIssuerStatusSuspended = "suspended"
+	TrustedIssuerSourceManual    = "manual"
+	TrustedIssuerSourceTrustList = "trust_list"
+
+	// Trust policies applied to credentials from issuers outside the registry
+	TrustPolicyReject = "reject"
+	TrustPolicyFlag   = "flag"
+
+	TrustReasonTrusted           = "trusted"
+	TrustReasonUnknownIssuer     = "issuer_not_registered"
+	TrustReasonSuspended         = "issuer_suspended"
+	TrustReasonTypeNotAllowed    = "verification_type_not_allowed"
+	TrustReasonNotYetValid       = "issuer_not_yet_valid"
+	TrustReasonExpired           = "issuer_expired"
+	TrustReasonOutOfJurisdiction = "jurisdiction_not_allowed"
+)
+
+// Master Key Rotation Constants
+const (
+	// How a master key in the key history was endorsed
+	ActorKeyEndorsementRegistration = "registration"
+	ActorKeyEndorsementSignature    = "signature"
+	ActorKeyEndorsementRecovery     = "recovery"
+
+	KeyRecoveryStatusPending   = "pending"
+	KeyRecoveryStatusCompleted = "completed"
+	KeyRecoveryStatusCancelled = "cancelled"
+
+	KeyRotationProofJWTType = "key-rotation+jwt"
+	KeyRotationProofMaxAge  = 5  // minutes
+	DefaultKeyRecoveryDelay = 72 // hours
+)
+
+// Key Possession Challenge Constants
+const (
+	ChallengePurposeRegistration = "registration"
+	ChallengePurposeLogin        = "login"
+
+	RegistrationProofJWTType = "registration-proof+jwt"
+	LoginProofJWTType        = "login-proof+jwt"
+	ChallengeTTL             = 5  // minutes
+	ChallengeByteLength      = 32 // bytes
+)
+
+// Universal Identifier Constants
+const (
+	MaxIdentifiersPerActor          = 5
+	DefaultIdentifierCoolingOffDays = 30
+	DefaultIdentifierDomain         = "finternet"
+	DefaultIdentifierPattern        = `^[\p{L}\p{N}]([\p{L}\p{N}._-]{0,62}[\p{L}\p{N}])?$`
+	ReservedWordKindReserved        = "reserved" // the local part may not be the word
+	ReservedWordKindBlocked         = "blocked"  // the local part may not contain the word
+)
+
+// Batch Resolution Constants
+const (
+	MaxResolveBatchSize     = 100
+	ResolutionJWTType       = "resolution+jwt"
+	ResolutionProofValidity = 3600 // seconds
+	DefaultResolveCacheSize = 10000
+	DefaultResolveCacheTTL  = 300 // seconds
+)
+
+// Account Deletion Constants
+const (
+	AccountDeletionStatusPending   = "pending"
+	AccountDeletionStatusCancelled = "cancelled"
+	AccountDeletionStatusCompleted = "completed"
+
+	ErasureCertificateJWTType = "erasure-certificate+jwt"
+	ErasedEmailDomain         = "erased.invalid"
+	DefaultErasureGraceDays   = 30
+)
+
+// Data Export Constants
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+	DataExportStatusExpired = "expired"
+
+	DataExportFormat          = "finternet-data-export"
+	DataExportVersion         = 1
+	DataExportRecordsPath     = "export.json"
+	DataExportManifestPath    = "manifest.json"
+	DataExportSignaturePath   = "manifest.jwt"
+	DataExportManifestJWTType = "data-export-manifest+jwt"
+	DataExportDocumentsDir    = "documents"
+	DataExportFallbackName    = "content"
+	DataExportStorageDir      = "exports"
+	DataExportContentType     = "application/zip"
+	DataExportSealedType      = "application/octet-stream"
+	DataExportMaxLoginEvents  = 1000
+	DataExportListLimit       = 20
+
+	DefaultExportLinkTTL   = 15  // minutes
+	DefaultExportRetention = 168 // hours
+)
+
+// OID4VCI Constants
+const (
+	OID4VCIGrantPreAuthorizedCode = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	OID4VCIProofTypeJWT           = "jwt"
+	OID4VCIProofJWTType           = "openid4vci-proof+jwt"
+	OID4VCIFormatJWTVCJSON        = "jwt_vc_json"
+	OID4VCIOfferScheme            = "openid-credential-offer://"
+	OID4VCITokenTypeBearer        = "Bearer"
+
+	CredentialConfigVerifiedIdentity = "VerifiedIdentityCredential_jwt_vc_json"
+	CredentialTypeVerifiedIdentity   = "VerifiedIdentityCredential"
+
+	CredentialOfferStatusOffered  = "offered"
+	CredentialOfferStatusRedeemed = "redeemed"
+	CredentialOfferStatusRevoked  = "revoked"
+
+	OID4VCIOfferTTL          = 10  // minutes
+	OID4VCIAccessTokenTTL    = 10  // minutes
+	OID4VCINonceTTL          = 5   // minutes
+	OID4VCIProofMaxAge       = 5   // minutes
+	OID4VCITxCodeLength      = 6   // digits
+	OID4VCIMaxTxCodeAttempts = 5   // attempts before the offer is revoked
+	IssuedCredentialValidity = 365 // days
+	SecretByteLength         = 32  // bytes
+
+	DefaultIssuerDisplayName = "Finternet"
+	IssuerKeyFragment        = "#issuer-key-1"
+
+	// OAuth 2.0 and OID4VCI error codes
+	OAuthErrInvalidRequest              = "invalid_request"
+	OAuthErrInvalidGrant                = "invalid_grant"
+	OAuthErrUnsupportedGrantType        = "unsupported_grant_type"
+	OAuthErrInvalidToken                = "invalid_token"
+	OAuthErrInvalidProof                = "invalid_proof"
+	OAuthErrInvalidNonce                = "invalid_nonce"
+	OAuthErrUnsupportedCredentialType   = "unsupported_credential_type"
+	OAuthErrUnsupportedCredentialFormat = "unsupported_credential_format"
+	OAuthErrServerError                 = "server_error"
+)
+
+// Contact Verification Constants
+const (
+	VerificationChannelEmail = "email"
+	VerificationChannelPhone = "phone"
+
+	VerificationCodeStatusPending   = "pending"
+	VerificationCodeStatusConsumed  = "consumed"
+	VerificationCodeStatusExpired   = "expired"
+	VerificationCodeStatusExhausted = "exhausted" // too many incorrect codes were entered
+
+	VerificationCodeDigits      = 6
+	VerificationCodeTTL         = 10  // minutes
+	VerificationMaxAttempts     = 5   // incorrect codes before a new code is needed
+	VerificationResendInterval  = 60  // seconds between messages on a channel
+	VerificationMaxSendsPerHour = 5   // messages per channel
+	EmailVerificationLinkTTL    = 720 // minutes; the auth provider's default action token lifespan
+
+	SMSProviderLog         = "log"
+	SMSVerificationMessage = "Your Finternet verification code is %s. It expires in %d minutes."
+)
+
+// Job Queue Constants
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusDead      = "dead"
+
+	JobTypeCredentialVerification = "credential_verification"
+	JobTypeWebhookDelivery        = "webhook_delivery"
+	JobTypeKeyRecovery            = "key_recovery"
+	JobTypeAccountErasure         = "account_erasure"
+	JobTypeDataExport             = "data_export"
+	JobTypeDataExportExpiry       = "data_export_expiry"
+	JobTypePresentationCallback   = "presentation_callback"
+
+	DefaultJobWorkers     = 4
+	DefaultJobMaxAttempts = 5
+	JobPollInterval       = 2    // seconds
+	JobBackoffBase        = 10   // seconds
+	JobBackoffMax         = 3600 // seconds
+	JobLeaseTimeout       = 300  // seconds
+	JobShutdownTimeout    = 30   // seconds
+	JobErrorMaxLength     = 1000
+)
+
+// Webhook Constants
+const (
+	WebhookScopeActor   = "actor"
+	WebhookScopePartner = "partner"
+
+	WebhookEventCredentialStatusChanged  = "credential.status_changed"
+	WebhookEventVerificationLevelChanged = "actor.verification_level_changed"
+	WebhookEventDocumentUploaded         = "document.uploaded"
+	WebhookEventKeyRotated               = "actor.key_rotated"
+	WebhookEventKeyRecoveryRequested     = "actor.key_recovery_requested"
+	WebhookEventDeletionRequested        = "actor.deletion_requested"
+	WebhookEventDeletionCancelled        = "actor.deletion_cancelled"
+	WebhookEventAccountErased            = "actor.erased"
+	WebhookEventDataExportReady          = "actor.data_export_ready"
+
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+
+	HTTPHeaderWebhookID        = "X-Webhook-Id"
+	HTTPHeaderWebhookDelivery  = "X-Webhook-Delivery"
+	HTTPHeaderWebhookEvent     = "X-Webhook-Event"
+	HTTPHeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HTTPHeaderWebhookSignature = "X-Webhook-Signature"
+	WebhookSignaturePrefix     = "sha256="
+
+	WebhookSecretBytes     = 32
+	WebhookDeliveryTimeout = 10 // seconds
+)
+
+// Wallet Archive Constants
+const (
+	WalletArchiveFormat        = "finternet-wallet"
+	WalletArchiveVersion       = 1
+	WalletManifestPath         = "manifest.json"
+	WalletSignaturePath        = "manifest.jwt"
+	WalletManifestJWTType      = "wallet-manifest+jwt"
+	WalletArchiveContentType   = "application/zip"
+	WalletArchiveMaxSize       = 32 << 20 // bytes, compressed upload
+	WalletArchiveMaxExpanded   = 64 << 20 // bytes, all files decompressed
+	WalletArchiveMaxFiles      = 2000
+	WalletDocumentContentName  = "content"
+	WalletDocumentMetadataName = "document.json"
+	WalletTokenMetadataName    = "token.json"
+	WalletCredentialJSONName   = "credential.json"
+	WalletCredentialJWTName    = "credential.jwt"
+)
+
+// Organization Constants
+const (
+	// Member roles, from most to least privileged. Owners manage every role; admins manage
+	// operators and viewers; operators add and remove credentials and documents; viewers read them.
+	OrganizationRoleOwner    = "owner"
+	OrganizationRoleAdmin    = "admin"
+	OrganizationRoleOperator = "operator"
+	OrganizationRoleViewer   = "viewer"
+
+	OrganizationInvitationStatusPending  = "pending"
+	OrganizationInvitationStatusAccepted = "accepted"
+	OrganizationInvitationStatusDeclined = "declined"
+	OrganizationInvitationStatusRevoked  = "revoked"
+	OrganizationInvitationStatusExpired  = "expired"
+
+	OrganizationInvitationTTL       = 7 // days
+	OrganizationInvitationListLimit = 100
+)
+
+// Delegation Constants
+const (
+	// Operations a delegate can perform for the principal. Delegates never manage grants, keys or
+	// the account itself.
+	DelegationScopeProfileUpdate   = "profile:update"
+	DelegationScopeCredentialsAdd  = "credentials:add"
+	DelegationScopeCredentialsList = "credentials:list"
+	DelegationScopeDocumentsUpload = "documents:upload"
+
+	DelegationRelationshipGuardian       = "guardian"
+	DelegationRelationshipAccountant     = "accountant"
+	DelegationRelationshipRepresentative = "representative"
+
+	DelegationGrantStatusScheduled = "scheduled"
+	DelegationGrantStatusActive    = "active"
+	DelegationGrantStatusRevoked   = "revoked"
+	DelegationGrantStatusExpired   = "expired"
+
+	MaxDelegationDuration = 366 // days
+)
+
+// Sharing Constants
+const (
+	ShareGrantStatusActive  = "active"
+	ShareGrantStatusRevoked = "revoked"
+	ShareGrantStatusExpired = "expired"
+
+	ShareAccessList       = "list"
+	ShareAccessCredential = "view_credential"
+	ShareAccessDocument   = "view_document"
+
+	MaxShareDuration        = 365 // days
+	SharedDocumentURLExpiry = 15  // minutes
+)
+
+// OID4VP Constants
+const (
+	OID4VPResponseTypeVPToken  = "vp_token"
+	OID4VPResponseModeDirect   = "direct_post"
+	OID4VPClientIDSchemeDID    = "did"
+	OID4VPRequestObjectType    = "oauth-authz-req+jwt"
+	OID4VPRequestObjectMIME    = "application/oauth-authz-req+jwt"
+	OID4VPSelfIssuedAudience   = "https://self-issued.me/v2"
+	OID4VPAuthorizationScheme  = "openid4vp://"
+	OID4VPFormatJWTVPJSON      = "jwt_vp_json"
+	OID4VPFormatJWTVP          = "jwt_vp"
+	OID4VPFormatJWTVC          = "jwt_vc"
+	OID4VPErrAccessDenied      = "access_denied"
+	OID4VPErrInvalidSubmission = "invalid_presentation_submission"
+
+	PresentationStatusPending   = "pending"
+	PresentationStatusRetrieved = "retrieved"
+	PresentationStatusVerified  = "verified"
+	PresentationStatusRejected  = "rejected"
+	PresentationStatusExpired   = "expired"
+
+	CallbackStatusPending   = "pending"
+	CallbackStatusDelivered = "delivered"
+	CallbackStatusFailed    = "failed"
+
+	PresentationCallbackEvent = "presentation.completed"
+
+	OID4VPRequestTTL = 10 // minutes
+)
+
+// Role Constants
+const (
+	DefaultAdminRole = "admin"
+)
+
 // Entity Constants
 const (
 	EntityTypeActor  = "ACTOR"
 	CredentialTypeVC = "VerifiableCredential"
 	TokenStandardVC  = "VC"
+
+	TokenStandardVCJWT    = "VC-JWT"
+	CredentialFormatVCJWT = "vc+jwt"
 )
 
 // Verification Level Constants
 const (
-	VerificationLevelUnverified = "Tier0_Unverified"
+	VerificationLevelUnverified          = "Tier0_Unverified"
+	VerificationLevelVerified            = "Tier1_Verified"
+	VerificationLevelInstitutionVerified = "Tier2_InstitutionVerified"
+
+	VerificationTypeGovernmentID           = "GovernmentID"
+	VerificationTypeProofOfAddress         = "ProofOfAddress"
+	VerificationTypeBusinessRegistration   = "BusinessRegistration"
+	VerificationTypeInstitutionAttestation = "InstitutionAttestation"
+
+	// Reasons recorded in the verification level history
+	LevelChangeCredentialVerified = "credential_verified"
+	LevelChangeCredentialRejected = "credential_rejected"
+	LevelChangeCredentialRevoked  = "credential_revoked"
+	LevelChangeCredentialDeleted  = "credential_deleted"
 )
 
 // Response Messages
@@ -72,6 +533,17 @@ const (
 	MsgOperationSuccessful             = "Operation successful."
 	MsgFileUploadedSuccessfully        = "File uploaded successfully"
 	MsgUploadedAwaitingVerification    = "Uploaded. Awaiting verification."
+	MsgCredentialFlaggedUntrusted      = "Credential submitted, but its issuer is not in the trusted issuer registry."
+	MsgManualReviewRequired            = "No automated verifier applies; awaiting manual review."
+	MsgMasterKeyRotated                = "Master key rotated"
+	MsgKeyRecoveryScheduled            = "Key recovery scheduled; the new key takes effect after the waiting period unless cancelled with the current key"
+	MsgAccountDeletionScheduled        = "Account deletion scheduled; the account is erased after the grace period unless cancelled"
+	MsgAccountDeletionCancelled        = "Account deletion cancelled"
+	MsgDataExportRequested             = "Data export requested; a webhook is sent and the export is listed as ready once the bundle is prepared"
+	MsgEmailVerificationSent           = "Verification email sent; follow the link it contains, then check the verification status"
+	MsgPhoneVerificationSent           = "Verification code sent by SMS"
+	MsgOrganizationMemberRemoved       = "Member removed from the organization"
+	MsgOrganizationLeft                = "You have left the organization"
 )
 
 // HTTP Status Codes
@@ -179,6 +651,10 @@ const (
 	// Grant Types
 	KeycloakGrantTypePassword          = "password"
 	KeycloakGrantTypeClientCredentials = "client_credentials"
+	KeycloakGrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// Token Types
+	KeycloakTokenTypeRefresh = "urn:ietf:params:oauth:token-type:refresh_token"
 
 	// Scopes
 	KeycloakScopeOpenID = "openid"
@@ -197,10 +673,22 @@ const (
 	// Provider Name
 	KeycloakProviderName = "keycloak"
 
+	// Event Types recorded as login history
+	KeycloakEventLogin         = "LOGIN"
+	KeycloakEventLoginError    = "LOGIN_ERROR"
+	KeycloakEventLogout        = "LOGOUT"
+	KeycloakEventTokenExchange = "TOKEN_EXCHANGE"
+
+	// Required actions sent to users by email
+	KeycloakActionVerifyEmail    = "VERIFY_EMAIL"
+	KeycloakActionUpdatePassword = "UPDATE_PASSWORD"
+
 	// API Endpoints
 	KeycloakPathAdminUsers              = "/admin/realms/%s/users"
+	KeycloakPathAdminUser               = "/admin/realms/%s/users/%s"
 	KeycloakPathAdminUserLogout         = "/admin/realms/%s/users/%s/logout"
 	KeycloakPathAdminUserExecuteActions = "/admin/realms/%s/users/%s/execute-actions-email"
+	KeycloakPathAdminEvents             = "/admin/realms/%s/events"
 	KeycloakPathToken                   = "/realms/%s/protocol/openid-connect/token"
 	KeycloakPathMasterToken             = "/realms/master/protocol/openid-connect/token"
 	KeycloakPathCerts                   = "/realms/%s/protocol/openid-connect/certs"
@@ -214,6 +702,14 @@ const (
 	HTTPHeaderAuthorization = "Authorization"
 	HTTPHeaderBearer        = "Bearer"
 	HTTPHeaderBearerLower   = "bearer"
+	HTTPHeaderAccept        = "Accept"
+	HTTPHeaderCacheControl  = "Cache-Control"
+	HTTPHeaderPragma        = "Pragma"
+	HTTPHeaderWWWAuth       = "WWW-Authenticate"
+	HTTPHeaderUserAgent     = "User-Agent"
+	HTTPHeaderDisposition   = "Content-Disposition"
+	HTTPHeaderOrganization  = "X-Organization-ID"
+	HTTPHeaderOnBehalfOf    = "X-On-Behalf-Of"
 )
 
 // HTTP Request Parameter Constants
@@ -224,6 +720,9 @@ const (
 	HTTPParamUsername     = "username"
 	HTTPParamPassword     = "password"
 	HTTPParamScope        = "scope"
+
+	HTTPParamRequestedSubject   = "requested_subject"
+	HTTPParamRequestedTokenType = "requested_token_type"
 )
 
 // API Request Defaults
@@ -246,6 +745,7 @@ const (
 	ValidationTagNameOneOf    = "oneof"
 	ValidationTagNamePassword = "password"
 	ValidationTagNameE164     = "e164"
+	ValidationTagNameFragment = "fragment"
 )
 
 // Validation Error Messages
@@ -261,6 +761,7 @@ const (
 	ValidationMsgOneOf    = "Invalid value for field %s"
 	ValidationMsgPassword = "Field %s must contain at least 1 letter and 1 number, minimum length %d"
 	ValidationMsgE164     = "Field %s must be a valid phone number in E.164 format (e.g., +1234567890)"
+	ValidationMsgFragment = "Field %s must contain only letters, digits, '.', '-' and '_'"
 	ValidationMsgDefault  = "Field validation for '%s' failed on the '%s' tag"
 )
 
@@ -269,6 +770,7 @@ const (
 	RegexDigit      = `[0-9]`
 	RegexLetter     = `[a-zA-Z]`
 	RegexDigitsOnly = `^[0-9]+$`
+	RegexFragment   = `^[A-Za-z0-9._-]+$`
 )
 
 // Entity Type Constants
@@ -281,8 +783,34 @@ const (
 const (
 	TableNameActors            = "actors"
 	TableNameIdentifiers       = "identifiers"
+	TableNameReleasedIDs       = "released_identifiers"
 	TableNameActorIntegrations = "actor_integrations"
 	TableNameTokens            = "tokens"
+	TableNameTrustedIssuers    = "trusted_issuers"
+	TableNameLevelHistory      = "verification_level_history"
+	TableNameCredentialOffers  = "credential_offers"
+	TableNamePresentations     = "presentation_requests"
+	TableNameShareGrants       = "share_grants"
+	TableNameShareGrantTokens  = "share_grant_tokens"
+	TableNameShareAccessLogs   = "share_access_logs"
+	TableNameJobs              = "jobs"
+	TableNameWebhooks          = "webhook_subscriptions"
+	TableNameWebhookDeliveries = "webhook_deliveries"
+	TableNameTokenDocuments    = "token_documents"
+	TableNameActorKeys         = "actor_keys"
+	TableNameKeyRecoveries     = "key_recoveries"
+	TableNameKeyChallenges     = "key_challenges"
+	TableNameDIDServices       = "did_services"
+	TableNameNamespaces        = "namespace_domains"
+	TableNameReservedWords     = "reserved_words"
+	TableNameAccountDeletions  = "account_deletions"
+	TableNameRetentionHolds    = "retention_holds"
+	TableNameDataExports       = "data_exports"
+	TableNameVerificationCodes = "verification_codes"
+	TableNameOrgMembers        = "organization_members"
+	TableNameOrgInvitations    = "organization_invitations"
+	TableNameDelegationGrants  = "delegation_grants"
+	TableNameDelegatedActions  = "delegated_actions"
 )
 
 // Database Constants
@@ -299,12 +827,21 @@ const (
 	DBConnMaxLifetime = 60 // minutes
 )
 
+// Pagination Constants
+const (
+	DefaultPageSize             = 20
+	MaxPageSize                 = 100
+	MasterKeyBackfillBatchSize  = 500
+	IdentifierBackfillBatchSize = 500
+)
+
 // Timeout and Cache Duration Constants
 const (
 	HTTPClientTimeout      = 30 // seconds
 	HTTPClientTimeoutShort = 10 // seconds
 	KeycloakCacheDuration  = 1  // hours
 	StorageURLExpiration   = 24 // hours
+	JWTClockSkew           = 60 // seconds
 )
 
 // Environment Constants
@@ -314,26 +851,48 @@ const (
 
 // Environment Variable Names
 const (
-	EnvAppEnv                = "APP_ENV"
-	EnvAppHost               = "APP_HOST"
-	EnvAppPort               = "APP_PORT"
-	EnvDBHost                = "DB_HOST"
-	EnvDBUser                = "DB_USER"
-	EnvDBPassword            = "DB_PASSWORD"
-	EnvDBName                = "DB_NAME"
-	EnvDBPort                = "DB_PORT"
-	EnvKeycloakURL           = "KEYCLOAK_URL"
-	EnvKeycloakRealm         = "KEYCLOAK_REALM"
-	EnvKeycloakClientID      = "KEYCLOAK_CLIENT_ID"
-	EnvKeycloakClientSecret  = "KEYCLOAK_CLIENT_SECRET"
-	EnvKeycloakAdminUser     = "KEYCLOAK_ADMIN_USER"
-	EnvKeycloakAdminPassword = "KEYCLOAK_ADMIN_PASSWORD"
+	EnvAppEnv                 = "APP_ENV"
+	EnvAppHost                = "APP_HOST"
+	EnvAppPort                = "APP_PORT"
+	EnvDBHost                 = "DB_HOST"
+	EnvDBUser                 = "DB_USER"
+	EnvDBPassword             = "DB_PASSWORD"
+	EnvDBName                 = "DB_NAME"
+	EnvDBPort                 = "DB_PORT"
+	EnvKeycloakURL            = "KEYCLOAK_URL"
+	EnvKeycloakRealm          = "KEYCLOAK_REALM"
+	EnvKeycloakClientID       = "KEYCLOAK_CLIENT_ID"
+	EnvKeycloakClientSecret   = "KEYCLOAK_CLIENT_SECRET"
+	EnvKeycloakAdminUser      = "KEYCLOAK_ADMIN_USER"
+	EnvKeycloakAdminPassword  = "KEYCLOAK_ADMIN_PASSWORD"
+	EnvKeycloakAdminRole      = "KEYCLOAK_ADMIN_ROLE"
+	EnvTrustPolicy            = "TRUST_POLICY"
+	EnvTrustListKeyFile       = "TRUST_LIST_PUBLIC_KEY_FILE"
+	EnvVerificationRulesFile  = "VERIFICATION_RULES_FILE"
+	EnvIssuerURL              = "ISSUER_URL"
+	EnvIssuerSigningKeyFile   = "ISSUER_SIGNING_KEY_FILE"
+	EnvIssuerDisplayName      = "ISSUER_DISPLAY_NAME"
+	EnvJobWorkers             = "JOB_WORKERS"
+	EnvJobMaxAttempts         = "JOB_MAX_ATTEMPTS"
+	EnvOrphanedDocumentPolicy = "ORPHANED_DOCUMENT_POLICY"
+	EnvKeyRecoveryDelay       = "KEY_RECOVERY_DELAY_HOURS"
+	EnvIdentifierCoolingOff   = "IDENTIFIER_COOLING_OFF_DAYS"
+	EnvIdentifierDomain       = "IDENTIFIER_DEFAULT_DOMAIN"
+	EnvIdentifierPattern      = "IDENTIFIER_LOCAL_PATTERN"
+	EnvResolveCacheSize       = "RESOLVE_CACHE_SIZE"
+	EnvResolveCacheTTL        = "RESOLVE_CACHE_TTL_SECONDS"
+	EnvErasureGraceDays       = "ERASURE_GRACE_DAYS"
+	EnvExportKeyFile          = "EXPORT_ENCRYPTION_KEY_FILE"
+	EnvExportLinkTTL          = "EXPORT_LINK_TTL_MINUTES"
+	EnvExportRetention        = "EXPORT_RETENTION_HOURS"
+	EnvSMSProvider            = "SMS_PROVIDER"
 )
 
 // Server Configuration
 const (
 	ServerHeaderName = "Fiber"
 	AppName          = "Fiber API"
+	RequestBodyLimit = 40 << 20 // bytes; leaves room for multipart overhead around a wallet archive
 )
 
 // Logger Configuration
@@ -362,6 +921,25 @@ const (
 	RouteHealthCheck  = "/health-check"
 	RouteDocs         = "/docs"
 	RouteDocsWildcard = "/*"
+
+	RouteWellKnownCredentialIssuer = "/.well-known/openid-credential-issuer"
+	RouteWellKnownOAuthServer      = "/.well-known/oauth-authorization-server"
+	RouteWellKnownDID              = "/.well-known/did.json"
+	RouteActorDIDDocument          = "/:identifier/did.json"
+	RouteDID                       = "/did"
+	RouteOID4VCI                   = "/oid4vci"
+	RouteOID4VCIToken              = "/token"
+	RouteOID4VCICredential         = "/credential"
+	RouteOID4VP                    = "/oid4vp"
+	RouteOID4VPRequestObject       = "/request/:requestId"
+	RouteOID4VPResponse            = "/response"
+	RouteSharing                   = "/sharing"
+	RouteJobs                      = "/jobs"
+	RouteWebhooks                  = "/webhooks"
+	RouteWallet                    = "/wallet"
+	RouteDataExportDownload        = "/actor/exports/download/:token"
+	RouteOrganizations             = "/organizations"
+	RouteDelegations               = "/delegations"
 )
 
 // Storage Provider Error Messages
@@ -371,15 +949,18 @@ const (
 	ErrFailedToCloseGCSWriter       = "failed to close GCS writer"
 	ErrFailedToGenerateSignedURL    = "failed to generate signed URL"
 	ErrFailedToDeleteFileFromGCS    = "failed to delete file from GCS"
+	ErrFailedToReadFileFromGCS      = "failed to read file from GCS"
 	ErrFailedToLoadAWSConfig        = "failed to load AWS config"
 	ErrFailedToUploadFileToS3       = "failed to upload file to S3"
 	ErrFailedToGeneratePreSignedURL = "failed to generate pre-signed URL"
 	ErrFailedToDeleteFileFromS3     = "failed to delete file from S3"
+	ErrFailedToDownloadFileFromS3   = "failed to download file from S3"
 	ErrFailedToCreateMinIOClient    = "failed to create MinIO client"
 	ErrFailedToCheckBucketExistence = "failed to check bucket existence"
 	ErrFailedToCreateBucket         = "failed to create bucket"
 	ErrFailedToUploadFileToMinIO    = "failed to upload file to MinIO"
 	ErrFailedToDeleteFileFromMinIO  = "failed to delete file from MinIO"
+	ErrFailedToDownloadFromMinIO    = "failed to download file from MinIO"
 	ErrSizeMismatch                 = "size mismatch: wrote %d bytes, expected %d"
 	ErrNotFound                     = "NotFound"
 	ErrNoSuchKey                    = "NoSuchKey"
@@ -3,17 +3,26 @@ package container
 import (
 	"app/src/adapter"
 	"app/src/config"
+	"app/src/constants"
 	"app/src/controller"
 	"app/src/database"
+	"app/src/did"
+	"app/src/keys"
 	"app/src/middleware"
+	"app/src/queue"
 	"app/src/repository"
 	"app/src/router"
+	"app/src/seal"
 	"app/src/service"
 	"app/src/utils"
 	"app/src/validation"
+	"crypto"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
 	"go.uber.org/dig"
+	"gorm.io/gorm"
 )
 
 // Container holds the dependency injection container
@@ -34,6 +43,11 @@ func NewContainer() (*Container, error) {
 		database.NewDatabase,
 		validation.NewValidator,
 		ProvideStorageFactory,
+		adapter.NewLazyStorage,
+		did.NewResolver,
+		ProvidePlatformSigner,
+		ProvideExportSealer,
+		ProvideSMSSender,
 
 		// Repositories
 		repository.NewActorRepository,
@@ -41,12 +55,65 @@ func NewContainer() (*Container, error) {
 		repository.NewActorIntegrationRepository,
 		repository.NewCredentialsRepository,
 		repository.NewDocumentRepository,
+		repository.NewTrustedIssuerRepository,
+		repository.NewVerificationLevelHistoryRepository,
+		repository.NewCredentialOfferRepository,
+		repository.NewPresentationRequestRepository,
+		repository.NewShareGrantRepository,
+		repository.NewShareAccessLogRepository,
+		repository.NewJobRepository,
+		repository.NewWebhookSubscriptionRepository,
+		repository.NewWebhookDeliveryRepository,
+		repository.NewActorKeyRepository,
+		repository.NewKeyRecoveryRepository,
+		repository.NewKeyChallengeRepository,
+		repository.NewDIDServiceRepository,
+		repository.NewNamespaceRepository,
+		repository.NewAccountDeletionRepository,
+		repository.NewRetentionHoldRepository,
+		repository.NewErasureRepository,
+		repository.NewDataExportRepository,
+		repository.NewPortabilityRepository,
+		repository.NewVerificationCodeRepository,
+		repository.NewOrganizationRepository,
+		repository.NewDelegationGrantRepository,
+		repository.NewDelegatedActionRepository,
 
 		// Services
+		service.NewJobService,
+		service.NewWebhookService,
 		service.NewAuthService,
+		service.NewChallengeService,
 		service.NewActorService,
+		service.NewActorKeyService,
+		service.NewDIDDocumentService,
+		service.NewIdentifierService,
+		service.NewNamespaceService,
+		service.NewResolverService,
+		service.NewDeletionService,
+		service.NewExportService,
+		service.NewContactVerificationService,
+		service.NewOrganizationService,
+		service.NewDelegationService,
+		service.NewTrustedIssuerService,
+		service.NewVerificationLevelService,
+		service.NewCredentialJWTService,
 		service.NewCredentialsService,
+		service.NewOID4VCIService,
+		service.NewOID4VPService,
+		service.NewShareService,
+		service.NewWalletService,
 		service.NewHealthCheckService,
+		service.NewCredentialVerificationHandler,
+		service.NewWebhookDeliveryHandler,
+		service.NewKeyRecoveryHandler,
+		service.NewAccountErasureHandler,
+		service.NewDataExportHandler,
+		service.NewDataExportExpiryHandler,
+		service.NewPresentationCallbackHandler,
+
+		// Background workers
+		ProvideJobPool,
 
 		// Middleware
 		middleware.NewAuthJWTValidator,
@@ -56,6 +123,21 @@ func NewContainer() (*Container, error) {
 		// Controllers
 		controller.NewActorController,
 		controller.NewCredentialsController,
+		controller.NewTrustedIssuerController,
+		controller.NewOID4VCIController,
+		controller.NewOID4VPController,
+		controller.NewShareController,
+		controller.NewJobController,
+		controller.NewWebhookController,
+		controller.NewWalletController,
+		controller.NewDIDController,
+		controller.NewIdentifierController,
+		controller.NewNamespaceController,
+		controller.NewDeletionController,
+		controller.NewExportController,
+		controller.NewContactVerificationController,
+		controller.NewOrganizationController,
+		controller.NewDelegationController,
 		controller.NewHealthCheckController,
 
 		// Router
@@ -79,6 +161,88 @@ func ProvideStorageFactory(cfg *config.Config) *adapter.StorageFactory {
 	return adapter.NewStorageFactory(cfg.StorageConfig)
 }
 
+// ProvidePlatformSigner loads the key the platform signs issued credentials with. Without a
+// configured key file an ephemeral key is generated, so issued credentials stop verifying on restart.
+func ProvidePlatformSigner(cfg *config.Config, log *logrus.Logger) (*keys.Signer, error) {
+	issuerDID, err := did.WebDIDFromURL(cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var key crypto.PrivateKey
+	if cfg.IssuerKeyFile != "" {
+		if key, err = keys.LoadPrivateKeyFile(cfg.IssuerKeyFile); err != nil {
+			return nil, err
+		}
+	} else {
+		log.Warnf("%s not set; using an ephemeral issuer signing key", constants.EnvIssuerSigningKeyFile)
+		if key, err = keys.GenerateSigningKey(); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys.NewSigner(issuerDID+constants.IssuerKeyFragment, key)
+}
+
+// ProvideExportSealer loads the key that wraps the keys data export bundles are encrypted with.
+// Without a configured key file an ephemeral key is generated, so bundles prepared before a restart
+// can no longer be downloaded.
+func ProvideExportSealer(cfg *config.Config, log *logrus.Logger) (*seal.Sealer, error) {
+	var key []byte
+	var err error
+	if cfg.ExportKeyFile != "" {
+		if key, err = seal.LoadKeyFile(cfg.ExportKeyFile); err != nil {
+			return nil, err
+		}
+	} else {
+		log.Warnf("%s not set; using an ephemeral export encryption key", constants.EnvExportKeyFile)
+		if key, err = seal.GenerateKey(); err != nil {
+			return nil, err
+		}
+	}
+	return seal.NewSealer(key)
+}
+
+// ProvideSMSSender creates the sender of phone verification codes. The log sender is the only one
+// available, so in production the codes never reach the actors.
+func ProvideSMSSender(cfg *config.Config, log *logrus.Logger) adapter.SMSSender {
+	if cfg.IsProd {
+		log.Warnf("%s is %s; phone verification codes are only written to the log", constants.EnvSMSProvider, cfg.SMSProvider)
+	}
+	return adapter.NewLogSMSSender(log)
+}
+
+// ProvideJobPool creates the background job worker pool with a handler registered for every job type
+func ProvideJobPool(
+	cfg *config.Config,
+	db *gorm.DB,
+	jobs repository.JobRepository,
+	log *logrus.Logger,
+	credentialVerification *service.CredentialVerificationHandler,
+	webhookDelivery *service.WebhookDeliveryHandler,
+	keyRecovery *service.KeyRecoveryHandler,
+	accountErasure *service.AccountErasureHandler,
+	dataExport *service.DataExportHandler,
+	dataExportExpiry *service.DataExportExpiryHandler,
+	presentationCallback *service.PresentationCallbackHandler,
+) *queue.Pool {
+	pool := queue.NewPool(db, jobs, log, queue.Options{
+		Workers:      cfg.JobWorkers,
+		PollInterval: constants.JobPollInterval * time.Second,
+		LeaseTimeout: constants.JobLeaseTimeout * time.Second,
+		BackoffBase:  constants.JobBackoffBase * time.Second,
+		BackoffMax:   constants.JobBackoffMax * time.Second,
+	})
+	pool.Register(constants.JobTypeCredentialVerification, credentialVerification)
+	pool.Register(constants.JobTypeWebhookDelivery, webhookDelivery)
+	pool.Register(constants.JobTypeKeyRecovery, keyRecovery)
+	pool.Register(constants.JobTypeAccountErasure, accountErasure)
+	pool.Register(constants.JobTypeDataExport, dataExport)
+	pool.Register(constants.JobTypeDataExportExpiry, dataExportExpiry)
+	pool.Register(constants.JobTypePresentationCallback, presentationCallback)
+	return pool
+}
+
 // NewFiberApp creates a new Fiber application
 func NewFiberApp(cfg *config.Config) *fiber.App {
 	return fiber.New(config.FiberConfig(cfg))
@@ -8,35 +8,39 @@ import (
 	"app/src/service"
 	"app/src/utils"
 	"app/src/validation"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 type ActorController struct {
-	ActorService    service.ActorService
-	ResponseBuilder *utils.ResponseBuilder
+	ActorService             service.ActorService
+	ActorKeyService          service.ActorKeyService
+	ChallengeService         service.ChallengeService
+	ResolverService          service.ResolverService
+	VerificationLevelService service.VerificationLevelService
+	ResponseBuilder          *utils.ResponseBuilder
 }
 
 func NewActorController(
 	actorService service.ActorService,
+	actorKeyService service.ActorKeyService,
+	challengeService service.ChallengeService,
+	resolverService service.ResolverService,
+	verificationLevelService service.VerificationLevelService,
 	responseBuilder *utils.ResponseBuilder,
 ) *ActorController {
 	return &ActorController{
-		ActorService:    actorService,
-		ResponseBuilder: responseBuilder,
+		ActorService:             actorService,
+		ActorKeyService:          actorKeyService,
+		ChallengeService:         challengeService,
+		ResolverService:          resolverService,
+		VerificationLevelService: verificationLevelService,
+		ResponseBuilder:          responseBuilder,
 	}
 }
 
-// getActorID extracts the authenticated actor ID from context
-func (a *ActorController) getActorID(c *fiber.Ctx) (uuid.UUID, error) {
-	actorID, ok := c.Locals("actorID").(uuid.UUID)
-	if !ok {
-		return uuid.Nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
-	}
-	return actorID, nil
-}
-
 // getAuthUserID extracts the auth user ID from JWT claims
 func (a *ActorController) getAuthUserID(c *fiber.Ctx) (string, error) {
 	claims, ok := c.Locals("auth_claims").(*middleware.AuthClaims)
@@ -52,15 +56,44 @@ func (a *ActorController) getUniversalIdentifier(actorID uuid.UUID) string {
 	return identifier
 }
 
+// @Tags         Actor
+// @Summary      Issue a key possession challenge
+// @Description  Issues a single-use nonce, valid for a few minutes, that the client signs with the master key to prove possession of it, at registration (purpose registration, the default) or at key login (purpose login).
+// @Accept       json
+// @Produce      json
+// @Param        request  body  validation.ApiRequest_ChallengeRequest  true  "Request body"
+// @Router       /v1/actor/challenge [post]
+// @Success      200  {object}  response.ApiResponse_ChallengeResponse
+// @Failure      400  {object}  response.ApiResponse_Error  "Bad request"
+func (a *ActorController) IssueChallenge(c *fiber.Ctx) error {
+	var req validation.ApiRequest_ChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	challenge, err := a.ChallengeService.Issue(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	responseData := response.ChallengeResponse{
+		Challenge: challenge.Nonce,
+		ExpiresAt: challenge.ExpiresAt.Format(time.RFC3339),
+	}
+
+	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
+}
+
 // @Tags         Actor
 // @Summary      Register a new actor
 // @Description  Creates a new user actor, generates and stores the reproducible DID from the master public key, and links them.
+// @Description  The proof is a JWT with typ registration-proof+jwt signed with the master key, with aud the issuer URL, a recent iat, nonce set to a challenge from /v1/actor/challenge and payload_hash set to the base64url SHA-256 of the RFC 8785 canonical JSON of the request object without proof.
 // @Accept       json
 // @Produce      json
 // @Param        request  body  validation.ApiRequest_RegistrationRequest  true  "Request body"
 // @Router       /v1/actor/create [post]
 // @Success      201  {object}  response.ApiResponse_RegistrationSuccess
-// @Failure      400  {object}  response.ApiResponse_Error  "Bad request, such as a missing required field"
+// @Failure      400  {object}  response.ApiResponse_Error  "Bad request, such as a missing required field, an invalid proof or an unknown or expired challenge"
 // @Failure      409  {object}  response.ApiResponse_Error  "Conflict, an identifier is already in use"
 func (a *ActorController) RegisterActor(c *fiber.Ctx) error {
 	var req validation.ApiRequest_RegistrationRequest
@@ -83,7 +116,7 @@ func (a *ActorController) RegisterActor(c *fiber.Ctx) error {
 
 // @Tags         Actor
 // @Summary      Login actor
-// @Description  Authenticates an actor and returns JWT tokens
+// @Description  Authenticates an actor and returns JWT tokens. The username may be any of the actor's universal identifiers or its email.
 // @Accept       json
 // @Produce      json
 // @Param        request  body  validation.ApiRequest_LoginRequest  true  "Request body"
@@ -111,6 +144,36 @@ func (a *ActorController) Login(c *fiber.Ctx) error {
 	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
 }
 
+// @Tags         Actor
+// @Summary      Login actor with the master key
+// @Description  Authenticates an actor by a signature of its master key instead of a password and returns JWT tokens. The proof is a JWT with typ login-proof+jwt signed with the master key, with sub the actor's DID, aud the issuer URL, a recent iat and nonce set to a challenge from /v1/actor/challenge with purpose login.
+// @Accept       json
+// @Produce      json
+// @Param        request  body  validation.ApiRequest_KeyLoginRequest  true  "Request body"
+// @Router       /v1/actor/loginWithKey [post]
+// @Success      200  {object}  response.ApiResponse  "Login successful"
+// @Failure      400  {object}  response.ApiResponse_Error  "Bad request, such as an unknown or expired challenge"
+// @Failure      401  {object}  response.ApiResponse_Error  "Invalid credentials or proof"
+func (a *ActorController) LoginWithKey(c *fiber.Ctx) error {
+	var req validation.ApiRequest_KeyLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	authToken, err := a.ActorService.LoginWithKey(c, &req)
+	if err != nil {
+		return err
+	}
+
+	responseData := response.AuthResponse{
+		AccessToken: authToken.AccessToken,
+		TokenType:   authToken.TokenType,
+		ExpiresIn:   authToken.ExpiresIn,
+	}
+
+	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
+}
+
 // @Tags         Actor
 // @Summary      Update actor profile
 // @Description  Allows an authenticated user to update their profile information, such as name and phone number. The actor to update is determined by the session token.
@@ -118,6 +181,7 @@ func (a *ActorController) Login(c *fiber.Ctx) error {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        request  body  validation.ApiRequest_UpdateActorRequest  true  "Request body"
+// @Param        X-On-Behalf-Of  header  string  false  "Principal to act for, when called by a delegate"
 // @Router       /v1/actor/update [post]
 // @Success      200  {object}  response.ApiResponse_ActorProfile
 // @Failure      400  {object}  response.ApiResponse_Error  "Bad request"
@@ -129,7 +193,7 @@ func (a *ActorController) UpdateActor(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
 	}
 
-	actorID, err := a.getActorID(c)
+	actorID, err := utils.ActorIDFromContext(c)
 	if err != nil {
 		return err
 	}
@@ -159,7 +223,7 @@ func (a *ActorController) GetProfile(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
 	}
 
-	actorID, err := a.getActorID(c)
+	actorID, err := utils.ActorIDFromContext(c)
 	if err != nil {
 		return err
 	}
@@ -173,6 +237,58 @@ func (a *ActorController) GetProfile(c *fiber.Ctx) error {
 	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
 }
 
+// @Tags         Actor
+// @Summary      Get verification level history
+// @Description  Returns the authenticated actor's current verification level and every level change, newest first.
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /v1/actor/verificationHistory [post]
+// @Success      200  {object}  response.ApiResponse_VerificationHistory
+// @Failure      401  {object}  response.ApiResponse_Error  "Unauthorized"
+func (a *ActorController) GetVerificationHistory(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	actor, err := a.ActorService.GetProfile(c, actorID)
+	if err != nil {
+		return err
+	}
+
+	history, err := a.VerificationLevelService.GetHistory(c, actorID)
+	if err != nil {
+		return err
+	}
+
+	responseData := response.VerificationHistoryResponse{
+		VerificationLevel: actor.VerificationLevel,
+		History:           make([]response.VerificationLevelChange, 0, len(history)),
+	}
+	for _, entry := range history {
+		change := response.VerificationLevelChange{
+			PreviousLevel: entry.PreviousLevel,
+			NewLevel:      entry.NewLevel,
+			Reason:        entry.Reason,
+			ChangedAt:     entry.CreatedAt.Format(time.RFC3339),
+		}
+		if entry.TokenID != nil {
+			credentialID := entry.TokenID.String()
+			change.CredentialID = &credentialID
+		}
+		responseData.History = append(responseData.History, change)
+	}
+
+	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
+}
+
 // @Tags         Actor
 // @Summary      Actor signout
 // @Description  Logs out the user from auth provider and revokes all their active sessions.
@@ -231,9 +347,60 @@ func (a *ActorController) ForgotPassword(c *fiber.Ctx) error {
 	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
 }
 
+// @Tags         Actor
+// @Summary      Rotate the master key
+// @Description  Replaces the authenticated actor's master key. With a proof, a JWT with typ "key-rotation+jwt" signed by the current master key whose sub is the actor's DID, aud is the issuer URL and new_key_thumbprint is the RFC 7638 thumbprint of the new key, the rotation takes effect immediately. With the account password instead, for a lost key, the new key takes effect after the recovery waiting period; subscribers to actor.key_recovery_requested are notified and a rotation with the current key in the meantime cancels it. The actor's DID becomes the did:key of the new key; prior keys stay in the key history.
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  validation.ApiRequest_RotateKeyRequest  true  "Request body"
+// @Router       /v1/actor/rotateKey [post]
+// @Success      200  {object}  response.ApiResponse_RotateKeyResponse  "Key rotated"
+// @Success      202  {object}  response.ApiResponse_RotateKeyResponse  "Key recovery scheduled"
+// @Failure      400  {object}  response.ApiResponse_Error  "Bad request, such as an invalid key or rotation proof"
+// @Failure      401  {object}  response.ApiResponse_Error  "Unauthorized, or the password is wrong"
+// @Failure      409  {object}  response.ApiResponse_Error  "Conflict, the key was already used or a recovery is pending"
+func (a *ActorController) RotateKey(c *fiber.Ctx) error {
+	var req validation.ApiRequest_RotateKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := a.ActorKeyService.RotateKey(c, actorID, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	if recovery := result.Recovery; recovery != nil {
+		recoveryID := recovery.RecoveryID.String()
+		responseData := response.RotateKeyResponse{
+			Status:      recovery.Status,
+			Thumbprint:  recovery.Thumbprint,
+			EffectiveAt: recovery.EffectiveAt.Format(time.RFC3339),
+			RecoveryID:  &recoveryID,
+			Message:     constants.MsgKeyRecoveryScheduled,
+		}
+		return a.ResponseBuilder.AcceptedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
+	}
+
+	responseData := response.RotateKeyResponse{
+		Status:      constants.KeyRecoveryStatusCompleted,
+		DID:         result.Key.DID,
+		Thumbprint:  *result.Key.Thumbprint,
+		EffectiveAt: result.Key.ValidFrom.Format(time.RFC3339),
+		Message:     constants.MsgMasterKeyRotated,
+	}
+	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
+}
+
 // @Tags         Actor
 // @Summary      Resolve a universal identifier
-// @Description  Publicly resolves a universal identifier to its master public key and DID.
+// @Description  Publicly resolves a universal identifier to its master public key and DID. With at, an RFC 3339 time, the master key that was valid at that time is returned with its validity interval.
 // @Accept       json
 // @Produce      json
 // @Param        request  body  validation.ApiRequest_ResolveRequest  true  "Request body"
@@ -257,6 +424,59 @@ func (a *ActorController) ResolveUniversalIdentifier(c *fiber.Ctx) error {
 		DID:                 actor.DID,
 	}
 
+	if req.Request.At != "" {
+		key, err := a.ActorKeyService.FindKeyValidAt(c, actor.ActorID, req.Request.At)
+		if err != nil {
+			return err
+		}
+
+		validFrom := key.ValidFrom.Format(time.RFC3339)
+		responseData.MasterPublicKey = key.PublicKey
+		responseData.DID = key.DID
+		responseData.ValidFrom = &validFrom
+		if key.ValidUntil != nil {
+			validUntil := key.ValidUntil.Format(time.RFC3339)
+			responseData.ValidUntil = &validUntil
+		}
+	}
+
+	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
+}
+
+// @Tags         Actor
+// @Summary      Resolve universal identifiers in bulk
+// @Description  Publicly resolves up to 100 universal identifiers to their current master public key and DID. Each resolution carries a JWS of type resolution+jwt signed by the platform key (kid in the platform DID document at /.well-known/did.json), with the canonical identifier as sub and the did and master_public_key claims, valid for an hour; callers can cache and verify it offline. Identifiers nobody holds are listed in notFound.
+// @Accept       json
+// @Produce      json
+// @Param        request  body  validation.ApiRequest_ResolveBatchRequest  true  "Request body"
+// @Router       /v1/actor/resolveBatch [post]
+// @Success      200  {object}  response.ApiResponse_ResolveBatchResponse
+// @Failure      400  {object}  response.ApiResponse_Error  "Bad request"
+func (a *ActorController) ResolveBatch(c *fiber.Ctx) error {
+	var req validation.ApiRequest_ResolveBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	result, err := a.ResolverService.ResolveBatch(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	responseData := response.ResolveBatchResponse{
+		Resolved: make([]response.ResolvedIdentifierResponse, 0, len(result.Resolved)),
+		NotFound: result.NotFound,
+	}
+	for _, resolution := range result.Resolved {
+		responseData.Resolved = append(responseData.Resolved, response.ResolvedIdentifierResponse{
+			Requested:           resolution.Requested,
+			UniversalIdentifier: resolution.Identifier,
+			MasterPublicKey:     resolution.MasterPublicKey,
+			DID:                 resolution.DID,
+			Signature:           resolution.Signature,
+		})
+	}
+
 	return a.ResponseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, responseData)
 }
 
@@ -266,10 +486,13 @@ func (a *ActorController) buildActorProfileResponse(actor *model.Actor) response
 		DID:                    actor.DID,
 		UniversalIdentifier:    a.getUniversalIdentifier(actor.ActorID),
 		Email:                  actor.Email,
+		EmailVerified:          actor.EmailVerified,
 		FirstName:              actor.FirstName,
 		LastName:               actor.LastName,
 		PhoneNumber:            actor.PhoneNumber,
+		PhoneVerified:          actor.PhoneVerified,
 		MasterPublicKey:        actor.MasterPublicKey,
+		MasterKeyThumbprint:    actor.MasterKeyThumbprint,
 		VerificationLevel:      actor.VerificationLevel,
 		EntityType:             actor.EntityType,
 		Nationality:            actor.Nationality,
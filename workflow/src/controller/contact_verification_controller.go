@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ContactVerificationController handles email address and phone number verification requests
+type ContactVerificationController struct {
+	verificationService service.ContactVerificationService
+	responseBuilder     *utils.ResponseBuilder
+}
+
+// NewContactVerificationController creates a new contact verification controller
+func NewContactVerificationController(
+	verificationService service.ContactVerificationService,
+	responseBuilder *utils.ResponseBuilder,
+) *ContactVerificationController {
+	return &ContactVerificationController{
+		verificationService: verificationService,
+		responseBuilder:     responseBuilder,
+	}
+}
+
+// @Tags         Actor
+// @Summary      Send email verification
+// @Description  Sends the caller an email with a link to verify their email address, through the auth provider. Once the link has been followed, /actor/verify/email/check records the address as verified. A message can be sent every 60 seconds, and at most 5 per hour.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/verify/email/send [post]
+// @Success      200  {object}  response.Response[response.VerificationSentResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Email address already verified"
+// @Failure      429  {object}  example.ErrorEnvelope[example.TooManyRequestsExample]  "Too many verification messages"
+func (vc *ContactVerificationController) SendEmailVerification(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	code, err := vc.verificationService.SendEmailVerification(c)
+	if err != nil {
+		return err
+	}
+
+	return vc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID,
+		buildVerificationSentResponse(code, constants.MsgEmailVerificationSent))
+}
+
+// @Tags         Actor
+// @Summary      Check email verification
+// @Description  Records the caller's email address as verified once they have followed the link sent by /actor/verify/email/send or at registration, and returns the verification status of their email address and phone number.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/verify/email/check [post]
+// @Success      200  {object}  response.Response[response.ContactVerificationResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (vc *ContactVerificationController) CheckEmailVerification(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	actor, err := vc.verificationService.CheckEmailVerification(c)
+	if err != nil {
+		return err
+	}
+
+	return vc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildContactVerificationResponse(actor))
+}
+
+// @Tags         Actor
+// @Summary      Send phone verification code
+// @Description  Sends a 6-digit one-time code by SMS to the caller's phone number. The code expires after 10 minutes and only the latest code sent can be used. A code can be sent every 60 seconds, and at most 5 per hour.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/verify/phone/send [post]
+// @Success      200  {object}  response.Response[response.VerificationSentResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or no phone number set"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Phone number already verified"
+// @Failure      429  {object}  example.ErrorEnvelope[example.TooManyRequestsExample]  "Too many verification messages"
+func (vc *ContactVerificationController) SendPhoneVerification(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	code, err := vc.verificationService.SendPhoneVerification(c)
+	if err != nil {
+		return err
+	}
+
+	return vc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID,
+		buildVerificationSentResponse(code, constants.MsgPhoneVerificationSent))
+}
+
+// @Tags         Actor
+// @Summary      Confirm phone verification
+// @Description  Records the caller's phone number as verified when the code matches the latest one sent to it. After 5 incorrect codes a new code must be requested. Changing the phone number through /actor/update clears its verification.
+// @Produce      json
+// @Param        request body  response.Request[validation.ConfirmPhoneVerificationRequest]  true  "Request body"
+// @Router       /actor/verify/phone/confirm [post]
+// @Success      200  {object}  response.Response[response.ContactVerificationResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body, or invalid or expired code"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Phone number already verified"
+// @Failure      429  {object}  example.ErrorEnvelope[example.TooManyRequestsExample]  "Too many incorrect codes"
+func (vc *ContactVerificationController) ConfirmPhoneVerification(c *fiber.Ctx) error {
+	var req response.Request[validation.ConfirmPhoneVerificationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	actor, err := vc.verificationService.ConfirmPhoneVerification(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return vc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildContactVerificationResponse(actor))
+}
+
+func buildVerificationSentResponse(code *model.VerificationCode, message string) response.VerificationSentResponse {
+	return response.VerificationSentResponse{
+		Channel:   code.Channel,
+		Target:    code.Target,
+		ExpiresAt: code.ExpiresAt.UTC().Format(time.RFC3339),
+		Message:   message,
+	}
+}
+
+func buildContactVerificationResponse(actor *model.Actor) response.ContactVerificationResponse {
+	resp := response.ContactVerificationResponse{
+		Email:         actor.Email,
+		EmailVerified: actor.EmailVerified,
+		PhoneVerified: actor.PhoneVerified,
+	}
+	if actor.EmailVerifiedAt != nil {
+		resp.EmailVerifiedAt = actor.EmailVerifiedAt.UTC().Format(time.RFC3339)
+	}
+	if actor.PhoneNumber != nil {
+		resp.PhoneNumber = *actor.PhoneNumber
+	}
+	if actor.PhoneVerifiedAt != nil {
+		resp.PhoneVerifiedAt = actor.PhoneVerifiedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
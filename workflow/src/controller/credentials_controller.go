@@ -11,9 +11,6 @@ import (
 	"app/src/utils"
 	"app/src/validation"
 	"fmt"
-	"path/filepath"
-	"strings"
-	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -24,12 +21,10 @@ import (
 // CredentialController handles credential-related HTTP requests
 type CredentialController struct {
 	credentialsService service.CredentialsService
-	storageFactory     *adapter.StorageFactory
-	storageProvider    adapter.StorageProvider
-	storageOnce        sync.Once
-	storageErr         error
+	storage            *adapter.LazyStorage
 	db                 *gorm.DB
 	documentRepo       repository.DocumentRepository
+	webhooks           service.WebhookService
 	responseBuilder    *utils.ResponseBuilder
 }
 
@@ -37,41 +32,35 @@ type CredentialController struct {
 // Storage provider is initialized lazily on first use to avoid startup failures
 func NewCredentialsController(
 	credentialsService service.CredentialsService,
-	storageFactory *adapter.StorageFactory,
+	storage *adapter.LazyStorage,
 	db *gorm.DB,
 	documentRepo repository.DocumentRepository,
+	webhooks service.WebhookService,
 	responseBuilder *utils.ResponseBuilder,
 ) *CredentialController {
 	return &CredentialController{
 		credentialsService: credentialsService,
-		storageFactory:     storageFactory,
+		storage:            storage,
 		db:                 db,
 		documentRepo:       documentRepo,
+		webhooks:           webhooks,
 		responseBuilder:    responseBuilder,
 	}
 }
 
-// getStorageProvider returns the storage provider, initializing it lazily on first call
-func (cc *CredentialController) getStorageProvider() (adapter.StorageProvider, error) {
-	cc.storageOnce.Do(func() {
-		cc.storageProvider, cc.storageErr = cc.storageFactory.NewProvider()
-		if cc.storageErr != nil {
-			cc.storageErr = fmt.Errorf("%s: %w", constants.ErrFailedToCreateStorageProvider, cc.storageErr)
-		}
-	})
-	return cc.storageProvider, cc.storageErr
-}
-
 // @Tags         Credentials
 // @Summary      Add a credential for verification
-// @Description  Submits a credential (VC or manual document ref) for asynchronous verification, creating a credential token in 'Pending' state.
+// @Description  Submits a credential for asynchronous verification, creating a credential token in 'Pending' state and a verification job whose progress can be polled with /jobs/get. The credential is either a JSON-LD object (verifiableCredential) or a compact VC-JWT (verifiableCredentialJwt) whose signature is checked against the issuer's DID-resolved key. Up to 10 uploaded documents are linked as evidence, each with a role (front, back, selfie, proof_of_address); the legacy single documentId is linked as the front.
 // @Produce      json
 // @Param        request body  response.Request[validation.AddCredentialRequest]  true  "Request body"
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        X-On-Behalf-Of  header  string  false  "Principal to act for, when called by a delegate"
 // @Router       /credentials/add [post]
 // @Success      202  {object}  response.Response[response.AddCredentialSuccessResponse]  "Credential accepted for verification"
 // @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request"
 // @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
 // @Failure      404  {object}  example.ErrorEnvelope[example.DocumentNotFoundExample]  "Document ID not found"
+// @Failure      422  {object}  example.ErrorEnvelope[example.ParamsUnprocessableEntityExample]  "Issuer not trusted and the trust policy rejects it"
 func (cc *CredentialController) AddCredential(c *fiber.Ctx) error {
 	var req response.Request[validation.AddCredentialRequest]
 	if err := c.BodyParser(&req); err != nil {
@@ -82,15 +71,20 @@ func (cc *CredentialController) AddCredential(c *fiber.Ctx) error {
 		AddCredentialRequest: validation.ValidateAddCredentials(req.Request),
 	}
 
-	token, err := cc.credentialsService.AddCredential(c, credentials)
+	token, job, err := cc.credentialsService.AddCredential(c, credentials)
 	if err != nil {
 		return err
 	}
 
 	payload := response.AddCredentialSuccessResponse{
 		CredentialID: token.TokenID.String(),
-		Status:       constants.StatusPending,
+		JobID:        job.JobID.String(),
+		Status:       token.Status,
 		Message:      constants.MsgCredentialSubmittedSuccessfully,
+		Documents:    buildCredentialDocumentResponses(token.Documents),
+	}
+	if token.Status == constants.TokenStatusUntrusted {
+		payload.Message = constants.MsgCredentialFlaggedUntrusted
 	}
 
 	return cc.responseBuilder.AcceptedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
@@ -98,9 +92,11 @@ func (cc *CredentialController) AddCredential(c *fiber.Ctx) error {
 
 // @Tags         Credentials
 // @Summary      List credentials
-// @Description  List all credentials for a user
+// @Description  Lists the authenticated actor's credentials, newest first, with cursor pagination and optional status, verificationType, issuer and created-range filters.
 // @Produce      json
 // @Param        request body  response.Request[validation.ListCredentialsRequest]  true  "Request body"
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        X-On-Behalf-Of  header  string  false  "Principal to act for, when called by a delegate"
 // @Router       /credentials/list [post]
 // @Success      200 {object} response.Response[response.ListCredentialsSuccessResponse]
 // @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
@@ -112,19 +108,19 @@ func (cc *CredentialController) ListCredentials(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
 	}
 
-	tokens, err := cc.credentialsService.ListCredentials(c)
+	tokens, nextCursor, err := cc.credentialsService.ListCredentials(c, &req.Request)
 	if err != nil {
 		return err
 	}
 
-	payload := make([]response.CredentialsSuccessResponse, 0, len(tokens))
-	for _, token := range tokens {
-		payload = append(payload, response.CredentialsSuccessResponse{
-			CredentialID: token.TokenID.String(),
-			Type:         constants.CredentialTypeVC,
-			Status:       constants.StatusPending,
-			SubmittedAt:  token.CreatedAt.Format(time.RFC3339),
-		})
+	credentials := make([]response.CredentialsSuccessResponse, 0, len(tokens))
+	for i := range tokens {
+		credentials = append(credentials, cc.buildCredentialResponse(&tokens[i]))
+	}
+
+	payload := response.ListCredentialsSuccessResponse{
+		Credentials: credentials,
+		NextCursor:  nextCursor,
 	}
 
 	return cc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
@@ -132,9 +128,11 @@ func (cc *CredentialController) ListCredentials(c *fiber.Ctx) error {
 
 // @Tags         Credentials
 // @Summary      Get credential
-// @Description  Get credential for a user
+// @Description  Get a credential owned by the authenticated actor
 // @Produce      json
 // @Param        request body  response.Request[validation.GetCredentialRequest]  true  "Request body"
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        X-On-Behalf-Of  header  string  false  "Principal to act for, when called by a delegate"
 // @Router       /credentials/get [post]
 // @Success      200  {object}  response.Response[response.CredentialsSuccessResponse]
 // @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
@@ -152,21 +150,17 @@ func (cc *CredentialController) GetCredential(c *fiber.Ctx) error {
 		return err
 	}
 
-	payload := response.CredentialsSuccessResponse{
-		CredentialID: req.Request.CredentialID,
-		Type:         constants.CredentialTypeVC,
-		Status:       constants.StatusPending,
-		SubmittedAt:  token.CreatedAt.Format(time.RFC3339),
-	}
+	payload := cc.buildCredentialResponse(token)
 
 	return cc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
 }
 
 // @Tags         Credentials
 // @Summary      Delete credential
-// @Description  Delete credential for a user
+// @Description  Delete a credential owned by the authenticated actor. Its evidence documents are kept, or deleted when no other credential links to them and ORPHANED_DOCUMENT_POLICY is delete.
 // @Produce      json
 // @Param        request body  response.Request[validation.DeleteCredentialRequest]  true  "Request body"
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
 // @Router       /credentials/delete [post]
 // @Success      200  {object}  response.Response[response.DeleteCredentialResponse]
 // @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
@@ -190,25 +184,58 @@ func (cc *CredentialController) DeleteCredential(c *fiber.Ctx) error {
 	return cc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
 }
 
+// @Tags         Admin
+// @Summary      Record a verification decision
+// @Description  Marks a credential as Verified, Rejected or Revoked and recomputes the owner's verification level. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.UpdateCredentialStatusRequest]  true  "Request body"
+// @Router       /admin/credentials/updateStatus [post]
+// @Success      200  {object}  response.Response[response.CredentialsSuccessResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Credential not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Status transition not allowed"
+func (cc *CredentialController) UpdateCredentialStatus(c *fiber.Ctx) error {
+	var req response.Request[validation.UpdateCredentialStatusRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	token, err := cc.credentialsService.UpdateCredentialStatus(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := cc.buildCredentialResponse(token)
+
+	return cc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
 // @Tags         Credentials
 // @Summary      Upload a document for verification
 // @Description  Uploads a document (e.g., passport) for manual verification. Returns a document ID used in /credentials/add.
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        document formData file true "Document to upload"
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        X-On-Behalf-Of  header  string  false  "Principal to act for, when called by a delegate"
 // @Router       /credentials/upload [post]
 // @Success      201  {object}  response.Response[response.UploadCredentialResponse]  "Document uploaded successfully"
 // @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request"
 // @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
 func (cc *CredentialController) UploadFile(c *fiber.Ctx) error {
 	// Get storage provider (lazy initialization)
-	storageProvider, err := cc.getStorageProvider()
+	storageProvider, err := cc.storage.Provider()
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, 
 			fmt.Sprintf("Storage provider unavailable: %v", err))
 	}
 
-	actorID := uuid.Must(uuid.NewV7())
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return err
+	}
 
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -222,7 +249,7 @@ func (cc *CredentialController) UploadFile(c *fiber.Ctx) error {
 	defer fileReader.Close()
 
 	// Build storage key and upload file
-	storageKey, fileExt := cc.buildStorageKey(file.Filename)
+	storageKey, fileExt := utils.BuildStorageKey(file.Filename)
 	opts := &adapter.UploadOptions{
 		ContentType: file.Header.Get(constants.HTTPHeaderContentType),
 		Metadata: map[string]string{
@@ -248,7 +275,18 @@ func (cc *CredentialController) UploadFile(c *fiber.Ctx) error {
 	}
 
 	if err := cc.db.Transaction(func(tx *gorm.DB) error {
-		return cc.documentRepo.Create(c.Context(), tx, document)
+		if err := cc.documentRepo.Create(c.Context(), tx, document); err != nil {
+			return err
+		}
+		return cc.webhooks.Publish(c.Context(), tx, service.WebhookEvent{
+			Type:    constants.WebhookEventDocumentUploaded,
+			ActorID: actorID,
+			Data: map[string]interface{}{
+				"documentId": document.DocumentID.String(),
+				"fileName":   document.FileName,
+				"mimeType":   fileExt,
+			},
+		})
 	}); err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, 
 			fmt.Sprintf("Failed to save document record: %v", err))
@@ -267,14 +305,32 @@ func (cc *CredentialController) UploadFile(c *fiber.Ctx) error {
 		payload)
 }
 
-// buildStorageKey generates a unique storage key from filename
-func (cc *CredentialController) buildStorageKey(filename string) (storageKey, fileExt string) {
-	fileExt = strings.TrimPrefix(filepath.Ext(filename), ".")
-	if fileExt == "" {
-		fileExt = "bin"
+// buildCredentialResponse maps a stored token to its API representation
+func (cc *CredentialController) buildCredentialResponse(token *model.Token) response.CredentialsSuccessResponse {
+	return response.CredentialsSuccessResponse{
+		CredentialID: token.TokenID.String(),
+		Type:         token.TokenType,
+		Status:       token.Status,
+		Issuer:       token.IssuerDID,
+		SubmittedAt:  token.CreatedAt.Format(time.RFC3339),
+		Documents:    buildCredentialDocumentResponses(token.Documents),
 	}
+}
 
-	baseName := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
-	storageKey = fmt.Sprintf("/%s_%d.%s", baseName, time.Now().UnixNano(), fileExt)
-	return
+// buildCredentialDocumentResponses maps the evidence documents linked to a credential to their summaries
+func buildCredentialDocumentResponses(links []model.TokenDocument) []response.CredentialDocumentResponse {
+	documents := make([]response.CredentialDocumentResponse, 0, len(links))
+	for _, link := range links {
+		summary := response.CredentialDocumentResponse{
+			DocumentID: link.DocumentID.String(),
+			Role:       link.Role,
+		}
+		if link.Document != nil {
+			summary.FileName = link.Document.FileName
+			summary.MimeType = link.Document.MimeType
+			summary.UploadedAt = link.Document.UploadedAt.UTC().Format(time.RFC3339)
+		}
+		documents = append(documents, summary)
+	}
+	return documents
 }
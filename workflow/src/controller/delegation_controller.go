@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DelegationController handles delegated access requests
+type DelegationController struct {
+	delegationService service.DelegationService
+	responseBuilder   *utils.ResponseBuilder
+}
+
+// NewDelegationController creates a new delegation controller
+func NewDelegationController(
+	delegationService service.DelegationService,
+	responseBuilder *utils.ResponseBuilder,
+) *DelegationController {
+	return &DelegationController{
+		delegationService: delegationService,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// @Tags         Delegation
+// @Summary      Delegate access
+// @Description  Authorizes the actor identified by the given universal identifier to perform the listed operations for the caller from validFrom (default now) until expiresAt or until revoked, for at most 366 days. The delegate acts by sending the caller's actor ID in the X-On-Behalf-Of header on /actor/update (profile:update), /credentials/add (credentials:add), /credentials/list and /credentials/get (credentials:list) and /credentials/upload (documents:upload). Every such request is recorded in the delegation audit log.
+// @Produce      json
+// @Param        request body  response.Request[validation.CreateDelegationGrantRequest]  true  "Request body"
+// @Router       /delegations/grants/create [post]
+// @Success      201  {object}  response.Response[response.DelegationGrantResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body, time window, or delegating to yourself"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Delegate not found"
+func (dc *DelegationController) CreateGrant(c *fiber.Ctx) error {
+	var req response.Request[validation.CreateDelegationGrantRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grant, err := dc.delegationService.CreateGrant(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDelegationGrantResponse(grant))
+}
+
+// @Tags         Delegation
+// @Summary      List delegation grants
+// @Description  Lists the delegation grants made by the caller, newest first, with cursor pagination and an optional status filter.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListDelegationGrantsRequest]  true  "Request body"
+// @Router       /delegations/grants/list [post]
+// @Success      200  {object}  response.Response[response.ListDelegationGrantsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (dc *DelegationController) ListGrants(c *fiber.Ctx) error {
+	var req response.Request[validation.ListDelegationGrantsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grants, nextCursor, err := dc.delegationService.ListGrants(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDelegationGrantsResponse(grants, nextCursor))
+}
+
+// @Tags         Delegation
+// @Summary      Revoke a delegation grant
+// @Description  Revokes a delegation grant made by the caller. Requests the delegate sends afterwards are rejected.
+// @Produce      json
+// @Param        request body  response.Request[validation.DelegationGrantIDRequest]  true  "Request body"
+// @Router       /delegations/grants/revoke [post]
+// @Success      200  {object}  response.Response[response.DelegationGrantResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Delegation grant not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Delegation grant already revoked"
+func (dc *DelegationController) RevokeGrant(c *fiber.Ctx) error {
+	var req response.Request[validation.DelegationGrantIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grant, err := dc.delegationService.RevokeGrant(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDelegationGrantResponse(grant))
+}
+
+// @Tags         Delegation
+// @Summary      Review delegated actions
+// @Description  Lists the requests delegates made for the caller, newest first, with the delegate, the operation and the status returned. Optionally restricted to one grant.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListDelegatedActionsRequest]  true  "Request body"
+// @Router       /delegations/grants/actions [post]
+// @Success      200  {object}  response.Response[response.ListDelegatedActionsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (dc *DelegationController) ListActions(c *fiber.Ctx) error {
+	var req response.Request[validation.ListDelegatedActionsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	actions, nextCursor, err := dc.delegationService.ListActions(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDelegatedActionsResponse(actions, nextCursor))
+}
+
+// @Tags         Delegation
+// @Summary      List delegation grants received
+// @Description  Lists the delegation grants other actors made to the caller, newest first, with cursor pagination and an optional status filter.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListDelegationGrantsRequest]  true  "Request body"
+// @Router       /delegations/received/list [post]
+// @Success      200  {object}  response.Response[response.ListDelegationGrantsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (dc *DelegationController) ListReceivedGrants(c *fiber.Ctx) error {
+	var req response.Request[validation.ListDelegationGrantsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grants, nextCursor, err := dc.delegationService.ListReceivedGrants(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDelegationGrantsResponse(grants, nextCursor))
+}
+
+// @Tags         Delegation
+// @Summary      Review actions performed as a delegate
+// @Description  Lists the requests the caller made for other actors as a delegate, newest first. Optionally restricted to one grant.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListDelegatedActionsRequest]  true  "Request body"
+// @Router       /delegations/received/actions [post]
+// @Success      200  {object}  response.Response[response.ListDelegatedActionsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (dc *DelegationController) ListPerformedActions(c *fiber.Ctx) error {
+	var req response.Request[validation.ListDelegatedActionsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	actions, nextCursor, err := dc.delegationService.ListPerformedActions(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDelegatedActionsResponse(actions, nextCursor))
+}
+
+// buildDelegationGrantsResponse maps one page of delegation grants to its response representation
+func buildDelegationGrantsResponse(grants []model.DelegationGrant, nextCursor string) response.ListDelegationGrantsResponse {
+	payload := response.ListDelegationGrantsResponse{
+		Grants:     make([]response.DelegationGrantResponse, 0, len(grants)),
+		NextCursor: nextCursor,
+	}
+	for i := range grants {
+		payload.Grants = append(payload.Grants, buildDelegationGrantResponse(&grants[i]))
+	}
+	return payload
+}
+
+// buildDelegationGrantResponse maps a delegation grant to its response representation
+func buildDelegationGrantResponse(grant *model.DelegationGrant) response.DelegationGrantResponse {
+	return response.DelegationGrantResponse{
+		GrantID:             grant.GrantID.String(),
+		PrincipalID:         grant.PrincipalID.String(),
+		PrincipalIdentifier: grant.PrincipalIdentifier,
+		DelegateID:          grant.DelegateID.String(),
+		DelegateIdentifier:  grant.DelegateIdentifier,
+		Scopes:              grant.Scopes,
+		Relationship:        grant.Relationship,
+		Status:              grant.Status(time.Now()),
+		ValidFrom:           grant.ValidFrom.UTC().Format(time.RFC3339),
+		ExpiresAt:           grant.ExpiresAt.UTC().Format(time.RFC3339),
+		RevokedAt:           formatOptionalTime(grant.RevokedAt),
+		CreatedAt:           grant.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// buildDelegatedActionsResponse maps one page of delegated actions to its response representation
+func buildDelegatedActionsResponse(actions []model.DelegatedAction, nextCursor string) response.ListDelegatedActionsResponse {
+	payload := response.ListDelegatedActionsResponse{
+		Entries:    make([]response.DelegatedActionResponse, 0, len(actions)),
+		NextCursor: nextCursor,
+	}
+	for _, action := range actions {
+		payload.Entries = append(payload.Entries, response.DelegatedActionResponse{
+			ActionID:    action.ActionID.String(),
+			GrantID:     action.GrantID.String(),
+			PrincipalID: action.PrincipalID.String(),
+			DelegateID:  action.DelegateID.String(),
+			Scope:       action.Scope,
+			Method:      action.Method,
+			Path:        action.Path,
+			StatusCode:  action.StatusCode,
+			IPAddress:   action.IPAddress,
+			UserAgent:   action.UserAgent,
+			PerformedAt: action.PerformedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return payload
+}
@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeletionController handles account deletion requests and the retention holds that limit them
+type DeletionController struct {
+	deletionService service.DeletionService
+	responseBuilder *utils.ResponseBuilder
+}
+
+// NewDeletionController creates a new deletion controller
+func NewDeletionController(
+	deletionService service.DeletionService,
+	responseBuilder *utils.ResponseBuilder,
+) *DeletionController {
+	return &DeletionController{
+		deletionService: deletionService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Tags         Actor
+// @Summary      Request account deletion
+// @Description  Schedules the erasure of the caller's account after the grace period (30 days by default). Until then the account works as before and the deletion can be cancelled; subscribers to actor.deletion_requested are notified. The erasure deletes the auth provider user, releases the universal identifiers into their cooling-off period and deletes the actor's records and stored documents. Records under a retention hold are kept with the personal data on the account replaced by placeholders. The password is required again. The response contains a certificateToken, shown only once, that the erasure certificate is fetched with after the account is gone.
+// @Produce      json
+// @Param        request body  response.Request[validation.RequestDeletionRequest]  true  "Request body"
+// @Router       /actor/deletion/request [post]
+// @Success      202  {object}  response.Response[response.AccountDeletionResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized or wrong password"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "A deletion is already pending"
+func (dc *DeletionController) RequestDeletion(c *fiber.Ctx) error {
+	var req response.Request[validation.RequestDeletionRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	deletion, certificateToken, err := dc.deletionService.RequestDeletion(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := buildAccountDeletionResponse(deletion)
+	payload.CertificateToken = certificateToken
+	payload.Message = constants.MsgAccountDeletionScheduled
+	return dc.responseBuilder.AcceptedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Actor
+// @Summary      Get account deletion
+// @Description  Returns the caller's most recent account deletion request and its status.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/deletion/get [post]
+// @Success      200  {object}  response.Response[response.AccountDeletionResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "No deletion requested"
+func (dc *DeletionController) GetDeletion(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	deletion, err := dc.deletionService.GetDeletion(c)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildAccountDeletionResponse(deletion))
+}
+
+// @Tags         Actor
+// @Summary      Cancel account deletion
+// @Description  Cancels the caller's pending account deletion during the grace period. Subscribers to actor.deletion_cancelled are notified.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/deletion/cancel [post]
+// @Success      200  {object}  response.Response[response.AccountDeletionResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "No deletion pending"
+func (dc *DeletionController) CancelDeletion(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	deletion, err := dc.deletionService.CancelDeletion(c)
+	if err != nil {
+		return err
+	}
+
+	payload := buildAccountDeletionResponse(deletion)
+	payload.Message = constants.MsgAccountDeletionCancelled
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Actor
+// @Summary      Get erasure certificate
+// @Description  Returns a completed account deletion with its erasure certificate, a JWT with typ "erasure-certificate+jwt" signed by the platform's issuer key. Its sub is the erased actor's DID, jti the deletion ID, iat the time of erasure and erased the number of records deleted per category; pseudonymized is true when records under retention hold were kept. The account no longer exists, so the certificate is fetched with the deletion ID and the certificateToken returned when the deletion was requested. The certificate names the erased actor; without the token the response is the same as for an unknown deletion.
+// @Produce      json
+// @Param        request body  response.Request[validation.DeletionCertificateRequest]  true  "Request body"
+// @Router       /actor/deletion/certificate [post]
+// @Success      200  {object}  response.Response[response.AccountDeletionResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Deletion not found, not completed or wrong certificate token"
+func (dc *DeletionController) GetCertificate(c *fiber.Ctx) error {
+	var req response.Request[validation.DeletionCertificateRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	deletion, err := dc.deletionService.GetCertificate(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildAccountDeletionResponse(deletion))
+}
+
+// @Tags         Admin
+// @Summary      Place a retention hold
+// @Description  Places a legal or regulatory hold on an actor's records. While a hold is active, erasing the account keeps the credentials, documents, verification history, access logs and key history and pseudonymizes the account instead of deleting it. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.AddRetentionHoldRequest]  true  "Request body"
+// @Router       /admin/retentionHolds/add [post]
+// @Success      201  {object}  response.Response[response.RetentionHoldResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or expiry"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Actor not found"
+func (dc *DeletionController) AddHold(c *fiber.Ctx) error {
+	var req response.Request[validation.AddRetentionHoldRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	hold, err := dc.deletionService.AddHold(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildRetentionHoldResponse(hold))
+}
+
+// @Tags         Admin
+// @Summary      List retention holds
+// @Description  Returns the retention holds of an actor, released and expired ones included, newest first. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListRetentionHoldsRequest]  true  "Request body"
+// @Router       /admin/retentionHolds/list [post]
+// @Success      200  {object}  response.Response[response.ListRetentionHoldsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (dc *DeletionController) ListHolds(c *fiber.Ctx) error {
+	var req response.Request[validation.ListRetentionHoldsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	holds, err := dc.deletionService.ListHolds(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListRetentionHoldsResponse{Holds: make([]response.RetentionHoldResponse, 0, len(holds))}
+	for i := range holds {
+		payload.Holds = append(payload.Holds, buildRetentionHoldResponse(&holds[i]))
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Admin
+// @Summary      Release a retention hold
+// @Description  Ends a retention hold. Accounts already erased while it was active stay pseudonymized. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.RetentionHoldRequest]  true  "Request body"
+// @Router       /admin/retentionHolds/release [post]
+// @Success      200  {object}  response.Response[response.RetentionHoldResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Retention hold not found"
+func (dc *DeletionController) ReleaseHold(c *fiber.Ctx) error {
+	var req response.Request[validation.RetentionHoldRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	hold, err := dc.deletionService.ReleaseHold(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildRetentionHoldResponse(hold))
+}
+
+func buildAccountDeletionResponse(deletion *model.AccountDeletion) response.AccountDeletionResponse {
+	resp := response.AccountDeletionResponse{
+		DeletionID:    deletion.DeletionID.String(),
+		ActorID:       deletion.ActorID.String(),
+		Status:        deletion.Status,
+		EffectiveAt:   deletion.EffectiveAt.UTC().Format(time.RFC3339),
+		Pseudonymized: deletion.Pseudonymized,
+		CreatedAt:     deletion.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if deletion.Reason != nil {
+		resp.Reason = *deletion.Reason
+	}
+	if deletion.CancelledAt != nil {
+		resp.CancelledAt = deletion.CancelledAt.UTC().Format(time.RFC3339)
+	}
+	if deletion.CompletedAt != nil {
+		resp.CompletedAt = deletion.CompletedAt.UTC().Format(time.RFC3339)
+		resp.Summary = deletion.Summary
+	}
+	if deletion.Certificate != nil {
+		resp.Certificate = *deletion.Certificate
+	}
+	return resp
+}
+
+func buildRetentionHoldResponse(hold *model.RetentionHold) response.RetentionHoldResponse {
+	resp := response.RetentionHoldResponse{
+		HoldID:    hold.HoldID.String(),
+		ActorID:   hold.ActorID.String(),
+		Reason:    hold.Reason,
+		CreatedAt: hold.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if hold.ExpiresAt != nil {
+		resp.ExpiresAt = hold.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if hold.ReleasedAt != nil {
+		resp.ReleasedAt = hold.ReleasedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
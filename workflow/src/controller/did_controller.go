@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DIDController serves actor did:web documents and manages the service endpoints they publish
+type DIDController struct {
+	didDocumentService service.DIDDocumentService
+	responseBuilder    *utils.ResponseBuilder
+}
+
+// NewDIDController creates a new DID controller
+func NewDIDController(
+	didDocumentService service.DIDDocumentService,
+	responseBuilder *utils.ResponseBuilder,
+) *DIDController {
+	return &DIDController{
+		didDocumentService: didDocumentService,
+		responseBuilder:    responseBuilder,
+	}
+}
+
+// @Tags         DID
+// @Summary      Actor DID document
+// @Description  Serves the did:web document of the actor with the given universal identifier. It lists every master key the actor has held as a Multikey verification method, of which only the current key is an authentication and assertion method, the services the actor registered, and the actor's did:key as alsoKnownAs.
+// @Produce      json
+// @Param        identifier  path  string  true  "Universal identifier"
+// @Router       /{identifier}/did.json [get]
+// @Success      200  {object}  object  "DID document"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Unknown identifier"
+func (dc *DIDController) ActorDocument(c *fiber.Ctx) error {
+	identifier, err := url.PathUnescape(c.Params("identifier"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+	}
+
+	document, err := dc.didDocumentService.ActorDocument(c, identifier)
+	if err != nil {
+		return err
+	}
+	return c.JSON(document)
+}
+
+// @Tags         DID
+// @Summary      Publish a DID service
+// @Description  Adds a service endpoint to the caller's did:web document. The id is the service ID relative to the DID and must be unique among the caller's services.
+// @Produce      json
+// @Param        request body  response.Request[validation.AddDIDServiceRequest]  true  "Request body"
+// @Router       /did/services/add [post]
+// @Success      201  {object}  response.Response[response.DIDServiceResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or insecure endpoint"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "A service with this ID already exists"
+func (dc *DIDController) AddService(c *fiber.Ctx) error {
+	var req response.Request[validation.AddDIDServiceRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	service, err := dc.didDocumentService.AddService(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return dc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildDIDServiceResponse(service))
+}
+
+// @Tags         DID
+// @Summary      List DID services
+// @Description  Returns the caller's did:web identifier and the service endpoints its document publishes. Actors without a universal identifier have no did:web.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /did/services/list [post]
+// @Success      200  {object}  response.Response[response.ListDIDServicesResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (dc *DIDController) ListServices(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	list, err := dc.didDocumentService.ListServices(c)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListDIDServicesResponse{
+		DID:      list.DID,
+		Services: make([]response.DIDServiceResponse, 0, len(list.Services)),
+	}
+	for i := range list.Services {
+		payload.Services = append(payload.Services, buildDIDServiceResponse(&list.Services[i]))
+	}
+
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         DID
+// @Summary      Remove a DID service
+// @Description  Removes a service endpoint from the caller's did:web document.
+// @Produce      json
+// @Param        request body  response.Request[validation.DIDServiceIDRequest]  true  "Request body"
+// @Router       /did/services/remove [post]
+// @Success      200  {object}  response.Response[map[string]string]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "DID service not found"
+func (dc *DIDController) RemoveService(c *fiber.Ctx) error {
+	var req response.Request[validation.DIDServiceIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	if err := dc.didDocumentService.RemoveService(c, &req.Request); err != nil {
+		return err
+	}
+
+	payload := map[string]string{"id": req.Request.ID}
+	return dc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// buildDIDServiceResponse maps a service endpoint to its API representation
+func buildDIDServiceResponse(service *model.DIDService) response.DIDServiceResponse {
+	return response.DIDServiceResponse{
+		ID:              service.Fragment,
+		Type:            service.Type,
+		ServiceEndpoint: service.Endpoint,
+		CreatedAt:       service.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
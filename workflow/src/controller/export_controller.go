@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportController handles personal data export requests and downloads
+type ExportController struct {
+	exportService   service.ExportService
+	responseBuilder *utils.ResponseBuilder
+}
+
+// NewExportController creates a new data export controller
+func NewExportController(
+	exportService service.ExportService,
+	responseBuilder *utils.ResponseBuilder,
+) *ExportController {
+	return &ExportController{
+		exportService:   exportService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Tags         Actor
+// @Summary      Request data export
+// @Description  Starts preparing a zip bundle of everything held about the caller: export.json with the profile, identifiers, integrations, key history, credentials, documents, share grants, audit events and the login history kept by the auth provider, the stored document files under documents/<documentId>/, and manifest.json with the SHA-256 hash of every file, signed by the platform key in manifest.jwt. The bundle is stored encrypted and kept for 7 days by default; subscribers to actor.data_export_ready are notified when it is ready. Only one export can be prepared at a time.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/exports/request [post]
+// @Success      202  {object}  response.Response[response.DataExportResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "An export is already being prepared"
+func (ec *ExportController) RequestExport(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	export, err := ec.exportService.RequestExport(c)
+	if err != nil {
+		return err
+	}
+
+	payload := buildDataExportResponse(export)
+	payload.Message = constants.MsgDataExportRequested
+	return ec.responseBuilder.AcceptedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Actor
+// @Summary      List data exports
+// @Description  Returns the caller's most recent data exports, newest first.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/exports/list [post]
+// @Success      200  {object}  response.Response[response.ListDataExportsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (ec *ExportController) ListExports(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	exports, err := ec.exportService.ListExports(c)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListDataExportsResponse{Exports: make([]response.DataExportResponse, 0, len(exports))}
+	for i := range exports {
+		payload.Exports = append(payload.Exports, buildDataExportResponse(&exports[i]))
+	}
+
+	return ec.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Actor
+// @Summary      Create data export download link
+// @Description  Issues a download link for a ready export. The link works once and expires after 15 minutes by default; issuing a new link invalidates the previous one.
+// @Produce      json
+// @Param        request body  response.Request[validation.DataExportRequest]  true  "Request body"
+// @Router       /actor/exports/link [post]
+// @Success      200  {object}  response.Response[response.DataExportLinkResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Export not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Export not ready"
+func (ec *ExportController) CreateLink(c *fiber.Ctx) error {
+	var req response.Request[validation.DataExportRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	link, err := ec.exportService.CreateLink(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return ec.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, response.DataExportLinkResponse{
+		ExportID:  link.Export.ExportID.String(),
+		URL:       link.URL,
+		ExpiresAt: link.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+// @Tags         Actor
+// @Summary      Download data export
+// @Description  Downloads the decrypted zip bundle of a data export through a link issued by /actor/exports/link. The token in the path is the only credential needed, and it is used up by the download.
+// @Produce      application/zip
+// @Param        token  path  string  true  "Download token"
+// @Router       /actor/exports/download/{token} [get]
+// @Success      200  {file}  file  "Data export bundle"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Link invalid, expired or already used"
+func (ec *ExportController) Download(c *fiber.Ctx) error {
+	noStore(c)
+
+	bundle, err := ec.exportService.Download(c, c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(constants.HTTPHeaderContentType, constants.DataExportContentType)
+	c.Set(constants.HTTPHeaderDisposition, fmt.Sprintf("attachment; filename=%q", bundle.FileName))
+	return c.Send(bundle.Data)
+}
+
+func buildDataExportResponse(export *model.DataExport) response.DataExportResponse {
+	resp := response.DataExportResponse{
+		ExportID:  export.ExportID.String(),
+		Status:    export.Status,
+		CreatedAt: export.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if export.Size != nil {
+		resp.Size = *export.Size
+	}
+	if export.SHA256 != nil {
+		resp.SHA256 = *export.SHA256
+	}
+	if export.Error != nil {
+		resp.Error = *export.Error
+	}
+	if export.ExpiresAt != nil {
+		resp.ExpiresAt = export.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if export.DownloadedAt != nil {
+		resp.DownloadedAt = export.DownloadedAt.UTC().Format(time.RFC3339)
+	}
+	if export.CompletedAt != nil {
+		resp.CompletedAt = export.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdentifierController manages the universal identifiers (aliases) of the caller
+type IdentifierController struct {
+	identifierService service.IdentifierService
+	responseBuilder   *utils.ResponseBuilder
+}
+
+// NewIdentifierController creates a new identifier controller
+func NewIdentifierController(
+	identifierService service.IdentifierService,
+	responseBuilder *utils.ResponseBuilder,
+) *IdentifierController {
+	return &IdentifierController{
+		identifierService: identifierService,
+		responseBuilder:   responseBuilder,
+	}
+}
+
+// @Tags         Actor
+// @Summary      Add a universal identifier
+// @Description  Adds an alias to the caller's universal identifiers. Any identifier can be used to log in and to resolve the actor. An actor holds at most 5 identifiers; the first one becomes primary. Identifiers released by another actor cannot be claimed until their cooling-off period is over.
+// @Produce      json
+// @Param        request body  response.Request[validation.IdentifierRequest]  true  "Request body"
+// @Router       /actor/identifiers/add [post]
+// @Success      201  {object}  response.Response[response.IdentifierResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or identifier limit reached"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Identifier in use or in its cooling-off period"
+func (ic *IdentifierController) AddIdentifier(c *fiber.Ctx) error {
+	var req response.Request[validation.IdentifierRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	identifier, err := ic.identifierService.AddIdentifier(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return ic.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildIdentifierResponse(identifier))
+}
+
+// @Tags         Actor
+// @Summary      List universal identifiers
+// @Description  Returns the caller's universal identifiers, primary first.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/identifiers/list [post]
+// @Success      200  {object}  response.Response[response.ListIdentifiersResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (ic *IdentifierController) ListIdentifiers(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	identifiers, err := ic.identifierService.ListIdentifiers(c)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListIdentifiersResponse{
+		Identifiers: make([]response.IdentifierResponse, 0, len(identifiers)),
+	}
+	for i := range identifiers {
+		payload.Identifiers = append(payload.Identifiers, buildIdentifierResponse(&identifiers[i]))
+	}
+
+	return ic.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Actor
+// @Summary      Set the primary universal identifier
+// @Description  Makes one of the caller's universal identifiers primary. The primary identifier is shown on the profile and names the actor's did:web.
+// @Produce      json
+// @Param        request body  response.Request[validation.IdentifierRequest]  true  "Request body"
+// @Router       /actor/identifiers/setPrimary [post]
+// @Success      200  {object}  response.Response[response.IdentifierResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Identifier not found"
+func (ic *IdentifierController) SetPrimary(c *fiber.Ctx) error {
+	var req response.Request[validation.IdentifierRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	identifier, err := ic.identifierService.SetPrimary(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return ic.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildIdentifierResponse(identifier))
+}
+
+// @Tags         Actor
+// @Summary      Remove a universal identifier
+// @Description  Releases one of the caller's universal identifiers. The primary identifier cannot be removed. A released identifier stays reserved for the caller during a cooling-off period, after which anyone can claim it.
+// @Produce      json
+// @Param        request body  response.Request[validation.IdentifierRequest]  true  "Request body"
+// @Router       /actor/identifiers/remove [post]
+// @Success      200  {object}  response.Response[response.ReleasedIdentifierResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or primary identifier"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Identifier not found"
+func (ic *IdentifierController) RemoveIdentifier(c *fiber.Ctx) error {
+	var req response.Request[validation.IdentifierRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	released, err := ic.identifierService.RemoveIdentifier(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ReleasedIdentifierResponse{
+		Identifier:  released.Identifier,
+		AvailableAt: released.AvailableAt.UTC().Format(time.RFC3339),
+	}
+	return ic.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// buildIdentifierResponse maps a universal identifier to its API representation
+func buildIdentifierResponse(identifier *model.Identifier) response.IdentifierResponse {
+	return response.IdentifierResponse{
+		Identifier: identifier.Identifier,
+		IsPrimary:  identifier.IsPrimary,
+		CreatedAt:  identifier.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// JobController exposes the progress of background jobs to the actors that started them
+type JobController struct {
+	jobService      service.JobService
+	responseBuilder *utils.ResponseBuilder
+}
+
+// NewJobController creates a new job controller
+func NewJobController(
+	jobService service.JobService,
+	responseBuilder *utils.ResponseBuilder,
+) *JobController {
+	return &JobController{
+		jobService:      jobService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Tags         Jobs
+// @Summary      Poll a job
+// @Description  Returns the progress of a background job started by the caller, such as the verification of a submitted credential. Failed attempts are retried with backoff; a job that keeps failing ends in the 'dead' state.
+// @Produce      json
+// @Param        request body  response.Request[validation.JobIDRequest]  true  "Request body"
+// @Router       /jobs/get [post]
+// @Success      200  {object}  response.Response[response.JobResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Job not found"
+func (jc *JobController) GetJob(c *fiber.Ctx) error {
+	var req response.Request[validation.JobIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	job, err := jc.jobService.GetJob(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return jc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildJobResponse(job))
+}
+
+// buildJobResponse maps a job to its polling representation
+func buildJobResponse(job *model.Job) response.JobResponse {
+	payload := response.JobResponse{
+		JobID:       job.JobID.String(),
+		Type:        job.Type,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   job.UpdatedAt.UTC().Format(time.RFC3339),
+		CompletedAt: formatOptionalTime(job.CompletedAt),
+	}
+	if job.ResourceID != nil {
+		resourceID := job.ResourceID.String()
+		payload.ResourceID = &resourceID
+	}
+	if job.Status == constants.JobStatusQueued {
+		payload.NextRunAt = formatOptionalTime(&job.RunAt)
+	}
+	if len(job.Result) > 0 {
+		payload.Result = job.Result
+	}
+	return payload
+}
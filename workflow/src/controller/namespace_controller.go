@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NamespaceController handles administration of the universal identifier namespace
+type NamespaceController struct {
+	namespaceService service.NamespaceService
+	responseBuilder  *utils.ResponseBuilder
+}
+
+// NewNamespaceController creates a new namespace controller
+func NewNamespaceController(
+	namespaceService service.NamespaceService,
+	responseBuilder *utils.ResponseBuilder,
+) *NamespaceController {
+	return &NamespaceController{
+		namespaceService: namespaceService,
+		responseBuilder:  responseBuilder,
+	}
+}
+
+// @Tags         Admin
+// @Summary      Register an identifier domain
+// @Description  Registers a universal identifier domain to an organization's actor. Only the owner can claim identifiers local@domain in it unless the domain is open. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.AddNamespaceDomainRequest]  true  "Request body"
+// @Router       /admin/namespaces/domains/add [post]
+// @Success      201  {object}  response.Response[response.NamespaceDomainResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or domain"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Owner actor not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Domain already registered"
+func (nc *NamespaceController) AddDomain(c *fiber.Ctx) error {
+	var req response.Request[validation.AddNamespaceDomainRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	domain, err := nc.namespaceService.AddDomain(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return nc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildNamespaceDomainResponse(domain))
+}
+
+// @Tags         Admin
+// @Summary      List identifier domains
+// @Description  Returns the default universal identifier domain, open to everyone, and the domains registered to organizations. Requires the admin role.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /admin/namespaces/domains/list [post]
+// @Success      200  {object}  response.Response[response.ListNamespaceDomainsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (nc *NamespaceController) ListDomains(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	domains, err := nc.namespaceService.ListDomains(c)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListNamespaceDomainsResponse{
+		DefaultDomain: nc.namespaceService.DefaultDomain(),
+		Domains:       make([]response.NamespaceDomainResponse, 0, len(domains)),
+	}
+	for i := range domains {
+		payload.Domains = append(payload.Domains, buildNamespaceDomainResponse(&domains[i]))
+	}
+
+	return nc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Admin
+// @Summary      Remove an identifier domain
+// @Description  Removes a registered universal identifier domain, so no new identifiers can be claimed in it. Identifiers already claimed in it are kept. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.NamespaceDomainRequest]  true  "Request body"
+// @Router       /admin/namespaces/domains/remove [post]
+// @Success      200  {object}  response.Response[map[string]string]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Domain not found"
+func (nc *NamespaceController) RemoveDomain(c *fiber.Ctx) error {
+	var req response.Request[validation.NamespaceDomainRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	if err := nc.namespaceService.RemoveDomain(c, &req.Request); err != nil {
+		return err
+	}
+
+	payload := map[string]string{"domain": req.Request.Domain}
+	return nc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Admin
+// @Summary      Reserve or block a word
+// @Description  Adds a word to the identifier word list. A reserved word cannot be the local part of a new universal identifier; a blocked word cannot appear anywhere in it. Words are matched by their confusable skeleton ignoring '.', '-' and '_', so look-alike spellings are caught too. Existing identifiers are not affected. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.AddReservedWordRequest]  true  "Request body"
+// @Router       /admin/namespaces/words/add [post]
+// @Success      201  {object}  response.Response[response.ReservedWordResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Word or a look-alike already listed"
+func (nc *NamespaceController) AddWord(c *fiber.Ctx) error {
+	var req response.Request[validation.AddReservedWordRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	word, err := nc.namespaceService.AddWord(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return nc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildReservedWordResponse(word))
+}
+
+// @Tags         Admin
+// @Summary      List reserved and blocked words
+// @Description  Returns the identifier word list. Requires the admin role.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /admin/namespaces/words/list [post]
+// @Success      200  {object}  response.Response[response.ListReservedWordsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (nc *NamespaceController) ListWords(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	words, err := nc.namespaceService.ListWords(c)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListReservedWordsResponse{
+		Words: make([]response.ReservedWordResponse, 0, len(words)),
+	}
+	for i := range words {
+		payload.Words = append(payload.Words, buildReservedWordResponse(&words[i]))
+	}
+
+	return nc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Admin
+// @Summary      Remove a reserved or blocked word
+// @Description  Removes a word, or the listed look-alike of it, from the identifier word list. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.ReservedWordRequest]  true  "Request body"
+// @Router       /admin/namespaces/words/remove [post]
+// @Success      200  {object}  response.Response[map[string]string]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Word not found"
+func (nc *NamespaceController) RemoveWord(c *fiber.Ctx) error {
+	var req response.Request[validation.ReservedWordRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	if err := nc.namespaceService.RemoveWord(c, &req.Request); err != nil {
+		return err
+	}
+
+	payload := map[string]string{"word": req.Request.Word}
+	return nc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// buildNamespaceDomainResponse maps a registered identifier domain to its API representation
+func buildNamespaceDomainResponse(domain *model.NamespaceDomain) response.NamespaceDomainResponse {
+	return response.NamespaceDomainResponse{
+		Domain:       domain.Domain,
+		OwnerActorID: domain.OwnerActorID.String(),
+		Open:         domain.Open,
+		CreatedAt:    domain.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// buildReservedWordResponse maps a reserved or blocked word to its API representation
+func buildReservedWordResponse(word *model.ReservedWord) response.ReservedWordResponse {
+	resp := response.ReservedWordResponse{
+		Word:      word.Word,
+		Kind:      word.Kind,
+		CreatedAt: word.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if word.Reason != nil {
+		resp.Reason = *word.Reason
+	}
+	return resp
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// OID4VCIController exposes the OpenID for Verifiable Credential Issuance endpoints.
+// Wallet-facing endpoints respond with plain OAuth 2.0 JSON rather than the API envelope.
+type OID4VCIController struct {
+	oid4vciService  service.OID4VCIService
+	responseBuilder *utils.ResponseBuilder
+	log             *logrus.Logger
+}
+
+// NewOID4VCIController creates a new OID4VCI controller
+func NewOID4VCIController(
+	oid4vciService service.OID4VCIService,
+	responseBuilder *utils.ResponseBuilder,
+	log *logrus.Logger,
+) *OID4VCIController {
+	return &OID4VCIController{
+		oid4vciService:  oid4vciService,
+		responseBuilder: responseBuilder,
+		log:             log,
+	}
+}
+
+// @Tags         OID4VCI
+// @Summary      Credential issuer metadata
+// @Description  Publishes the OID4VCI credential issuer metadata, including the credential endpoint and supported credential configurations.
+// @Produce      json
+// @Router       /.well-known/openid-credential-issuer [get]
+// @Success      200  {object}  response.CredentialIssuerMetadata
+func (oc *OID4VCIController) IssuerMetadata(c *fiber.Ctx) error {
+	return c.JSON(oc.oid4vciService.IssuerMetadata())
+}
+
+// @Tags         OID4VCI
+// @Summary      Authorization server metadata
+// @Description  Publishes OAuth 2.0 authorization server metadata for the pre-authorized code grant.
+// @Produce      json
+// @Router       /.well-known/oauth-authorization-server [get]
+// @Success      200  {object}  response.AuthorizationServerMetadata
+func (oc *OID4VCIController) AuthorizationServerMetadata(c *fiber.Ctx) error {
+	return c.JSON(oc.oid4vciService.AuthorizationServerMetadata())
+}
+
+// @Tags         OID4VCI
+// @Summary      Issuer DID document
+// @Description  Serves the did:web document holding the key that signs issued credentials.
+// @Produce      json
+// @Router       /.well-known/did.json [get]
+// @Success      200  {object}  object  "DID document"
+func (oc *OID4VCIController) IssuerDIDDocument(c *fiber.Ctx) error {
+	document, err := oc.oid4vciService.IssuerDIDDocument()
+	if err != nil {
+		return err
+	}
+	return c.JSON(document)
+}
+
+// @Tags         OID4VCI
+// @Summary      Create a credential offer
+// @Description  Creates a single-use OID4VCI credential offer for the authenticated actor using the pre-authorized code flow. The returned URI can be rendered as a QR code for a wallet; the optional transaction code must be shown to the user separately.
+// @Produce      json
+// @Param        request body  response.Request[validation.CreateCredentialOfferRequest]  true  "Request body"
+// @Router       /oid4vci/offers/create [post]
+// @Success      201  {object}  response.Response[response.CredentialOfferResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or unsupported credential configuration"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (oc *OID4VCIController) CreateOffer(c *fiber.Ctx) error {
+	var req response.Request[validation.CreateCredentialOfferRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	offer, err := oc.oid4vciService.CreateOffer(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, offer)
+}
+
+// @Tags         OID4VCI
+// @Summary      Token endpoint
+// @Description  Exchanges a pre-authorized code (and transaction code, when required) for an access token and c_nonce.
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        grant_type           formData  string  true   "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+// @Param        pre-authorized_code  formData  string  true   "Pre-authorized code from the credential offer"
+// @Param        tx_code              formData  string  false  "Transaction code"
+// @Router       /oid4vci/token [post]
+// @Success      200  {object}  response.OID4VCITokenResponse
+// @Failure      400  {object}  response.OAuthErrorResponse
+func (oc *OID4VCIController) Token(c *fiber.Ctx) error {
+	noStore(c)
+
+	var req validation.OID4VCITokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return protocolError(c, oc.log, &service.ProtocolError{
+			Status: fiber.StatusBadRequest, Code: constants.OAuthErrInvalidRequest, Description: constants.ErrInvalidRequestBody,
+		})
+	}
+
+	token, err := oc.oid4vciService.ExchangeToken(c, &req)
+	if err != nil {
+		return protocolError(c, oc.log, err)
+	}
+
+	return c.JSON(token)
+}
+
+// @Tags         OID4VCI
+// @Summary      Credential endpoint
+// @Description  Issues a VC-JWT bound to the wallet key proven by the proof JWT. Requires the access token from the token endpoint.
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header  string                               true  "Bearer access token"
+// @Param        request        body    validation.OID4VCICredentialRequest  true  "Credential request"
+// @Router       /oid4vci/credential [post]
+// @Success      200  {object}  response.OID4VCICredentialResponse
+// @Failure      400  {object}  response.OAuthErrorResponse
+// @Failure      401  {object}  response.OAuthErrorResponse
+func (oc *OID4VCIController) Credential(c *fiber.Ctx) error {
+	noStore(c)
+
+	var req validation.OID4VCICredentialRequest
+	if err := c.BodyParser(&req); err != nil {
+		return protocolError(c, oc.log, &service.ProtocolError{
+			Status: fiber.StatusBadRequest, Code: constants.OAuthErrInvalidRequest, Description: constants.ErrInvalidRequestBody,
+		})
+	}
+
+	credential, err := oc.oid4vciService.IssueCredential(c, bearerToken(c), &req)
+	if err != nil {
+		return protocolError(c, oc.log, err)
+	}
+
+	return c.JSON(credential)
+}
+
+// protocolError writes err as an OAuth 2.0 error response; unexpected errors become server_error
+func protocolError(c *fiber.Ctx, log *logrus.Logger, err error) error {
+	var protocolErr *service.ProtocolError
+	if !errors.As(err, &protocolErr) {
+		log.Errorf("Protocol request %s failed: %+v", c.Path(), err)
+		protocolErr = &service.ProtocolError{Status: fiber.StatusInternalServerError, Code: constants.OAuthErrServerError}
+	}
+
+	if protocolErr.Status == fiber.StatusUnauthorized {
+		c.Set(constants.HTTPHeaderWWWAuth, constants.HTTPHeaderBearer+` error="`+protocolErr.Code+`"`)
+	}
+
+	body := response.OAuthErrorResponse{Error: protocolErr.Code, ErrorDescription: protocolErr.Description}
+	if protocolErr.CNonce != nil {
+		body.CNonce = *protocolErr.CNonce
+		body.CNonceExpiresIn = constants.OID4VCINonceTTL * 60
+	}
+
+	return c.Status(protocolErr.Status).JSON(body)
+}
+
+// noStore prevents caching of responses that carry tokens or credentials
+func noStore(c *fiber.Ctx) {
+	c.Set(constants.HTTPHeaderCacheControl, "no-store")
+	c.Set(constants.HTTPHeaderPragma, "no-cache")
+}
+
+// bearerToken returns the token of a Bearer Authorization header, or an empty string
+func bearerToken(c *fiber.Ctx) string {
+	scheme, token, found := strings.Cut(c.Get(constants.HTTPHeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, constants.HTTPHeaderBearer) {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// OID4VPController exposes the OpenID for Verifiable Presentations verifier endpoints.
+// Wallet-facing endpoints respond with plain OAuth 2.0 JSON rather than the API envelope.
+type OID4VPController struct {
+	oid4vpService   service.OID4VPService
+	responseBuilder *utils.ResponseBuilder
+	log             *logrus.Logger
+}
+
+// NewOID4VPController creates a new OID4VP controller
+func NewOID4VPController(
+	oid4vpService service.OID4VPService,
+	responseBuilder *utils.ResponseBuilder,
+	log *logrus.Logger,
+) *OID4VPController {
+	return &OID4VPController{
+		oid4vpService:   oid4vpService,
+		responseBuilder: responseBuilder,
+		log:             log,
+	}
+}
+
+// @Tags         OID4VP
+// @Summary      Create a presentation request
+// @Description  Creates an OID4VP authorization request for the given Presentation Exchange definition. The returned openid4vp:// URI references a signed request object and can be rendered as a QR code. The result can be polled or pushed to the optional callback URL, signed like webhook deliveries with the returned callbackSecret.
+// @Produce      json
+// @Param        request body  response.Request[validation.CreatePresentationRequest]  true  "Request body"
+// @Router       /oid4vp/requests/create [post]
+// @Success      201  {object}  response.Response[response.PresentationRequestResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or presentation definition"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (pc *OID4VPController) CreateRequest(c *fiber.Ctx) error {
+	var req response.Request[validation.CreatePresentationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	request, err := pc.oid4vpService.CreateRequest(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return pc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, request)
+}
+
+// @Tags         OID4VP
+// @Summary      Poll a presentation request
+// @Description  Returns the status of a presentation request created by the caller and, once answered, the verified credentials or the reasons for rejection.
+// @Produce      json
+// @Param        request body  response.Request[validation.PresentationRequestIDRequest]  true  "Request body"
+// @Router       /oid4vp/requests/get [post]
+// @Success      200  {object}  response.Response[response.PresentationStatusResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Presentation request not found"
+func (pc *OID4VPController) GetRequest(c *fiber.Ctx) error {
+	var req response.Request[validation.PresentationRequestIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	request, err := pc.oid4vpService.GetRequest(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return pc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, pc.buildStatusResponse(request))
+}
+
+// @Tags         OID4VP
+// @Summary      Request object
+// @Description  Serves the signed authorization request referenced by request_uri. Wallets resolve the verifier key from the did:web client_id.
+// @Produce      application/oauth-authz-req+jwt
+// @Param        requestId  path  string  true  "Presentation request ID"
+// @Router       /oid4vp/request/{requestId} [get]
+// @Success      200  {string}  string  "Signed request object"
+// @Failure      400  {object}  response.OAuthErrorResponse
+// @Failure      404  {object}  response.OAuthErrorResponse
+func (pc *OID4VPController) RequestObject(c *fiber.Ctx) error {
+	noStore(c)
+
+	requestObject, err := pc.oid4vpService.RequestObject(c, c.Params("requestId"))
+	if err != nil {
+		return protocolError(c, pc.log, err)
+	}
+
+	c.Set(constants.HTTPHeaderContentType, constants.OID4VPRequestObjectMIME)
+	return c.SendString(requestObject)
+}
+
+// @Tags         OID4VP
+// @Summary      Direct post response
+// @Description  Receives the wallet's authorization response (vp_token and presentation_submission) and evaluates it against the presentation definition.
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        vp_token                 formData  string  false  "Verifiable presentation(s)"
+// @Param        presentation_submission  formData  string  false  "Presentation submission JSON"
+// @Param        state                    formData  string  true   "State from the request object"
+// @Router       /oid4vp/response [post]
+// @Success      200  {object}  object
+// @Failure      400  {object}  response.OAuthErrorResponse
+func (pc *OID4VPController) DirectPost(c *fiber.Ctx) error {
+	noStore(c)
+
+	var req validation.OID4VPDirectPostRequest
+	if err := c.BodyParser(&req); err != nil {
+		return protocolError(c, pc.log, &service.ProtocolError{
+			Status: fiber.StatusBadRequest, Code: constants.OAuthErrInvalidRequest, Description: constants.ErrInvalidRequestBody,
+		})
+	}
+
+	if err := pc.oid4vpService.HandleResponse(c, &req); err != nil {
+		return protocolError(c, pc.log, err)
+	}
+
+	return c.JSON(fiber.Map{})
+}
+
+// buildStatusResponse maps a presentation request to its polling representation
+func (pc *OID4VPController) buildStatusResponse(request *model.PresentationRequest) response.PresentationStatusResponse {
+	payload := response.PresentationStatusResponse{
+		RequestID:      request.RequestID.String(),
+		Status:         request.Status,
+		CallbackStatus: request.CallbackStatus,
+		ExpiresAt:      request.ExpiresAt.UTC().Format(time.RFC3339),
+		RetrievedAt:    formatOptionalTime(request.RetrievedAt),
+		CompletedAt:    formatOptionalTime(request.CompletedAt),
+	}
+	if request.CompletedAt != nil {
+		result := request.Result.Data()
+		payload.Result = &result
+	}
+	return payload
+}
+
+// formatOptionalTime formats an optional timestamp as RFC 3339
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
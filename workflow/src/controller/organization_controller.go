@@ -0,0 +1,350 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OrganizationController handles organization member and invitation requests
+type OrganizationController struct {
+	organizationService service.OrganizationService
+	responseBuilder     *utils.ResponseBuilder
+}
+
+// NewOrganizationController creates a new organization controller
+func NewOrganizationController(
+	organizationService service.OrganizationService,
+	responseBuilder *utils.ResponseBuilder,
+) *OrganizationController {
+	return &OrganizationController{
+		organizationService: organizationService,
+		responseBuilder:     responseBuilder,
+	}
+}
+
+// @Tags         Organization
+// @Summary      Invite a member
+// @Description  Invites an Individual actor, named by universal identifier, to join the organization with a role. Called by the Business actor, or by a member with at least the admin role through the X-Organization-ID header. Admins can only invite to the operator and viewer roles. The invitation expires after 7 days.
+// @Produce      json
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        request body  response.Request[validation.CreateOrganizationInvitationRequest]  true  "Request body"
+// @Router       /organizations/invitations/create [post]
+// @Success      201  {object}  response.Response[response.OrganizationInvitationResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or invitee is not an Individual actor"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Not an organization, or role not allowed"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "No actor holds the identifier"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Already a member, or an invitation is pending"
+func (oc *OrganizationController) CreateInvitation(c *fiber.Ctx) error {
+	var req response.Request[validation.CreateOrganizationInvitationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	invitation, err := oc.organizationService.Invite(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationInvitationResponse(invitation))
+}
+
+// @Tags         Organization
+// @Summary      List invitations
+// @Description  Lists the 100 most recent invitations of the organization, newest first, whatever their status. Requires at least the admin role when called by a member.
+// @Produce      json
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /organizations/invitations/list [post]
+// @Success      200  {object}  response.Response[response.ListOrganizationInvitationsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Not an organization, or role not allowed"
+func (oc *OrganizationController) ListInvitations(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	invitations, err := oc.organizationService.ListInvitations(c)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationInvitationsResponse(invitations))
+}
+
+// @Tags         Organization
+// @Summary      Revoke an invitation
+// @Description  Revokes a pending invitation of the organization. Admins can only revoke invitations to the operator and viewer roles.
+// @Produce      json
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        request body  response.Request[validation.OrganizationInvitationRequest]  true  "Request body"
+// @Router       /organizations/invitations/revoke [post]
+// @Success      200  {object}  response.Response[response.OrganizationInvitationResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Not an organization, or role not allowed"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Invitation not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Invitation no longer pending"
+func (oc *OrganizationController) RevokeInvitation(c *fiber.Ctx) error {
+	var req response.Request[validation.OrganizationInvitationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	invitation, err := oc.organizationService.RevokeInvitation(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationInvitationResponse(invitation))
+}
+
+// @Tags         Organization
+// @Summary      List members
+// @Description  Lists the members of the organization and their roles, oldest first. Any member can list them through the X-Organization-ID header.
+// @Produce      json
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /organizations/members/list [post]
+// @Success      200  {object}  response.Response[response.ListOrganizationMembersResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Not an organization, or not a member"
+func (oc *OrganizationController) ListMembers(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	members, err := oc.organizationService.ListMembers(c)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationMembersResponse(members))
+}
+
+// @Tags         Organization
+// @Summary      Change the role of a member
+// @Description  Changes the role of a member of the organization. Admins can only move members between the operator and viewer roles. The last owner cannot be demoted.
+// @Produce      json
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        request body  response.Request[validation.UpdateOrganizationMemberRequest]  true  "Request body"
+// @Router       /organizations/members/updateRole [post]
+// @Success      200  {object}  response.Response[response.OrganizationMemberResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Not an organization, or role not allowed"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Member not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Member is the last owner"
+func (oc *OrganizationController) UpdateMemberRole(c *fiber.Ctx) error {
+	var req response.Request[validation.UpdateOrganizationMemberRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	member, err := oc.organizationService.UpdateMemberRole(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationMemberResponse(*member))
+}
+
+// @Tags         Organization
+// @Summary      Remove a member
+// @Description  Removes a member from the organization. Admins can only remove operators and viewers. The last owner cannot be removed.
+// @Produce      json
+// @Param        X-Organization-ID  header  string  false  "Organization to act for, when called by a member"
+// @Param        request body  response.Request[validation.OrganizationMemberRequest]  true  "Request body"
+// @Router       /organizations/members/remove [post]
+// @Success      200  {object}  response.Response[response.SuccessResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Not an organization, or role not allowed"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Member not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Member is the last owner"
+func (oc *OrganizationController) RemoveMember(c *fiber.Ctx) error {
+	var req response.Request[validation.OrganizationMemberRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	if err := oc.organizationService.RemoveMember(c, &req.Request); err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID,
+		response.SuccessResponse{Message: constants.MsgOrganizationMemberRemoved})
+}
+
+// @Tags         Actor
+// @Summary      List my organization invitations
+// @Description  Lists the pending invitations of the caller to join organizations, newest first.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/organizations/invitations/list [post]
+// @Success      200  {object}  response.Response[response.ListOrganizationInvitationsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (oc *OrganizationController) ListMyInvitations(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	invitations, err := oc.organizationService.ListMyInvitations(c)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationInvitationsResponse(invitations))
+}
+
+// @Tags         Actor
+// @Summary      Accept an organization invitation
+// @Description  Accepts a pending invitation of the caller, making them a member of the organization with the role it offers.
+// @Produce      json
+// @Param        request body  response.Request[validation.OrganizationInvitationRequest]  true  "Request body"
+// @Router       /actor/organizations/invitations/accept [post]
+// @Success      200  {object}  response.Response[response.OrganizationMemberResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Invitation not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Invitation no longer pending, or already a member"
+func (oc *OrganizationController) AcceptInvitation(c *fiber.Ctx) error {
+	var req response.Request[validation.OrganizationInvitationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	member, err := oc.organizationService.AcceptInvitation(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationMemberResponse(*member))
+}
+
+// @Tags         Actor
+// @Summary      Decline an organization invitation
+// @Description  Declines a pending invitation of the caller.
+// @Produce      json
+// @Param        request body  response.Request[validation.OrganizationInvitationRequest]  true  "Request body"
+// @Router       /actor/organizations/invitations/decline [post]
+// @Success      200  {object}  response.Response[response.OrganizationInvitationResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Invitation not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Invitation no longer pending"
+func (oc *OrganizationController) DeclineInvitation(c *fiber.Ctx) error {
+	var req response.Request[validation.OrganizationInvitationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	invitation, err := oc.organizationService.DeclineInvitation(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationInvitationResponse(invitation))
+}
+
+// @Tags         Actor
+// @Summary      List my organizations
+// @Description  Lists the organizations the caller is a member of and their role in each. The organization ID is passed in the X-Organization-ID header to act for it.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /actor/organizations/list [post]
+// @Success      200  {object}  response.Response[response.ListOrganizationMembersResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (oc *OrganizationController) ListMemberships(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	members, err := oc.organizationService.ListMemberships(c)
+	if err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildOrganizationMembersResponse(members))
+}
+
+// @Tags         Actor
+// @Summary      Leave an organization
+// @Description  Ends the caller's membership in an organization. The last owner cannot leave.
+// @Produce      json
+// @Param        request body  response.Request[validation.OrganizationRequest]  true  "Request body"
+// @Router       /actor/organizations/leave [post]
+// @Success      200  {object}  response.Response[response.SuccessResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Not a member of the organization"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Caller is the last owner"
+func (oc *OrganizationController) LeaveOrganization(c *fiber.Ctx) error {
+	var req response.Request[validation.OrganizationRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	if err := oc.organizationService.LeaveOrganization(c, &req.Request); err != nil {
+		return err
+	}
+
+	return oc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID,
+		response.SuccessResponse{Message: constants.MsgOrganizationLeft})
+}
+
+func buildOrganizationInvitationResponse(invitation *model.OrganizationInvitation) response.OrganizationInvitationResponse {
+	return response.OrganizationInvitationResponse{
+		InvitationID:           invitation.InvitationID.String(),
+		OrganizationID:         invitation.OrganizationID.String(),
+		OrganizationIdentifier: invitation.OrganizationIdentifier,
+		InviteeID:              invitation.InviteeID.String(),
+		InviteeIdentifier:      invitation.InviteeIdentifier,
+		Role:                   invitation.Role,
+		Status:                 invitation.Status(time.Now()),
+		InvitedBy:              invitation.InvitedBy.String(),
+		ExpiresAt:              invitation.ExpiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:              invitation.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func buildOrganizationInvitationsResponse(invitations []model.OrganizationInvitation) response.ListOrganizationInvitationsResponse {
+	resp := response.ListOrganizationInvitationsResponse{Invitations: make([]response.OrganizationInvitationResponse, 0, len(invitations))}
+	for i := range invitations {
+		resp.Invitations = append(resp.Invitations, buildOrganizationInvitationResponse(&invitations[i]))
+	}
+	return resp
+}
+
+func buildOrganizationMemberResponse(member model.OrganizationMember) response.OrganizationMemberResponse {
+	return response.OrganizationMemberResponse{
+		OrganizationID: member.OrganizationID.String(),
+		ActorID:        member.ActorID.String(),
+		Role:           member.Role,
+		CreatedAt:      member.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func buildOrganizationMembersResponse(members []model.OrganizationMember) response.ListOrganizationMembersResponse {
+	resp := response.ListOrganizationMembersResponse{Members: make([]response.OrganizationMemberResponse, 0, len(members))}
+	for _, member := range members {
+		resp.Members = append(resp.Members, buildOrganizationMemberResponse(member))
+	}
+	return resp
+}
@@ -0,0 +1,317 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ShareController handles consent-based credential sharing requests
+type ShareController struct {
+	shareService    service.ShareService
+	responseBuilder *utils.ResponseBuilder
+}
+
+// NewShareController creates a new share controller
+func NewShareController(
+	shareService service.ShareService,
+	responseBuilder *utils.ResponseBuilder,
+) *ShareController {
+	return &ShareController{
+		shareService:    shareService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Tags         Sharing
+// @Summary      Share credentials
+// @Description  Grants the actor identified by the given universal identifier read access to selected credentials of the caller and the documents submitted with them, until expiresAt or until revoked.
+// @Produce      json
+// @Param        request body  response.Request[validation.CreateShareGrantRequest]  true  "Request body"
+// @Router       /sharing/grants/create [post]
+// @Success      201  {object}  response.Response[response.ShareGrantResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body, expiry, or sharing with yourself"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Grantee or credential not found"
+func (sc *ShareController) CreateGrant(c *fiber.Ctx) error {
+	var req response.Request[validation.CreateShareGrantRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grant, err := sc.shareService.CreateGrant(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return sc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildShareGrantResponse(grant))
+}
+
+// @Tags         Sharing
+// @Summary      List share grants
+// @Description  Lists the grants made by the caller, newest first, with cursor pagination and an optional status filter.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListShareGrantsRequest]  true  "Request body"
+// @Router       /sharing/grants/list [post]
+// @Success      200  {object}  response.Response[response.ListShareGrantsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (sc *ShareController) ListGrants(c *fiber.Ctx) error {
+	var req response.Request[validation.ListShareGrantsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grants, nextCursor, err := sc.shareService.ListGrants(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildShareGrantsResponse(grants, nextCursor))
+}
+
+// @Tags         Sharing
+// @Summary      Revoke a share grant
+// @Description  Revokes a grant made by the caller. The grantee loses access immediately.
+// @Produce      json
+// @Param        request body  response.Request[validation.ShareGrantIDRequest]  true  "Request body"
+// @Router       /sharing/grants/revoke [post]
+// @Success      200  {object}  response.Response[response.ShareGrantResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Share grant not found"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Share grant is already revoked"
+func (sc *ShareController) RevokeGrant(c *fiber.Ctx) error {
+	var req response.Request[validation.ShareGrantIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grant, err := sc.shareService.RevokeGrant(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildShareGrantResponse(grant))
+}
+
+// @Tags         Sharing
+// @Summary      Review access to shared data
+// @Description  Lists every access grantees made to the caller's shared credentials and documents, newest first, optionally limited to one grant.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListShareAccessLogRequest]  true  "Request body"
+// @Router       /sharing/grants/accessLog [post]
+// @Success      200  {object}  response.Response[response.ListShareAccessLogResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (sc *ShareController) ListAccessLog(c *fiber.Ctx) error {
+	var req response.Request[validation.ListShareAccessLogRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	entries, nextCursor, err := sc.shareService.ListAccessLog(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListShareAccessLogResponse{
+		Entries:    make([]response.ShareAccessLogResponse, 0, len(entries)),
+		NextCursor: nextCursor,
+	}
+	for i := range entries {
+		payload.Entries = append(payload.Entries, buildShareAccessLogResponse(&entries[i]))
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Sharing
+// @Summary      List grants received
+// @Description  Lists the grants other actors made to the caller, newest first, with cursor pagination and an optional status filter.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListShareGrantsRequest]  true  "Request body"
+// @Router       /sharing/received/list [post]
+// @Success      200  {object}  response.Response[response.ListShareGrantsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (sc *ShareController) ListReceivedGrants(c *fiber.Ctx) error {
+	var req response.Request[validation.ListShareGrantsRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grants, nextCursor, err := sc.shareService.ListReceivedGrants(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildShareGrantsResponse(grants, nextCursor))
+}
+
+// @Tags         Sharing
+// @Summary      List shared credentials
+// @Description  Lists the credentials covered by an active grant made to the caller. The access is recorded in the owner's access log.
+// @Produce      json
+// @Param        request body  response.Request[validation.ShareGrantIDRequest]  true  "Request body"
+// @Router       /sharing/received/credentials [post]
+// @Success      200  {object}  response.Response[response.ListSharedCredentialsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Share grant not found, revoked, or expired"
+func (sc *ShareController) ListSharedCredentials(c *fiber.Ctx) error {
+	var req response.Request[validation.ShareGrantIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	grant, tokens, err := sc.shareService.ListSharedCredentials(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListSharedCredentialsResponse{
+		GrantID:         grant.GrantID.String(),
+		OwnerIdentifier: grant.OwnerIdentifier,
+		ExpiresAt:       grant.ExpiresAt.UTC().Format(time.RFC3339),
+		Credentials:     make([]response.SharedCredentialResponse, 0, len(tokens)),
+	}
+	for i := range tokens {
+		payload.Credentials = append(payload.Credentials, buildSharedCredentialResponse(&tokens[i]))
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Sharing
+// @Summary      Get a shared credential
+// @Description  Returns one credential covered by an active grant made to the caller, including the credential itself. The access is recorded in the owner's access log.
+// @Produce      json
+// @Param        request body  response.Request[validation.SharedCredentialRequest]  true  "Request body"
+// @Router       /sharing/received/getCredential [post]
+// @Success      200  {object}  response.Response[response.SharedCredentialResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Share grant or credential not found"
+func (sc *ShareController) GetSharedCredential(c *fiber.Ctx) error {
+	var req response.Request[validation.SharedCredentialRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	token, err := sc.shareService.GetSharedCredential(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildSharedCredentialResponse(token))
+}
+
+// @Tags         Sharing
+// @Summary      Download a shared document
+// @Description  Returns a short-lived download URL for an evidence document linked to a credential covered by an active grant made to the caller. The access is recorded in the owner's access log.
+// @Produce      json
+// @Param        request body  response.Request[validation.SharedDocumentRequest]  true  "Request body"
+// @Router       /sharing/received/getDocument [post]
+// @Success      200  {object}  response.Response[response.SharedDocumentResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Share grant, credential, or document not found"
+func (sc *ShareController) GetSharedDocument(c *fiber.Ctx) error {
+	var req response.Request[validation.SharedDocumentRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	shared, err := sc.shareService.GetSharedDocument(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := response.SharedDocumentResponse{
+		DocumentID:  shared.Document.DocumentID.String(),
+		FileName:    shared.Document.FileName,
+		MimeType:    shared.Document.MimeType,
+		DownloadURL: shared.DownloadURL,
+		ExpiresAt:   shared.ExpiresAt.Format(time.RFC3339),
+	}
+
+	return sc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// buildShareGrantsResponse maps one page of grants to its response representation
+func buildShareGrantsResponse(grants []model.ShareGrant, nextCursor string) response.ListShareGrantsResponse {
+	payload := response.ListShareGrantsResponse{
+		Grants:     make([]response.ShareGrantResponse, 0, len(grants)),
+		NextCursor: nextCursor,
+	}
+	for i := range grants {
+		payload.Grants = append(payload.Grants, buildShareGrantResponse(&grants[i]))
+	}
+	return payload
+}
+
+// buildShareGrantResponse maps a share grant to its response representation
+func buildShareGrantResponse(grant *model.ShareGrant) response.ShareGrantResponse {
+	credentialIDs := make([]string, 0, len(grant.Tokens))
+	for _, tokenID := range grant.TokenIDs() {
+		credentialIDs = append(credentialIDs, tokenID.String())
+	}
+
+	return response.ShareGrantResponse{
+		GrantID:           grant.GrantID.String(),
+		OwnerIdentifier:   grant.OwnerIdentifier,
+		GranteeIdentifier: grant.GranteeIdentifier,
+		CredentialIDs:     credentialIDs,
+		Purpose:           grant.Purpose,
+		Status:            grant.Status(time.Now()),
+		ExpiresAt:         grant.ExpiresAt.UTC().Format(time.RFC3339),
+		RevokedAt:         formatOptionalTime(grant.RevokedAt),
+		CreatedAt:         grant.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// buildShareAccessLogResponse maps an access log entry to its response representation
+func buildShareAccessLogResponse(entry *model.ShareAccessLog) response.ShareAccessLogResponse {
+	payload := response.ShareAccessLogResponse{
+		LogID:      entry.LogID.String(),
+		GrantID:    entry.GrantID.String(),
+		GranteeID:  entry.GranteeID.String(),
+		Action:     entry.Action,
+		IPAddress:  entry.IPAddress,
+		UserAgent:  entry.UserAgent,
+		AccessedAt: entry.AccessedAt.UTC().Format(time.RFC3339),
+	}
+	if entry.TokenID != nil {
+		credentialID := entry.TokenID.String()
+		payload.CredentialID = &credentialID
+	}
+	if entry.DocumentID != nil {
+		documentID := entry.DocumentID.String()
+		payload.DocumentID = &documentID
+	}
+	return payload
+}
+
+// buildSharedCredentialResponse maps a shared credential to the representation visible to grantees
+func buildSharedCredentialResponse(token *model.Token) response.SharedCredentialResponse {
+	payload := response.SharedCredentialResponse{
+		CredentialID:         token.TokenID.String(),
+		Type:                 token.TokenType,
+		Status:               token.Status,
+		Issuer:               token.IssuerDID,
+		SubmittedAt:          token.CreatedAt.UTC().Format(time.RFC3339),
+		VerifiableCredential: token.Metadata["verifiableCredential"],
+	}
+	payload.CredentialJWT, _ = token.Metadata["credentialJwt"].(string)
+	payload.Documents = buildCredentialDocumentResponses(token.Documents)
+	payload.HasDocument = len(token.Documents) > 0
+	return payload
+}
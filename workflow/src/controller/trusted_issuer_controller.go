@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrustedIssuerController handles trusted issuer registry administration
+type TrustedIssuerController struct {
+	trustedIssuerService service.TrustedIssuerService
+	responseBuilder      *utils.ResponseBuilder
+}
+
+// NewTrustedIssuerController creates a new trusted issuer controller
+func NewTrustedIssuerController(
+	trustedIssuerService service.TrustedIssuerService,
+	responseBuilder *utils.ResponseBuilder,
+) *TrustedIssuerController {
+	return &TrustedIssuerController{
+		trustedIssuerService: trustedIssuerService,
+		responseBuilder:      responseBuilder,
+	}
+}
+
+// @Tags         Admin
+// @Summary      Register a trusted issuer
+// @Description  Adds an issuer DID to the trusted issuer registry for the given verification types and jurisdictions. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.TrustedIssuerRequest]  true  "Request body"
+// @Router       /admin/trustedIssuers/add [post]
+// @Success      201  {object}  response.Response[response.TrustedIssuerResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      409  {object}  example.ErrorEnvelope[example.ParamsConflictExample]  "Issuer DID already registered"
+func (tc *TrustedIssuerController) AddIssuer(c *fiber.Ctx) error {
+	var req response.Request[validation.TrustedIssuerRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	issuer, err := tc.trustedIssuerService.AddIssuer(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return tc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, tc.buildIssuerResponse(issuer))
+}
+
+// @Tags         Admin
+// @Summary      List trusted issuers
+// @Description  Lists the trusted issuer registry, optionally filtered by status. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListTrustedIssuersRequest]  true  "Request body"
+// @Router       /admin/trustedIssuers/list [post]
+// @Success      200  {object}  response.Response[response.ListTrustedIssuersResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (tc *TrustedIssuerController) ListIssuers(c *fiber.Ctx) error {
+	var req response.Request[validation.ListTrustedIssuersRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	issuers, err := tc.trustedIssuerService.ListIssuers(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ListTrustedIssuersResponse{
+		Issuers: make([]response.TrustedIssuerResponse, 0, len(issuers)),
+	}
+	for i := range issuers {
+		payload.Issuers = append(payload.Issuers, tc.buildIssuerResponse(&issuers[i]))
+	}
+
+	return tc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Admin
+// @Summary      Get a trusted issuer
+// @Description  Returns a single trusted issuer registry entry. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.TrustedIssuerIDRequest]  true  "Request body"
+// @Router       /admin/trustedIssuers/get [post]
+// @Success      200  {object}  response.Response[response.TrustedIssuerResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Trusted issuer not found"
+func (tc *TrustedIssuerController) GetIssuer(c *fiber.Ctx) error {
+	var req response.Request[validation.TrustedIssuerIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	issuer, err := tc.trustedIssuerService.GetIssuer(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return tc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, tc.buildIssuerResponse(issuer))
+}
+
+// @Tags         Admin
+// @Summary      Update a trusted issuer
+// @Description  Updates the allowed types, jurisdictions, validity window or status of a trusted issuer. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.UpdateTrustedIssuerRequest]  true  "Request body"
+// @Router       /admin/trustedIssuers/update [post]
+// @Success      200  {object}  response.Response[response.TrustedIssuerResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Trusted issuer not found"
+func (tc *TrustedIssuerController) UpdateIssuer(c *fiber.Ctx) error {
+	var req response.Request[validation.UpdateTrustedIssuerRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	issuer, err := tc.trustedIssuerService.UpdateIssuer(c, &req.Request)
+	if err != nil {
+		return err
+	}
+
+	return tc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, tc.buildIssuerResponse(issuer))
+}
+
+// @Tags         Admin
+// @Summary      Delete a trusted issuer
+// @Description  Removes an issuer from the trusted issuer registry. Requires the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.TrustedIssuerIDRequest]  true  "Request body"
+// @Router       /admin/trustedIssuers/delete [post]
+// @Success      200  {object}  response.Response[response.DeleteCredentialResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Trusted issuer not found"
+func (tc *TrustedIssuerController) DeleteIssuer(c *fiber.Ctx) error {
+	var req response.Request[validation.TrustedIssuerIDRequest]
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	if err := tc.trustedIssuerService.DeleteIssuer(c, &req.Request); err != nil {
+		return err
+	}
+
+	payload := map[string]string{
+		"message": constants.MsgOperationSuccessful,
+	}
+
+	return tc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+}
+
+// @Tags         Admin
+// @Summary      Import a signed trust list
+// @Description  Imports a JWS-signed trust-list file. The signature is verified against the configured trust-list key and every entry is upserted by issuer DID. Requires the admin role.
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "Signed trust list (JWS compact or JSON serialization)"
+// @Router       /admin/trustedIssuers/import [post]
+// @Success      200  {object}  response.Response[response.ImportTrustListResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Missing file, bad signature or malformed trust list"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (tc *TrustedIssuerController) ImportTrustList(c *fiber.Ctx) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrFileRequired)
+	}
+
+	fileReader, err := file.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToOpenFile)
+	}
+	defer fileReader.Close()
+
+	content, err := io.ReadAll(fileReader)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToOpenFile)
+	}
+
+	trustList, err := tc.trustedIssuerService.ImportTrustList(c, content)
+	if err != nil {
+		return err
+	}
+
+	payload := response.ImportTrustListResponse{
+		Version:  trustList.Version,
+		Imported: len(trustList.Issuers),
+	}
+
+	return tc.responseBuilder.OKWithMetadata(c,
+		constants.DefaultRequestID,
+		constants.DefaultRequestVersion,
+		time.Now().UTC().Format(time.RFC3339),
+		constants.DefaultMsgID,
+		payload)
+}
+
+// buildIssuerResponse maps a registry entry to its API representation
+func (tc *TrustedIssuerController) buildIssuerResponse(issuer *model.TrustedIssuer) response.TrustedIssuerResponse {
+	resp := response.TrustedIssuerResponse{
+		IssuerID:          issuer.IssuerID.String(),
+		IssuerDID:         issuer.IssuerDID,
+		Name:              issuer.Name,
+		VerificationTypes: issuer.VerificationTypes,
+		Jurisdictions:     issuer.Jurisdictions,
+		Status:            issuer.Status,
+		Source:            issuer.Source,
+		UpdatedAt:         issuer.UpdatedAt.Format(time.RFC3339),
+	}
+	if resp.Jurisdictions == nil {
+		resp.Jurisdictions = []string{}
+	}
+	if issuer.ValidFrom != nil {
+		validFrom := issuer.ValidFrom.Format(time.RFC3339)
+		resp.ValidFrom = &validFrom
+	}
+	if issuer.ValidUntil != nil {
+		validUntil := issuer.ValidUntil.Format(time.RFC3339)
+		resp.ValidUntil = &validUntil
+	}
+	return resp
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WalletController handles wallet export and import requests
+type WalletController struct {
+	walletService   service.WalletService
+	responseBuilder *utils.ResponseBuilder
+}
+
+// NewWalletController creates a new wallet controller
+func NewWalletController(
+	walletService service.WalletService,
+	responseBuilder *utils.ResponseBuilder,
+) *WalletController {
+	return &WalletController{
+		walletService:   walletService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Tags         Wallet
+// @Summary      Export wallet
+// @Description  Downloads a zip archive of the caller's credentials, their original VC payloads and linked documents. manifest.json lists the SHA-256 hash of every file and manifest.jwt signs the manifest with the node's DID key, so the archive can be imported on any node.
+// @Produce      application/zip
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /wallet/export [post]
+// @Success      200  {file}  file  "Wallet archive"
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+func (wc *WalletController) Export(c *fiber.Ctx) error {
+	var req validation.ApiRequest_Empty
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	export, err := wc.walletService.Export(c)
+	if err != nil {
+		return err
+	}
+
+	c.Set(constants.HTTPHeaderContentType, constants.WalletArchiveContentType)
+	c.Set(constants.HTTPHeaderDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName))
+	return c.Send(export.Data)
+}
+
+// @Tags         Wallet
+// @Summary      Import wallet
+// @Description  Recreates the documents and credentials of a wallet archive under the caller after verifying the manifest signature against the exporting node's DID and every file against the manifest. Imported credentials are verified again: they start in 'Pending' with a verification job, except rejected and revoked credentials, which keep their status. Credentials the trust policy rejects are skipped.
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "Wallet archive"
+// @Router       /wallet/import [post]
+// @Success      201  {object}  response.Response[response.ImportWalletResponse]  "Wallet imported"
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Missing, malformed or unsupported archive"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      413  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Archive too large"
+// @Failure      422  {object}  example.ErrorEnvelope[example.ParamsUnprocessableEntityExample]  "Invalid signature or contents not matching the manifest"
+func (wc *WalletController) Import(c *fiber.Ctx) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrFileRequired)
+	}
+	if file.Size > constants.WalletArchiveMaxSize {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, constants.ErrWalletArchiveTooLarge)
+	}
+
+	fileReader, err := file.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToOpenFile)
+	}
+	defer fileReader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(fileReader, constants.WalletArchiveMaxSize))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToOpenFile)
+	}
+
+	result, err := wc.walletService.Import(c, data)
+	if err != nil {
+		return err
+	}
+
+	return wc.responseBuilder.CreatedWithMetadata(c,
+		constants.DefaultRequestID,
+		constants.DefaultRequestVersion,
+		time.Now().UTC().Format(time.RFC3339),
+		constants.DefaultMsgID,
+		buildImportWalletResponse(result))
+}
+
+// buildImportWalletResponse maps an import result to its API representation
+func buildImportWalletResponse(result *service.WalletImport) response.ImportWalletResponse {
+	payload := response.ImportWalletResponse{
+		ExportID:      result.ExportID,
+		Issuer:        result.Issuer,
+		SourceActorID: result.SourceActorID,
+		Documents:     make([]response.ImportedDocumentResponse, 0, len(result.Documents)),
+		Credentials:   make([]response.ImportedCredentialResponse, 0, len(result.Credentials)),
+		Skipped:       make([]response.SkippedCredentialResponse, 0, len(result.Skipped)),
+	}
+
+	for _, document := range result.Documents {
+		payload.Documents = append(payload.Documents, response.ImportedDocumentResponse{
+			SourceDocumentID: document.SourceID.String(),
+			DocumentID:       document.Document.DocumentID.String(),
+			FileName:         document.Document.FileName,
+		})
+	}
+	for _, credential := range result.Credentials {
+		entry := response.ImportedCredentialResponse{
+			SourceCredentialID: credential.SourceID.String(),
+			CredentialID:       credential.Token.TokenID.String(),
+			Status:             credential.Token.Status,
+		}
+		if credential.Job != nil {
+			jobID := credential.Job.JobID.String()
+			entry.JobID = &jobID
+		}
+		payload.Credentials = append(payload.Credentials, entry)
+	}
+	for _, skipped := range result.Skipped {
+		payload.Skipped = append(payload.Skipped, response.SkippedCredentialResponse{
+			SourceCredentialID: skipped.SourceID.String(),
+			Reason:             skipped.Reason,
+		})
+	}
+
+	return payload
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/response"
+	_ "app/src/response/example"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookController handles webhook subscription and delivery log requests. Each handler is
+// mounted twice: under /webhooks for the caller's own subscriptions and under /admin/webhooks
+// for partner subscriptions, which receive events about every actor.
+type WebhookController struct {
+	webhookService  service.WebhookService
+	responseBuilder *utils.ResponseBuilder
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(
+	webhookService service.WebhookService,
+	responseBuilder *utils.ResponseBuilder,
+) *WebhookController {
+	return &WebhookController{
+		webhookService:  webhookService,
+		responseBuilder: responseBuilder,
+	}
+}
+
+// @Tags         Webhooks
+// @Summary      Subscribe to events
+// @Description  Registers an endpoint that receives the selected events as signed JSON POST requests. The signing secret is returned only in this response; each request carries X-Webhook-Timestamp and X-Webhook-Signature, the hex HMAC-SHA256 of "<timestamp>.<body>" prefixed with "sha256=". Partner subscriptions require the admin role.
+// @Produce      json
+// @Param        request body  response.Request[validation.CreateWebhookSubscriptionRequest]  true  "Request body"
+// @Router       /webhooks/subscriptions/create [post]
+// @Router       /admin/webhooks/subscriptions/create [post]
+// @Success      201  {object}  response.Response[response.WebhookSubscriptionResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or insecure URL"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (wc *WebhookController) CreateSubscription(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req response.Request[validation.CreateWebhookSubscriptionRequest]
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+
+		subscription, err := wc.webhookService.CreateSubscription(c, scope, &req.Request)
+		if err != nil {
+			return err
+		}
+
+		payload := buildWebhookSubscriptionResponse(subscription)
+		payload.Secret = subscription.Secret
+		return wc.responseBuilder.CreatedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+	}
+}
+
+// @Tags         Webhooks
+// @Summary      List webhook subscriptions
+// @Description  Lists the subscriptions of the given scope, newest first. Signing secrets are not returned.
+// @Produce      json
+// @Param        request body  validation.ApiRequest_Empty  true  "Request body"
+// @Router       /webhooks/subscriptions/list [post]
+// @Router       /admin/webhooks/subscriptions/list [post]
+// @Success      200  {object}  response.Response[response.ListWebhookSubscriptionsResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (wc *WebhookController) ListSubscriptions(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req validation.ApiRequest_Empty
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+
+		subscriptions, err := wc.webhookService.ListSubscriptions(c, scope)
+		if err != nil {
+			return err
+		}
+
+		payload := response.ListWebhookSubscriptionsResponse{
+			Subscriptions: make([]response.WebhookSubscriptionResponse, 0, len(subscriptions)),
+		}
+		for i := range subscriptions {
+			payload.Subscriptions = append(payload.Subscriptions, buildWebhookSubscriptionResponse(&subscriptions[i]))
+		}
+
+		return wc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+	}
+}
+
+// @Tags         Webhooks
+// @Summary      Delete a webhook subscription
+// @Description  Stops sending events to the endpoint. Pending deliveries fail on their next attempt; the delivery log is kept.
+// @Produce      json
+// @Param        request body  response.Request[validation.WebhookSubscriptionIDRequest]  true  "Request body"
+// @Router       /webhooks/subscriptions/delete [post]
+// @Router       /admin/webhooks/subscriptions/delete [post]
+// @Success      200  {object}  response.Response[map[string]string]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Webhook subscription not found"
+func (wc *WebhookController) DeleteSubscription(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req response.Request[validation.WebhookSubscriptionIDRequest]
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+
+		if err := wc.webhookService.DeleteSubscription(c, scope, &req.Request); err != nil {
+			return err
+		}
+
+		payload := map[string]string{"subscriptionId": req.Request.SubscriptionID}
+		return wc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+	}
+}
+
+// @Tags         Webhooks
+// @Summary      Review webhook deliveries
+// @Description  Lists delivery attempts of the given scope, newest first, with cursor pagination and optional subscription, event type and status filters.
+// @Produce      json
+// @Param        request body  response.Request[validation.ListWebhookDeliveriesRequest]  true  "Request body"
+// @Router       /webhooks/deliveries/list [post]
+// @Router       /admin/webhooks/deliveries/list [post]
+// @Success      200  {object}  response.Response[response.ListWebhookDeliveriesResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body or cursor"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+func (wc *WebhookController) ListDeliveries(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req response.Request[validation.ListWebhookDeliveriesRequest]
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+
+		deliveries, nextCursor, err := wc.webhookService.ListDeliveries(c, scope, &req.Request)
+		if err != nil {
+			return err
+		}
+
+		payload := response.ListWebhookDeliveriesResponse{
+			Deliveries: make([]response.WebhookDeliveryResponse, 0, len(deliveries)),
+			NextCursor: nextCursor,
+		}
+		for i := range deliveries {
+			payload.Deliveries = append(payload.Deliveries, buildWebhookDeliveryResponse(&deliveries[i]))
+		}
+
+		return wc.responseBuilder.OKWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, payload)
+	}
+}
+
+// @Tags         Webhooks
+// @Summary      Replay a webhook delivery
+// @Description  Sends the event of an earlier delivery again to its subscription, signed with the current secret. The replay is a new delivery with the same event ID, so receivers can deduplicate on X-Webhook-Id.
+// @Produce      json
+// @Param        request body  response.Request[validation.WebhookDeliveryIDRequest]  true  "Request body"
+// @Router       /webhooks/deliveries/replay [post]
+// @Router       /admin/webhooks/deliveries/replay [post]
+// @Success      202  {object}  response.Response[response.WebhookDeliveryResponse]
+// @Failure      400  {object}  example.ErrorEnvelope[example.BadRequestExample]  "Invalid request body"
+// @Failure      401  {object}  example.ErrorEnvelope[example.UnauthorizedExample]  "Unauthorized. JWT missing, invalid, or expired."
+// @Failure      403  {object}  example.ErrorEnvelope[example.ForbiddenExample]  "Caller lacks the admin role"
+// @Failure      404  {object}  example.ErrorEnvelope[example.NotFoundExample]  "Webhook delivery or subscription not found"
+func (wc *WebhookController) ReplayDelivery(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req response.Request[validation.WebhookDeliveryIDRequest]
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+
+		delivery, err := wc.webhookService.ReplayDelivery(c, scope, &req.Request)
+		if err != nil {
+			return err
+		}
+
+		return wc.responseBuilder.AcceptedWithMetadata(c, req.ID, req.Ver, req.Ts, *req.Params.MsgID, buildWebhookDeliveryResponse(delivery))
+	}
+}
+
+// buildWebhookSubscriptionResponse maps a subscription to its API representation without the secret
+func buildWebhookSubscriptionResponse(subscription *model.WebhookSubscription) response.WebhookSubscriptionResponse {
+	return response.WebhookSubscriptionResponse{
+		SubscriptionID: subscription.SubscriptionID.String(),
+		Scope:          subscription.Scope,
+		URL:            subscription.URL,
+		Events:         subscription.Events,
+		Description:    subscription.Description,
+		CreatedAt:      subscription.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// buildWebhookDeliveryResponse maps a delivery log entry to its API representation
+func buildWebhookDeliveryResponse(delivery *model.WebhookDelivery) response.WebhookDeliveryResponse {
+	payload := response.WebhookDeliveryResponse{
+		DeliveryID:     delivery.DeliveryID.String(),
+		SubscriptionID: delivery.SubscriptionID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		Payload:        delivery.Payload,
+		CreatedAt:      delivery.CreatedAt.UTC().Format(time.RFC3339),
+		DeliveredAt:    formatOptionalTime(delivery.DeliveredAt),
+	}
+	if delivery.ReplayOf != nil {
+		replayOf := delivery.ReplayOf.String()
+		payload.ReplayOf = &replayOf
+	}
+	return payload
+}
@@ -6,17 +6,22 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
 CREATE TABLE IF NOT EXISTS actors (
     actor_id UUID PRIMARY KEY,
-    did UUID NOT NULL UNIQUE,
+    did TEXT NOT NULL UNIQUE,
     email VARCHAR NOT NULL UNIQUE,
     first_name VARCHAR NOT NULL,
     last_name VARCHAR NOT NULL,
     phone_number VARCHAR UNIQUE,
     master_public_key VARCHAR NOT NULL UNIQUE,
+    master_key_thumbprint VARCHAR(64) UNIQUE,
     entity_type VARCHAR NOT NULL,
     nationality VARCHAR,
     country_of_residence VARCHAR,
     country_of_incorporation VARCHAR,
     verification_level VARCHAR NOT NULL DEFAULT 'Tier0_Unverified',
+    email_verified BOOLEAN NOT NULL DEFAULT false,
+    email_verified_at TIMESTAMPTZ,
+    phone_verified BOOLEAN NOT NULL DEFAULT false,
+    phone_verified_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ NOT NULL
 );
 
@@ -25,9 +30,14 @@ CREATE TABLE IF NOT EXISTS actors (
 CREATE TABLE IF NOT EXISTS identifiers (
     identifier VARCHAR PRIMARY KEY, 
     entity_type VARCHAR NOT NULL,
-    entity_id UUID NOT NULL
+    entity_id UUID NOT NULL,
+    is_primary boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    skeleton varchar NOT NULL
 );
 
+CREATE UNIQUE INDEX IF NOT EXISTS idx_identifiers_skeleton ON identifiers(skeleton);
+
 -----------------------------------
 
 CREATE TABLE IF NOT EXISTS actor_integrations (
@@ -59,3 +69,356 @@ CREATE TABLE IF NOT EXISTS documents (
     created_at      TIMESTAMP       DEFAULT CURRENT_TIMESTAMP  NOT NULL
 );
 
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS trusted_issuers (
+    issuer_id uuid PRIMARY KEY,
+    issuer_did varchar NOT NULL UNIQUE,
+    name varchar NOT NULL,
+    verification_types jsonb NOT NULL,
+    jurisdictions jsonb,
+    valid_from timestamptz,
+    valid_until timestamptz,
+    status varchar(20) NOT NULL DEFAULT 'active',
+    source varchar(20) NOT NULL DEFAULT 'manual',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS verification_level_history (
+    history_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    previous_level varchar(50) NOT NULL,
+    new_level varchar(50) NOT NULL,
+    reason varchar(50) NOT NULL,
+    token_id uuid,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS credential_offers (
+    offer_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    credential_configuration_ids jsonb NOT NULL,
+    pre_authorized_code_hash varchar(64) NOT NULL UNIQUE,
+    tx_code_hash varchar(64),
+    tx_code_attempts integer NOT NULL DEFAULT 0,
+    status varchar(20) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    access_token_hash varchar(64) UNIQUE,
+    access_token_expires_at timestamptz,
+    c_nonce varchar(64),
+    c_nonce_expires_at timestamptz,
+    redeemed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS presentation_requests (
+    request_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    state varchar(64) NOT NULL UNIQUE,
+    nonce varchar(64) NOT NULL,
+    client_id varchar NOT NULL,
+    presentation_definition jsonb NOT NULL,
+    callback_url varchar,
+    callback_secret varchar,
+    callback_status varchar(20),
+    status varchar(20) NOT NULL,
+    result jsonb NOT NULL DEFAULT '{}',
+    expires_at timestamptz NOT NULL,
+    retrieved_at timestamptz,
+    completed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS share_grants (
+    grant_id uuid PRIMARY KEY,
+    owner_id uuid NOT NULL,
+    owner_identifier varchar NOT NULL,
+    grantee_id uuid NOT NULL,
+    grantee_identifier varchar NOT NULL,
+    purpose varchar(255),
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS share_grant_tokens (
+    grant_id uuid NOT NULL,
+    token_id uuid NOT NULL,
+    PRIMARY KEY (grant_id, token_id)
+);
+
+CREATE TABLE IF NOT EXISTS share_access_logs (
+    log_id uuid PRIMARY KEY,
+    grant_id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    grantee_id uuid NOT NULL,
+    action varchar(30) NOT NULL,
+    token_id uuid,
+    document_id uuid,
+    ip_address varchar(45),
+    user_agent varchar(512),
+    accessed_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS jobs (
+    job_id uuid PRIMARY KEY,
+    type varchar(50) NOT NULL,
+    actor_id uuid NOT NULL,
+    resource_id uuid,
+    payload jsonb NOT NULL DEFAULT '{}',
+    status varchar(20) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    max_attempts integer NOT NULL,
+    run_at timestamptz NOT NULL DEFAULT now(),
+    locked_by varchar(255),
+    locked_at timestamptz,
+    last_error text,
+    result jsonb NOT NULL DEFAULT '{}',
+    completed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    subscription_id uuid PRIMARY KEY,
+    owner_id uuid NOT NULL,
+    scope varchar(20) NOT NULL,
+    url varchar(2048) NOT NULL,
+    events jsonb NOT NULL,
+    secret varchar(64) NOT NULL,
+    description varchar(255),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id uuid PRIMARY KEY,
+    subscription_id uuid NOT NULL,
+    owner_id uuid NOT NULL,
+    scope varchar(20) NOT NULL,
+    event_id uuid NOT NULL,
+    event_type varchar(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(20) NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    response_status integer,
+    last_error text,
+    replay_of uuid,
+    delivered_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS token_documents (
+    token_id uuid NOT NULL,
+    document_id uuid NOT NULL,
+    role varchar(30) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (token_id, document_id)
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS actor_keys (
+    key_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    public_key text NOT NULL,
+    thumbprint varchar(64) UNIQUE,
+    did text NOT NULL,
+    endorsement varchar(20) NOT NULL,
+    valid_from timestamptz NOT NULL,
+    valid_until timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS key_recoveries (
+    recovery_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    public_key text NOT NULL,
+    thumbprint varchar(64) NOT NULL,
+    status varchar(20) NOT NULL,
+    effective_at timestamptz NOT NULL,
+    completed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS key_challenges (
+    challenge_id uuid PRIMARY KEY,
+    nonce varchar(64) NOT NULL UNIQUE,
+    purpose varchar(20) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    consumed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS did_services (
+    service_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    fragment varchar(64) NOT NULL,
+    type varchar(100) NOT NULL,
+    endpoint varchar(2048) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS released_identifiers (
+    identifier varchar PRIMARY KEY,
+    entity_type varchar NOT NULL,
+    entity_id uuid NOT NULL,
+    released_at timestamptz NOT NULL,
+    available_at timestamptz NOT NULL,
+    skeleton varchar NOT NULL
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS namespace_domains (
+    domain varchar PRIMARY KEY,
+    owner_actor_id uuid NOT NULL,
+    open boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS reserved_words (
+    skeleton varchar PRIMARY KEY,
+    word varchar NOT NULL,
+    kind varchar NOT NULL,
+    reason varchar,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS account_deletions (
+    deletion_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    status varchar(20) NOT NULL,
+    reason varchar(255),
+    pseudonymized boolean NOT NULL DEFAULT false,
+    summary jsonb NOT NULL DEFAULT '{}',
+    certificate text,
+    certificate_token_hash varchar(64),
+    effective_at timestamptz NOT NULL,
+    cancelled_at timestamptz,
+    completed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS retention_holds (
+    hold_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    reason varchar(255) NOT NULL,
+    expires_at timestamptz,
+    released_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    export_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    status varchar(20) NOT NULL,
+    storage_path text,
+    size bigint,
+    sha256 varchar(64),
+    wrapped_key text,
+    download_token_hash varchar(64) UNIQUE,
+    link_expires_at timestamptz,
+    downloaded_at timestamptz,
+    error text,
+    expires_at timestamptz,
+    completed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS verification_codes (
+    code_id uuid PRIMARY KEY,
+    actor_id uuid NOT NULL,
+    channel varchar(10) NOT NULL,
+    target varchar NOT NULL,
+    code_hash varchar(64),
+    attempts integer NOT NULL DEFAULT 0,
+    expires_at timestamptz NOT NULL,
+    consumed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id uuid NOT NULL,
+    actor_id uuid NOT NULL,
+    role varchar(20) NOT NULL,
+    invitation_id uuid,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (organization_id, actor_id)
+);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    invitation_id uuid PRIMARY KEY,
+    organization_id uuid NOT NULL,
+    organization_identifier varchar NOT NULL,
+    invitee_id uuid NOT NULL,
+    invitee_identifier varchar NOT NULL,
+    role varchar(20) NOT NULL,
+    invited_by uuid NOT NULL,
+    expires_at timestamptz NOT NULL,
+    accepted_at timestamptz,
+    declined_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+-----------------------------------
+
+CREATE TABLE IF NOT EXISTS delegation_grants (
+    grant_id uuid PRIMARY KEY,
+    principal_id uuid NOT NULL,
+    principal_identifier varchar NOT NULL,
+    delegate_id uuid NOT NULL,
+    delegate_identifier varchar NOT NULL,
+    scopes jsonb NOT NULL,
+    relationship varchar(30),
+    valid_from timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS delegated_actions (
+    action_id uuid PRIMARY KEY,
+    grant_id uuid NOT NULL,
+    principal_id uuid NOT NULL,
+    delegate_id uuid NOT NULL,
+    scope varchar(50) NOT NULL,
+    method varchar(10) NOT NULL,
+    path varchar(255) NOT NULL,
+    status_code integer NOT NULL,
+    ip_address varchar(45),
+    user_agent varchar(512),
+    performed_at timestamptz NOT NULL DEFAULT now()
+);
@@ -0,0 +1,2 @@
+-- Drop keyset pagination index
+DROP INDEX IF EXISTS idx_tokens_account_created;
@@ -0,0 +1,2 @@
+-- Support keyset pagination of an account's credentials, newest first
+CREATE INDEX IF NOT EXISTS idx_tokens_account_created ON tokens(account_id, created_at DESC, token_id DESC);
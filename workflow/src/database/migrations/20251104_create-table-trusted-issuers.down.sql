@@ -0,0 +1,2 @@
+-- Drop trusted_issuers table
+DROP TABLE IF EXISTS trusted_issuers;
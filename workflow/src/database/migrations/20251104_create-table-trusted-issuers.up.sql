@@ -0,0 +1,17 @@
+-- Create trusted_issuers table
+CREATE TABLE IF NOT EXISTS trusted_issuers (
+    issuer_id           UUID            PRIMARY KEY,
+    issuer_did          VARCHAR         NOT NULL UNIQUE,
+    name                VARCHAR         NOT NULL,
+    verification_types  JSONB           NOT NULL,
+    jurisdictions       JSONB,
+    valid_from          TIMESTAMPTZ,
+    valid_until         TIMESTAMPTZ,
+    status              VARCHAR(20)     NOT NULL DEFAULT 'active',
+    source              VARCHAR(20)     NOT NULL DEFAULT 'manual',
+    created_at          TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    updated_at          TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create index on status for filtered registry listings
+CREATE INDEX IF NOT EXISTS idx_trusted_issuers_status ON trusted_issuers(status);
@@ -0,0 +1,2 @@
+-- Drop verification_level_history table
+DROP TABLE IF EXISTS verification_level_history;
@@ -0,0 +1,13 @@
+-- Create verification_level_history table
+CREATE TABLE IF NOT EXISTS verification_level_history (
+    history_id      UUID            PRIMARY KEY,
+    actor_id        UUID            NOT NULL,
+    previous_level  VARCHAR(50)     NOT NULL,
+    new_level       VARCHAR(50)     NOT NULL,
+    reason          VARCHAR(50)     NOT NULL,
+    token_id        UUID,
+    created_at      TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create index on actor_id for per-actor history lookups
+CREATE INDEX IF NOT EXISTS idx_verification_level_history_actor ON verification_level_history(actor_id, created_at DESC);
@@ -0,0 +1,2 @@
+-- Drop credential_offers table
+DROP TABLE IF EXISTS credential_offers;
@@ -0,0 +1,20 @@
+-- Create credential_offers table for OID4VCI pre-authorized code offers
+CREATE TABLE IF NOT EXISTS credential_offers (
+    offer_id                        UUID            PRIMARY KEY,
+    actor_id                        UUID            NOT NULL,
+    credential_configuration_ids    JSONB           NOT NULL,
+    pre_authorized_code_hash        VARCHAR(64)     NOT NULL UNIQUE,
+    tx_code_hash                    VARCHAR(64),
+    tx_code_attempts                INTEGER         NOT NULL DEFAULT 0,
+    status                          VARCHAR(20)     NOT NULL,
+    expires_at                      TIMESTAMPTZ     NOT NULL,
+    access_token_hash               VARCHAR(64)     UNIQUE,
+    access_token_expires_at         TIMESTAMPTZ,
+    c_nonce                         VARCHAR(64),
+    c_nonce_expires_at              TIMESTAMPTZ,
+    redeemed_at                     TIMESTAMPTZ,
+    created_at                      TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create index on actor_id for per-actor offer lookups
+CREATE INDEX IF NOT EXISTS idx_credential_offers_actor ON credential_offers(actor_id);
@@ -0,0 +1,2 @@
+-- Drop presentation_requests table
+DROP TABLE IF EXISTS presentation_requests;
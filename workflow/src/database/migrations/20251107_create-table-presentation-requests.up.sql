@@ -0,0 +1,20 @@
+-- Create presentation_requests table for OID4VP authorization requests
+CREATE TABLE IF NOT EXISTS presentation_requests (
+    request_id                  UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    state                       VARCHAR(64)     NOT NULL UNIQUE,
+    nonce                       VARCHAR(64)     NOT NULL,
+    client_id                   VARCHAR         NOT NULL,
+    presentation_definition     JSONB           NOT NULL,
+    callback_url                VARCHAR,
+    callback_status             VARCHAR(20),
+    status                      VARCHAR(20)     NOT NULL,
+    result                      JSONB           NOT NULL DEFAULT '{}',
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    retrieved_at                TIMESTAMPTZ,
+    completed_at                TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create index on actor_id for per-verifier lookups
+CREATE INDEX IF NOT EXISTS idx_presentation_requests_actor ON presentation_requests(actor_id);
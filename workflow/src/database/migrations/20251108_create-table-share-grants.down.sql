@@ -0,0 +1,4 @@
+-- Drop credential sharing tables
+DROP TABLE IF EXISTS share_access_logs;
+DROP TABLE IF EXISTS share_grant_tokens;
+DROP TABLE IF EXISTS share_grants;
@@ -0,0 +1,43 @@
+-- Create share_grants table for consent-based credential sharing
+CREATE TABLE IF NOT EXISTS share_grants (
+    grant_id                    UUID            PRIMARY KEY,
+    owner_id                    UUID            NOT NULL,
+    owner_identifier            VARCHAR         NOT NULL,
+    grantee_id                  UUID            NOT NULL,
+    grantee_identifier          VARCHAR         NOT NULL,
+    purpose                     VARCHAR(255),
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    revoked_at                  TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create indexes for listing grants made and received
+CREATE INDEX IF NOT EXISTS idx_share_grants_owner ON share_grants(owner_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_share_grants_grantee ON share_grants(grantee_id, created_at DESC);
+
+-- Create share_grant_tokens table linking grants to the shared credentials
+CREATE TABLE IF NOT EXISTS share_grant_tokens (
+    grant_id                    UUID            NOT NULL,
+    token_id                    UUID            NOT NULL,
+    PRIMARY KEY (grant_id, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_grant_tokens_token ON share_grant_tokens(token_id);
+
+-- Create share_access_logs table recording grantee access to shared data
+CREATE TABLE IF NOT EXISTS share_access_logs (
+    log_id                      UUID            PRIMARY KEY,
+    grant_id                    UUID            NOT NULL,
+    owner_id                    UUID            NOT NULL,
+    grantee_id                  UUID            NOT NULL,
+    action                      VARCHAR(30)     NOT NULL,
+    token_id                    UUID,
+    document_id                 UUID,
+    ip_address                  VARCHAR(45),
+    user_agent                  VARCHAR(512),
+    accessed_at                 TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create indexes for the owner's access review
+CREATE INDEX IF NOT EXISTS idx_share_access_logs_owner ON share_access_logs(owner_id, accessed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_share_access_logs_grant ON share_access_logs(grant_id);
@@ -0,0 +1,25 @@
+-- Create jobs table backing the background job queue
+CREATE TABLE IF NOT EXISTS jobs (
+    job_id                      UUID            PRIMARY KEY,
+    type                        VARCHAR(50)     NOT NULL,
+    actor_id                    UUID            NOT NULL,
+    resource_id                 UUID,
+    payload                     JSONB           NOT NULL DEFAULT '{}',
+    status                      VARCHAR(20)     NOT NULL,
+    attempts                    INTEGER         NOT NULL DEFAULT 0,
+    max_attempts                INTEGER         NOT NULL,
+    run_at                      TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    locked_by                   VARCHAR(255),
+    locked_at                   TIMESTAMPTZ,
+    last_error                  TEXT,
+    result                      JSONB           NOT NULL DEFAULT '{}',
+    completed_at                TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    updated_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create partial index for workers claiming runnable jobs
+CREATE INDEX IF NOT EXISTS idx_jobs_runnable ON jobs(run_at) WHERE status IN ('queued', 'running');
+
+-- Create index on actor_id for progress polling
+CREATE INDEX IF NOT EXISTS idx_jobs_actor ON jobs(actor_id);
@@ -0,0 +1,42 @@
+-- Create webhook_subscriptions table for actor and partner event endpoints
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    subscription_id             UUID            PRIMARY KEY,
+    owner_id                    UUID            NOT NULL,
+    scope                       VARCHAR(20)     NOT NULL,
+    url                         VARCHAR(2048)   NOT NULL,
+    events                      JSONB           NOT NULL,
+    secret                      VARCHAR(64)     NOT NULL,
+    description                 VARCHAR(255),
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create index on owner_id for actor-scoped lookups
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_owner ON webhook_subscriptions(owner_id);
+
+-- Create GIN index for matching subscriptions by event type
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_events ON webhook_subscriptions USING GIN (events);
+
+-- Create webhook_deliveries table recording every delivery attempt
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id                 UUID            PRIMARY KEY,
+    subscription_id             UUID            NOT NULL,
+    owner_id                    UUID            NOT NULL,
+    scope                       VARCHAR(20)     NOT NULL,
+    event_id                    UUID            NOT NULL,
+    event_type                  VARCHAR(50)     NOT NULL,
+    payload                     JSONB           NOT NULL,
+    status                      VARCHAR(20)     NOT NULL,
+    attempts                    INTEGER         NOT NULL DEFAULT 0,
+    response_status             INTEGER,
+    last_error                  TEXT,
+    replay_of                   UUID,
+    delivered_at                TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    updated_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create index on subscription_id for filtering the delivery log
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id);
+
+-- Create index for paging the delivery log of an owner, newest first
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_owner_created ON webhook_deliveries(owner_id, created_at DESC, delivery_id DESC);
@@ -0,0 +1,2 @@
+-- Drop token_documents table
+DROP TABLE IF EXISTS token_documents;
@@ -0,0 +1,18 @@
+-- Create token_documents table linking credentials to their evidence documents
+CREATE TABLE IF NOT EXISTS token_documents (
+    token_id                    UUID            NOT NULL,
+    document_id                 UUID            NOT NULL,
+    role                        VARCHAR(30)     NOT NULL,    -- front, back, selfie, proof_of_address
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    PRIMARY KEY (token_id, document_id)
+);
+
+-- Support finding the credentials a document is linked to
+CREATE INDEX IF NOT EXISTS idx_token_documents_document ON token_documents(document_id);
+
+-- Link credentials added before this migration to the single document they were submitted with
+INSERT INTO token_documents (token_id, document_id, role, created_at)
+SELECT t.token_id, d.document_id, 'front', t.created_at
+FROM tokens t
+JOIN documents d ON d.document_id::text = t.metadata->>'documentId' AND d.account_id = t.account_id
+ON CONFLICT DO NOTHING;
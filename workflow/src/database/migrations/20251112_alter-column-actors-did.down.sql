@@ -0,0 +1,7 @@
+-- Restore the UUID column; DIDs that are not UUIDs are replaced with random ones
+ALTER TABLE actors ALTER COLUMN did TYPE UUID USING (
+    CASE WHEN did ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$'
+         THEN did::uuid
+         ELSE gen_random_uuid()
+    END
+);
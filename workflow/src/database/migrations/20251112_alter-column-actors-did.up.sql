@@ -0,0 +1,3 @@
+-- Store DIDs as text so actors can hold the did:key derived from their master public key.
+-- Existing UUID values are kept until the backfill command converts them.
+ALTER TABLE actors ALTER COLUMN did TYPE TEXT USING did::text;
@@ -0,0 +1 @@
+ALTER TABLE actors DROP COLUMN IF EXISTS master_key_thumbprint;
@@ -0,0 +1,4 @@
+-- Store the RFC 7638 JWK thumbprint of the master public key. Uniqueness of master keys is
+-- enforced on the thumbprint, so the same key cannot be registered twice in different encodings.
+-- Existing actors get theirs from the backfill command, hence the column is nullable.
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS master_key_thumbprint VARCHAR(64) UNIQUE;
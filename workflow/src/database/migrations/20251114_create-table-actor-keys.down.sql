@@ -0,0 +1,3 @@
+-- Drop master key history tables
+DROP TABLE IF EXISTS key_recoveries;
+DROP TABLE IF EXISTS actor_keys;
@@ -0,0 +1,39 @@
+-- Create actor_keys table holding the master key history of every actor
+CREATE TABLE IF NOT EXISTS actor_keys (
+    key_id                      UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    public_key                  TEXT            NOT NULL,    -- canonical PEM SubjectPublicKeyInfo
+    thumbprint                  VARCHAR(64)     UNIQUE,      -- RFC 7638 JWK thumbprint; a key is never reused
+    did                         TEXT            NOT NULL,    -- did:key of this key
+    endorsement                 VARCHAR(20)     NOT NULL,    -- registration, signature, recovery
+    valid_from                  TIMESTAMPTZ     NOT NULL,
+    valid_until                 TIMESTAMPTZ,                 -- NULL for the current key
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Support "which key was valid at time T" lookups
+CREATE INDEX IF NOT EXISTS idx_actor_keys_actor_valid_from ON actor_keys(actor_id, valid_from DESC);
+
+-- An actor has exactly one current key
+CREATE UNIQUE INDEX IF NOT EXISTS idx_actor_keys_current ON actor_keys(actor_id) WHERE valid_until IS NULL;
+
+-- Record the key every existing actor registered with
+INSERT INTO actor_keys (key_id, actor_id, public_key, thumbprint, did, endorsement, valid_from, created_at)
+SELECT gen_random_uuid(), a.actor_id, a.master_public_key, a.master_key_thumbprint, a.did, 'registration', a.created_at, a.created_at
+FROM actors a
+WHERE NOT EXISTS (SELECT 1 FROM actor_keys k WHERE k.actor_id = a.actor_id);
+
+-- Create key_recoveries table for master key replacements authorized without the current key
+CREATE TABLE IF NOT EXISTS key_recoveries (
+    recovery_id                 UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    public_key                  TEXT            NOT NULL,
+    thumbprint                  VARCHAR(64)     NOT NULL,
+    status                      VARCHAR(20)     NOT NULL,    -- pending, completed, cancelled
+    effective_at                TIMESTAMPTZ     NOT NULL,
+    completed_at                TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- An actor has at most one pending recovery
+CREATE UNIQUE INDEX IF NOT EXISTS idx_key_recoveries_pending ON key_recoveries(actor_id) WHERE status = 'pending';
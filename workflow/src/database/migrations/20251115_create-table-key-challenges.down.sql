@@ -0,0 +1,2 @@
+-- Drop key_challenges table
+DROP TABLE IF EXISTS key_challenges;
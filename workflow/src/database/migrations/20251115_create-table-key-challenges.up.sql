@@ -0,0 +1,12 @@
+-- Create key_challenges table holding single-use nonces for proofs of key possession
+CREATE TABLE IF NOT EXISTS key_challenges (
+    challenge_id                UUID            PRIMARY KEY,
+    nonce                       VARCHAR(64)     NOT NULL UNIQUE,
+    purpose                     VARCHAR(20)     NOT NULL,    -- registration
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    consumed_at                 TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Support purging expired challenges
+CREATE INDEX IF NOT EXISTS idx_key_challenges_expires_at ON key_challenges(expires_at);
@@ -0,0 +1,2 @@
+-- Drop did_services table
+DROP TABLE IF EXISTS did_services;
@@ -0,0 +1,12 @@
+-- Create did_services table holding the service endpoints of actor did:web documents
+CREATE TABLE IF NOT EXISTS did_services (
+    service_id                  UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    fragment                    VARCHAR(64)     NOT NULL,    -- service ID relative to the actor's DID
+    type                        VARCHAR(100)    NOT NULL,    -- e.g. LinkedDomains, DIDCommMessaging
+    endpoint                    VARCHAR(2048)   NOT NULL,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Service IDs are unique within a document
+CREATE UNIQUE INDEX IF NOT EXISTS idx_did_services_actor_fragment ON did_services(actor_id, fragment);
@@ -0,0 +1,6 @@
+-- Drop identifier aliases support
+DROP TABLE IF EXISTS released_identifiers;
+DROP INDEX IF EXISTS idx_identifiers_entity;
+DROP INDEX IF EXISTS idx_identifiers_primary;
+ALTER TABLE identifiers DROP COLUMN IF EXISTS created_at;
+ALTER TABLE identifiers DROP COLUMN IF EXISTS is_primary;
@@ -0,0 +1,25 @@
+-- Allow several universal identifiers per entity, exactly one of them primary
+ALTER TABLE identifiers ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE identifiers ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- Every entity so far has a single identifier, which becomes its primary one
+UPDATE identifiers i SET is_primary = true
+WHERE NOT EXISTS (
+    SELECT 1 FROM identifiers o
+    WHERE o.entity_type = i.entity_type AND o.entity_id = i.entity_id AND (o.is_primary OR o.identifier < i.identifier)
+);
+
+-- An entity has at most one primary identifier
+CREATE UNIQUE INDEX IF NOT EXISTS idx_identifiers_primary ON identifiers(entity_type, entity_id) WHERE is_primary;
+
+-- Support listing the identifiers of an entity
+CREATE INDEX IF NOT EXISTS idx_identifiers_entity ON identifiers(entity_type, entity_id);
+
+-- Create released_identifiers table reserving given-up identifiers for a cooling-off period
+CREATE TABLE IF NOT EXISTS released_identifiers (
+    identifier                  VARCHAR         PRIMARY KEY,
+    entity_type                 VARCHAR         NOT NULL,
+    entity_id                   UUID            NOT NULL,    -- former holder, who may reclaim it
+    released_at                 TIMESTAMPTZ     NOT NULL,
+    available_at                TIMESTAMPTZ     NOT NULL     -- others can claim it from then on
+);
@@ -0,0 +1,7 @@
+-- Drop identifier namespace support; canonicalized identifiers are left as they are
+DROP TABLE IF EXISTS reserved_words;
+DROP TABLE IF EXISTS namespace_domains;
+DROP INDEX IF EXISTS idx_released_identifiers_skeleton;
+ALTER TABLE released_identifiers DROP COLUMN IF EXISTS skeleton;
+DROP INDEX IF EXISTS idx_identifiers_skeleton;
+ALTER TABLE identifiers DROP COLUMN IF EXISTS skeleton;
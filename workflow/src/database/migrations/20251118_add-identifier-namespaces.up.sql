@@ -0,0 +1,40 @@
+-- Identifiers are unique by their confusable skeleton (UTS #39), computed by the application.
+-- Existing identifiers are canonicalized (case folded and qualified with the configured default
+-- domain) and given their skeleton by the backfill-identifiers command, which makes skeletons
+-- unique and required once every identifier has one of its own. It must run right after this
+-- migration: until then existing identifiers are not found by their canonical form, and look-alikes
+-- of them are not detected.
+ALTER TABLE identifiers ADD COLUMN IF NOT EXISTS skeleton VARCHAR;
+
+ALTER TABLE released_identifiers ADD COLUMN IF NOT EXISTS skeleton VARCHAR;
+CREATE INDEX IF NOT EXISTS idx_released_identifiers_skeleton ON released_identifiers(skeleton);
+
+-- Create namespace_domains table holding identifier domains registered to organizations
+CREATE TABLE IF NOT EXISTS namespace_domains (
+    domain                      VARCHAR         PRIMARY KEY,
+    owner_actor_id              UUID            NOT NULL,                    -- organization owning the namespace
+    open                        BOOLEAN         NOT NULL DEFAULT false,      -- anyone may claim identifiers in it
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Create reserved_words table holding words identifiers may not be or contain
+CREATE TABLE IF NOT EXISTS reserved_words (
+    skeleton                    VARCHAR         PRIMARY KEY,                 -- confusable skeleton without separators
+    word                        VARCHAR         NOT NULL,
+    kind                        VARCHAR         NOT NULL,                    -- reserved (whole local part) or blocked (anywhere)
+    reason                      VARCHAR,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+-- Reserve the names that impersonate the platform
+INSERT INTO reserved_words (skeleton, word, kind) VALUES
+    ('aclrnin', 'admin', 'reserved'),
+    ('aclrninistrator', 'administrator', 'reserved'),
+    ('root', 'root', 'reserved'),
+    ('systern', 'system', 'reserved'),
+    ('support', 'support', 'reserved'),
+    ('security', 'security', 'reserved'),
+    ('help', 'help', 'reserved'),
+    ('official', 'official', 'reserved'),
+    ('finternet', 'finternet', 'reserved')
+ON CONFLICT (skeleton) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Drop account deletion tables
+DROP TABLE IF EXISTS retention_holds;
+DROP TABLE IF EXISTS account_deletions;
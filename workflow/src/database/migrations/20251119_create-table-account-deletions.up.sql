@@ -0,0 +1,31 @@
+-- Create account_deletions table for account deletion requests and their erasure certificates
+CREATE TABLE IF NOT EXISTS account_deletions (
+    deletion_id                 UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,    -- kept after erasure so the certificate can be looked up
+    status                      VARCHAR(20)     NOT NULL,    -- pending, cancelled, completed
+    reason                      VARCHAR(255),
+    pseudonymized               BOOLEAN         NOT NULL DEFAULT false,  -- data under retention hold was kept pseudonymized
+    summary                     JSONB           NOT NULL DEFAULT '{}',   -- records erased per category
+    certificate                 TEXT,                        -- platform-signed erasure certificate (JWS)
+    effective_at                TIMESTAMPTZ     NOT NULL,    -- end of the grace period
+    cancelled_at                TIMESTAMPTZ,
+    completed_at                TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_actor_id ON account_deletions(actor_id);
+
+-- An actor has at most one pending deletion
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_deletions_pending ON account_deletions(actor_id) WHERE status = 'pending';
+
+-- Create retention_holds table for legal or regulatory obligations to keep an actor's records
+CREATE TABLE IF NOT EXISTS retention_holds (
+    hold_id                     UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    reason                      VARCHAR(255)    NOT NULL,
+    expires_at                  TIMESTAMPTZ,                 -- NULL holds until released
+    released_at                 TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_holds_actor_id ON retention_holds(actor_id);
@@ -0,0 +1,23 @@
+-- Create data_exports table for personal data exports (data portability)
+CREATE TABLE IF NOT EXISTS data_exports (
+    export_id                   UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    status                      VARCHAR(20)     NOT NULL,    -- pending, ready, failed, expired
+    storage_path                TEXT,                        -- encrypted bundle in document storage
+    size                        BIGINT,                      -- bytes of the unencrypted bundle
+    sha256                      VARCHAR(64),                 -- hash of the unencrypted bundle
+    wrapped_key                 TEXT,                        -- bundle data key wrapped by the export encryption key
+    download_token_hash         VARCHAR(64),                 -- SHA-256 of the current single-use download token
+    link_expires_at             TIMESTAMPTZ,
+    downloaded_at               TIMESTAMPTZ,
+    error                       TEXT,
+    expires_at                  TIMESTAMPTZ,                 -- the bundle is deleted from storage at this time
+    completed_at                TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_actor_id ON data_exports(actor_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_download_token_hash ON data_exports(download_token_hash);
+
+-- An actor has at most one export being prepared
+CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_pending ON data_exports(actor_id) WHERE status = 'pending';
@@ -0,0 +1,6 @@
+-- Drop contact verification support
+DROP TABLE IF EXISTS verification_codes;
+ALTER TABLE actors DROP COLUMN IF EXISTS phone_verified_at;
+ALTER TABLE actors DROP COLUMN IF EXISTS phone_verified;
+ALTER TABLE actors DROP COLUMN IF EXISTS email_verified_at;
+ALTER TABLE actors DROP COLUMN IF EXISTS email_verified;
@@ -0,0 +1,21 @@
+-- Record whether the email address and phone number of an actor have been proven. Existing
+-- actors start unverified: their email was marked verified in the auth provider without proof.
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS phone_verified BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMPTZ;
+
+-- Create verification_codes table recording every verification message sent to an actor
+CREATE TABLE IF NOT EXISTS verification_codes (
+    code_id                     UUID            PRIMARY KEY,
+    actor_id                    UUID            NOT NULL,
+    channel                     VARCHAR(10)     NOT NULL,    -- email or phone
+    target                      VARCHAR         NOT NULL,    -- address or number the message was sent to
+    code_hash                   VARCHAR(64),                 -- SHA-256 of the one-time code; unset for email links
+    attempts                    INTEGER         NOT NULL DEFAULT 0,
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    consumed_at                 TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_verification_codes_actor_channel ON verification_codes(actor_id, channel, created_at);
@@ -0,0 +1,3 @@
+-- Drop organization tables
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
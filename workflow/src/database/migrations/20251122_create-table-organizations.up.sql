@@ -0,0 +1,30 @@
+-- Create organization_members table making Individual actors members of Business actors
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id             UUID            NOT NULL,    -- Business actor
+    actor_id                    UUID            NOT NULL,    -- Individual actor
+    role                        VARCHAR(20)     NOT NULL,    -- owner, admin, operator, viewer
+    invitation_id               UUID,                        -- invitation the membership was accepted from
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    PRIMARY KEY (organization_id, actor_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_members_actor_id ON organization_members(actor_id);
+
+-- Create organization_invitations table for invitations to join an organization
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    invitation_id               UUID            PRIMARY KEY,
+    organization_id             UUID            NOT NULL,
+    organization_identifier     VARCHAR         NOT NULL,
+    invitee_id                  UUID            NOT NULL,
+    invitee_identifier          VARCHAR         NOT NULL,
+    role                        VARCHAR(20)     NOT NULL,
+    invited_by                  UUID            NOT NULL,    -- the organization, or the member who invited
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    accepted_at                 TIMESTAMPTZ,
+    declined_at                 TIMESTAMPTZ,
+    revoked_at                  TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_organization_id ON organization_invitations(organization_id);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_invitee_id ON organization_invitations(invitee_id);
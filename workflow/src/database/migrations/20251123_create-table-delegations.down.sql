@@ -0,0 +1,3 @@
+-- Drop delegation tables
+DROP TABLE IF EXISTS delegated_actions;
+DROP TABLE IF EXISTS delegation_grants;
@@ -0,0 +1,36 @@
+-- Create delegation_grants table letting a principal authorize a delegate to act for them
+CREATE TABLE IF NOT EXISTS delegation_grants (
+    grant_id                    UUID            PRIMARY KEY,
+    principal_id                UUID            NOT NULL,    -- actor the delegate acts for
+    principal_identifier        VARCHAR         NOT NULL,
+    delegate_id                 UUID            NOT NULL,    -- actor allowed to act
+    delegate_identifier         VARCHAR         NOT NULL,
+    scopes                      JSONB           NOT NULL,    -- operations allowed, e.g. ["credentials:add"]
+    relationship                VARCHAR(30),                 -- guardian, accountant, representative
+    valid_from                  TIMESTAMPTZ     NOT NULL,
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    revoked_at                  TIMESTAMPTZ,
+    created_at                  TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegation_grants_principal_id ON delegation_grants(principal_id);
+CREATE INDEX IF NOT EXISTS idx_delegation_grants_delegate_id ON delegation_grants(delegate_id);
+
+-- Create delegated_actions table auditing every request made under a delegation grant
+CREATE TABLE IF NOT EXISTS delegated_actions (
+    action_id                   UUID            PRIMARY KEY,
+    grant_id                    UUID            NOT NULL,
+    principal_id                UUID            NOT NULL,
+    delegate_id                 UUID            NOT NULL,
+    scope                       VARCHAR(50)     NOT NULL,
+    method                      VARCHAR(10)     NOT NULL,
+    path                        VARCHAR(255)    NOT NULL,
+    status_code                 INTEGER         NOT NULL,    -- HTTP status returned to the delegate
+    ip_address                  VARCHAR(45),
+    user_agent                  VARCHAR(512),
+    performed_at                TIMESTAMPTZ     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegated_actions_grant_id ON delegated_actions(grant_id);
+CREATE INDEX IF NOT EXISTS idx_delegated_actions_principal_id ON delegated_actions(principal_id, performed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_delegated_actions_delegate_id ON delegated_actions(delegate_id, performed_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE presentation_requests DROP COLUMN IF EXISTS callback_secret;
@@ -0,0 +1,3 @@
+-- Presentation callbacks are signed like webhook deliveries with a secret returned when the
+-- request is created. Requests created before have none and their callbacks stay unsigned.
+ALTER TABLE presentation_requests ADD COLUMN IF NOT EXISTS callback_secret VARCHAR;
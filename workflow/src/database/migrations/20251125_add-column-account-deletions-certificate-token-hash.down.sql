@@ -0,0 +1 @@
+ALTER TABLE account_deletions DROP COLUMN IF EXISTS certificate_token_hash;
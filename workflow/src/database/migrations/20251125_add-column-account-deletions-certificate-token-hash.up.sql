@@ -0,0 +1,3 @@
+-- Erasure certificates are fetched with a token returned when the deletion is requested.
+-- Deletions requested before have none, so their certificates can no longer be fetched.
+ALTER TABLE account_deletions ADD COLUMN IF NOT EXISTS certificate_token_hash VARCHAR(64);
@@ -0,0 +1,193 @@
+package did
+
+import (
+	"app/src/keys"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Verification method types understood when extracting public keys
+const (
+	TypeMultikey                   = "Multikey"
+	TypeJSONWebKey2020             = "JsonWebKey2020"
+	TypeJSONWebKey                 = "JsonWebKey"
+	TypeEd25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	TypeEd25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	TypeEcdsaSecp256k1Key2019      = "EcdsaSecp256k1VerificationKey2019"
+)
+
+// JSON-LD contexts of the documents this service publishes
+const (
+	ContextDIDv1    = "https://www.w3.org/ns/did/v1"
+	ContextMultikey = "https://w3id.org/security/multikey/v1"
+)
+
+// ErrVerificationMethodNotFound is returned when a key reference does not match the document
+var ErrVerificationMethodNotFound = errors.New("verification method not found")
+
+// Document represents the subset of a DID document used for key lookup
+type Document struct {
+	Context            interface{}          `json:"@context,omitempty"`
+	ID                 string               `json:"id"`
+	Controller         interface{}          `json:"controller,omitempty"`
+	AlsoKnownAs        []string             `json:"alsoKnownAs,omitempty"`
+	VerificationMethod []VerificationMethod `json:"verificationMethod,omitempty"`
+	Authentication     []MethodReference    `json:"authentication,omitempty"`
+	AssertionMethod    []MethodReference    `json:"assertionMethod,omitempty"`
+	Service            []Service            `json:"service,omitempty"`
+}
+
+// VerificationMethod represents a public key entry of a DID document
+type VerificationMethod struct {
+	ID                 string    `json:"id"`
+	Type               string    `json:"type"`
+	Controller         string    `json:"controller"`
+	PublicKeyJwk       *keys.JWK `json:"publicKeyJwk,omitempty"`
+	PublicKeyMultibase string    `json:"publicKeyMultibase,omitempty"`
+	PublicKeyBase58    string    `json:"publicKeyBase58,omitempty"`
+}
+
+// Service represents a service endpoint of a DID document
+type Service struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	ServiceEndpoint interface{} `json:"serviceEndpoint"`
+}
+
+// MethodReference is a verification relationship entry: either a reference to a
+// verification method by ID or an embedded verification method
+type MethodReference struct {
+	Reference string
+	Embedded  *VerificationMethod
+}
+
+func (r *MethodReference) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &r.Reference)
+	}
+
+	var vm VerificationMethod
+	if err := json.Unmarshal(data, &vm); err != nil {
+		return err
+	}
+	r.Embedded = &vm
+	return nil
+}
+
+func (r MethodReference) MarshalJSON() ([]byte, error) {
+	if r.Embedded != nil {
+		return json.Marshal(r.Embedded)
+	}
+	return json.Marshal(r.Reference)
+}
+
+// PublicKey extracts the Go public key of the verification method
+func (vm *VerificationMethod) PublicKey() (crypto.PublicKey, error) {
+	switch {
+	case vm.PublicKeyJwk != nil:
+		return vm.PublicKeyJwk.PublicKey()
+	case vm.PublicKeyMultibase != "":
+		return keys.DecodeMultibaseKey(vm.PublicKeyMultibase)
+	case vm.PublicKeyBase58 != "" && vm.Type == TypeEd25519VerificationKey2018:
+		return keys.DecodeBase58Ed25519(vm.PublicKeyBase58)
+	default:
+		return nil, fmt.Errorf("%w: verification method %s of type %s", keys.ErrUnsupportedKey, vm.ID, vm.Type)
+	}
+}
+
+// NewMultikeyMethod describes a public key as a Multikey verification method of controller,
+// identified by the key's JWK thumbprint (RFC 7638) so its ID does not change between documents
+func NewMultikeyMethod(controller string, key crypto.PublicKey) (*VerificationMethod, error) {
+	multibase, err := keys.EncodeMultibaseKey(key)
+	if err != nil {
+		return nil, err
+	}
+	thumbprint, err := keys.Thumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+	return &VerificationMethod{
+		ID:                 controller + "#" + thumbprint,
+		Type:               TypeMultikey,
+		Controller:         controller,
+		PublicKeyMultibase: multibase,
+	}, nil
+}
+
+// FindVerificationMethod returns the verification method matching ref, which may be
+// an absolute DID URL or a fragment relative to the document ID
+func (d *Document) FindVerificationMethod(ref string) (*VerificationMethod, error) {
+	target := d.absoluteID(ref)
+
+	for i := range d.VerificationMethod {
+		if d.absoluteID(d.VerificationMethod[i].ID) == target {
+			return &d.VerificationMethod[i], nil
+		}
+	}
+
+	// Keys may also be embedded directly in a verification relationship
+	for _, relationship := range [][]MethodReference{d.AssertionMethod, d.Authentication} {
+		for _, entry := range relationship {
+			if entry.Embedded != nil && d.absoluteID(entry.Embedded.ID) == target {
+				return entry.Embedded, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrVerificationMethodNotFound, ref)
+}
+
+// AssertionMethods returns the verification methods authorized to issue credentials
+func (d *Document) AssertionMethods() []*VerificationMethod {
+	return d.resolveRelationship(d.AssertionMethod)
+}
+
+// AuthenticationMethods returns the verification methods authorized to authenticate the subject
+func (d *Document) AuthenticationMethods() []*VerificationMethod {
+	return d.resolveRelationship(d.Authentication)
+}
+
+// IsAssertionMethod reports whether the verification method is authorized to issue credentials
+func (d *Document) IsAssertionMethod(vm *VerificationMethod) bool {
+	for _, method := range d.AssertionMethods() {
+		if d.absoluteID(method.ID) == d.absoluteID(vm.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAuthenticationMethod reports whether the verification method is authorized to authenticate the subject
+func (d *Document) IsAuthenticationMethod(vm *VerificationMethod) bool {
+	for _, method := range d.AuthenticationMethods() {
+		if d.absoluteID(method.ID) == d.absoluteID(vm.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Document) resolveRelationship(relationship []MethodReference) []*VerificationMethod {
+	methods := make([]*VerificationMethod, 0, len(relationship))
+	for _, entry := range relationship {
+		if entry.Embedded != nil {
+			methods = append(methods, entry.Embedded)
+			continue
+		}
+		if vm, err := d.FindVerificationMethod(entry.Reference); err == nil {
+			methods = append(methods, vm)
+		}
+	}
+	return methods
+}
+
+// absoluteID expands a relative fragment reference against the document ID
+func (d *Document) absoluteID(ref string) string {
+	if strings.HasPrefix(ref, "#") {
+		return d.ID + ref
+	}
+	return ref
+}
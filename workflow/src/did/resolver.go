@@ -0,0 +1,287 @@
+package did
+
+import (
+	"app/src/cache"
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/netguard"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DID methods supported by the resolver
+const (
+	MethodKey = "key"
+	MethodJWK = "jwk"
+	MethodWeb = "web"
+)
+
+const (
+	// maxDocumentSize bounds the size of a fetched did:web document
+	maxDocumentSize = 1 << 20
+
+	// webCacheSize and webCacheTTL bound the did:web documents kept between resolutions
+	webCacheSize = 1000
+	webCacheTTL  = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidDID is returned for identifiers that are not syntactically valid DIDs
+	ErrInvalidDID = errors.New("invalid DID")
+
+	// ErrUnsupportedMethod is returned for DID methods the resolver cannot handle
+	ErrUnsupportedMethod = errors.New("unsupported DID method")
+
+	// ErrResolutionFailed is returned when a DID document cannot be retrieved
+	ErrResolutionFailed = errors.New("DID resolution failed")
+)
+
+// Resolver resolves DIDs to DID documents
+type Resolver interface {
+	Resolve(ctx context.Context, did string) (*Document, error)
+}
+
+// resolver resolves did:key and did:jwk locally and did:web over HTTPS. did:web hosts come from
+// untrusted input such as a credential issuer, so documents are only fetched from public
+// addresses and are cached to keep repeated resolutions from reaching the host.
+type resolver struct {
+	client *http.Client
+	web    *cache.LRU[string, *Document]
+}
+
+// NewResolver creates a resolver for the did:key, did:jwk and did:web methods
+func NewResolver() Resolver {
+	return &resolver{
+		client: netguard.NewClient(constants.HTTPClientTimeoutShort * time.Second),
+		web:    cache.NewLRU[string, *Document](webCacheSize, webCacheTTL),
+	}
+}
+
+func (r *resolver) Resolve(ctx context.Context, did string) (*Document, error) {
+	method, identifier, err := Parse(did)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method {
+	case MethodKey:
+		return resolveKey(did, identifier)
+	case MethodJWK:
+		return resolveJWK(did, identifier)
+	case MethodWeb:
+		return r.resolveWeb(ctx, did, identifier)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMethod, method)
+	}
+}
+
+// Parse splits a DID (without path, query or fragment) into its method and method-specific identifier
+func Parse(did string) (method, identifier string, err error) {
+	parts := strings.SplitN(did, ":", 3)
+	if len(parts) != 3 || parts[0] != "did" || parts[1] == "" || parts[2] == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidDID, did)
+	}
+	if strings.ContainsAny(parts[2], "/?#") {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidDID, did)
+	}
+	return parts[1], parts[2], nil
+}
+
+// SplitURL separates a DID URL into the DID and its fragment
+func SplitURL(didURL string) (did, fragment string) {
+	did, fragment, _ = strings.Cut(didURL, "#")
+	return did, fragment
+}
+
+// resolveKey expands a did:key into a document with a single Multikey method
+func resolveKey(did, identifier string) (*Document, error) {
+	if _, err := keys.DecodeMultibaseKey(identifier); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDID, err)
+	}
+
+	vmID := did + "#" + identifier
+	return singleKeyDocument(did, VerificationMethod{
+		ID:                 vmID,
+		Type:               TypeMultikey,
+		Controller:         did,
+		PublicKeyMultibase: identifier,
+	}), nil
+}
+
+// resolveJWK expands a did:jwk into a document with a single JsonWebKey method
+func resolveJWK(did, identifier string) (*Document, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDID, err)
+	}
+
+	var jwk keys.JWK
+	if err := json.Unmarshal(raw, &jwk); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDID, err)
+	}
+	if _, err := jwk.PublicKey(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDID, err)
+	}
+
+	return singleKeyDocument(did, VerificationMethod{
+		ID:           did + "#0",
+		Type:         TypeJSONWebKey2020,
+		Controller:   did,
+		PublicKeyJwk: &jwk,
+	}), nil
+}
+
+func singleKeyDocument(did string, vm VerificationMethod) *Document {
+	ref := []MethodReference{{Reference: vm.ID}}
+	return &Document{
+		Context:            []string{"https://www.w3.org/ns/did/v1"},
+		ID:                 did,
+		VerificationMethod: []VerificationMethod{vm},
+		Authentication:     ref,
+		AssertionMethod:    ref,
+	}
+}
+
+// WebDocumentURL returns the HTTPS location of a did:web document. Hosts must be domain names;
+// IP addresses are rejected.
+func WebDocumentURL(identifier string) (string, error) {
+	segments := strings.Split(identifier, ":")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil || decoded == "" {
+			return "", fmt.Errorf("%w: did:web:%s", ErrInvalidDID, identifier)
+		}
+		segments[i] = decoded
+	}
+
+	host := segments[0]
+	if strings.ContainsAny(host, "/@") {
+		return "", fmt.Errorf("%w: did:web:%s", ErrInvalidDID, identifier)
+	}
+	hostURL, err := url.Parse("https://" + host)
+	if err != nil || hostURL.Hostname() == "" {
+		return "", fmt.Errorf("%w: did:web:%s", ErrInvalidDID, identifier)
+	}
+	if _, err := netip.ParseAddr(hostURL.Hostname()); err == nil {
+		return "", fmt.Errorf("%w: did:web host is an IP address: %s", ErrInvalidDID, host)
+	}
+
+	if len(segments) == 1 {
+		return "https://" + host + "/.well-known/did.json", nil
+	}
+	return "https://" + host + "/" + strings.Join(segments[1:], "/") + "/did.json", nil
+}
+
+func (r *resolver) resolveWeb(ctx context.Context, did, identifier string) (*Document, error) {
+	if document, ok := r.web.Get(did); ok {
+		return document, nil
+	}
+
+	documentURL, err := WebDocumentURL(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResolutionFailed, err)
+	}
+	req.Header.Set(constants.HTTPHeaderAccept, "application/did+json, application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResolutionFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned status %d", ErrResolutionFailed, documentURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResolutionFailed, err)
+	}
+	if len(body) > maxDocumentSize {
+		return nil, fmt.Errorf("%w: document exceeds %d bytes", ErrResolutionFailed, maxDocumentSize)
+	}
+
+	var document Document
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResolutionFailed, err)
+	}
+	if document.ID != did {
+		return nil, fmt.Errorf("%w: document id %q does not match %q", ErrResolutionFailed, document.ID, did)
+	}
+
+	r.web.Add(did, &document)
+	return &document, nil
+}
+
+// WebDIDFromURL derives the did:web identifier of the host serving baseURL.
+// The document for the returned DID is expected at /.well-known/did.json on that host, or at
+// /<path...>/did.json when path segments are given.
+func WebDIDFromURL(baseURL string, path ...string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("%w: cannot derive did:web from %q", ErrInvalidDID, baseURL)
+	}
+
+	segments := []string{strings.ReplaceAll(u.Host, ":", "%3A")}
+	for _, segment := range path {
+		if segment == "" {
+			return "", fmt.Errorf("%w: empty did:web path segment", ErrInvalidDID)
+		}
+		segments = append(segments, escapeWebSegment(segment))
+	}
+	return "did:" + MethodWeb + ":" + strings.Join(segments, ":"), nil
+}
+
+// escapeWebSegment percent-encodes every byte of a did:web path segment that is not a DID
+// idchar (ALPHA, DIGIT, ".", "-" and "_"), so the segment survives both DID and URL parsing
+func escapeWebSegment(segment string) string {
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		c := segment[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// JWKDID encodes a public JWK as a did:jwk identifier
+func JWKDID(jwk *keys.JWK) (string, error) {
+	if _, err := jwk.PublicKey(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidDID, err)
+	}
+
+	// Only the public members are carried in the identifier
+	public := keys.JWK{Kty: jwk.Kty, Crv: jwk.Crv, X: jwk.X, Y: jwk.Y, N: jwk.N, E: jwk.E}
+	raw, err := json.Marshal(public)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidDID, err)
+	}
+	return "did:" + MethodJWK + ":" + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// KeyDID encodes an Ed25519, secp256k1 or P-256 public key as a did:key identifier
+func KeyDID(key crypto.PublicKey) (string, error) {
+	identifier, err := keys.EncodeMultibaseKey(key)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidDID, err)
+	}
+	return "did:" + MethodKey + ":" + identifier, nil
+}
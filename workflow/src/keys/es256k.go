@@ -0,0 +1,69 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AlgES256K is the JOSE algorithm name for ECDSA over secp256k1 with SHA-256 (RFC 8812)
+const AlgES256K = "ES256K"
+
+// SigningMethodES256K implements jwt.SigningMethod for ES256K, which golang-jwt does not ship
+type SigningMethodES256K struct{}
+
+// ES256K is the shared ES256K signing method instance
+var ES256K = &SigningMethodES256K{}
+
+func init() {
+	jwt.RegisterSigningMethod(AlgES256K, func() jwt.SigningMethod {
+		return ES256K
+	})
+}
+
+func (m *SigningMethodES256K) Alg() string {
+	return AlgES256K
+}
+
+// Verify checks a 64-byte R||S signature against a *secp256k1.PublicKey
+func (m *SigningMethodES256K) Verify(signingString string, sig []byte, key interface{}) error {
+	pubKey, ok := key.(*secp256k1.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+	if len(sig) != 64 {
+		return jwt.ErrSignatureInvalid
+	}
+
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig[:32]) || s.SetByteSlice(sig[32:]) {
+		return jwt.ErrSignatureInvalid
+	}
+
+	hash := sha256.Sum256([]byte(signingString))
+	if !ecdsa.NewSignature(&r, &s).Verify(hash[:], pubKey) {
+		return jwt.ErrSignatureInvalid
+	}
+	return nil
+}
+
+// Sign produces a 64-byte R||S signature with a *secp256k1.PrivateKey
+func (m *SigningMethodES256K) Sign(signingString string, key interface{}) ([]byte, error) {
+	privKey, ok := key.(*secp256k1.PrivateKey)
+	if !ok {
+		return nil, jwt.ErrInvalidKeyType
+	}
+	if privKey == nil {
+		return nil, errors.New("nil secp256k1 private key")
+	}
+
+	hash := sha256.Sum256([]byte(signingString))
+	sig := ecdsa.Sign(privKey, hash[:])
+
+	r, s := sig.R(), sig.S()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	return append(rBytes[:], sBytes[:]...), nil
+}
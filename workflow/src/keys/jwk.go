@@ -0,0 +1,143 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// JWK key types and curves
+const (
+	KeyTypeEC  = "EC"
+	KeyTypeRSA = "RSA"
+	KeyTypeOKP = "OKP"
+
+	CurveP256      = "P-256"
+	CurveP384      = "P-384"
+	CurveP521      = "P-521"
+	CurveSecp256k1 = "secp256k1"
+	CurveEd25519   = "Ed25519"
+)
+
+// ErrUnsupportedKey is returned for key types or curves that are not supported
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+// JWK represents a public JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+// PublicKey converts the JWK into a Go public key. The result is one of
+// *rsa.PublicKey, *ecdsa.PublicKey, *secp256k1.PublicKey or ed25519.PublicKey.
+func (j *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case KeyTypeRSA:
+		return j.rsaPublicKey()
+	case KeyTypeEC:
+		return j.ecPublicKey()
+	case KeyTypeOKP:
+		if j.Crv != CurveEd25519 {
+			return nil, fmt.Errorf("%w: OKP curve %q", ErrUnsupportedKey, j.Crv)
+		}
+		x, err := decodeCoordinate(j.X, "x")
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key length %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedKey, j.Kty)
+	}
+}
+
+func (j *JWK) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeCoordinate(j.N, "n")
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeCoordinate(j.E, "e")
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("invalid RSA public exponent")
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+func (j *JWK) ecPublicKey() (crypto.PublicKey, error) {
+	x, err := decodeCoordinate(j.X, "x")
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeCoordinate(j.Y, "y")
+	if err != nil {
+		return nil, err
+	}
+
+	if j.Crv == CurveSecp256k1 {
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid secp256k1 coordinate length")
+		}
+		uncompressed := append(append([]byte{0x04}, x...), y...)
+		return secp256k1.ParsePubKey(uncompressed)
+	}
+
+	curve, err := namedCurve(j.Crv)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !curve.IsOnCurve(key.X, key.Y) {
+		return nil, fmt.Errorf("point is not on curve %s", j.Crv)
+	}
+	return key, nil
+}
+
+// namedCurve maps a JWK curve name to the standard library curve
+func namedCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case CurveP256:
+		return elliptic.P256(), nil
+	case CurveP384:
+		return elliptic.P384(), nil
+	case CurveP521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("%w: EC curve %q", ErrUnsupportedKey, crv)
+	}
+}
+
+// decodeCoordinate decodes a required base64url JWK member
+func decodeCoordinate(value, name string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing JWK member %q", name)
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWK member %q: %w", name, err)
+	}
+	return decoded, nil
+}
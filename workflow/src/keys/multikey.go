@@ -0,0 +1,78 @@
+package keys
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/mr-tron/base58"
+)
+
+// Multicodec prefixes (unsigned varint encoded) for public keys
+var (
+	multicodecEd25519   = []byte{0xed, 0x01}
+	multicodecSecp256k1 = []byte{0xe7, 0x01}
+	multicodecP256      = []byte{0x80, 0x24}
+	multicodecRSA       = []byte{0x85, 0x24}
+)
+
+// multibaseBase58BTC is the multibase prefix for base58btc encoding
+const multibaseBase58BTC = 'z'
+
+// DecodeMultibaseKey decodes a base58btc multibase, multicodec-prefixed public key
+// as used by did:key and the Multikey verification method type
+func DecodeMultibaseKey(value string) (crypto.PublicKey, error) {
+	if len(value) < 2 || value[0] != multibaseBase58BTC {
+		return nil, errors.New("unsupported multibase encoding")
+	}
+
+	decoded, err := base58.Decode(value[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid base58 key: %w", err)
+	}
+
+	return decodeMulticodecKey(decoded)
+}
+
+// decodeMulticodecKey parses raw key bytes according to their multicodec prefix
+func decodeMulticodecKey(data []byte) (crypto.PublicKey, error) {
+	switch {
+	case bytes.HasPrefix(data, multicodecEd25519):
+		raw := data[len(multicodecEd25519):]
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key length %d", len(raw))
+		}
+		return ed25519.PublicKey(raw), nil
+	case bytes.HasPrefix(data, multicodecSecp256k1):
+		return secp256k1.ParsePubKey(data[len(multicodecSecp256k1):])
+	case bytes.HasPrefix(data, multicodecP256):
+		raw := data[len(multicodecP256):]
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), raw)
+		if x == nil {
+			return nil, errors.New("invalid compressed P-256 key")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	case bytes.HasPrefix(data, multicodecRSA):
+		return x509.ParsePKCS1PublicKey(data[len(multicodecRSA):])
+	default:
+		return nil, fmt.Errorf("%w: unknown multicodec prefix", ErrUnsupportedKey)
+	}
+}
+
+// DecodeBase58Ed25519 decodes a raw base58 Ed25519 key (publicKeyBase58)
+func DecodeBase58Ed25519(value string) (ed25519.PublicKey, error) {
+	raw, err := base58.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base58 key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 key length %d", len(raw))
+	}
+	return ed25519.PublicKey(raw), nil
+}
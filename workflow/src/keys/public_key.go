@@ -0,0 +1,242 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/mr-tron/base58"
+)
+
+// RSA modulus sizes accepted for account keys, in bits
+const (
+	MinRSAKeyBits = 2048
+	MaxRSAKeyBits = 8192
+)
+
+// ErrWeakKey is returned for keys below the minimum accepted strength
+var ErrWeakKey = errors.New("key is too weak")
+
+var (
+	// oidPublicKeyECDSA identifies an elliptic curve key in a SubjectPublicKeyInfo (RFC 5480)
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	// oidCurveSecp256k1 names the secp256k1 curve (SEC 2), which crypto/x509 does not support
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// subjectPublicKeyInfo is the ASN.1 structure of a PEM "PUBLIC KEY" block
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// NormalizedKey is a validated public key with its canonical encodings
+type NormalizedKey struct {
+	Key crypto.PublicKey
+
+	// PEM is the PEM-encoded SubjectPublicKeyInfo of the key
+	PEM string
+
+	// Thumbprint is the base64url SHA-256 JWK thumbprint of the key (RFC 7638)
+	Thumbprint string
+}
+
+// NormalizePublicKey parses a public key in any supported form, checks that it is an RSA, P-256,
+// secp256k1 or Ed25519 key of acceptable strength and returns its canonical encodings. The same
+// key always yields the same PEM and thumbprint, whatever form it was given in.
+func NormalizePublicKey(value string) (*NormalizedKey, error) {
+	key, err := ParsePublicKey(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckKeyStrength(key); err != nil {
+		return nil, err
+	}
+
+	encoded, err := MarshalPublicKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	thumbprint, err := Thumbprint(key)
+	if err != nil {
+		return nil, err
+	}
+	return &NormalizedKey{Key: key, PEM: encoded, Thumbprint: thumbprint}, nil
+}
+
+// ParsePublicKey parses a public key given as a PEM-encoded SubjectPublicKeyInfo, a public JWK
+// or a base58btc multibase, multicodec-prefixed key. The result is one of *rsa.PublicKey,
+// *ecdsa.PublicKey, *secp256k1.PublicKey or ed25519.PublicKey.
+func ParsePublicKey(value string) (crypto.PublicKey, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, "-----BEGIN"):
+		return parsePEMPublicKey(value)
+	case strings.HasPrefix(value, "{"):
+		return parseJWKPublicKey(value)
+	default:
+		return DecodeMultibaseKey(value)
+	}
+}
+
+// parsePEMPublicKey decodes a PEM "PUBLIC KEY" block, including secp256k1 keys
+func parsePEMPublicKey(value string) (crypto.PublicKey, error) {
+	block, rest := pem.Decode([]byte(value))
+	if block == nil || block.Type != "PUBLIC KEY" || len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, errors.New("invalid PEM public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err == nil {
+		return key, nil
+	}
+
+	var spki subjectPublicKeyInfo
+	if rest, asnErr := asn1.Unmarshal(block.Bytes, &spki); asnErr != nil || len(rest) > 0 {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	var curve asn1.ObjectIdentifier
+	if !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if _, asnErr := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve); asnErr != nil || !curve.Equal(oidCurveSecp256k1) {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	return secp256k1.ParsePubKey(spki.PublicKey.RightAlign())
+}
+
+// parseJWKPublicKey decodes a JWK, refusing keys that carry private members
+func parseJWKPublicKey(value string) (crypto.PublicKey, error) {
+	var jwk struct {
+		JWK
+		D string `json:"d"`
+		P string `json:"p"`
+	}
+	if err := json.Unmarshal([]byte(value), &jwk); err != nil {
+		return nil, fmt.Errorf("invalid JWK: %w", err)
+	}
+	if jwk.D != "" || jwk.P != "" {
+		return nil, errors.New("JWK contains private key material")
+	}
+	return jwk.PublicKey()
+}
+
+// CheckKeyStrength accepts RSA keys of MinRSAKeyBits to MaxRSAKeyBits bits, P-256, secp256k1
+// and Ed25519 keys
+func CheckKeyStrength(key crypto.PublicKey) error {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < MinRSAKeyBits {
+			return fmt.Errorf("%w: RSA modulus of %d bits, at least %d required", ErrWeakKey, k.N.BitLen(), MinRSAKeyBits)
+		}
+		if k.N.BitLen() > MaxRSAKeyBits {
+			return fmt.Errorf("%w: RSA modulus of %d bits, at most %d supported", ErrUnsupportedKey, k.N.BitLen(), MaxRSAKeyBits)
+		}
+		if k.E < 65537 {
+			return fmt.Errorf("%w: RSA public exponent %d, at least 65537 required", ErrWeakKey, k.E)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return fmt.Errorf("%w: EC curve %s", ErrUnsupportedKey, k.Curve.Params().Name)
+		}
+		return nil
+	case *secp256k1.PublicKey, ed25519.PublicKey:
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+}
+
+// MarshalPublicKeyPEM encodes a public key as a PEM SubjectPublicKeyInfo, including secp256k1 keys
+func MarshalPublicKeyPEM(key crypto.PublicKey) (string, error) {
+	var der []byte
+	var err error
+	if k, ok := key.(*secp256k1.PublicKey); ok {
+		der, err = marshalSecp256k1SPKI(k)
+	} else {
+		der, err = x509.MarshalPKIXPublicKey(key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsupportedKey, err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// marshalSecp256k1SPKI encodes a secp256k1 key as an uncompressed-point SubjectPublicKeyInfo
+func marshalSecp256k1SPKI(key *secp256k1.PublicKey) ([]byte, error) {
+	curve, err := asn1.Marshal(oidCurveSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	point := key.SerializeUncompressed()
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: curve},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
+
+// Thumbprint computes the base64url SHA-256 JWK thumbprint of a public key (RFC 7638, and
+// RFC 8037 for Ed25519). Only the required members, in lexicographic order, are hashed.
+func Thumbprint(key crypto.PublicKey) (string, error) {
+	jwk, err := NewJWK(key)
+	if err != nil {
+		return "", err
+	}
+
+	var members string
+	switch jwk.Kty {
+	case KeyTypeEC:
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	case KeyTypeRSA:
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.Kty, jwk.N)
+	case KeyTypeOKP:
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedKey, jwk.Kty)
+	}
+
+	digest := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
+
+// EncodeMultibaseKey encodes an RSA, Ed25519, secp256k1 or P-256 public key as a base58btc
+// multibase, multicodec-prefixed value as used by did:key. EC keys are compressed and RSA keys
+// are PKCS #1 encoded.
+func EncodeMultibaseKey(key crypto.PublicKey) (string, error) {
+	var data []byte
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		data = append(append([]byte{}, multicodecEd25519...), k...)
+	case *secp256k1.PublicKey:
+		data = append(append([]byte{}, multicodecSecp256k1...), k.SerializeCompressed()...)
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return "", fmt.Errorf("%w: EC curve %s", ErrUnsupportedKey, k.Curve.Params().Name)
+		}
+		data = append(append([]byte{}, multicodecP256...), elliptic.MarshalCompressed(k.Curve, k.X, k.Y)...)
+	case *rsa.PublicKey:
+		data = append(append([]byte{}, multicodecRSA...), x509.MarshalPKCS1PublicKey(k)...)
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+
+	return string(multibaseBase58BTC) + base58.Encode(data), nil
+}
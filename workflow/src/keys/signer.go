@@ -0,0 +1,174 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer signs JWTs with a private key under a fixed key ID
+type Signer struct {
+	keyID  string
+	key    crypto.PrivateKey
+	public crypto.PublicKey
+	method jwt.SigningMethod
+}
+
+// NewSigner creates a signer, selecting the JWS algorithm from the key type
+func NewSigner(keyID string, key crypto.PrivateKey) (*Signer, error) {
+	signer := &Signer{keyID: keyID, key: key}
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		signer.public = &k.PublicKey
+		switch k.Curve {
+		case elliptic.P256():
+			signer.method = jwt.SigningMethodES256
+		case elliptic.P384():
+			signer.method = jwt.SigningMethodES384
+		case elliptic.P521():
+			signer.method = jwt.SigningMethodES512
+		default:
+			return nil, fmt.Errorf("%w: unsupported ECDSA curve", ErrUnsupportedKey)
+		}
+	case ed25519.PrivateKey:
+		signer.public = k.Public()
+		signer.method = jwt.SigningMethodEdDSA
+	case *rsa.PrivateKey:
+		signer.public = &k.PublicKey
+		signer.method = jwt.SigningMethodPS256
+	case *secp256k1.PrivateKey:
+		signer.public = k.PubKey()
+		signer.method = ES256K
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+
+	return signer, nil
+}
+
+// KeyID returns the key identifier placed in the kid header
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// Algorithm returns the JWS algorithm used by the signer
+func (s *Signer) Algorithm() string {
+	return s.method.Alg()
+}
+
+// PublicKey returns the public half of the signing key
+func (s *Signer) PublicKey() crypto.PublicKey {
+	return s.public
+}
+
+// PublicJWK returns the public key as a JWK carrying the signer's key ID
+func (s *Signer) PublicJWK() (*JWK, error) {
+	jwk, err := NewJWK(s.public)
+	if err != nil {
+		return nil, err
+	}
+	jwk.Kid = s.keyID
+	jwk.Alg = s.method.Alg()
+	return jwk, nil
+}
+
+// Sign produces a compact JWS over the claims with the given extra header values
+func (s *Signer) Sign(claims jwt.Claims, header map[string]interface{}) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	for name, value := range header {
+		token.Header[name] = value
+	}
+	token.Header["kid"] = s.keyID
+
+	return token.SignedString(s.key)
+}
+
+// GenerateSigningKey creates a new P-256 private key
+func GenerateSigningKey() (crypto.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// LoadPrivateKeyFile reads a PEM encoded private key in PKCS#8, SEC 1 or PKCS#1 form
+func LoadPrivateKeyFile(path string) (crypto.PrivateKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("failed to decode private key: no PEM block found")
+	}
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+}
+
+// NewJWK converts a supported public key into its JWK representation
+func NewJWK(pub crypto.PublicKey) (*JWK, error) {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		crv, size, err := curveName(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		return &JWK{Kty: KeyTypeEC, Crv: crv, X: encodeFixed(k.X, size), Y: encodeFixed(k.Y, size)}, nil
+	case ed25519.PublicKey:
+		return &JWK{Kty: KeyTypeOKP, Crv: CurveEd25519, X: base64.RawURLEncoding.EncodeToString(k)}, nil
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: KeyTypeRSA,
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *secp256k1.PublicKey:
+		uncompressed := k.SerializeUncompressed()
+		return &JWK{
+			Kty: KeyTypeEC,
+			Crv: CurveSecp256k1,
+			X:   base64.RawURLEncoding.EncodeToString(uncompressed[1:33]),
+			Y:   base64.RawURLEncoding.EncodeToString(uncompressed[33:]),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+	}
+}
+
+// curveName returns the JWK curve name and coordinate size of a standard library curve
+func curveName(curve elliptic.Curve) (string, int, error) {
+	switch curve {
+	case elliptic.P256():
+		return CurveP256, 32, nil
+	case elliptic.P384():
+		return CurveP384, 48, nil
+	case elliptic.P521():
+		return CurveP521, 66, nil
+	default:
+		return "", 0, fmt.Errorf("%w: unsupported ECDSA curve", ErrUnsupportedKey)
+	}
+}
+
+// encodeFixed base64url-encodes a coordinate left-padded to the curve size
+func encodeFixed(v *big.Int, size int) string {
+	return base64.RawURLEncoding.EncodeToString(v.FillBytes(make([]byte, size)))
+}
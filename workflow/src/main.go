@@ -2,13 +2,17 @@ package main
 
 import (
 	"app/src/config"
+	"app/src/constants"
 	"app/src/container"
 	"app/src/database"
+	"app/src/queue"
 	"app/src/router"
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -42,9 +46,13 @@ func run(
 	db *gorm.DB,
 	app *fiber.App,
 	r *router.Router, // Router instance - ensures routes are initialized
+	jobPool *queue.Pool,
 ) error {
 	log.Info("Application starting...")
 
+	// Start background job workers
+	jobPool.Start()
+
 	// Get server address
 	address := cfg.GetServerAddress()
 
@@ -59,7 +67,7 @@ func run(
 	}()
 
 	// Handle graceful shutdown
-	return handleGracefulShutdown(log, db, app, serverErrors)
+	return handleGracefulShutdown(log, db, app, jobPool, serverErrors)
 }
 
 // handleGracefulShutdown handles graceful shutdown of the application
@@ -67,6 +75,7 @@ func handleGracefulShutdown(
 	log *logrus.Logger,
 	db *gorm.DB,
 	app *fiber.App,
+	jobPool *queue.Pool,
 	serverErrors <-chan error,
 ) error {
 	quit := make(chan os.Signal, 1)
@@ -79,6 +88,13 @@ func handleGracefulShutdown(
 	case sig := <-quit:
 		log.Infof("Received signal: %v. Shutting down server...", sig)
 
+		// Let in-flight jobs finish before the database connection closes
+		ctx, cancel := context.WithTimeout(context.Background(), constants.JobShutdownTimeout*time.Second)
+		if err := jobPool.Stop(ctx); err != nil {
+			log.Errorf("Error stopping job workers: %v", err)
+		}
+		cancel()
+
 		// Close database connection
 		if err := database.Close(db, log); err != nil {
 			log.Errorf("Error closing database: %v", err)
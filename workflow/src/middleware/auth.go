@@ -4,6 +4,8 @@ import (
 	"app/src/config"
 	"app/src/constants"
 	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
 	"context"
 	"crypto/rsa"
 	"encoding/base64"
@@ -47,9 +49,22 @@ type AuthClaims struct {
 	Name              string `json:"name"`
 	GivenName         string `json:"given_name"`
 	FamilyName        string `json:"family_name"`
+	RealmAccess       struct {
+		Roles []string `json:"roles"`
+	} `json:"realm_access"`
 	jwt.RegisteredClaims
 }
 
+// HasRole reports whether the token grants the given realm role
+func (c *AuthClaims) HasRole(role string) bool {
+	for _, r := range c.RealmAccess.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 // AuthJWTValidator handles JWT validation with the auth provider
 type AuthJWTValidator struct {
 	log           *logrus.Logger
@@ -93,12 +108,31 @@ func NewAuthJWTValidator(cfg *config.Config, log *logrus.Logger, db *gorm.DB) (*
 
 // AuthMiddleware handles authentication middleware
 type AuthMiddleware struct {
-	validator *AuthJWTValidator
+	log              *logrus.Logger
+	validator        *AuthJWTValidator
+	db               *gorm.DB
+	organizations    repository.OrganizationRepository
+	delegations      repository.DelegationGrantRepository
+	delegatedActions repository.DelegatedActionRepository
 }
 
 // NewAuthMiddleware creates a new auth middleware instance
-func NewAuthMiddleware(validator *AuthJWTValidator) *AuthMiddleware {
-	return &AuthMiddleware{validator: validator}
+func NewAuthMiddleware(
+	log *logrus.Logger,
+	validator *AuthJWTValidator,
+	db *gorm.DB,
+	organizations repository.OrganizationRepository,
+	delegations repository.DelegationGrantRepository,
+	delegatedActions repository.DelegatedActionRepository,
+) *AuthMiddleware {
+	return &AuthMiddleware{
+		log:              log,
+		validator:        validator,
+		db:               db,
+		organizations:    organizations,
+		delegations:      delegations,
+		delegatedActions: delegatedActions,
+	}
 }
 
 // Authenticate returns a fiber.Handler that validates JWT tokens
@@ -118,7 +152,7 @@ func (m *AuthMiddleware) Authenticate() fiber.Handler {
 			return fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
 		}
 
-		actorID, err := m.validator.FindActorByUsername(c.Context(), claims.PreferredUsername, claims.Email)
+		actorID, err := m.validator.FindActorBySubject(c.Context(), claims.Sub)
 		if err != nil {
 			return fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
 		}
@@ -130,6 +164,156 @@ func (m *AuthMiddleware) Authenticate() fiber.Handler {
 	}
 }
 
+// RequireRole returns a fiber.Handler that only lets through callers holding the given realm role.
+// It must be mounted after Authenticate.
+func (m *AuthMiddleware) RequireRole(role string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("auth_claims").(*AuthClaims)
+		if !ok || claims == nil {
+			return fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
+		}
+
+		if !claims.HasRole(role) {
+			return fiber.NewError(fiber.StatusForbidden, constants.ErrForbidden)
+		}
+
+		return c.Next()
+	}
+}
+
+// ActAsOrganization returns a fiber.Handler that lets a member act for the organization named in the
+// X-Organization-ID header when their role includes the required one. The organization then takes
+// the place of the caller: its ID replaces the actor ID, and the member's ID and role are kept as
+// memberID and organizationRole. Without the header callers act for themselves. It must be mounted
+// after Authenticate.
+func (m *AuthMiddleware) ActAsOrganization(required string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(constants.HTTPHeaderOrganization)
+		if header == "" {
+			return c.Next()
+		}
+		if c.Get(constants.HTTPHeaderOnBehalfOf) != "" {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrConflictingActingHeaders)
+		}
+
+		organizationID, err := uuid.Parse(header)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidOrganizationHeader)
+		}
+
+		actorID, ok := c.Locals("actorID").(uuid.UUID)
+		if !ok {
+			return fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
+		}
+
+		// The organization's own login already acts for it
+		if organizationID == actorID {
+			return c.Next()
+		}
+
+		member, err := m.organizations.FindMember(c.Context(), m.db, organizationID, actorID)
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusForbidden, constants.ErrNotOrganizationMember)
+			}
+			return err
+		}
+
+		if !model.OrganizationRoleAtLeast(member.Role, required) {
+			return fiber.NewError(fiber.StatusForbidden, constants.ErrOrganizationRoleInsufficient)
+		}
+
+		c.Locals("actorID", organizationID)
+		c.Locals("memberID", actorID)
+		c.Locals("organizationRole", member.Role)
+
+		return c.Next()
+	}
+}
+
+// ActOnBehalfOf returns a fiber.Handler that lets a delegate act for the principal named in the
+// X-On-Behalf-Of header when an active delegation grant of the principal covers the scope. The
+// principal then takes the place of the caller: its ID replaces the actor ID, and the delegate's
+// ID and the grant are kept as delegateID and delegationGrantID. Every request let through is
+// recorded with both identities and its outcome. Without the header callers act for themselves.
+// It must be mounted after Authenticate.
+func (m *AuthMiddleware) ActOnBehalfOf(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		header := c.Get(constants.HTTPHeaderOnBehalfOf)
+		if header == "" {
+			return c.Next()
+		}
+		if c.Get(constants.HTTPHeaderOrganization) != "" {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrConflictingActingHeaders)
+		}
+
+		principalID, err := uuid.Parse(header)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidOnBehalfOfHeader)
+		}
+
+		delegateID, ok := c.Locals("actorID").(uuid.UUID)
+		if !ok {
+			return fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
+		}
+
+		if principalID == delegateID {
+			return c.Next()
+		}
+
+		now := time.Now()
+		grants, err := m.delegations.FindActive(c.Context(), m.db, principalID, delegateID, now)
+		if err != nil {
+			return err
+		}
+
+		var grant *model.DelegationGrant
+		for i := range grants {
+			if grants[i].Allows(scope, now) {
+				grant = &grants[i]
+				break
+			}
+		}
+		if grant == nil {
+			m.log.Warnf("Actor %s denied acting for actor %s: no active delegation for %s", delegateID, principalID, scope)
+			return fiber.NewError(fiber.StatusForbidden, constants.ErrNoActiveDelegation)
+		}
+
+		c.Locals("actorID", principalID)
+		c.Locals("delegateID", delegateID)
+		c.Locals("delegationGrantID", grant.GrantID)
+
+		err = c.Next()
+		m.recordDelegatedAction(c, grant, scope, err)
+		return err
+	}
+}
+
+// recordDelegatedAction audits a request made under a delegation grant. The status is the one the
+// error handler will respond with when the handler failed. Recording is best-effort: the request
+// has already been served.
+func (m *AuthMiddleware) recordDelegatedAction(c *fiber.Ctx, grant *model.DelegationGrant, scope string, handlerErr error) {
+	status := c.Response().StatusCode()
+	if handlerErr != nil {
+		status = utils.ErrorStatus(handlerErr)
+	}
+
+	err := m.delegatedActions.Create(c.Context(), m.db, &model.DelegatedAction{
+		GrantID:     grant.GrantID,
+		PrincipalID: grant.PrincipalID,
+		DelegateID:  grant.DelegateID,
+		Scope:       scope,
+		Method:      c.Method(),
+		Path:        c.Path(),
+		StatusCode:  status,
+		IPAddress:   c.IP(),
+		UserAgent:   utils.Truncate(c.Get(constants.HTTPHeaderUserAgent), 512),
+	})
+	if err != nil {
+		m.log.Errorf("Failed to audit delegated action of actor %s for actor %s: %v", grant.DelegateID, grant.PrincipalID, err)
+	}
+}
+
 // extractBearerToken extracts and validates the Bearer token from the Authorization header
 func (m *AuthMiddleware) extractBearerToken(c *fiber.Ctx) (string, error) {
 	authHeader := c.Get(constants.HTTPHeaderAuthorization)
@@ -280,22 +464,21 @@ func (v *AuthJWTValidator) ValidateSessionActive(token string) error {
 	return nil
 }
 
-// FindActorByUsername finds an actor ID by username or email
-func (v *AuthJWTValidator) FindActorByUsername(ctx context.Context, username, email string) (uuid.UUID, error) {
-	var actor model.Actor
-
-	// Try by universal identifier first
-	var identifier model.Identifier
-	if v.db.WithContext(ctx).Where("identifier = ?", username).First(&identifier).Error == nil {
-		if v.db.WithContext(ctx).Where("actor_id = ?", identifier.EntityID).First(&actor).Error == nil {
-			return actor.ActorID, nil
-		}
+// FindActorBySubject finds the ID of the actor linked to the auth provider user a token was issued
+// for. Tokens are matched by subject rather than username, since the username is an identifier the
+// actor may release and somebody else may claim later.
+func (v *AuthJWTValidator) FindActorBySubject(ctx context.Context, subject string) (uuid.UUID, error) {
+	if subject == "" {
+		return uuid.Nil, fmt.Errorf("token has no subject")
 	}
 
-	// Try by email
-	if v.db.WithContext(ctx).Where("email = ?", email).First(&actor).Error == nil {
-		return actor.ActorID, nil
+	var integration model.ActorIntegration
+	err := v.db.WithContext(ctx).
+		Where("provider = ? AND external_user_id = ?", constants.KeycloakProviderName, subject).
+		First(&integration).Error
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("actor not found: %w", err)
 	}
 
-	return uuid.Nil, fmt.Errorf("actor not found")
+	return integration.ActorID, nil
 }
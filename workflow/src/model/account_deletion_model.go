@@ -0,0 +1,73 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// AccountDeletion is a request to erase an actor's account. The account is erased at EffectiveAt,
+// after a grace period during which the actor can cancel the request. The record outlives the
+// actor so that the erasure certificate can still be retrieved, with the token whose digest is
+// kept in CertificateTokenHash.
+type AccountDeletion struct {
+	DeletionID           uuid.UUID         `gorm:"column:deletion_id;type:uuid;primaryKey" json:"deletionId"`
+	ActorID              uuid.UUID         `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	Status               string            `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Reason               *string           `gorm:"column:reason;type:varchar(255)" json:"reason,omitempty"`
+	Pseudonymized        bool              `gorm:"column:pseudonymized;not null;default:false" json:"pseudonymized"`
+	Summary              datatypes.JSONMap `gorm:"column:summary;type:jsonb;not null" json:"summary"`
+	Certificate          *string           `gorm:"column:certificate;type:text" json:"certificate,omitempty"`
+	CertificateTokenHash *string           `gorm:"column:certificate_token_hash;type:varchar(64)" json:"-"`
+	EffectiveAt          time.Time         `gorm:"column:effective_at;type:timestamptz;not null" json:"effectiveAt"`
+	CancelledAt          *time.Time        `gorm:"column:cancelled_at;type:timestamptz" json:"cancelledAt,omitempty"`
+	CompletedAt          *time.Time        `gorm:"column:completed_at;type:timestamptz" json:"completedAt,omitempty"`
+	CreatedAt            time.Time         `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (deletion *AccountDeletion) BeforeCreate(_ *gorm.DB) error {
+	deletionID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	deletion.DeletionID = deletionID
+	if deletion.Summary == nil {
+		deletion.Summary = datatypes.JSONMap{}
+	}
+	return nil
+}
+
+// TableName overrides the table name used by AccountDeletion to `account_deletions`
+func (AccountDeletion) TableName() string {
+	return constants.TableNameAccountDeletions
+}
+
+// RetentionHold is a legal or regulatory obligation to keep an actor's records. While a hold is
+// active, erasing the account pseudonymizes the records it covers instead of deleting them.
+type RetentionHold struct {
+	HoldID     uuid.UUID  `gorm:"column:hold_id;type:uuid;primaryKey" json:"holdId"`
+	ActorID    uuid.UUID  `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	Reason     string     `gorm:"column:reason;type:varchar(255);not null" json:"reason"`
+	ExpiresAt  *time.Time `gorm:"column:expires_at;type:timestamptz" json:"expiresAt,omitempty"`
+	ReleasedAt *time.Time `gorm:"column:released_at;type:timestamptz" json:"releasedAt,omitempty"`
+	CreatedAt  time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (hold *RetentionHold) BeforeCreate(_ *gorm.DB) error {
+	holdID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	hold.HoldID = holdID
+	return nil
+}
+
+// TableName overrides the table name used by RetentionHold to `retention_holds`
+func (RetentionHold) TableName() string {
+	return constants.TableNameRetentionHolds
+}
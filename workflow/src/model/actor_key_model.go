@@ -0,0 +1,38 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ActorKey is one entry of an actor's master key history. The current key has no ValidUntil;
+// a rotation closes it and opens the next, so the intervals of an actor's keys never overlap.
+type ActorKey struct {
+	KeyID       uuid.UUID  `gorm:"column:key_id;type:uuid;primaryKey" json:"keyId"`
+	ActorID     uuid.UUID  `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	PublicKey   string     `gorm:"column:public_key;type:text;not null" json:"publicKey"`
+	Thumbprint  *string    `gorm:"column:thumbprint;type:varchar(64);uniqueIndex" json:"thumbprint,omitempty"`
+	DID         string     `gorm:"column:did;type:text;not null" json:"did"`
+	Endorsement string     `gorm:"column:endorsement;type:varchar(20);not null" json:"endorsement"`
+	ValidFrom   time.Time  `gorm:"column:valid_from;type:timestamptz;not null" json:"validFrom"`
+	ValidUntil  *time.Time `gorm:"column:valid_until;type:timestamptz" json:"validUntil,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (key *ActorKey) BeforeCreate(_ *gorm.DB) error {
+	keyID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	key.KeyID = keyID
+	return nil
+}
+
+// TableName overrides the table name used by ActorKey to `actor_keys`
+func (ActorKey) TableName() string {
+	return constants.TableNameActorKeys
+}
@@ -9,19 +9,24 @@ import (
 )
 
 type Actor struct {
-	ActorID                uuid.UUID `gorm:"primaryKey;not null" json:"id"`
-	DID                    uuid.UUID `gorm:"column:did;type:uuid;uniqueIndex;not null" json:"did"`
-	Email                  string    `gorm:"uniqueIndex;not null" json:"email"`
-	FirstName              string    `gorm:"column:first_name;not null" json:"firstName"`
-	LastName               string    `gorm:"column:last_name;not null" json:"lastName"`
-	PhoneNumber            *string   `gorm:"column:phone_number;uniqueIndex" json:"phoneNumber,omitempty"`
-	MasterPublicKey        string    `gorm:"column:master_public_key;uniqueIndex;not null" json:"masterPublicKey"`
-	EntityType             string    `gorm:"column:entity_type;type:varchar(50);not null" json:"entityType"`
-	Nationality            *string   `gorm:"column:nationality;type:varchar(10)" json:"nationality,omitempty"`
-	CountryOfResidence     *string   `gorm:"column:country_of_residence;type:varchar(10)" json:"countryOfResidence,omitempty"`
-	CountryOfIncorporation *string   `gorm:"column:country_of_incorporation;type:varchar(10)" json:"countryOfIncorporation,omitempty"`
-	VerificationLevel      string    `gorm:"column:verification_level;type:varchar(50);not null;default:'Tier0_Unverified'" json:"verificationLevel"`
-	CreatedAt              time.Time `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`
+	ActorID                uuid.UUID  `gorm:"primaryKey;not null" json:"id"`
+	DID                    string     `gorm:"column:did;type:text;uniqueIndex;not null" json:"did"`
+	Email                  string     `gorm:"uniqueIndex;not null" json:"email"`
+	FirstName              string     `gorm:"column:first_name;not null" json:"firstName"`
+	LastName               string     `gorm:"column:last_name;not null" json:"lastName"`
+	PhoneNumber            *string    `gorm:"column:phone_number;uniqueIndex" json:"phoneNumber,omitempty"`
+	MasterPublicKey        string     `gorm:"column:master_public_key;uniqueIndex;not null" json:"masterPublicKey"`
+	MasterKeyThumbprint    *string    `gorm:"column:master_key_thumbprint;type:varchar(64);uniqueIndex" json:"masterKeyThumbprint,omitempty"`
+	EntityType             string     `gorm:"column:entity_type;type:varchar(50);not null" json:"entityType"`
+	Nationality            *string    `gorm:"column:nationality;type:varchar(10)" json:"nationality,omitempty"`
+	CountryOfResidence     *string    `gorm:"column:country_of_residence;type:varchar(10)" json:"countryOfResidence,omitempty"`
+	CountryOfIncorporation *string    `gorm:"column:country_of_incorporation;type:varchar(10)" json:"countryOfIncorporation,omitempty"`
+	VerificationLevel      string     `gorm:"column:verification_level;type:varchar(50);not null;default:'Tier0_Unverified'" json:"verificationLevel"`
+	EmailVerified          bool       `gorm:"column:email_verified;not null;default:false" json:"emailVerified"`
+	EmailVerifiedAt        *time.Time `gorm:"column:email_verified_at;type:timestamptz" json:"emailVerifiedAt,omitempty"`
+	PhoneVerified          bool       `gorm:"column:phone_verified;not null;default:false" json:"phoneVerified"`
+	PhoneVerifiedAt        *time.Time `gorm:"column:phone_verified_at;type:timestamptz" json:"phoneVerifiedAt,omitempty"`
+	CreatedAt              time.Time  `gorm:"column:created_at;autoCreateTime:milli" json:"createdAt"`
 
 	// Relationships
 	Identifiers      []Identifier      `gorm:"foreignKey:EntityID" json:"identifiers,omitempty"`
@@ -36,7 +41,6 @@ func (actor *Actor) BeforeCreate(_ *gorm.DB) error {
 	}
 
 	actor.ActorID = actorID
-	actor.DID = uuid.New()
 	return nil
 }
 
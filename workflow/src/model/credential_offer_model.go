@@ -0,0 +1,44 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// CredentialOffer tracks an OID4VCI pre-authorized code offer through token and credential issuance.
+// Secrets are stored as SHA-256 digests only.
+type CredentialOffer struct {
+	OfferID                    uuid.UUID                   `gorm:"column:offer_id;type:uuid;primaryKey" json:"offerId"`
+	ActorID                    uuid.UUID                   `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	CredentialConfigurationIDs datatypes.JSONSlice[string] `gorm:"column:credential_configuration_ids;type:jsonb;not null" json:"credentialConfigurationIds"`
+	PreAuthorizedCodeHash      string                      `gorm:"column:pre_authorized_code_hash;type:varchar(64);uniqueIndex;not null" json:"-"`
+	TxCodeHash                 *string                     `gorm:"column:tx_code_hash;type:varchar(64)" json:"-"`
+	TxCodeAttempts             int                         `gorm:"column:tx_code_attempts;not null;default:0" json:"-"`
+	Status                     string                      `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	ExpiresAt                  time.Time                   `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	AccessTokenHash            *string                     `gorm:"column:access_token_hash;type:varchar(64);uniqueIndex" json:"-"`
+	AccessTokenExpiresAt       *time.Time                  `gorm:"column:access_token_expires_at;type:timestamptz" json:"-"`
+	CNonce                     *string                     `gorm:"column:c_nonce;type:varchar(64)" json:"-"`
+	CNonceExpiresAt            *time.Time                  `gorm:"column:c_nonce_expires_at;type:timestamptz" json:"-"`
+	RedeemedAt                 *time.Time                  `gorm:"column:redeemed_at;type:timestamptz" json:"redeemedAt,omitempty"`
+	CreatedAt                  time.Time                   `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (offer *CredentialOffer) BeforeCreate(_ *gorm.DB) error {
+	offerID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	offer.OfferID = offerID
+	return nil
+}
+
+// TableName overrides the table name used by CredentialOffer to `credential_offers`
+func (CredentialOffer) TableName() string {
+	return constants.TableNameCredentialOffers
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"app/src/constants"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +18,7 @@ type Token struct {
 	Status        string            `gorm:"column:status;type:varchar" json:"status"`
 	Metadata      datatypes.JSONMap `gorm:"column:metadata;type:jsonb" json:"metadata"`
 	CreatedAt     time.Time         `gorm:"column:created_at;type:timestamptz;default:now()" json:"created_at"`
+	Documents     []TokenDocument   `gorm:"foreignKey:TokenID;references:TokenID" json:"documents,omitempty"`
 }
 
 // TableName specifies the table name for Token model
@@ -35,10 +37,29 @@ type VerifiableCredential struct {
 	Proof             map[string]interface{} `json:"proof" validate:"required"`
 }
 
-// CredentialPayload represents the payload structure
+// CredentialPayload represents the payload structure.
+// Exactly one of the JSON-LD credential or the compact VC-JWT must be present. Evidence is
+// given either as a list of documents with roles or, for older clients, as a single documentId.
 type CredentialPayload struct {
-	VerifiableCredential VerifiableCredential `json:"verifiableCredential" validate:"required"`
-	DocumentID           string               `json:"documentId" validate:"required,uuid"`
+	VerifiableCredential    *VerifiableCredential `json:"verifiableCredential,omitempty" validate:"required_without=VerifiableCredentialJWT,excluded_with=VerifiableCredentialJWT"`
+	VerifiableCredentialJWT string                `json:"verifiableCredentialJwt,omitempty" validate:"omitempty,jwt"`
+	DocumentID              string                `json:"documentId,omitempty" validate:"required_without=Documents,excluded_with=Documents,omitempty,uuid"`
+	Documents               []CredentialDocument  `json:"documents,omitempty" validate:"omitempty,min=1,max=10,unique=DocumentID,dive"`
+}
+
+// CredentialDocument references an uploaded document and the role it plays in a credential
+type CredentialDocument struct {
+	DocumentID string `json:"documentId" validate:"required,uuid"`
+	Role       string `json:"role" validate:"required,oneof=front back selfie proof_of_address"`
+}
+
+// EvidenceDocuments returns the documents submitted with the credential. A legacy single
+// documentId is treated as the front of the evidence.
+func (p *CredentialPayload) EvidenceDocuments() []CredentialDocument {
+	if p.DocumentID != "" {
+		return []CredentialDocument{{DocumentID: p.DocumentID, Role: constants.DocumentRoleFront}}
+	}
+	return p.Documents
 }
 
 // AddCredentialRequest represents the request structure for adding credentials
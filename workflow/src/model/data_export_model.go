@@ -0,0 +1,44 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExport is a bundle of everything held about an actor, prepared on request for data
+// portability. The bundle is stored encrypted until ExpiresAt and handed out through short-lived,
+// single-use download links; only the hash of the current link token is kept.
+type DataExport struct {
+	ExportID          uuid.UUID  `gorm:"column:export_id;type:uuid;primaryKey" json:"exportId"`
+	ActorID           uuid.UUID  `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	Status            string     `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	StoragePath       *string    `gorm:"column:storage_path;type:text" json:"-"`
+	Size              *int64     `gorm:"column:size" json:"size,omitempty"`
+	SHA256            *string    `gorm:"column:sha256;type:varchar(64)" json:"sha256,omitempty"`
+	WrappedKey        *string    `gorm:"column:wrapped_key;type:text" json:"-"`
+	DownloadTokenHash *string    `gorm:"column:download_token_hash;type:varchar(64);uniqueIndex" json:"-"`
+	LinkExpiresAt     *time.Time `gorm:"column:link_expires_at;type:timestamptz" json:"-"`
+	DownloadedAt      *time.Time `gorm:"column:downloaded_at;type:timestamptz" json:"downloadedAt,omitempty"`
+	Error             *string    `gorm:"column:error;type:text" json:"error,omitempty"`
+	ExpiresAt         *time.Time `gorm:"column:expires_at;type:timestamptz" json:"expiresAt,omitempty"`
+	CompletedAt       *time.Time `gorm:"column:completed_at;type:timestamptz" json:"completedAt,omitempty"`
+	CreatedAt         time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (export *DataExport) BeforeCreate(_ *gorm.DB) error {
+	exportID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	export.ExportID = exportID
+	return nil
+}
+
+// TableName overrides the table name used by DataExport to `data_exports`
+func (DataExport) TableName() string {
+	return constants.TableNameDataExports
+}
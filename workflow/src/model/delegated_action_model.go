@@ -0,0 +1,40 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DelegatedAction records one request a delegate made for the principal under a delegation grant,
+// with the outcome returned to the delegate
+type DelegatedAction struct {
+	ActionID    uuid.UUID `gorm:"column:action_id;type:uuid;primaryKey" json:"actionId"`
+	GrantID     uuid.UUID `gorm:"column:grant_id;type:uuid;index;not null" json:"grantId"`
+	PrincipalID uuid.UUID `gorm:"column:principal_id;type:uuid;index;not null" json:"principalId"`
+	DelegateID  uuid.UUID `gorm:"column:delegate_id;type:uuid;index;not null" json:"delegateId"`
+	Scope       string    `gorm:"column:scope;type:varchar(50);not null" json:"scope"`
+	Method      string    `gorm:"column:method;type:varchar(10);not null" json:"method"`
+	Path        string    `gorm:"column:path;type:varchar(255);not null" json:"path"`
+	StatusCode  int       `gorm:"column:status_code;not null" json:"statusCode"`
+	IPAddress   string    `gorm:"column:ip_address;type:varchar(45)" json:"ipAddress,omitempty"`
+	UserAgent   string    `gorm:"column:user_agent;type:varchar(512)" json:"userAgent,omitempty"`
+	PerformedAt time.Time `gorm:"column:performed_at;type:timestamptz;autoCreateTime" json:"performedAt"`
+}
+
+func (action *DelegatedAction) BeforeCreate(_ *gorm.DB) error {
+	actionID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	action.ActionID = actionID
+	return nil
+}
+
+// TableName overrides the table name used by DelegatedAction to `delegated_actions`
+func (DelegatedAction) TableName() string {
+	return constants.TableNameDelegatedActions
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"app/src/constants"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DelegationGrant authorizes a delegate to perform scoped operations for the principal, for example
+// a guardian managing the account of a minor, during a time window. Identifiers are recorded as
+// they were when the grant was made.
+type DelegationGrant struct {
+	GrantID             uuid.UUID                   `gorm:"column:grant_id;type:uuid;primaryKey" json:"grantId"`
+	PrincipalID         uuid.UUID                   `gorm:"column:principal_id;type:uuid;index;not null" json:"principalId"`
+	PrincipalIdentifier string                      `gorm:"column:principal_identifier;type:varchar;not null" json:"principalIdentifier"`
+	DelegateID          uuid.UUID                   `gorm:"column:delegate_id;type:uuid;index;not null" json:"delegateId"`
+	DelegateIdentifier  string                      `gorm:"column:delegate_identifier;type:varchar;not null" json:"delegateIdentifier"`
+	Scopes              datatypes.JSONSlice[string] `gorm:"column:scopes;type:jsonb;not null" json:"scopes"`
+	Relationship        *string                     `gorm:"column:relationship;type:varchar(30)" json:"relationship,omitempty"`
+	ValidFrom           time.Time                   `gorm:"column:valid_from;type:timestamptz;not null" json:"validFrom"`
+	ExpiresAt           time.Time                   `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	RevokedAt           *time.Time                  `gorm:"column:revoked_at;type:timestamptz" json:"revokedAt,omitempty"`
+	CreatedAt           time.Time                   `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (grant *DelegationGrant) BeforeCreate(_ *gorm.DB) error {
+	grantID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	grant.GrantID = grantID
+	return nil
+}
+
+// Status reports whether the grant is scheduled, active, revoked or expired at the given instant
+func (grant *DelegationGrant) Status(at time.Time) string {
+	switch {
+	case grant.RevokedAt != nil:
+		return constants.DelegationGrantStatusRevoked
+	case !at.Before(grant.ExpiresAt):
+		return constants.DelegationGrantStatusExpired
+	case at.Before(grant.ValidFrom):
+		return constants.DelegationGrantStatusScheduled
+	default:
+		return constants.DelegationGrantStatusActive
+	}
+}
+
+// Allows reports whether the grant lets the delegate perform an operation of the given scope at
+// the given instant
+func (grant *DelegationGrant) Allows(scope string, at time.Time) bool {
+	return grant.Status(at) == constants.DelegationGrantStatusActive && slices.Contains(grant.Scopes, scope)
+}
+
+// TableName overrides the table name used by DelegationGrant to `delegation_grants`
+func (DelegationGrant) TableName() string {
+	return constants.TableNameDelegationGrants
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DIDService is a service endpoint an actor publishes in its did:web document. Fragment is the
+// service ID relative to the DID and is unique per actor.
+type DIDService struct {
+	ServiceID uuid.UUID `gorm:"column:service_id;type:uuid;primaryKey" json:"serviceId"`
+	ActorID   uuid.UUID `gorm:"column:actor_id;type:uuid;not null;uniqueIndex:idx_did_services_actor_fragment" json:"actorId"`
+	Fragment  string    `gorm:"column:fragment;type:varchar(64);not null;uniqueIndex:idx_did_services_actor_fragment" json:"fragment"`
+	Type      string    `gorm:"column:type;type:varchar(100);not null" json:"type"`
+	Endpoint  string    `gorm:"column:endpoint;type:varchar(2048);not null" json:"endpoint"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (service *DIDService) BeforeCreate(_ *gorm.DB) error {
+	serviceID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	service.ServiceID = serviceID
+	return nil
+}
+
+// TableName overrides the table name used by DIDService to `did_services`
+func (DIDService) TableName() string {
+	return constants.TableNameDIDServices
+}
@@ -2,14 +2,20 @@ package model
 
 import (
 	"app/src/constants"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Identifier is a universal identifier of an entity. An actor may hold several identifiers
+// (aliases), exactly one of which is primary.
 type Identifier struct {
 	Identifier string    `gorm:"primaryKey;not null" json:"identifier"`
 	EntityType string    `gorm:"not null" json:"entityType"`
 	EntityID   uuid.UUID `gorm:"not null" json:"entityId"`
+	IsPrimary  bool      `gorm:"column:is_primary;not null;default:false" json:"isPrimary"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+	Skeleton   string    `gorm:"column:skeleton;not null;uniqueIndex" json:"-"` // confusable skeleton (UTS #39)
 
 	// Relationships
 	Actor *Actor `gorm:"foreignKey:EntityID" json:"actor,omitempty"`
@@ -19,3 +25,34 @@ type Identifier struct {
 func (Identifier) TableName() string {
 	return constants.TableNameIdentifiers
 }
+
+// ReleasedIdentifier reserves an identifier its holder gave up until AvailableAt, so nobody else
+// can claim it while contacts may still send to it. The former holder can reclaim it at any time.
+type ReleasedIdentifier struct {
+	Identifier  string    `gorm:"column:identifier;primaryKey" json:"identifier"`
+	EntityType  string    `gorm:"column:entity_type;not null" json:"entityType"`
+	EntityID    uuid.UUID `gorm:"column:entity_id;type:uuid;not null" json:"entityId"`
+	ReleasedAt  time.Time `gorm:"column:released_at;type:timestamptz;not null" json:"releasedAt"`
+	AvailableAt time.Time `gorm:"column:available_at;type:timestamptz;not null" json:"availableAt"`
+	Skeleton    string    `gorm:"column:skeleton;not null;index" json:"-"`
+}
+
+// TableName overrides the table name used by ReleasedIdentifier to `released_identifiers`
+func (ReleasedIdentifier) TableName() string {
+	return constants.TableNameReleasedIDs
+}
+
+// ReservedAgainst reports whether the reservation keeps claimantID from claiming the identifier
+// at the given time. It never applies to the former holder and ends at AvailableAt.
+func (r *ReleasedIdentifier) ReservedAgainst(claimantID uuid.UUID, at time.Time) bool {
+	return r.EntityID != claimantID && at.Before(r.AvailableAt)
+}
+
+// ResolvedIdentifier is a universal identifier with the DID and master public key of the actor
+// holding it, as read by a batch resolution
+type ResolvedIdentifier struct {
+	Identifier      string    `gorm:"column:identifier"`
+	ActorID         uuid.UUID `gorm:"column:actor_id"`
+	DID             string    `gorm:"column:did"`
+	MasterPublicKey string    `gorm:"column:master_public_key"`
+}
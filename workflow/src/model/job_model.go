@@ -0,0 +1,53 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Job is a unit of background work in the Postgres-backed queue. Workers claim queued jobs
+// with SKIP LOCKED; failed attempts are rescheduled with backoff until MaxAttempts is reached,
+// after which the job is dead-lettered.
+type Job struct {
+	JobID       uuid.UUID         `gorm:"column:job_id;type:uuid;primaryKey" json:"jobId"`
+	Type        string            `gorm:"column:type;type:varchar(50);not null" json:"type"`
+	ActorID     uuid.UUID         `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	ResourceID  *uuid.UUID        `gorm:"column:resource_id;type:uuid" json:"resourceId,omitempty"`
+	Payload     datatypes.JSONMap `gorm:"column:payload;type:jsonb;not null" json:"payload"`
+	Status      string            `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Attempts    int               `gorm:"column:attempts;not null" json:"attempts"`
+	MaxAttempts int               `gorm:"column:max_attempts;not null" json:"maxAttempts"`
+	RunAt       time.Time         `gorm:"column:run_at;type:timestamptz;not null" json:"runAt"`
+	LockedBy    *string           `gorm:"column:locked_by;type:varchar(255)" json:"-"`
+	LockedAt    *time.Time        `gorm:"column:locked_at;type:timestamptz" json:"-"`
+	LastError   *string           `gorm:"column:last_error;type:text" json:"lastError,omitempty"`
+	Result      datatypes.JSONMap `gorm:"column:result;type:jsonb;not null" json:"result"`
+	CompletedAt *time.Time        `gorm:"column:completed_at;type:timestamptz" json:"completedAt,omitempty"`
+	CreatedAt   time.Time         `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time         `gorm:"column:updated_at;type:timestamptz;autoUpdateTime" json:"updatedAt"`
+}
+
+func (job *Job) BeforeCreate(_ *gorm.DB) error {
+	jobID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	job.JobID = jobID
+	if job.Payload == nil {
+		job.Payload = datatypes.JSONMap{}
+	}
+	if job.Result == nil {
+		job.Result = datatypes.JSONMap{}
+	}
+	return nil
+}
+
+// TableName overrides the table name used by Job to `jobs`
+func (Job) TableName() string {
+	return constants.TableNameJobs
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KeyChallenge is a single-use nonce a client signs with its key to prove it holds the private key
+type KeyChallenge struct {
+	ChallengeID uuid.UUID  `gorm:"column:challenge_id;type:uuid;primaryKey" json:"challengeId"`
+	Nonce       string     `gorm:"column:nonce;type:varchar(64);uniqueIndex;not null" json:"nonce"`
+	Purpose     string     `gorm:"column:purpose;type:varchar(20);not null" json:"purpose"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	ConsumedAt  *time.Time `gorm:"column:consumed_at;type:timestamptz" json:"consumedAt,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (challenge *KeyChallenge) BeforeCreate(_ *gorm.DB) error {
+	challengeID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	challenge.ChallengeID = challengeID
+	return nil
+}
+
+// TableName overrides the table name used by KeyChallenge to `key_challenges`
+func (KeyChallenge) TableName() string {
+	return constants.TableNameKeyChallenges
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// KeyRecovery is a master key replacement authorized without the current key. It takes effect
+// at EffectiveAt, giving the holder of the current key time to notice and cancel it.
+type KeyRecovery struct {
+	RecoveryID  uuid.UUID  `gorm:"column:recovery_id;type:uuid;primaryKey" json:"recoveryId"`
+	ActorID     uuid.UUID  `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	PublicKey   string     `gorm:"column:public_key;type:text;not null" json:"publicKey"`
+	Thumbprint  string     `gorm:"column:thumbprint;type:varchar(64);not null" json:"thumbprint"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	EffectiveAt time.Time  `gorm:"column:effective_at;type:timestamptz;not null" json:"effectiveAt"`
+	CompletedAt *time.Time `gorm:"column:completed_at;type:timestamptz" json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (recovery *KeyRecovery) BeforeCreate(_ *gorm.DB) error {
+	recoveryID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	recovery.RecoveryID = recoveryID
+	return nil
+}
+
+// TableName overrides the table name used by KeyRecovery to `key_recoveries`
+func (KeyRecovery) TableName() string {
+	return constants.TableNameKeyRecoveries
+}
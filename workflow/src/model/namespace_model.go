@@ -0,0 +1,38 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NamespaceDomain is a universal identifier domain registered to an organization. Only the owner
+// can claim identifiers in it unless the domain is open. The platform's default domain is open to
+// everyone and is not registered.
+type NamespaceDomain struct {
+	Domain       string    `gorm:"column:domain;primaryKey" json:"domain"`
+	OwnerActorID uuid.UUID `gorm:"column:owner_actor_id;type:uuid;not null" json:"ownerActorId"`
+	Open         bool      `gorm:"column:open;not null;default:false" json:"open"`
+	CreatedAt    time.Time `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+// TableName overrides the table name used by NamespaceDomain to `namespace_domains`
+func (NamespaceDomain) TableName() string {
+	return constants.TableNameNamespaces
+}
+
+// ReservedWord is a word universal identifiers may not be (reserved) or contain (blocked). Words
+// are matched by their confusable skeleton, so look-alike spellings are caught as well.
+type ReservedWord struct {
+	Skeleton  string    `gorm:"column:skeleton;primaryKey" json:"-"`
+	Word      string    `gorm:"column:word;not null" json:"word"`
+	Kind      string    `gorm:"column:kind;not null" json:"kind"`
+	Reason    *string   `gorm:"column:reason" json:"reason,omitempty"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+// TableName overrides the table name used by ReservedWord to `reserved_words`
+func (ReservedWord) TableName() string {
+	return constants.TableNameReservedWords
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationMember makes an Individual actor a member of a Business actor (the organization),
+// letting them act for it within the limits of their role
+type OrganizationMember struct {
+	OrganizationID uuid.UUID  `gorm:"column:organization_id;type:uuid;primaryKey" json:"organizationId"`
+	ActorID        uuid.UUID  `gorm:"column:actor_id;type:uuid;primaryKey;index" json:"actorId"`
+	Role           string     `gorm:"column:role;type:varchar(20);not null" json:"role"`
+	InvitationID   *uuid.UUID `gorm:"column:invitation_id;type:uuid" json:"invitationId,omitempty"`
+	CreatedAt      time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+// OrganizationInvitation invites an Individual actor to join an organization with a role. The
+// invitation is pending until it is accepted, declined, revoked or expires. Identifiers are
+// recorded as they were when the invitation was made.
+type OrganizationInvitation struct {
+	InvitationID           uuid.UUID  `gorm:"column:invitation_id;type:uuid;primaryKey" json:"invitationId"`
+	OrganizationID         uuid.UUID  `gorm:"column:organization_id;type:uuid;index;not null" json:"organizationId"`
+	OrganizationIdentifier string     `gorm:"column:organization_identifier;type:varchar;not null" json:"organizationIdentifier"`
+	InviteeID              uuid.UUID  `gorm:"column:invitee_id;type:uuid;index;not null" json:"inviteeId"`
+	InviteeIdentifier      string     `gorm:"column:invitee_identifier;type:varchar;not null" json:"inviteeIdentifier"`
+	Role                   string     `gorm:"column:role;type:varchar(20);not null" json:"role"`
+	InvitedBy              uuid.UUID  `gorm:"column:invited_by;type:uuid;not null" json:"invitedBy"`
+	ExpiresAt              time.Time  `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	AcceptedAt             *time.Time `gorm:"column:accepted_at;type:timestamptz" json:"acceptedAt,omitempty"`
+	DeclinedAt             *time.Time `gorm:"column:declined_at;type:timestamptz" json:"declinedAt,omitempty"`
+	RevokedAt              *time.Time `gorm:"column:revoked_at;type:timestamptz" json:"revokedAt,omitempty"`
+	CreatedAt              time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (invitation *OrganizationInvitation) BeforeCreate(_ *gorm.DB) error {
+	invitationID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	invitation.InvitationID = invitationID
+	return nil
+}
+
+// Status reports whether the invitation is pending, accepted, declined, revoked or expired at the
+// given instant
+func (invitation *OrganizationInvitation) Status(at time.Time) string {
+	switch {
+	case invitation.AcceptedAt != nil:
+		return constants.OrganizationInvitationStatusAccepted
+	case invitation.DeclinedAt != nil:
+		return constants.OrganizationInvitationStatusDeclined
+	case invitation.RevokedAt != nil:
+		return constants.OrganizationInvitationStatusRevoked
+	case !at.Before(invitation.ExpiresAt):
+		return constants.OrganizationInvitationStatusExpired
+	default:
+		return constants.OrganizationInvitationStatusPending
+	}
+}
+
+// organizationRoleRanks orders the member roles; a higher rank includes the rights of lower ones
+var organizationRoleRanks = map[string]int{
+	constants.OrganizationRoleViewer:   1,
+	constants.OrganizationRoleOperator: 2,
+	constants.OrganizationRoleAdmin:    3,
+	constants.OrganizationRoleOwner:    4,
+}
+
+// OrganizationRoleAtLeast reports whether a member role includes the rights of the required role
+func OrganizationRoleAtLeast(role, required string) bool {
+	rank, ok := organizationRoleRanks[role]
+	return ok && rank >= organizationRoleRanks[required]
+}
+
+// CanManageOrganizationRole reports whether a member with the manager role may grant the role, or
+// change or remove the membership of a member holding it. Owners manage every role, admins only
+// the roles below their own.
+func CanManageOrganizationRole(manager, role string) bool {
+	if manager == constants.OrganizationRoleOwner {
+		_, ok := organizationRoleRanks[role]
+		return ok
+	}
+	return OrganizationRoleAtLeast(manager, constants.OrganizationRoleAdmin) &&
+		!OrganizationRoleAtLeast(role, manager)
+}
+
+// TableName overrides the table name used by OrganizationMember to `organization_members`
+func (OrganizationMember) TableName() string {
+	return constants.TableNameOrgMembers
+}
+
+// TableName overrides the table name used by OrganizationInvitation to `organization_invitations`
+func (OrganizationInvitation) TableName() string {
+	return constants.TableNameOrgInvitations
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"app/src/constants"
+	"app/src/pex"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// PresentationRequest tracks an OID4VP authorization request created by a verifier actor
+// from request object retrieval through evaluation of the wallet's direct_post response
+type PresentationRequest struct {
+	RequestID              uuid.UUID                                      `gorm:"column:request_id;type:uuid;primaryKey" json:"requestId"`
+	ActorID                uuid.UUID                                      `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	State                  string                                         `gorm:"column:state;type:varchar(64);uniqueIndex;not null" json:"-"`
+	Nonce                  string                                         `gorm:"column:nonce;type:varchar(64);not null" json:"-"`
+	ClientID               string                                         `gorm:"column:client_id;type:varchar;not null" json:"clientId"`
+	PresentationDefinition datatypes.JSONType[pex.PresentationDefinition] `gorm:"column:presentation_definition;type:jsonb;not null" json:"presentationDefinition"`
+	CallbackURL            *string                                        `gorm:"column:callback_url;type:varchar" json:"callbackUrl,omitempty"`
+	CallbackSecret         *string                                        `gorm:"column:callback_secret;type:varchar" json:"-"`
+	CallbackStatus         *string                                        `gorm:"column:callback_status;type:varchar(20)" json:"callbackStatus,omitempty"`
+	Status                 string                                         `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Result                 datatypes.JSONType[PresentationResult]         `gorm:"column:result;type:jsonb;not null" json:"result"`
+	ExpiresAt              time.Time                                      `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	RetrievedAt            *time.Time                                     `gorm:"column:retrieved_at;type:timestamptz" json:"retrievedAt,omitempty"`
+	CompletedAt            *time.Time                                     `gorm:"column:completed_at;type:timestamptz" json:"completedAt,omitempty"`
+	CreatedAt              time.Time                                      `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+// PresentationResult is the outcome of evaluating a presentation against its definition
+type PresentationResult struct {
+	Holder      string                `json:"holder,omitempty"`
+	Credentials []PresentedCredential `json:"credentials,omitempty"`
+	Errors      []string              `json:"errors,omitempty"`
+}
+
+// PresentedCredential is a verified credential matched to an input descriptor
+type PresentedCredential struct {
+	InputDescriptorID string                 `json:"inputDescriptorId"`
+	Issuer            string                 `json:"issuer"`
+	Subject           string                 `json:"subject,omitempty"`
+	Types             []string               `json:"types,omitempty"`
+	Fields            map[string]interface{} `json:"fields,omitempty"`
+}
+
+func (request *PresentationRequest) BeforeCreate(_ *gorm.DB) error {
+	requestID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	request.RequestID = requestID
+	return nil
+}
+
+// TableName overrides the table name used by PresentationRequest to `presentation_requests`
+func (PresentationRequest) TableName() string {
+	return constants.TableNamePresentations
+}
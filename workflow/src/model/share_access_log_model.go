@@ -0,0 +1,38 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShareAccessLog records one access by a grantee to data shared under a grant
+type ShareAccessLog struct {
+	LogID      uuid.UUID  `gorm:"column:log_id;type:uuid;primaryKey" json:"logId"`
+	GrantID    uuid.UUID  `gorm:"column:grant_id;type:uuid;index;not null" json:"grantId"`
+	OwnerID    uuid.UUID  `gorm:"column:owner_id;type:uuid;not null" json:"ownerId"`
+	GranteeID  uuid.UUID  `gorm:"column:grantee_id;type:uuid;not null" json:"granteeId"`
+	Action     string     `gorm:"column:action;type:varchar(30);not null" json:"action"`
+	TokenID    *uuid.UUID `gorm:"column:token_id;type:uuid" json:"tokenId,omitempty"`
+	DocumentID *uuid.UUID `gorm:"column:document_id;type:uuid" json:"documentId,omitempty"`
+	IPAddress  string     `gorm:"column:ip_address;type:varchar(45)" json:"ipAddress,omitempty"`
+	UserAgent  string     `gorm:"column:user_agent;type:varchar(512)" json:"userAgent,omitempty"`
+	AccessedAt time.Time  `gorm:"column:accessed_at;type:timestamptz;autoCreateTime" json:"accessedAt"`
+}
+
+func (log *ShareAccessLog) BeforeCreate(_ *gorm.DB) error {
+	logID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	log.LogID = logID
+	return nil
+}
+
+// TableName overrides the table name used by ShareAccessLog to `share_access_logs`
+func (ShareAccessLog) TableName() string {
+	return constants.TableNameShareAccessLogs
+}
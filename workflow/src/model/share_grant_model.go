@@ -0,0 +1,74 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShareGrant gives a grantee time-bound, revocable read access to selected credentials of the owner
+// and the documents linked to them. Identifiers are recorded as they were when the grant was made.
+type ShareGrant struct {
+	GrantID           uuid.UUID         `gorm:"column:grant_id;type:uuid;primaryKey" json:"grantId"`
+	OwnerID           uuid.UUID         `gorm:"column:owner_id;type:uuid;index;not null" json:"ownerId"`
+	OwnerIdentifier   string            `gorm:"column:owner_identifier;type:varchar;not null" json:"ownerIdentifier"`
+	GranteeID         uuid.UUID         `gorm:"column:grantee_id;type:uuid;index;not null" json:"granteeId"`
+	GranteeIdentifier string            `gorm:"column:grantee_identifier;type:varchar;not null" json:"granteeIdentifier"`
+	Purpose           *string           `gorm:"column:purpose;type:varchar(255)" json:"purpose,omitempty"`
+	ExpiresAt         time.Time         `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	RevokedAt         *time.Time        `gorm:"column:revoked_at;type:timestamptz" json:"revokedAt,omitempty"`
+	CreatedAt         time.Time         `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+	Tokens            []ShareGrantToken `gorm:"foreignKey:GrantID;references:GrantID" json:"tokens,omitempty"`
+}
+
+// ShareGrantToken links a share grant to one shared credential token
+type ShareGrantToken struct {
+	GrantID uuid.UUID `gorm:"column:grant_id;type:uuid;primaryKey" json:"grantId"`
+	TokenID uuid.UUID `gorm:"column:token_id;type:uuid;primaryKey" json:"tokenId"`
+}
+
+func (grant *ShareGrant) BeforeCreate(_ *gorm.DB) error {
+	grantID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	grant.GrantID = grantID
+	for i := range grant.Tokens {
+		grant.Tokens[i].GrantID = grantID
+	}
+	return nil
+}
+
+// Status reports whether the grant is active, revoked or expired at the given instant
+func (grant *ShareGrant) Status(at time.Time) string {
+	switch {
+	case grant.RevokedAt != nil:
+		return constants.ShareGrantStatusRevoked
+	case !at.Before(grant.ExpiresAt):
+		return constants.ShareGrantStatusExpired
+	default:
+		return constants.ShareGrantStatusActive
+	}
+}
+
+// TokenIDs returns the IDs of the credentials covered by the grant
+func (grant *ShareGrant) TokenIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(grant.Tokens))
+	for _, token := range grant.Tokens {
+		ids = append(ids, token.TokenID)
+	}
+	return ids
+}
+
+// TableName overrides the table name used by ShareGrant to `share_grants`
+func (ShareGrant) TableName() string {
+	return constants.TableNameShareGrants
+}
+
+// TableName overrides the table name used by ShareGrantToken to `share_grant_tokens`
+func (ShareGrantToken) TableName() string {
+	return constants.TableNameShareGrantTokens
+}
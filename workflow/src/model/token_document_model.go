@@ -0,0 +1,23 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenDocument links a credential token to an evidence document and records the part the
+// document plays in the credential, such as the front of an ID card or a selfie
+type TokenDocument struct {
+	TokenID    uuid.UUID `gorm:"column:token_id;type:uuid;primaryKey" json:"tokenId"`
+	DocumentID uuid.UUID `gorm:"column:document_id;type:uuid;primaryKey" json:"documentId"`
+	Role       string    `gorm:"column:role;type:varchar(30);not null" json:"role"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+	Document   *Document `gorm:"foreignKey:DocumentID;references:DocumentID" json:"document,omitempty"`
+}
+
+// TableName overrides the table name used by TokenDocument to `token_documents`
+func (TokenDocument) TableName() string {
+	return constants.TableNameTokenDocuments
+}
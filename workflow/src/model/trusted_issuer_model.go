@@ -0,0 +1,44 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// TrustedIssuer represents an issuer DID accepted for a set of verification types
+type TrustedIssuer struct {
+	IssuerID          uuid.UUID                   `gorm:"column:issuer_id;type:uuid;primaryKey" json:"issuerId"`
+	IssuerDID         string                      `gorm:"column:issuer_did;type:varchar;uniqueIndex;not null" json:"issuerDid"`
+	Name              string                      `gorm:"column:name;type:varchar;not null" json:"name"`
+	VerificationTypes datatypes.JSONSlice[string] `gorm:"column:verification_types;type:jsonb;not null" json:"verificationTypes"`
+	Jurisdictions     datatypes.JSONSlice[string] `gorm:"column:jurisdictions;type:jsonb" json:"jurisdictions"`
+	ValidFrom         *time.Time                  `gorm:"column:valid_from;type:timestamptz" json:"validFrom,omitempty"`
+	ValidUntil        *time.Time                  `gorm:"column:valid_until;type:timestamptz" json:"validUntil,omitempty"`
+	Status            string                      `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Source            string                      `gorm:"column:source;type:varchar(20);not null" json:"source"`
+	CreatedAt         time.Time                   `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+	UpdatedAt         time.Time                   `gorm:"column:updated_at;type:timestamptz;autoUpdateTime" json:"updatedAt"`
+}
+
+func (issuer *TrustedIssuer) BeforeCreate(_ *gorm.DB) error {
+	if issuer.IssuerID != uuid.Nil {
+		return nil
+	}
+
+	issuerID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	issuer.IssuerID = issuerID
+	return nil
+}
+
+// TableName overrides the table name used by TrustedIssuer to `trusted_issuers`
+func (TrustedIssuer) TableName() string {
+	return constants.TableNameTrustedIssuers
+}
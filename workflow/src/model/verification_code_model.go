@@ -0,0 +1,53 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerificationCode records a verification message sent to an actor's email address or phone
+// number. Phone messages carry a one-time code of which only the hash is kept; email messages are
+// verification links handled by the auth provider and are recorded for rate limiting only.
+type VerificationCode struct {
+	CodeID     uuid.UUID  `gorm:"column:code_id;type:uuid;primaryKey" json:"codeId"`
+	ActorID    uuid.UUID  `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	Channel    string     `gorm:"column:channel;type:varchar(10);not null" json:"channel"`
+	Target     string     `gorm:"column:target;not null" json:"target"`
+	CodeHash   *string    `gorm:"column:code_hash;type:varchar(64)" json:"-"`
+	Attempts   int        `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;type:timestamptz;not null" json:"expiresAt"`
+	ConsumedAt *time.Time `gorm:"column:consumed_at;type:timestamptz" json:"consumedAt,omitempty"`
+	CreatedAt  time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (code *VerificationCode) BeforeCreate(_ *gorm.DB) error {
+	codeID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	code.CodeID = codeID
+	return nil
+}
+
+// Status reports whether the code can still be redeemed at the given instant
+func (code *VerificationCode) Status(at time.Time) string {
+	switch {
+	case code.ConsumedAt != nil:
+		return constants.VerificationCodeStatusConsumed
+	case !at.Before(code.ExpiresAt):
+		return constants.VerificationCodeStatusExpired
+	case code.Attempts >= constants.VerificationMaxAttempts:
+		return constants.VerificationCodeStatusExhausted
+	default:
+		return constants.VerificationCodeStatusPending
+	}
+}
+
+// TableName overrides the table name used by VerificationCode to `verification_codes`
+func (VerificationCode) TableName() string {
+	return constants.TableNameVerificationCodes
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerificationLevelHistory records a change of an actor's verification level
+type VerificationLevelHistory struct {
+	HistoryID     uuid.UUID  `gorm:"column:history_id;type:uuid;primaryKey" json:"historyId"`
+	ActorID       uuid.UUID  `gorm:"column:actor_id;type:uuid;index;not null" json:"actorId"`
+	PreviousLevel string     `gorm:"column:previous_level;type:varchar(50);not null" json:"previousLevel"`
+	NewLevel      string     `gorm:"column:new_level;type:varchar(50);not null" json:"newLevel"`
+	Reason        string     `gorm:"column:reason;type:varchar(50);not null" json:"reason"`
+	TokenID       *uuid.UUID `gorm:"column:token_id;type:uuid" json:"tokenId,omitempty"`
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (history *VerificationLevelHistory) BeforeCreate(_ *gorm.DB) error {
+	historyID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	history.HistoryID = historyID
+	return nil
+}
+
+// TableName overrides the table name used by VerificationLevelHistory to `verification_level_history`
+func (VerificationLevelHistory) TableName() string {
+	return constants.TableNameLevelHistory
+}
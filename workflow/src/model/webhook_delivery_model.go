@@ -0,0 +1,45 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookDelivery records one event sent to one subscription. Retries reuse the delivery;
+// a replay creates a new delivery of the same event that references the original.
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID         `gorm:"column:delivery_id;type:uuid;primaryKey" json:"deliveryId"`
+	SubscriptionID uuid.UUID         `gorm:"column:subscription_id;type:uuid;index;not null" json:"subscriptionId"`
+	OwnerID        uuid.UUID         `gorm:"column:owner_id;type:uuid;not null" json:"ownerId"`
+	Scope          string            `gorm:"column:scope;type:varchar(20);not null" json:"scope"`
+	EventID        uuid.UUID         `gorm:"column:event_id;type:uuid;not null" json:"eventId"`
+	EventType      string            `gorm:"column:event_type;type:varchar(50);not null" json:"eventType"`
+	Payload        datatypes.JSONMap `gorm:"column:payload;type:jsonb;not null" json:"payload"`
+	Status         string            `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Attempts       int               `gorm:"column:attempts;not null" json:"attempts"`
+	ResponseStatus *int              `gorm:"column:response_status" json:"responseStatus,omitempty"`
+	LastError      *string           `gorm:"column:last_error;type:text" json:"lastError,omitempty"`
+	ReplayOf       *uuid.UUID        `gorm:"column:replay_of;type:uuid" json:"replayOf,omitempty"`
+	DeliveredAt    *time.Time        `gorm:"column:delivered_at;type:timestamptz" json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time         `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time         `gorm:"column:updated_at;type:timestamptz;autoUpdateTime" json:"updatedAt"`
+}
+
+func (delivery *WebhookDelivery) BeforeCreate(_ *gorm.DB) error {
+	deliveryID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	delivery.DeliveryID = deliveryID
+	return nil
+}
+
+// TableName overrides the table name used by WebhookDelivery to `webhook_deliveries`
+func (WebhookDelivery) TableName() string {
+	return constants.TableNameWebhookDeliveries
+}
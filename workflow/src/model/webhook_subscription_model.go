@@ -0,0 +1,39 @@
+package model
+
+import (
+	"app/src/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscription registers a URL to receive signed event notifications.
+// Actor-scoped subscriptions receive events about their owner; partner-scoped
+// subscriptions, managed by administrators, receive events about every actor.
+type WebhookSubscription struct {
+	SubscriptionID uuid.UUID                   `gorm:"column:subscription_id;type:uuid;primaryKey" json:"subscriptionId"`
+	OwnerID        uuid.UUID                   `gorm:"column:owner_id;type:uuid;index;not null" json:"ownerId"`
+	Scope          string                      `gorm:"column:scope;type:varchar(20);not null" json:"scope"`
+	URL            string                      `gorm:"column:url;type:varchar(2048);not null" json:"url"`
+	Events         datatypes.JSONSlice[string] `gorm:"column:events;type:jsonb;not null" json:"events"`
+	Secret         string                      `gorm:"column:secret;type:varchar(64);not null" json:"-"`
+	Description    *string                     `gorm:"column:description;type:varchar(255)" json:"description,omitempty"`
+	CreatedAt      time.Time                   `gorm:"column:created_at;type:timestamptz;autoCreateTime" json:"createdAt"`
+}
+
+func (subscription *WebhookSubscription) BeforeCreate(_ *gorm.DB) error {
+	subscriptionID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	subscription.SubscriptionID = subscriptionID
+	return nil
+}
+
+// TableName overrides the table name used by WebhookSubscription to `webhook_subscriptions`
+func (WebhookSubscription) TableName() string {
+	return constants.TableNameWebhooks
+}
@@ -0,0 +1,58 @@
+package namespace
+
+// confusables maps characters to the prototype of their confusable class. It is the subset of
+// the Unicode confusables.txt (UTS #39) relevant to identifiers: look-alikes of Latin letters and
+// digits from the Latin, Cyrillic, Greek and Armenian scripts. Prototypes are lowercase because
+// skeletons are computed on case folded identifiers; compatibility variants such as fullwidth and
+// mathematical letters are already removed by NFKC normalization.
+var confusables = map[rune]string{
+	// Latin and digits
+	'0': "o",
+	'1': "l",
+	'd': "cl",
+	'm': "rn",
+	'ı': "i",
+	'ɑ': "a",
+	'ɡ': "g",
+	'ɩ': "i",
+	'ſ': "f",
+	'ꞵ': "b",
+
+	// Cyrillic
+	'а': "a",
+	'ь': "b",
+	'с': "c",
+	'ԁ': "cl",
+	'е': "e",
+	'һ': "h",
+	'і': "i",
+	'ј': "j",
+	'ӏ': "l",
+	'о': "o",
+	'р': "p",
+	'ԛ': "q",
+	'ѕ': "s",
+	'у': "y",
+	'ѵ': "v",
+	'ԝ': "w",
+	'х': "x",
+
+	// Greek
+	'α': "a",
+	'ϲ': "c",
+	'ι': "i",
+	'ϳ': "j",
+	'ν': "v",
+	'ο': "o",
+	'ρ': "p",
+	'σ': "o",
+	'υ': "u",
+	'γ': "y",
+
+	// Armenian
+	'հ': "h",
+	'ո': "n",
+	'օ': "o",
+	'զ': "q",
+	'ս': "u",
+}
@@ -0,0 +1,186 @@
+// Package namespace implements the syntax of universal identifiers and the Unicode security
+// checks (UTS #39) that keep visually confusable identifiers apart.
+//
+// A universal identifier has the form local@domain. Identifiers without a domain belong to the
+// platform's default domain. Identifiers are compared in their canonical form, which is NFKC
+// normalized and case folded, and are unique by their confusable skeleton.
+package namespace
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the maximum length in bytes of a canonical universal identifier
+const MaxLength = 255
+
+// Separator separates the local part from the domain of a universal identifier
+const Separator = "@"
+
+var (
+	// ErrInvalidIdentifier reports an identifier that does not follow the namespace syntax
+	ErrInvalidIdentifier = errors.New("identifier does not follow the namespace syntax")
+	// ErrMixedScript reports a local part mixing letters of scripts that are not used together
+	ErrMixedScript = errors.New("identifier mixes letters of different scripts")
+	// ErrInvalidDomain reports a domain that is not a lowercase DNS-style name
+	ErrInvalidDomain = errors.New("invalid identifier domain")
+)
+
+// domainPattern accepts lowercase ASCII DNS-style names such as finternet or acme.example
+var domainPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// wordSeparators are ignored when matching reserved and blocked words, so "ad-min" is "admin"
+var wordSeparators = strings.NewReplacer(".", "", "-", "", "_", "")
+
+// Rules holds the configurable syntax of universal identifiers
+type Rules struct {
+	// DefaultDomain is the domain of identifiers given without one
+	DefaultDomain string
+	// LocalPattern is the syntax of the local part, matched against its canonical form
+	LocalPattern *regexp.Regexp
+}
+
+// Identifier is a parsed universal identifier in canonical form
+type Identifier struct {
+	Local  string
+	Domain string
+	// Skeleton is the confusable skeleton of the whole identifier; identifiers with the same
+	// skeleton look alike and cannot both be claimed
+	Skeleton string
+}
+
+// String returns the canonical form local@domain
+func (id *Identifier) String() string {
+	return id.Local + Separator + id.Domain
+}
+
+// Normalize returns the canonical form of s: NFKC normalized, so compatibility variants such as
+// fullwidth letters become their plain form, and case folded
+func Normalize(s string) string {
+	return norm.NFC.String(cases.Fold().String(norm.NFKC.String(s)))
+}
+
+// Parse checks raw against the rules and returns it in canonical form
+func (r *Rules) Parse(raw string) (*Identifier, error) {
+	canonical := Normalize(raw)
+
+	local, domain := canonical, r.DefaultDomain
+	if i := strings.LastIndex(canonical, Separator); i >= 0 {
+		local, domain = canonical[:i], canonical[i+1:]
+	}
+
+	if !r.LocalPattern.MatchString(local) {
+		return nil, ErrInvalidIdentifier
+	}
+	if !singleScript(local) {
+		return nil, ErrMixedScript
+	}
+	if !ValidDomain(domain) {
+		return nil, ErrInvalidDomain
+	}
+
+	id := &Identifier{Local: local, Domain: domain}
+	if len(id.String()) > MaxLength {
+		return nil, ErrInvalidIdentifier
+	}
+	id.Skeleton = Skeleton(id.String())
+	return id, nil
+}
+
+// Canonical returns the canonical form of raw for lookups, qualified with the default domain.
+// Input that does not parse is returned unchanged so that lookups of it simply find nothing.
+func (r *Rules) Canonical(raw string) string {
+	id, err := r.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return id.String()
+}
+
+// Legacy returns an identifier stored before the namespace syntax was enforced in canonical form,
+// qualified with the default domain when it has none. Unlike Parse it does not check the syntax,
+// which such identifiers may predate.
+func (r *Rules) Legacy(raw string) *Identifier {
+	canonical := Normalize(raw)
+
+	id := &Identifier{Local: canonical, Domain: r.DefaultDomain}
+	if i := strings.LastIndex(canonical, Separator); i >= 0 {
+		id.Local, id.Domain = canonical[:i], canonical[i+1:]
+	}
+	id.Skeleton = Skeleton(id.String())
+	return id
+}
+
+// ValidDomain reports whether domain is a lowercase DNS-style name
+func ValidDomain(domain string) bool {
+	return len(domain) <= MaxLength && domainPattern.MatchString(domain)
+}
+
+// WordSkeleton returns the skeleton a reserved or blocked word is matched by. Separators are
+// dropped so that they cannot be used to slip a word past the list.
+func WordSkeleton(word string) string {
+	return wordSeparators.Replace(Skeleton(Normalize(word)))
+}
+
+// Skeleton returns the UTS #39 skeleton of s: the NFD form with every character replaced by the
+// prototype of its confusable class, normalized to NFD again. Two strings with the same skeleton
+// are visually confusable.
+func Skeleton(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if prototype, ok := confusables[r]; ok {
+			b.WriteString(prototype)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFD.String(b.String())
+}
+
+// cjkScripts may be mixed with each other, as Japanese and Korean text does
+var cjkScripts = map[string]bool{"Han": true, "Hiragana": true, "Katakana": true, "Hangul": true, "Bopomofo": true}
+
+// singleScript reports whether all letters of s belong to one script, or only to scripts that are
+// written together. Characters of the Common and Inherited scripts such as digits, separators and
+// combining marks go with any script. This is a simplified form of the UTS #39 restriction levels.
+func singleScript(s string) bool {
+	scripts := map[string]bool{}
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if script := scriptOf(r); script != "" {
+			scripts[script] = true
+		}
+	}
+	if len(scripts) <= 1 {
+		return true
+	}
+	for script := range scripts {
+		if !cjkScripts[script] {
+			return false
+		}
+	}
+	return true
+}
+
+// scriptOf returns the Unicode script of r, or "" for the Common and Inherited scripts
+func scriptOf(r rune) string {
+	if unicode.Is(unicode.Latin, r) {
+		return "Latin"
+	}
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return ""
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+// Package pex implements the subset of DIF Presentation Exchange 2.0 used by the OID4VP verifier:
+// presentation definitions with field constraints, presentation submissions and JSON Schema filters.
+package pex
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PresentationDefinition describes the credentials a verifier requests.
+// Every input descriptor must be satisfied; submission requirements are not supported.
+type PresentationDefinition struct {
+	ID               string            `json:"id" validate:"required"`
+	Name             string            `json:"name,omitempty"`
+	Purpose          string            `json:"purpose,omitempty"`
+	InputDescriptors []InputDescriptor `json:"input_descriptors" validate:"required,min=1,dive"`
+}
+
+// InputDescriptor describes one requested credential
+type InputDescriptor struct {
+	ID          string                 `json:"id" validate:"required"`
+	Name        string                 `json:"name,omitempty"`
+	Purpose     string                 `json:"purpose,omitempty"`
+	Format      map[string]interface{} `json:"format,omitempty"`
+	Constraints Constraints            `json:"constraints"`
+}
+
+// Constraints restrict the credentials that satisfy an input descriptor. AllowBearer is an
+// extension of Presentation Exchange: credentials without a subject are only accepted for
+// descriptors that set it, since nothing binds them to the holder presenting them.
+type Constraints struct {
+	LimitDisclosure string  `json:"limit_disclosure,omitempty"`
+	Fields          []Field `json:"fields,omitempty" validate:"dive"`
+	AllowBearer     bool    `json:"allow_bearer,omitempty"`
+}
+
+// Field requires a value at one of the given paths, optionally matching a JSON Schema filter
+type Field struct {
+	ID       string                 `json:"id,omitempty"`
+	Name     string                 `json:"name,omitempty"`
+	Purpose  string                 `json:"purpose,omitempty"`
+	Path     []string               `json:"path" validate:"required,min=1"`
+	Filter   map[string]interface{} `json:"filter,omitempty"`
+	Optional bool                   `json:"optional,omitempty"`
+}
+
+// PresentationSubmission maps input descriptors to the presented credentials
+type PresentationSubmission struct {
+	ID            string       `json:"id"`
+	DefinitionID  string       `json:"definition_id"`
+	DescriptorMap []Descriptor `json:"descriptor_map"`
+}
+
+// Descriptor locates the credential submitted for an input descriptor
+type Descriptor struct {
+	ID         string      `json:"id"`
+	Format     string      `json:"format"`
+	Path       string      `json:"path"`
+	PathNested *Descriptor `json:"path_nested,omitempty"`
+}
+
+// Validate checks the parts of a definition that struct validation cannot express
+func (d *PresentationDefinition) Validate() error {
+	seen := make(map[string]bool, len(d.InputDescriptors))
+	for _, descriptor := range d.InputDescriptors {
+		if seen[descriptor.ID] {
+			return fmt.Errorf("duplicate input descriptor id %q", descriptor.ID)
+		}
+		seen[descriptor.ID] = true
+
+		for _, field := range descriptor.Constraints.Fields {
+			for _, path := range field.Path {
+				if err := ValidatePath(path); err != nil {
+					return err
+				}
+			}
+			if err := validateFilter(field.Filter); err != nil {
+				return fmt.Errorf("input descriptor %q: %w", descriptor.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// InputDescriptor returns the descriptor with the given ID
+func (d *PresentationDefinition) InputDescriptor(id string) (*InputDescriptor, bool) {
+	for i := range d.InputDescriptors {
+		if d.InputDescriptors[i].ID == id {
+			return &d.InputDescriptors[i], true
+		}
+	}
+	return nil, false
+}
+
+// CheckCoverage verifies that the submission answers this definition and covers every input descriptor
+func (d *PresentationDefinition) CheckCoverage(submission *PresentationSubmission) error {
+	if submission.DefinitionID != d.ID {
+		return fmt.Errorf("submission answers definition %q, expected %q", submission.DefinitionID, d.ID)
+	}
+
+	submitted := make(map[string]bool, len(submission.DescriptorMap))
+	for _, entry := range submission.DescriptorMap {
+		if _, ok := d.InputDescriptor(entry.ID); !ok {
+			return fmt.Errorf("submission references unknown input descriptor %q", entry.ID)
+		}
+		submitted[entry.ID] = true
+	}
+
+	for _, descriptor := range d.InputDescriptors {
+		if !submitted[descriptor.ID] {
+			return fmt.Errorf("no credential submitted for input descriptor %q", descriptor.ID)
+		}
+	}
+	return nil
+}
+
+// Match checks a decoded credential against the descriptor's field constraints and
+// returns the selected value of each satisfied field, keyed by field ID or first path
+func (descriptor *InputDescriptor) Match(credential interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(descriptor.Constraints.Fields))
+
+	for _, field := range descriptor.Constraints.Fields {
+		value, err := field.match(credential)
+		if err != nil {
+			if field.Optional && errors.Is(err, ErrPathNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("input descriptor %q: %w", descriptor.ID, err)
+		}
+
+		key := field.ID
+		if key == "" {
+			key = field.Path[0]
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// CheckHolderBinding checks that a credential about subject may be presented by holder for the
+// descriptor: the subject must be the holder, or absent when the descriptor allows bearer credentials
+func (descriptor *InputDescriptor) CheckHolderBinding(subject, holder string) error {
+	if subject == "" {
+		if !descriptor.Constraints.AllowBearer {
+			return fmt.Errorf("input descriptor %q: credential has no subject to bind to the holder", descriptor.ID)
+		}
+		return nil
+	}
+	if subject != holder {
+		return fmt.Errorf("credential subject %q is not the presentation holder", subject)
+	}
+	return nil
+}
+
+// match returns the first value selected by the field's paths that satisfies its filter
+func (field *Field) match(credential interface{}) (interface{}, error) {
+	found := false
+	for _, path := range field.Path {
+		value, err := Query(credential, path)
+		if err != nil {
+			if errors.Is(err, ErrPathNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		found = true
+		if field.Filter == nil || MatchFilter(field.Filter, value) {
+			return value, nil
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %v", ErrPathNotFound, field.Path)
+	}
+	return nil, fmt.Errorf("value at %v does not satisfy the filter", field.Path)
+}
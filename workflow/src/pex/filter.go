@@ -0,0 +1,158 @@
+package pex
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+)
+
+// supportedFilterKeywords lists the JSON Schema keywords understood in field filters
+var supportedFilterKeywords = map[string]bool{
+	"type": true, "const": true, "enum": true, "pattern": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+	"minLength": true, "maxLength": true, "contains": true, "not": true,
+	"$schema": true, "description": true, "title": true,
+}
+
+// validateFilter rejects filters using keywords outside the supported subset or invalid patterns
+func validateFilter(filter map[string]interface{}) error {
+	for keyword, value := range filter {
+		if !supportedFilterKeywords[keyword] {
+			return fmt.Errorf("unsupported filter keyword %q", keyword)
+		}
+
+		switch keyword {
+		case "pattern":
+			pattern, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("filter pattern must be a string")
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return fmt.Errorf("invalid filter pattern: %w", err)
+			}
+		case "contains", "not":
+			nested, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("filter %s must be a schema object", keyword)
+			}
+			if err := validateFilter(nested); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MatchFilter evaluates a JSON Schema filter (supported subset) against a decoded JSON value
+func MatchFilter(filter map[string]interface{}, value interface{}) bool {
+	for keyword, constraint := range filter {
+		if !matchKeyword(keyword, constraint, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchKeyword(keyword string, constraint, value interface{}) bool {
+	switch keyword {
+	case "type":
+		return matchType(constraint, value)
+	case "const":
+		return reflect.DeepEqual(constraint, value)
+	case "enum":
+		options, _ := constraint.([]interface{})
+		for _, option := range options {
+			if reflect.DeepEqual(option, value) {
+				return true
+			}
+		}
+		return false
+	case "pattern":
+		text, ok := value.(string)
+		if !ok {
+			return false
+		}
+		pattern, _ := constraint.(string)
+		matched, err := regexp.MatchString(pattern, text)
+		return err == nil && matched
+	case "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum":
+		return matchBound(keyword, constraint, value)
+	case "minLength", "maxLength":
+		text, ok := value.(string)
+		limit, isNumber := constraint.(float64)
+		if !ok || !isNumber {
+			return false
+		}
+		length := float64(utf8.RuneCountInString(text))
+		if keyword == "minLength" {
+			return length >= limit
+		}
+		return length <= limit
+	case "contains":
+		items, ok := value.([]interface{})
+		nested, _ := constraint.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if MatchFilter(nested, item) {
+				return true
+			}
+		}
+		return false
+	case "not":
+		nested, _ := constraint.(map[string]interface{})
+		return !MatchFilter(nested, value)
+	default:
+		// Annotation keywords such as title and description do not constrain the value
+		return true
+	}
+}
+
+func matchType(constraint, value interface{}) bool {
+	name, _ := constraint.(string)
+	switch name {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return false
+	}
+}
+
+func matchBound(keyword string, constraint, value interface{}) bool {
+	number, ok := value.(float64)
+	limit, isNumber := constraint.(float64)
+	if !ok || !isNumber {
+		return false
+	}
+
+	switch keyword {
+	case "minimum":
+		return number >= limit
+	case "maximum":
+		return number <= limit
+	case "exclusiveMinimum":
+		return number > limit
+	default:
+		return number < limit
+	}
+}
@@ -0,0 +1,100 @@
+package pex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrPathNotFound is returned when a JSONPath does not select a value
+var ErrPathNotFound = errors.New("path not found")
+
+// pathSegment is a single member name or array index of a JSONPath
+type pathSegment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// parsePath parses the JSONPath subset used by Presentation Exchange: a root "$" followed by
+// dot members (.name), bracketed members (['name'] or ["name"]) and array indexes ([0])
+func parsePath(path string) ([]pathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSONPath %q: must start with $", path)
+	}
+
+	var segments []pathSegment
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("invalid JSONPath %q: unsupported member %q", path, name)
+			}
+			segments = append(segments, pathSegment{name: name})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unterminated bracket", path)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, pathSegment{name: inner[1 : len(inner)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q: unsupported selector [%s]", path, inner)
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q: unexpected %q", path, rest[0])
+		}
+	}
+	return segments, nil
+}
+
+// ValidatePath reports whether path is within the supported JSONPath subset
+func ValidatePath(path string) error {
+	_, err := parsePath(path)
+	return err
+}
+
+// Query evaluates a JSONPath against a decoded JSON document
+func Query(document interface{}, path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := document
+	for _, segment := range segments {
+		if segment.isIndex {
+			array, ok := current.([]interface{})
+			if !ok || segment.index >= len(array) {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+			}
+			current = array[segment.index]
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+		if current, ok = object[segment.name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrPathNotFound, path)
+		}
+	}
+	return current, nil
+}
@@ -0,0 +1,243 @@
+// Package queue runs background jobs stored in Postgres. Workers claim jobs with
+// SELECT ... FOR UPDATE SKIP LOCKED, so any number of instances can share one queue.
+package queue
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Handler processes one job type. The returned result is stored on the job once it succeeds.
+// Returning an error schedules a retry unless the error is wrapped with Permanent.
+type Handler interface {
+	Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error)
+}
+
+// HandlerFunc adapts a function to the Handler interface
+type HandlerFunc func(ctx context.Context, job *model.Job) (map[string]interface{}, error)
+
+// Handle calls f(ctx, job)
+func (f HandlerFunc) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	return f(ctx, job)
+}
+
+// permanentError marks a failure that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the job is dead-lettered without further attempts
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// Backoff returns the delay before the given retry attempt: base doubled for every
+// previous attempt, capped at max
+func Backoff(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// Options configures a worker pool
+type Options struct {
+	Workers      int
+	PollInterval time.Duration
+	LeaseTimeout time.Duration
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+}
+
+// Pool runs registered job handlers on a fixed number of workers
+type Pool struct {
+	db       *gorm.DB
+	jobs     repository.JobRepository
+	log      *logrus.Logger
+	opts     Options
+	handlers map[string]Handler
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewPool creates a worker pool. Handlers must be registered before Start.
+func NewPool(db *gorm.DB, jobs repository.JobRepository, log *logrus.Logger, opts Options) *Pool {
+	return &Pool{
+		db:       db,
+		jobs:     jobs,
+		log:      log,
+		opts:     opts,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Register sets the handler for a job type
+func (p *Pool) Register(jobType string, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// Start launches the workers
+func (p *Pool) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	hostname, _ := os.Hostname()
+	for i := 0; i < p.opts.Workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		p.wg.Add(1)
+		go p.work(ctx, workerID)
+	}
+	p.log.Infof("Started %d job workers", p.opts.Workers)
+}
+
+// Stop stops claiming new jobs and waits for running jobs to finish or ctx to expire
+func (p *Pool) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("job workers did not stop: %w", ctx.Err())
+	}
+}
+
+// work claims and runs jobs until ctx is cancelled, sleeping between polls while the queue is empty
+func (p *Pool) work(ctx context.Context, workerID string) {
+	defer p.wg.Done()
+
+	types := make([]string, 0, len(p.handlers))
+	for jobType := range p.handlers {
+		types = append(types, jobType)
+	}
+
+	for {
+		job, err := p.claim(ctx, types, workerID)
+		if err != nil && ctx.Err() == nil {
+			p.log.Errorf("Job worker %s failed to claim a job: %v", workerID, err)
+		}
+
+		if job != nil {
+			p.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.opts.PollInterval):
+		}
+	}
+}
+
+func (p *Pool) claim(ctx context.Context, types []string, workerID string) (*model.Job, error) {
+	var job *model.Job
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		var err error
+		job, err = p.jobs.ClaimNext(ctx, tx, types, workerID, now, now.Add(-p.opts.LeaseTimeout))
+		return err
+	})
+	return job, err
+}
+
+// run executes a claimed job and records its outcome. The handler is not cancelled on
+// shutdown so an in-flight attempt can finish; the lease timeout bounds how long it may take,
+// and the outcome is discarded if the job was reclaimed in the meantime.
+func (p *Pool) run(ctx context.Context, job *model.Job) {
+	handlerCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.opts.LeaseTimeout)
+	defer cancel()
+
+	result, err := p.handle(handlerCtx, job)
+
+	workerID := *job.LockedBy
+	now := time.Now().UTC()
+	job.LockedBy = nil
+	job.LockedAt = nil
+
+	switch {
+	case err == nil:
+		job.Status = constants.JobStatusSucceeded
+		job.Result = datatypes.JSONMap(result)
+		job.LastError = nil
+		job.CompletedAt = &now
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		p.log.Warnf("Job %s (%s) dead-lettered after %d attempts: %v", job.JobID, job.Type, job.Attempts, err)
+		job.Status = constants.JobStatusDead
+		job.LastError = errorText(err)
+		job.CompletedAt = &now
+	default:
+		delay := Backoff(p.opts.BackoffBase, p.opts.BackoffMax, job.Attempts)
+		p.log.Infof("Job %s (%s) attempt %d failed, retrying in %s: %v", job.JobID, job.Type, job.Attempts, delay, err)
+		job.Status = constants.JobStatusQueued
+		job.LastError = errorText(err)
+		job.RunAt = now.Add(delay)
+	}
+	if job.Result == nil {
+		job.Result = datatypes.JSONMap{}
+	}
+
+	// A handler that overran its lease must not overwrite the attempt of the worker that reclaimed the job
+	if err := p.jobs.Complete(context.WithoutCancel(ctx), p.db, job, workerID); err != nil {
+		if errors.Is(err, repository.ErrJobLeaseLost) {
+			p.log.Warnf("Job %s (%s) lease was lost, discarding the outcome of attempt %d", job.JobID, job.Type, job.Attempts)
+			return
+		}
+		p.log.Errorf("Failed to record outcome of job %s: %v", job.JobID, err)
+	}
+}
+
+// handle dispatches a job to its handler, converting a panic into a permanent failure
+func (p *Pool) handle(ctx context.Context, job *model.Job) (result map[string]interface{}, err error) {
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		return nil, Permanent(fmt.Errorf("no handler registered for job type %q", job.Type))
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = Permanent(fmt.Errorf("handler panic: %v", recovered))
+		}
+	}()
+	return handler.Handle(ctx, job)
+}
+
+// errorText truncates an error message to the stored length
+func errorText(err error) *string {
+	message := utils.Truncate(err.Error(), constants.JobErrorMaxLength)
+	return &message
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AccountDeletionRepository defines the interface for account deletion data access
+type AccountDeletionRepository interface {
+	// Create records a pending account deletion; an actor can only have one pending at a time
+	Create(ctx context.Context, tx *gorm.DB, deletion *model.AccountDeletion) error
+
+	// FindByID finds an account deletion by ID
+	FindByID(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID) (*model.AccountDeletion, error)
+
+	// LockByID finds an account deletion by ID and locks it for the rest of the transaction
+	LockByID(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID) (*model.AccountDeletion, error)
+
+	// FindLatestByActorID finds the most recent account deletion requested by an actor
+	FindLatestByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.AccountDeletion, error)
+
+	// LockPendingByActorID finds the pending account deletion of an actor and locks it for the rest
+	// of the transaction
+	LockPendingByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.AccountDeletion, error)
+
+	// Cancel marks a pending account deletion as cancelled at the given time
+	Cancel(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID, at time.Time) error
+
+	// Complete marks a pending account deletion as completed with its summary and certificate
+	Complete(ctx context.Context, tx *gorm.DB, deletion *model.AccountDeletion) error
+}
+
+type accountDeletionRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountDeletionRepository creates a new instance of AccountDeletionRepository
+func NewAccountDeletionRepository(db *gorm.DB) AccountDeletionRepository {
+	return &accountDeletionRepository{db: db}
+}
+
+func (r *accountDeletionRepository) Create(ctx context.Context, tx *gorm.DB, deletion *model.AccountDeletion) error {
+	if err := tx.WithContext(ctx).Create(deletion).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrAccountDeletionPending)
+		}
+		return fmt.Errorf("failed to create account deletion: %w", err)
+	}
+	return nil
+}
+
+func (r *accountDeletionRepository) FindByID(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID) (*model.AccountDeletion, error) {
+	return r.findOne(tx.WithContext(ctx).Where("deletion_id = ?", deletionID))
+}
+
+func (r *accountDeletionRepository) LockByID(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID) (*model.AccountDeletion, error) {
+	return r.findOne(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("deletion_id = ?", deletionID))
+}
+
+func (r *accountDeletionRepository) FindLatestByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.AccountDeletion, error) {
+	return r.findOne(tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("created_at DESC"))
+}
+
+func (r *accountDeletionRepository) LockPendingByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.AccountDeletion, error) {
+	return r.findOne(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("actor_id = ? AND status = ?", actorID, constants.AccountDeletionStatusPending))
+}
+
+func (r *accountDeletionRepository) findOne(query *gorm.DB) (*model.AccountDeletion, error) {
+	var deletion model.AccountDeletion
+	if err := query.First(&deletion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrAccountDeletionNotFound)
+		}
+		return nil, fmt.Errorf("failed to find account deletion: %w", err)
+	}
+	return &deletion, nil
+}
+
+func (r *accountDeletionRepository) Cancel(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID, at time.Time) error {
+	err := tx.WithContext(ctx).Model(&model.AccountDeletion{}).
+		Where("deletion_id = ? AND status = ?", deletionID, constants.AccountDeletionStatusPending).
+		Updates(map[string]interface{}{
+			"status":       constants.AccountDeletionStatusCancelled,
+			"cancelled_at": at,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	return nil
+}
+
+func (r *accountDeletionRepository) Complete(ctx context.Context, tx *gorm.DB, deletion *model.AccountDeletion) error {
+	err := tx.WithContext(ctx).Model(&model.AccountDeletion{}).
+		Where("deletion_id = ? AND status = ?", deletion.DeletionID, constants.AccountDeletionStatusPending).
+		Updates(map[string]interface{}{
+			"status":        constants.AccountDeletionStatusCompleted,
+			"pseudonymized": deletion.Pseudonymized,
+			"summary":       deletion.Summary,
+			"certificate":   deletion.Certificate,
+			"completed_at":  deletion.CompletedAt,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to complete account deletion: %w", err)
+	}
+	return nil
+}
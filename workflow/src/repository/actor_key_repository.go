@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ActorKeyRepository defines the interface for master key history data access
+type ActorKeyRepository interface {
+	// Create records a master key of an actor
+	Create(ctx context.Context, tx *gorm.DB, key *model.ActorKey) error
+
+	// ExistsWithThumbprint checks if a key with the given thumbprint was ever registered by any actor
+	ExistsWithThumbprint(ctx context.Context, tx *gorm.DB, thumbprint string) (bool, error)
+
+	// FindValidAt finds the master key of an actor that was valid at the given time
+	FindValidAt(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, at time.Time) (*model.ActorKey, error)
+
+	// ListByActorID lists the master key history of an actor, oldest first
+	ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.ActorKey, error)
+
+	// CloseCurrent ends the validity of an actor's current key at the given time
+	CloseCurrent(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, at time.Time) error
+
+	// UpdateCurrent replaces the encoding, thumbprint and DID of an actor's current key
+	UpdateCurrent(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, publicKey, thumbprint, did string) error
+}
+
+type actorKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewActorKeyRepository creates a new instance of ActorKeyRepository
+func NewActorKeyRepository(db *gorm.DB) ActorKeyRepository {
+	return &actorKeyRepository{db: db}
+}
+
+func (r *actorKeyRepository) Create(ctx context.Context, tx *gorm.DB, key *model.ActorKey) error {
+	if err := tx.WithContext(ctx).Create(key).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrMasterPublicKeyAlreadyInUse)
+		}
+		return fmt.Errorf("failed to record actor key: %w", err)
+	}
+	return nil
+}
+
+func (r *actorKeyRepository) ExistsWithThumbprint(ctx context.Context, tx *gorm.DB, thumbprint string) (bool, error) {
+	if thumbprint == "" {
+		return false, nil
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).Model(&model.ActorKey{}).Where("thumbprint = ?", thumbprint).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check key thumbprint existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *actorKeyRepository) FindValidAt(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, at time.Time) (*model.ActorKey, error) {
+	var key model.ActorKey
+	err := tx.WithContext(ctx).
+		Where("actor_id = ? AND valid_from <= ? AND (valid_until IS NULL OR valid_until > ?)", actorID, at, at).
+		Order("valid_from DESC").
+		First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrNoKeyValidAt)
+		}
+		return nil, fmt.Errorf("failed to find actor key: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *actorKeyRepository) ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.ActorKey, error) {
+	var actorKeys []model.ActorKey
+	err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("valid_from ASC").Find(&actorKeys).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actor keys: %w", err)
+	}
+	return actorKeys, nil
+}
+
+func (r *actorKeyRepository) CloseCurrent(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, at time.Time) error {
+	err := tx.WithContext(ctx).Model(&model.ActorKey{}).
+		Where("actor_id = ? AND valid_until IS NULL", actorID).
+		Update("valid_until", at).Error
+	if err != nil {
+		return fmt.Errorf("failed to close actor key: %w", err)
+	}
+	return nil
+}
+
+func (r *actorKeyRepository) UpdateCurrent(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, publicKey, thumbprint, did string) error {
+	err := tx.WithContext(ctx).Model(&model.ActorKey{}).
+		Where("actor_id = ? AND valid_until IS NULL", actorID).
+		Updates(map[string]interface{}{
+			"public_key": publicKey,
+			"thumbprint": thumbprint,
+			"did":        did,
+		}).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrMasterPublicKeyAlreadyInUse)
+		}
+		return fmt.Errorf("failed to update actor key: %w", err)
+	}
+	return nil
+}
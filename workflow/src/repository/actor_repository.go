@@ -10,6 +10,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ActorRepository defines the interface for actor data access operations
@@ -23,6 +24,12 @@ type ActorRepository interface {
 	// FindByIDForUpdate finds an actor by ID for update operations (returns 401 instead of 404)
 	FindByIDForUpdate(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.Actor, error)
 
+	// LockByID finds an actor by ID and locks the row until the transaction ends
+	LockByID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.Actor, error)
+
+	// UpdateVerificationLevel sets an actor's verification level
+	UpdateVerificationLevel(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, level string) error
+
 	// FindByEmail finds an actor by email
 	FindByEmail(ctx context.Context, tx *gorm.DB, email string) (*model.Actor, error)
 
@@ -32,8 +39,11 @@ type ActorRepository interface {
 	// ExistsWithEmail checks if an actor with the given email exists
 	ExistsWithEmail(ctx context.Context, tx *gorm.DB, email string) (bool, error)
 
-	// ExistsWithMasterPublicKey checks if an actor with the given master public key exists
-	ExistsWithMasterPublicKey(ctx context.Context, tx *gorm.DB, masterPublicKey string) (bool, error)
+	// ListAfter retrieves up to limit actors ordered by ID, starting after the given actor ID
+	ListAfter(ctx context.Context, tx *gorm.DB, afterID uuid.UUID, limit int) ([]model.Actor, error)
+
+	// UpdateMasterKey sets the master public key, its thumbprint and the DID derived from it
+	UpdateMasterKey(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, masterPublicKey, thumbprint, did string) error
 
 	// ExistsWithPhoneNumber checks if an actor (excluding given actorID) with the phone number exists
 	ExistsWithPhoneNumber(ctx context.Context, tx *gorm.DB, phoneNumber string, excludeActorID uuid.UUID) (bool, error)
@@ -81,6 +91,26 @@ func (r *actorRepository) FindByIDForUpdate(ctx context.Context, tx *gorm.DB, ac
 	return &actor, nil
 }
 
+func (r *actorRepository) LockByID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.Actor, error) {
+	var actor model.Actor
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("actor_id = ?", actorID).First(&actor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrActorNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock actor: %w", err)
+	}
+	return &actor, nil
+}
+
+func (r *actorRepository) UpdateVerificationLevel(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, level string) error {
+	err := tx.WithContext(ctx).Model(&model.Actor{}).Where("actor_id = ?", actorID).Update("verification_level", level).Error
+	if err != nil {
+		return fmt.Errorf("failed to update verification level: %w", err)
+	}
+	return nil
+}
+
 func (r *actorRepository) FindByEmail(ctx context.Context, tx *gorm.DB, email string) (*model.Actor, error) {
 	var actor model.Actor
 	err := tx.WithContext(ctx).Where("email = ?", email).First(&actor).Error
@@ -109,17 +139,31 @@ func (r *actorRepository) ExistsWithEmail(ctx context.Context, tx *gorm.DB, emai
 	return count > 0, nil
 }
 
-func (r *actorRepository) ExistsWithMasterPublicKey(ctx context.Context, tx *gorm.DB, masterPublicKey string) (bool, error) {
-	if masterPublicKey == "" {
-		return false, nil
+func (r *actorRepository) ListAfter(ctx context.Context, tx *gorm.DB, afterID uuid.UUID, limit int) ([]model.Actor, error) {
+	var actors []model.Actor
+	err := tx.WithContext(ctx).Where("actor_id > ?", afterID).Order("actor_id").Limit(limit).Find(&actors).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list actors: %w", err)
 	}
+	return actors, nil
+}
 
-	var count int64
-	err := tx.WithContext(ctx).Model(&model.Actor{}).Where("master_public_key = ?", masterPublicKey).Count(&count).Error
-	if err != nil {
-		return false, fmt.Errorf("failed to check master public key existence: %w", err)
+func (r *actorRepository) UpdateMasterKey(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, masterPublicKey, thumbprint, did string) error {
+	result := tx.WithContext(ctx).Model(&model.Actor{}).Where("actor_id = ?", actorID).Updates(map[string]interface{}{
+		"master_public_key":     masterPublicKey,
+		"master_key_thumbprint": thumbprint,
+		"did":                   did,
+	})
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrMasterPublicKeyAlreadyInUse)
+		}
+		return fmt.Errorf("failed to update actor master key: %w", result.Error)
 	}
-	return count > 0, nil
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrActorNotFound)
+	}
+	return nil
 }
 
 func (r *actorRepository) ExistsWithPhoneNumber(ctx context.Context, tx *gorm.DB, phoneNumber string, excludeActorID uuid.UUID) (bool, error) {
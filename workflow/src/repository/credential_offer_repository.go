@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CredentialOfferRepository defines the interface for OID4VCI credential offer data access
+type CredentialOfferRepository interface {
+	// Create creates a new credential offer in the database
+	Create(ctx context.Context, tx *gorm.DB, offer *model.CredentialOffer) error
+
+	// LockByPreAuthorizedCodeHash finds an offer by its pre-authorized code digest and locks it, returning nil if absent
+	LockByPreAuthorizedCodeHash(ctx context.Context, tx *gorm.DB, codeHash string) (*model.CredentialOffer, error)
+
+	// FindByAccessTokenHash finds an offer by its access token digest without locking it, returning nil if absent
+	FindByAccessTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.CredentialOffer, error)
+
+	// LockByAccessTokenHash finds an offer by its access token digest and locks it, returning nil if absent
+	LockByAccessTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.CredentialOffer, error)
+
+	// Update updates a credential offer in the database
+	Update(ctx context.Context, tx *gorm.DB, offer *model.CredentialOffer) error
+}
+
+type credentialOfferRepository struct {
+	db *gorm.DB
+}
+
+// NewCredentialOfferRepository creates a new instance of CredentialOfferRepository
+func NewCredentialOfferRepository(db *gorm.DB) CredentialOfferRepository {
+	return &credentialOfferRepository{db: db}
+}
+
+func (r *credentialOfferRepository) Create(ctx context.Context, tx *gorm.DB, offer *model.CredentialOffer) error {
+	if err := tx.WithContext(ctx).Create(offer).Error; err != nil {
+		return fmt.Errorf("failed to create credential offer: %w", err)
+	}
+	return nil
+}
+
+func (r *credentialOfferRepository) LockByPreAuthorizedCodeHash(ctx context.Context, tx *gorm.DB, codeHash string) (*model.CredentialOffer, error) {
+	return r.lockBy(ctx, tx, "pre_authorized_code_hash = ?", codeHash)
+}
+
+func (r *credentialOfferRepository) FindByAccessTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.CredentialOffer, error) {
+	return r.findBy(tx.WithContext(ctx), "access_token_hash = ?", tokenHash)
+}
+
+func (r *credentialOfferRepository) LockByAccessTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.CredentialOffer, error) {
+	return r.lockBy(ctx, tx, "access_token_hash = ?", tokenHash)
+}
+
+func (r *credentialOfferRepository) lockBy(ctx context.Context, tx *gorm.DB, condition string, value string) (*model.CredentialOffer, error) {
+	return r.findBy(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), condition, value)
+}
+
+func (r *credentialOfferRepository) findBy(query *gorm.DB, condition string, value string) (*model.CredentialOffer, error) {
+	var offer model.CredentialOffer
+	err := query.Where(condition, value).First(&offer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Unknown codes and tokens are reported as protocol errors by the caller
+		}
+		return nil, fmt.Errorf("failed to find credential offer: %w", err)
+	}
+	return &offer, nil
+}
+
+func (r *credentialOfferRepository) Update(ctx context.Context, tx *gorm.DB, offer *model.CredentialOffer) error {
+	if err := tx.WithContext(ctx).Save(offer).Error; err != nil {
+		return fmt.Errorf("failed to update credential offer: %w", err)
+	}
+	return nil
+}
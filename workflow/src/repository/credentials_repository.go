@@ -3,9 +3,11 @@ package repository
 import (
 	"app/src/constants"
 	"app/src/model"
+	"app/src/utils"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -20,11 +22,41 @@ type CredentialsRepository interface {
 	// FindByID finds a credential by token ID
 	FindByID(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) (*model.Token, error)
 
-	// FindAll retrieves all credentials
-	FindAll(ctx context.Context, tx *gorm.DB) ([]model.Token, error)
+	// FindByIDForAccount finds a credential by token ID owned by the given account
+	FindByIDForAccount(ctx context.Context, tx *gorm.DB, tokenID, accountID uuid.UUID) (*model.Token, error)
 
-	// Delete deletes a credential by token ID
-	Delete(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) error
+	// FindByIDsForAccount finds the credentials with the given token IDs owned by the given account
+	FindByIDsForAccount(ctx context.Context, tx *gorm.DB, tokenIDs []uuid.UUID, accountID uuid.UUID) ([]model.Token, error)
+
+	// FindAllForAccount finds every credential owned by the given account, oldest first
+	FindAllForAccount(ctx context.Context, tx *gorm.DB, accountID uuid.UUID) ([]model.Token, error)
+
+	// List retrieves one page of credentials matching the filter, fetching one extra row to detect further pages
+	List(ctx context.Context, tx *gorm.DB, filter CredentialFilter) ([]model.Token, error)
+
+	// UpdateStatus sets the status of a credential
+	UpdateStatus(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID, status string) error
+
+	// FindVerifiedTypes returns the distinct verification types of an account's verified credentials
+	FindVerifiedTypes(ctx context.Context, tx *gorm.DB, accountID uuid.UUID) ([]string, error)
+
+	// FindLinkedDocumentIDs returns those of the given documents still linked to a credential
+	FindLinkedDocumentIDs(ctx context.Context, tx *gorm.DB, documentIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	// Delete deletes a credential by token ID owned by the given account, with its document links
+	Delete(ctx context.Context, tx *gorm.DB, tokenID, accountID uuid.UUID) error
+}
+
+// CredentialFilter narrows a credential listing to a single account and optional criteria
+type CredentialFilter struct {
+	AccountID        uuid.UUID
+	Status           string
+	VerificationType string
+	Issuer           string
+	CreatedFrom      *time.Time
+	CreatedTo        *time.Time
+	Cursor           *utils.Cursor
+	Limit            int
 }
 
 type credentialsRepository struct {
@@ -36,6 +68,13 @@ func NewCredentialsRepository(db *gorm.DB) CredentialsRepository {
 	return &credentialsRepository{db: db}
 }
 
+// preloadDocuments loads the evidence documents linked to each credential, in submission order
+func preloadDocuments(tx *gorm.DB) *gorm.DB {
+	return tx.
+		Preload("Documents", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, document_id") }).
+		Preload("Documents.Document")
+}
+
 func (r *credentialsRepository) Create(ctx context.Context, tx *gorm.DB, token *model.Token) error {
 	if err := tx.WithContext(ctx).Create(token).Error; err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
@@ -48,7 +87,7 @@ func (r *credentialsRepository) Create(ctx context.Context, tx *gorm.DB, token *
 
 func (r *credentialsRepository) FindByID(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) (*model.Token, error) {
 	var token model.Token
-	err := tx.WithContext(ctx).Where("token_id = ?", tokenID).First(&token).Error
+	err := preloadDocuments(tx.WithContext(ctx)).Where("token_id = ?", tokenID).First(&token).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
@@ -58,22 +97,116 @@ func (r *credentialsRepository) FindByID(ctx context.Context, tx *gorm.DB, token
 	return &token, nil
 }
 
-func (r *credentialsRepository) FindAll(ctx context.Context, tx *gorm.DB) ([]model.Token, error) {
+func (r *credentialsRepository) FindByIDForAccount(ctx context.Context, tx *gorm.DB, tokenID, accountID uuid.UUID) (*model.Token, error) {
+	var token model.Token
+	err := preloadDocuments(tx.WithContext(ctx)).Where("token_id = ? AND account_id = ?", tokenID, accountID).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
+		}
+		return nil, fmt.Errorf("failed to find credential: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *credentialsRepository) FindByIDsForAccount(ctx context.Context, tx *gorm.DB, tokenIDs []uuid.UUID, accountID uuid.UUID) ([]model.Token, error) {
+	var tokens []model.Token
+	err := preloadDocuments(tx.WithContext(ctx)).
+		Where("token_id IN ? AND account_id = ?", tokenIDs, accountID).
+		Order("created_at DESC, token_id DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find credentials: %w", err)
+	}
+	return tokens, nil
+}
+
+func (r *credentialsRepository) FindAllForAccount(ctx context.Context, tx *gorm.DB, accountID uuid.UUID) ([]model.Token, error) {
+	var tokens []model.Token
+	err := preloadDocuments(tx.WithContext(ctx)).
+		Where("account_id = ?", accountID).
+		Order("created_at, token_id").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find credentials: %w", err)
+	}
+	return tokens, nil
+}
+
+func (r *credentialsRepository) List(ctx context.Context, tx *gorm.DB, filter CredentialFilter) ([]model.Token, error) {
+	query := preloadDocuments(tx.WithContext(ctx)).Where("account_id = ?", filter.AccountID)
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.VerificationType != "" {
+		query = query.Where("token_type = ?", filter.VerificationType)
+	}
+	if filter.Issuer != "" {
+		query = query.Where("issuer_did = ?", filter.Issuer)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.Cursor != nil {
+		query = query.Where("(created_at, token_id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
 	var tokens []model.Token
-	err := tx.WithContext(ctx).Find(&tokens).Error
+	err := query.Order("created_at DESC, token_id DESC").Limit(filter.Limit + 1).Find(&tokens).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
 	}
 	return tokens, nil
 }
 
-func (r *credentialsRepository) Delete(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID) error {
-	result := tx.WithContext(ctx).Delete(&model.Token{}, "token_id = ?", tokenID)
+func (r *credentialsRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, tokenID uuid.UUID, status string) error {
+	result := tx.WithContext(ctx).Model(&model.Token{}).Where("token_id = ?", tokenID).Update("status", status)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update credential status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
+	}
+	return nil
+}
+
+func (r *credentialsRepository) FindVerifiedTypes(ctx context.Context, tx *gorm.DB, accountID uuid.UUID) ([]string, error) {
+	var types []string
+	err := tx.WithContext(ctx).Model(&model.Token{}).
+		Where("account_id = ? AND status = ?", accountID, constants.TokenStatusVerified).
+		Distinct().Pluck("token_type", &types).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve verified credential types: %w", err)
+	}
+	return types, nil
+}
+
+func (r *credentialsRepository) FindLinkedDocumentIDs(ctx context.Context, tx *gorm.DB, documentIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var linked []uuid.UUID
+	err := tx.WithContext(ctx).Model(&model.TokenDocument{}).
+		Where("document_id IN ?", documentIDs).
+		Distinct().Pluck("document_id", &linked).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find linked documents: %w", err)
+	}
+	return linked, nil
+}
+
+func (r *credentialsRepository) Delete(ctx context.Context, tx *gorm.DB, tokenID, accountID uuid.UUID) error {
+	result := tx.WithContext(ctx).Delete(&model.Token{}, "token_id = ? AND account_id = ?", tokenID, accountID)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete credential: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
 		return fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
 	}
+
+	if err := tx.WithContext(ctx).Delete(&model.TokenDocument{}, "token_id = ?", tokenID).Error; err != nil {
+		return fmt.Errorf("failed to delete credential document links: %w", err)
+	}
 	return nil
 }
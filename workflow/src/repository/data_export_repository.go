@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DataExportRepository defines the interface for personal data export data access
+type DataExportRepository interface {
+	// Create records a pending data export; an actor can only have one pending at a time
+	Create(ctx context.Context, tx *gorm.DB, export *model.DataExport) error
+
+	// FindByID finds a data export by ID
+	FindByID(ctx context.Context, tx *gorm.DB, exportID uuid.UUID) (*model.DataExport, error)
+
+	// LockByID finds a data export by ID and locks it for the rest of the transaction
+	LockByID(ctx context.Context, tx *gorm.DB, exportID uuid.UUID) (*model.DataExport, error)
+
+	// LockByDownloadTokenHash finds the data export a download link was issued for and locks it for
+	// the rest of the transaction
+	LockByDownloadTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.DataExport, error)
+
+	// ListByActorID lists the most recent data exports of an actor, newest first
+	ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, limit int) ([]model.DataExport, error)
+
+	// Update saves every field of a data export
+	Update(ctx context.Context, tx *gorm.DB, export *model.DataExport) error
+}
+
+type dataExportRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository creates a new instance of DataExportRepository
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, tx *gorm.DB, export *model.DataExport) error {
+	if err := tx.WithContext(ctx).Create(export).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrDataExportPending)
+		}
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+	return nil
+}
+
+func (r *dataExportRepository) FindByID(ctx context.Context, tx *gorm.DB, exportID uuid.UUID) (*model.DataExport, error) {
+	return r.findOne(tx.WithContext(ctx).Where("export_id = ?", exportID), constants.ErrDataExportNotFound)
+}
+
+func (r *dataExportRepository) LockByID(ctx context.Context, tx *gorm.DB, exportID uuid.UUID) (*model.DataExport, error) {
+	return r.findOne(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("export_id = ?", exportID), constants.ErrDataExportNotFound)
+}
+
+func (r *dataExportRepository) LockByDownloadTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.DataExport, error) {
+	return r.findOne(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("download_token_hash = ?", tokenHash), constants.ErrDataExportLinkInvalid)
+}
+
+func (r *dataExportRepository) findOne(query *gorm.DB, notFound string) (*model.DataExport, error) {
+	var export model.DataExport
+	if err := query.First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, notFound)
+		}
+		return nil, fmt.Errorf("failed to find data export: %w", err)
+	}
+	return &export, nil
+}
+
+func (r *dataExportRepository) ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, limit int) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("created_at DESC").Limit(limit).Find(&exports).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data exports: %w", err)
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) Update(ctx context.Context, tx *gorm.DB, export *model.DataExport) error {
+	if err := tx.WithContext(ctx).Save(export).Error; err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"app/src/model"
+	"app/src/utils"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DelegatedActionRepository defines the interface for delegated action audit data access
+type DelegatedActionRepository interface {
+	// Create records a request made by a delegate for a principal
+	Create(ctx context.Context, tx *gorm.DB, action *model.DelegatedAction) error
+
+	// List retrieves one page of delegated actions matching the filter, fetching one extra row to detect further pages
+	List(ctx context.Context, tx *gorm.DB, filter DelegatedActionFilter) ([]model.DelegatedAction, error)
+}
+
+// DelegatedActionFilter narrows a listing to the actions performed for a principal or by a
+// delegate, and optionally under one grant
+type DelegatedActionFilter struct {
+	PrincipalID *uuid.UUID
+	DelegateID  *uuid.UUID
+	GrantID     *uuid.UUID
+	Cursor      *utils.Cursor
+	Limit       int
+}
+
+type delegatedActionRepository struct {
+	db *gorm.DB
+}
+
+// NewDelegatedActionRepository creates a new instance of DelegatedActionRepository
+func NewDelegatedActionRepository(db *gorm.DB) DelegatedActionRepository {
+	return &delegatedActionRepository{db: db}
+}
+
+func (r *delegatedActionRepository) Create(ctx context.Context, tx *gorm.DB, action *model.DelegatedAction) error {
+	if err := tx.WithContext(ctx).Create(action).Error; err != nil {
+		return fmt.Errorf("failed to record delegated action: %w", err)
+	}
+	return nil
+}
+
+func (r *delegatedActionRepository) List(ctx context.Context, tx *gorm.DB, filter DelegatedActionFilter) ([]model.DelegatedAction, error) {
+	query := tx.WithContext(ctx)
+
+	if filter.PrincipalID != nil {
+		query = query.Where("principal_id = ?", *filter.PrincipalID)
+	}
+	if filter.DelegateID != nil {
+		query = query.Where("delegate_id = ?", *filter.DelegateID)
+	}
+	if filter.GrantID != nil {
+		query = query.Where("grant_id = ?", *filter.GrantID)
+	}
+	if filter.Cursor != nil {
+		query = query.Where("(performed_at, action_id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	var actions []model.DelegatedAction
+	err := query.Order("performed_at DESC, action_id DESC").Limit(filter.Limit + 1).Find(&actions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve delegated actions: %w", err)
+	}
+	return actions, nil
+}
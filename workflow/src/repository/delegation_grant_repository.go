@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DelegationGrantRepository defines the interface for delegation grant data access
+type DelegationGrantRepository interface {
+	// Create creates a delegation grant
+	Create(ctx context.Context, tx *gorm.DB, grant *model.DelegationGrant) error
+
+	// LockByIDForPrincipal finds a delegation grant made by the given principal and locks it for update
+	LockByIDForPrincipal(ctx context.Context, tx *gorm.DB, grantID, principalID uuid.UUID) (*model.DelegationGrant, error)
+
+	// FindActive finds the grants of a principal to a delegate that are in force at the given time
+	FindActive(ctx context.Context, tx *gorm.DB, principalID, delegateID uuid.UUID, at time.Time) ([]model.DelegationGrant, error)
+
+	// List retrieves one page of grants matching the filter, fetching one extra row to detect further pages
+	List(ctx context.Context, tx *gorm.DB, filter DelegationGrantFilter) ([]model.DelegationGrant, error)
+
+	// Revoke marks a delegation grant as revoked
+	Revoke(ctx context.Context, tx *gorm.DB, grantID uuid.UUID, revokedAt time.Time) error
+}
+
+// DelegationGrantFilter narrows a grant listing to grants made by a principal or received by a delegate
+type DelegationGrantFilter struct {
+	PrincipalID *uuid.UUID
+	DelegateID  *uuid.UUID
+	Status      string
+	At          time.Time
+	Cursor      *utils.Cursor
+	Limit       int
+}
+
+type delegationGrantRepository struct {
+	db *gorm.DB
+}
+
+// NewDelegationGrantRepository creates a new instance of DelegationGrantRepository
+func NewDelegationGrantRepository(db *gorm.DB) DelegationGrantRepository {
+	return &delegationGrantRepository{db: db}
+}
+
+func (r *delegationGrantRepository) Create(ctx context.Context, tx *gorm.DB, grant *model.DelegationGrant) error {
+	if err := tx.WithContext(ctx).Create(grant).Error; err != nil {
+		return fmt.Errorf("failed to create delegation grant: %w", err)
+	}
+	return nil
+}
+
+func (r *delegationGrantRepository) LockByIDForPrincipal(ctx context.Context, tx *gorm.DB, grantID, principalID uuid.UUID) (*model.DelegationGrant, error) {
+	var grant model.DelegationGrant
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("grant_id = ? AND principal_id = ?", grantID, principalID).First(&grant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrDelegationGrantNotFound)
+		}
+		return nil, fmt.Errorf("failed to find delegation grant: %w", err)
+	}
+	return &grant, nil
+}
+
+func (r *delegationGrantRepository) FindActive(ctx context.Context, tx *gorm.DB, principalID, delegateID uuid.UUID, at time.Time) ([]model.DelegationGrant, error) {
+	var grants []model.DelegationGrant
+	err := tx.WithContext(ctx).
+		Where("principal_id = ? AND delegate_id = ?", principalID, delegateID).
+		Where("revoked_at IS NULL AND valid_from <= ? AND expires_at > ?", at, at).
+		Order("created_at").Find(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delegation grants: %w", err)
+	}
+	return grants, nil
+}
+
+func (r *delegationGrantRepository) List(ctx context.Context, tx *gorm.DB, filter DelegationGrantFilter) ([]model.DelegationGrant, error) {
+	query := tx.WithContext(ctx)
+
+	if filter.PrincipalID != nil {
+		query = query.Where("principal_id = ?", *filter.PrincipalID)
+	}
+	if filter.DelegateID != nil {
+		query = query.Where("delegate_id = ?", *filter.DelegateID)
+	}
+	switch filter.Status {
+	case constants.DelegationGrantStatusScheduled:
+		query = query.Where("revoked_at IS NULL AND valid_from > ? AND expires_at > ?", filter.At, filter.At)
+	case constants.DelegationGrantStatusActive:
+		query = query.Where("revoked_at IS NULL AND valid_from <= ? AND expires_at > ?", filter.At, filter.At)
+	case constants.DelegationGrantStatusExpired:
+		query = query.Where("revoked_at IS NULL AND expires_at <= ?", filter.At)
+	case constants.DelegationGrantStatusRevoked:
+		query = query.Where("revoked_at IS NOT NULL")
+	}
+	if filter.Cursor != nil {
+		query = query.Where("(created_at, grant_id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	var grants []model.DelegationGrant
+	err := query.Order("created_at DESC, grant_id DESC").Limit(filter.Limit + 1).Find(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve delegation grants: %w", err)
+	}
+	return grants, nil
+}
+
+func (r *delegationGrantRepository) Revoke(ctx context.Context, tx *gorm.DB, grantID uuid.UUID, revokedAt time.Time) error {
+	result := tx.WithContext(ctx).Model(&model.DelegationGrant{}).
+		Where("grant_id = ? AND revoked_at IS NULL", grantID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke delegation grant: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusConflict, constants.ErrDelegationGrantAlreadyRevoked)
+	}
+	return nil
+}
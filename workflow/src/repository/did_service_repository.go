@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DIDServiceRepository defines the interface for DID document service endpoint data access
+type DIDServiceRepository interface {
+	// Create registers a service endpoint of an actor
+	Create(ctx context.Context, tx *gorm.DB, service *model.DIDService) error
+
+	// ListByActorID lists the service endpoints of an actor, oldest first
+	ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.DIDService, error)
+
+	// Delete removes the service endpoint of an actor with the given fragment
+	Delete(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, fragment string) error
+}
+
+type didServiceRepository struct {
+	db *gorm.DB
+}
+
+// NewDIDServiceRepository creates a new instance of DIDServiceRepository
+func NewDIDServiceRepository(db *gorm.DB) DIDServiceRepository {
+	return &didServiceRepository{db: db}
+}
+
+func (r *didServiceRepository) Create(ctx context.Context, tx *gorm.DB, service *model.DIDService) error {
+	if err := tx.WithContext(ctx).Create(service).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrDIDServiceExists)
+		}
+		return fmt.Errorf("failed to create DID service: %w", err)
+	}
+	return nil
+}
+
+func (r *didServiceRepository) ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.DIDService, error) {
+	var services []model.DIDService
+	err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("created_at ASC").Find(&services).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DID services: %w", err)
+	}
+	return services, nil
+}
+
+func (r *didServiceRepository) Delete(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, fragment string) error {
+	result := tx.WithContext(ctx).Where("actor_id = ? AND fragment = ?", actorID, fragment).Delete(&model.DIDService{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete DID service: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrDIDServiceNotFound)
+	}
+	return nil
+}
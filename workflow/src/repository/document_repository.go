@@ -17,6 +17,12 @@ type DocumentRepository interface {
 	// FindByID finds a document by ID
 	FindByID(ctx context.Context, tx *gorm.DB, documentID uuid.UUID) (*model.Document, error)
 
+	// FindByIDForAccount finds a document by ID owned by the given account
+	FindByIDForAccount(ctx context.Context, tx *gorm.DB, documentID, accountID uuid.UUID) (*model.Document, error)
+
+	// FindByIDsForAccount finds the documents with the given IDs owned by the given account
+	FindByIDsForAccount(ctx context.Context, tx *gorm.DB, documentIDs []uuid.UUID, accountID uuid.UUID) ([]model.Document, error)
+
 	// FindByPath finds a document by its path
 	FindByPath(ctx context.Context, tx *gorm.DB, path string) (*model.Document, error)
 
@@ -48,6 +54,26 @@ func (r *documentRepository) FindByID(ctx context.Context, tx *gorm.DB, document
 	return &document, nil
 }
 
+func (r *documentRepository) FindByIDForAccount(ctx context.Context, tx *gorm.DB, documentID, accountID uuid.UUID) (*model.Document, error) {
+	var document model.Document
+	if err := tx.WithContext(ctx).Where("document_id = ? AND account_id = ?", documentID, accountID).First(&document).Error; err != nil {
+		return nil, fmt.Errorf("failed to find document by ID: %w", err)
+	}
+	return &document, nil
+}
+
+func (r *documentRepository) FindByIDsForAccount(ctx context.Context, tx *gorm.DB, documentIDs []uuid.UUID, accountID uuid.UUID) ([]model.Document, error) {
+	var documents []model.Document
+	err := tx.WithContext(ctx).
+		Where("document_id IN ? AND account_id = ?", documentIDs, accountID).
+		Order("uploaded_at, document_id").
+		Find(&documents).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	return documents, nil
+}
+
 func (r *documentRepository) FindByPath(ctx context.Context, tx *gorm.DB, path string) (*model.Document, error) {
 	var document model.Document
 	if err := tx.WithContext(ctx).Where("storage_path = ?", path).First(&document).Error; err != nil {
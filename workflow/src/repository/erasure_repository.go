@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"app/src/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErasureRepository defines the interface for erasing the records of an actor across tables when
+// the account is deleted. Records fall into two groups: account data, which is always deleted, and
+// retained data, which a retention hold keeps and the actor row of which is pseudonymized instead.
+// Counts are keyed by record category for the erasure summary.
+type ErasureRepository interface {
+	// FindDocuments returns the documents uploaded by an actor, whose stored files must be deleted
+	// along with the records
+	FindDocuments(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.Document, error)
+
+	// FindDataExports returns the data exports of an actor, whose stored bundles must be deleted
+	// along with the records
+	FindDataExports(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.DataExport, error)
+
+	// DeleteAccountData deletes the integrations, DID services, webhooks, share grants, credential
+	// offers, presentation requests, key recoveries, data exports, owned identifier domains and
+	// queued jobs of an actor. Jobs of the type excluded, such as the running erasure job, are kept.
+	DeleteAccountData(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, excludedJobType string) (map[string]int64, error)
+
+	// DeleteRetainedData deletes the credentials, documents, verification level history, share
+	// access logs, master key history and the actor row of an actor
+	DeleteRetainedData(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (map[string]int64, error)
+
+	// PseudonymizeActor replaces the personal data on the actor row with placeholders, keeping the
+	// DID and key so that retained records can still be verified
+	PseudonymizeActor(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, email string) error
+}
+
+type erasureRepository struct {
+	db *gorm.DB
+}
+
+// NewErasureRepository creates a new instance of ErasureRepository
+func NewErasureRepository(db *gorm.DB) ErasureRepository {
+	return &erasureRepository{db: db}
+}
+
+// erasureStep deletes the records of one category
+type erasureStep struct {
+	category string
+	model    interface{}
+	where    string
+	args     []interface{}
+}
+
+func (r *erasureRepository) FindDocuments(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.Document, error) {
+	var documents []model.Document
+	if err := tx.WithContext(ctx).Where("account_id = ?", actorID).Find(&documents).Error; err != nil {
+		return nil, fmt.Errorf("failed to find documents of actor: %w", err)
+	}
+	return documents, nil
+}
+
+func (r *erasureRepository) FindDataExports(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.DataExport, error) {
+	var exports []model.DataExport
+	if err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Find(&exports).Error; err != nil {
+		return nil, fmt.Errorf("failed to find data exports of actor: %w", err)
+	}
+	return exports, nil
+}
+
+func (r *erasureRepository) DeleteAccountData(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, excludedJobType string) (map[string]int64, error) {
+	grants := tx.Model(&model.ShareGrant{}).Select("grant_id").Where("owner_id = ? OR grantee_id = ?", actorID, actorID)
+
+	return r.run(ctx, tx, []erasureStep{
+		{"integrations", &model.ActorIntegration{}, "actor_id = ?", []interface{}{actorID}},
+		{"didServices", &model.DIDService{}, "actor_id = ?", []interface{}{actorID}},
+		{"webhookDeliveries", &model.WebhookDelivery{}, "owner_id = ?", []interface{}{actorID}},
+		{"webhookSubscriptions", &model.WebhookSubscription{}, "owner_id = ?", []interface{}{actorID}},
+		{"shareGrantTokens", &model.ShareGrantToken{}, "grant_id IN (?)", []interface{}{grants}},
+		{"shareGrants", &model.ShareGrant{}, "owner_id = ? OR grantee_id = ?", []interface{}{actorID, actorID}},
+		{"credentialOffers", &model.CredentialOffer{}, "actor_id = ?", []interface{}{actorID}},
+		{"presentationRequests", &model.PresentationRequest{}, "actor_id = ?", []interface{}{actorID}},
+		{"keyRecoveries", &model.KeyRecovery{}, "actor_id = ?", []interface{}{actorID}},
+		{"dataExports", &model.DataExport{}, "actor_id = ?", []interface{}{actorID}},
+		{"verificationCodes", &model.VerificationCode{}, "actor_id = ?", []interface{}{actorID}},
+		{"organizationMembers", &model.OrganizationMember{}, "organization_id = ? OR actor_id = ?", []interface{}{actorID, actorID}},
+		{"organizationInvitations", &model.OrganizationInvitation{}, "organization_id = ? OR invitee_id = ?", []interface{}{actorID, actorID}},
+		{"delegationGrants", &model.DelegationGrant{}, "principal_id = ? OR delegate_id = ?", []interface{}{actorID, actorID}},
+		{"namespaceDomains", &model.NamespaceDomain{}, "owner_actor_id = ?", []interface{}{actorID}},
+		{"jobs", &model.Job{}, "actor_id = ? AND type <> ?", []interface{}{actorID, excludedJobType}},
+	})
+}
+
+func (r *erasureRepository) DeleteRetainedData(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (map[string]int64, error) {
+	tokens := tx.Model(&model.Token{}).Select("token_id").Where("account_id = ?", actorID)
+
+	return r.run(ctx, tx, []erasureStep{
+		{"credentialDocuments", &model.TokenDocument{}, "token_id IN (?)", []interface{}{tokens}},
+		{"credentials", &model.Token{}, "account_id = ?", []interface{}{actorID}},
+		{"documents", &model.Document{}, "account_id = ?", []interface{}{actorID}},
+		{"verificationLevelHistory", &model.VerificationLevelHistory{}, "actor_id = ?", []interface{}{actorID}},
+		{"shareAccessLogs", &model.ShareAccessLog{}, "owner_id = ? OR grantee_id = ?", []interface{}{actorID, actorID}},
+		{"delegatedActions", &model.DelegatedAction{}, "principal_id = ? OR delegate_id = ?", []interface{}{actorID, actorID}},
+		{"masterKeys", &model.ActorKey{}, "actor_id = ?", []interface{}{actorID}},
+		{"actor", &model.Actor{}, "actor_id = ?", []interface{}{actorID}},
+	})
+}
+
+func (r *erasureRepository) run(ctx context.Context, tx *gorm.DB, steps []erasureStep) (map[string]int64, error) {
+	counts := make(map[string]int64, len(steps))
+	for _, step := range steps {
+		result := tx.WithContext(ctx).Where(step.where, step.args...).Delete(step.model)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to erase %s: %w", step.category, result.Error)
+		}
+		counts[step.category] = result.RowsAffected
+	}
+	return counts, nil
+}
+
+func (r *erasureRepository) PseudonymizeActor(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, email string) error {
+	err := tx.WithContext(ctx).Model(&model.Actor{}).Where("actor_id = ?", actorID).
+		Updates(map[string]interface{}{
+			"email":                    email,
+			"first_name":               "",
+			"last_name":                "",
+			"phone_number":             nil,
+			"email_verified":           false,
+			"email_verified_at":        nil,
+			"phone_verified":           false,
+			"phone_verified_at":        nil,
+			"nationality":              nil,
+			"country_of_residence":     nil,
+			"country_of_incorporation": nil,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to pseudonymize actor: %w", err)
+	}
+	return nil
+}
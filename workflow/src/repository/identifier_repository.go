@@ -6,10 +6,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IdentifierRepository defines the interface for identifier data access operations
@@ -20,11 +22,46 @@ type IdentifierRepository interface {
 	// FindByValue finds an identifier by its value
 	FindByValue(ctx context.Context, tx *gorm.DB, identifierValue string) (*model.Identifier, error)
 
-	// FindByActorID finds an identifier by actor ID
+	// FindByActorID finds the primary identifier of an actor, or nil if it has none
 	FindByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.Identifier, error)
 
-	// ExistsWithIdentifier checks if an identifier already exists
-	ExistsWithIdentifier(ctx context.Context, tx *gorm.DB, identifier string) (bool, error)
+	// ResolveMany finds the actors holding the given identifiers in a single query. Identifiers
+	// nobody holds are missing from the result.
+	ResolveMany(ctx context.Context, tx *gorm.DB, identifiers []string) ([]model.ResolvedIdentifier, error)
+
+	// ListByActorID lists the identifiers of an actor, primary first
+	ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.Identifier, error)
+
+	// ExistsWithSkeleton checks if an identifier confusable with the given skeleton already exists
+	ExistsWithSkeleton(ctx context.Context, tx *gorm.DB, skeleton string) (bool, error)
+
+	// SetPrimary makes the given identifier of an actor its only primary identifier
+	SetPrimary(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, identifier string) error
+
+	// Release deletes an identifier and reserves it for its former holder until availableAt
+	Release(ctx context.Context, tx *gorm.DB, identifier *model.Identifier, releasedAt, availableAt time.Time) (*model.ReleasedIdentifier, error)
+
+	// IsReserved checks if an identifier confusable with the given skeleton was released by an
+	// entity other than claimantID and is still in its cooling-off period at the given time
+	IsReserved(ctx context.Context, tx *gorm.DB, skeleton string, claimantID uuid.UUID, at time.Time) (bool, error)
+
+	// ListAfter retrieves up to limit identifiers ordered by value, starting after the given one
+	ListAfter(ctx context.Context, tx *gorm.DB, after string, limit int) ([]model.Identifier, error)
+
+	// ListReleasedAfter retrieves up to limit released identifiers ordered by value, starting after
+	// the given one
+	ListReleasedAfter(ctx context.Context, tx *gorm.DB, after string, limit int) ([]model.ReleasedIdentifier, error)
+
+	// UpdateCanonical replaces an identifier with its canonical form and sets its skeleton
+	UpdateCanonical(ctx context.Context, tx *gorm.DB, identifier, canonical, skeleton string) error
+
+	// UpdateReleasedCanonical replaces a released identifier with its canonical form and sets its
+	// skeleton
+	UpdateReleasedCanonical(ctx context.Context, tx *gorm.DB, identifier, canonical, skeleton string) error
+
+	// EnforceSkeletons requires every identifier and released identifier to have a skeleton, and
+	// identifiers to be unique by it. It fails while any two identifiers share a skeleton.
+	EnforceSkeletons(ctx context.Context, tx *gorm.DB) error
 }
 
 type identifierRepository struct {
@@ -58,7 +95,7 @@ func (r *identifierRepository) FindByValue(ctx context.Context, tx *gorm.DB, ide
 func (r *identifierRepository) FindByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*model.Identifier, error) {
 	var identifier model.Identifier
 	err := tx.WithContext(ctx).
-		Where("entity_id = ? AND entity_type = ?", actorID, constants.EntityTypeActor).
+		Where("entity_id = ? AND entity_type = ? AND is_primary", actorID, constants.EntityTypeActor).
 		First(&identifier).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -69,15 +106,152 @@ func (r *identifierRepository) FindByActorID(ctx context.Context, tx *gorm.DB, a
 	return &identifier, nil
 }
 
-func (r *identifierRepository) ExistsWithIdentifier(ctx context.Context, tx *gorm.DB, identifier string) (bool, error) {
-	if identifier == "" {
+func (r *identifierRepository) ExistsWithSkeleton(ctx context.Context, tx *gorm.DB, skeleton string) (bool, error) {
+	if skeleton == "" {
 		return false, nil
 	}
 
 	var count int64
-	err := tx.WithContext(ctx).Model(&model.Identifier{}).Where("identifier = ?", identifier).Count(&count).Error
+	err := tx.WithContext(ctx).Model(&model.Identifier{}).Where("skeleton = ?", skeleton).Count(&count).Error
 	if err != nil {
 		return false, fmt.Errorf("failed to check identifier existence: %w", err)
 	}
 	return count > 0, nil
 }
+
+func (r *identifierRepository) ResolveMany(ctx context.Context, tx *gorm.DB, identifiers []string) ([]model.ResolvedIdentifier, error) {
+	var resolved []model.ResolvedIdentifier
+	if len(identifiers) == 0 {
+		return resolved, nil
+	}
+
+	err := tx.WithContext(ctx).
+		Table(constants.TableNameIdentifiers+" AS i").
+		Select("i.identifier, a.actor_id, a.did, a.master_public_key").
+		Joins("JOIN "+constants.TableNameActors+" AS a ON a.actor_id = i.entity_id").
+		Where("i.entity_type = ? AND i.identifier IN ?", constants.EntityTypeActor, identifiers).
+		Scan(&resolved).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve identifiers: %w", err)
+	}
+	return resolved, nil
+}
+
+func (r *identifierRepository) ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.Identifier, error) {
+	var identifiers []model.Identifier
+	err := tx.WithContext(ctx).
+		Where("entity_id = ? AND entity_type = ?", actorID, constants.EntityTypeActor).
+		Order("is_primary DESC, created_at ASC").
+		Find(&identifiers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identifiers: %w", err)
+	}
+	return identifiers, nil
+}
+
+func (r *identifierRepository) SetPrimary(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, identifier string) error {
+	err := tx.WithContext(ctx).Model(&model.Identifier{}).
+		Where("entity_id = ? AND entity_type = ? AND is_primary AND identifier <> ?", actorID, constants.EntityTypeActor, identifier).
+		Update("is_primary", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear primary identifier: %w", err)
+	}
+
+	result := tx.WithContext(ctx).Model(&model.Identifier{}).
+		Where("identifier = ? AND entity_id = ? AND entity_type = ?", identifier, actorID, constants.EntityTypeActor).
+		Update("is_primary", true)
+	if result.Error != nil {
+		return fmt.Errorf("failed to set primary identifier: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrIdentifierNotFound)
+	}
+	return nil
+}
+
+func (r *identifierRepository) Release(ctx context.Context, tx *gorm.DB, identifier *model.Identifier, releasedAt, availableAt time.Time) (*model.ReleasedIdentifier, error) {
+	if err := tx.WithContext(ctx).Delete(identifier).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete identifier: %w", err)
+	}
+
+	released := &model.ReleasedIdentifier{
+		Identifier:  identifier.Identifier,
+		EntityType:  identifier.EntityType,
+		EntityID:    identifier.EntityID,
+		ReleasedAt:  releasedAt,
+		AvailableAt: availableAt,
+		Skeleton:    identifier.Skeleton,
+	}
+	// An identifier released before and claimed again replaces its earlier reservation
+	err := tx.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(released).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve released identifier: %w", err)
+	}
+	return released, nil
+}
+
+func (r *identifierRepository) IsReserved(ctx context.Context, tx *gorm.DB, skeleton string, claimantID uuid.UUID, at time.Time) (bool, error) {
+	var released []model.ReleasedIdentifier
+	err := tx.WithContext(ctx).Where("skeleton = ?", skeleton).Find(&released).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check released identifier: %w", err)
+	}
+	for i := range released {
+		if released[i].ReservedAgainst(claimantID, at) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *identifierRepository) ListAfter(ctx context.Context, tx *gorm.DB, after string, limit int) ([]model.Identifier, error) {
+	var identifiers []model.Identifier
+	err := tx.WithContext(ctx).Where("identifier > ?", after).Order("identifier").Limit(limit).Find(&identifiers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identifiers: %w", err)
+	}
+	return identifiers, nil
+}
+
+func (r *identifierRepository) ListReleasedAfter(ctx context.Context, tx *gorm.DB, after string, limit int) ([]model.ReleasedIdentifier, error) {
+	var released []model.ReleasedIdentifier
+	err := tx.WithContext(ctx).Where("identifier > ?", after).Order("identifier").Limit(limit).Find(&released).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list released identifiers: %w", err)
+	}
+	return released, nil
+}
+
+func (r *identifierRepository) UpdateCanonical(ctx context.Context, tx *gorm.DB, identifier, canonical, skeleton string) error {
+	return r.updateCanonical(tx.WithContext(ctx).Model(&model.Identifier{}), identifier, canonical, skeleton)
+}
+
+func (r *identifierRepository) UpdateReleasedCanonical(ctx context.Context, tx *gorm.DB, identifier, canonical, skeleton string) error {
+	return r.updateCanonical(tx.WithContext(ctx).Model(&model.ReleasedIdentifier{}), identifier, canonical, skeleton)
+}
+
+func (r *identifierRepository) updateCanonical(query *gorm.DB, identifier, canonical, skeleton string) error {
+	err := query.Where("identifier = ?", identifier).
+		Updates(map[string]interface{}{"identifier": canonical, "skeleton": skeleton}).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrUniversalIdentifierAlreadyInUse)
+		}
+		return fmt.Errorf("failed to update identifier: %w", err)
+	}
+	return nil
+}
+
+func (r *identifierRepository) EnforceSkeletons(ctx context.Context, tx *gorm.DB) error {
+	statements := []string{
+		"ALTER TABLE " + constants.TableNameIdentifiers + " ALTER COLUMN skeleton SET NOT NULL",
+		"CREATE UNIQUE INDEX IF NOT EXISTS idx_identifiers_skeleton ON " + constants.TableNameIdentifiers + "(skeleton)",
+		"ALTER TABLE " + constants.TableNameReleasedIDs + " ALTER COLUMN skeleton SET NOT NULL",
+	}
+	for _, statement := range statements {
+		if err := tx.WithContext(ctx).Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to enforce identifier skeletons: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrJobLeaseLost is returned by Complete when the job is no longer held by the worker, because
+// its lease expired and another worker reclaimed it
+var ErrJobLeaseLost = errors.New("job lease lost")
+
+// JobRepository defines the interface for background job queue data access
+type JobRepository interface {
+	// Create enqueues a new job
+	Create(ctx context.Context, tx *gorm.DB, job *model.Job) error
+
+	// FindByIDForActor finds a job by ID owned by the given actor
+	FindByIDForActor(ctx context.Context, tx *gorm.DB, jobID, actorID uuid.UUID) (*model.Job, error)
+
+	// ClaimNext locks the next runnable job of the given types, skipping rows locked by other workers,
+	// and marks it running. Running jobs whose lease started before leaseCutoff are reclaimed, or
+	// dead-lettered when they have no attempts left. It returns nil when no job is runnable.
+	ClaimNext(ctx context.Context, tx *gorm.DB, types []string, workerID string, now, leaseCutoff time.Time) (*model.Job, error)
+
+	// Update updates a job in the database
+	Update(ctx context.Context, tx *gorm.DB, job *model.Job) error
+
+	// Complete records the outcome of an attempt if workerID still holds the job's lease, and
+	// returns ErrJobLeaseLost otherwise
+	Complete(ctx context.Context, tx *gorm.DB, job *model.Job, workerID string) error
+}
+
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new instance of JobRepository
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) Create(ctx context.Context, tx *gorm.DB, job *model.Job) error {
+	if err := tx.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRepository) FindByIDForActor(ctx context.Context, tx *gorm.DB, jobID, actorID uuid.UUID) (*model.Job, error) {
+	var job model.Job
+	err := tx.WithContext(ctx).Where("job_id = ? AND actor_id = ?", jobID, actorID).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrJobNotFound)
+		}
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+	return &job, nil
+}
+
+func (r *jobRepository) ClaimNext(ctx context.Context, tx *gorm.DB, types []string, workerID string, now, leaseCutoff time.Time) (*model.Job, error) {
+	for {
+		var job model.Job
+		err := tx.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("type IN ?", types).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_at < ?)",
+				constants.JobStatusQueued, now, constants.JobStatusRunning, leaseCutoff).
+			Order("run_at, job_id").
+			First(&job).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil // An empty queue is the normal idle state
+			}
+			return nil, fmt.Errorf("failed to claim job: %w", err)
+		}
+
+		// The worker holding an expired lease on the last attempt never recorded an outcome
+		if job.Status == constants.JobStatusRunning && job.Attempts >= job.MaxAttempts {
+			lastError := constants.ErrJobLeaseExpired
+			job.Status = constants.JobStatusDead
+			job.LastError = &lastError
+			job.LockedBy = nil
+			job.LockedAt = nil
+			job.CompletedAt = &now
+			if err := r.Update(ctx, tx, &job); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		job.Status = constants.JobStatusRunning
+		job.Attempts++
+		job.LockedBy = &workerID
+		job.LockedAt = &now
+		if err := r.Update(ctx, tx, &job); err != nil {
+			return nil, err
+		}
+		return &job, nil
+	}
+}
+
+func (r *jobRepository) Update(ctx context.Context, tx *gorm.DB, job *model.Job) error {
+	if err := tx.WithContext(ctx).Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRepository) Complete(ctx context.Context, tx *gorm.DB, job *model.Job, workerID string) error {
+	result := tx.WithContext(ctx).Model(job).
+		Where("locked_by = ? AND status = ?", workerID, constants.JobStatusRunning).
+		Select("*").
+		Updates(job)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrJobLeaseLost
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// KeyChallengeRepository defines the interface for key possession challenge data access
+type KeyChallengeRepository interface {
+	// Create stores an issued challenge
+	Create(ctx context.Context, tx *gorm.DB, challenge *model.KeyChallenge) error
+
+	// Consume marks an unexpired, unused challenge for the given purpose as used. Each challenge
+	// can be consumed once; any other nonce is rejected with 400.
+	Consume(ctx context.Context, tx *gorm.DB, nonce, purpose string, now time.Time) error
+
+	// DeleteExpired removes challenges that expired before the given time
+	DeleteExpired(ctx context.Context, tx *gorm.DB, before time.Time) error
+}
+
+type keyChallengeRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyChallengeRepository creates a new instance of KeyChallengeRepository
+func NewKeyChallengeRepository(db *gorm.DB) KeyChallengeRepository {
+	return &keyChallengeRepository{db: db}
+}
+
+func (r *keyChallengeRepository) Create(ctx context.Context, tx *gorm.DB, challenge *model.KeyChallenge) error {
+	if err := tx.WithContext(ctx).Create(challenge).Error; err != nil {
+		return fmt.Errorf("failed to create challenge: %w", err)
+	}
+	return nil
+}
+
+func (r *keyChallengeRepository) Consume(ctx context.Context, tx *gorm.DB, nonce, purpose string, now time.Time) error {
+	result := tx.WithContext(ctx).Model(&model.KeyChallenge{}).
+		Where("nonce = ? AND purpose = ? AND consumed_at IS NULL AND expires_at > ?", nonce, purpose, now).
+		Update("consumed_at", now)
+	if result.Error != nil {
+		return fmt.Errorf("failed to consume challenge: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidChallenge)
+	}
+	return nil
+}
+
+func (r *keyChallengeRepository) DeleteExpired(ctx context.Context, tx *gorm.DB, before time.Time) error {
+	if err := tx.WithContext(ctx).Where("expires_at < ?", before).Delete(&model.KeyChallenge{}).Error; err != nil {
+		return fmt.Errorf("failed to delete expired challenges: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KeyRecoveryRepository defines the interface for master key recovery data access
+type KeyRecoveryRepository interface {
+	// Create records a pending key recovery; an actor can only have one pending at a time
+	Create(ctx context.Context, tx *gorm.DB, recovery *model.KeyRecovery) error
+
+	// LockByID finds a key recovery by ID and locks it for the rest of the transaction
+	LockByID(ctx context.Context, tx *gorm.DB, recoveryID uuid.UUID) (*model.KeyRecovery, error)
+
+	// Complete marks a pending key recovery as completed at the given time
+	Complete(ctx context.Context, tx *gorm.DB, recoveryID uuid.UUID, at time.Time) error
+
+	// CancelPending cancels the pending key recovery of an actor, if any
+	CancelPending(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (bool, error)
+}
+
+type keyRecoveryRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyRecoveryRepository creates a new instance of KeyRecoveryRepository
+func NewKeyRecoveryRepository(db *gorm.DB) KeyRecoveryRepository {
+	return &keyRecoveryRepository{db: db}
+}
+
+func (r *keyRecoveryRepository) Create(ctx context.Context, tx *gorm.DB, recovery *model.KeyRecovery) error {
+	if err := tx.WithContext(ctx).Create(recovery).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrKeyRecoveryPending)
+		}
+		return fmt.Errorf("failed to create key recovery: %w", err)
+	}
+	return nil
+}
+
+func (r *keyRecoveryRepository) LockByID(ctx context.Context, tx *gorm.DB, recoveryID uuid.UUID) (*model.KeyRecovery, error) {
+	var recovery model.KeyRecovery
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("recovery_id = ?", recoveryID).First(&recovery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+		}
+		return nil, fmt.Errorf("failed to lock key recovery: %w", err)
+	}
+	return &recovery, nil
+}
+
+func (r *keyRecoveryRepository) Complete(ctx context.Context, tx *gorm.DB, recoveryID uuid.UUID, at time.Time) error {
+	err := tx.WithContext(ctx).Model(&model.KeyRecovery{}).
+		Where("recovery_id = ? AND status = ?", recoveryID, constants.KeyRecoveryStatusPending).
+		Updates(map[string]interface{}{
+			"status":       constants.KeyRecoveryStatusCompleted,
+			"completed_at": at,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to complete key recovery: %w", err)
+	}
+	return nil
+}
+
+func (r *keyRecoveryRepository) CancelPending(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (bool, error) {
+	result := tx.WithContext(ctx).Model(&model.KeyRecovery{}).
+		Where("actor_id = ? AND status = ?", actorID, constants.KeyRecoveryStatusPending).
+		Update("status", constants.KeyRecoveryStatusCancelled)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to cancel key recovery: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// NamespaceRepository defines the interface for identifier namespace data access: the domains
+// registered to organizations and the reserved and blocked word list
+type NamespaceRepository interface {
+	// CreateDomain registers an identifier domain
+	CreateDomain(ctx context.Context, tx *gorm.DB, domain *model.NamespaceDomain) error
+
+	// FindDomain finds a registered identifier domain
+	FindDomain(ctx context.Context, tx *gorm.DB, domain string) (*model.NamespaceDomain, error)
+
+	// ListDomains lists the registered identifier domains by name
+	ListDomains(ctx context.Context, tx *gorm.DB) ([]model.NamespaceDomain, error)
+
+	// DeleteDomain removes a registered identifier domain
+	DeleteDomain(ctx context.Context, tx *gorm.DB, domain string) error
+
+	// CreateWord adds a word to the reserved and blocked word list
+	CreateWord(ctx context.Context, tx *gorm.DB, word *model.ReservedWord) error
+
+	// ListWords lists the reserved and blocked words by word
+	ListWords(ctx context.Context, tx *gorm.DB) ([]model.ReservedWord, error)
+
+	// DeleteWord removes the word with the given skeleton from the list
+	DeleteWord(ctx context.Context, tx *gorm.DB, skeleton string) error
+
+	// FindMatchingWord finds a word an identifier local part with the given word skeleton may not
+	// use: a reserved word equal to it or a blocked word within it. It returns nil if there is none.
+	FindMatchingWord(ctx context.Context, tx *gorm.DB, skeleton string) (*model.ReservedWord, error)
+}
+
+type namespaceRepository struct {
+	db *gorm.DB
+}
+
+// NewNamespaceRepository creates a new instance of NamespaceRepository
+func NewNamespaceRepository(db *gorm.DB) NamespaceRepository {
+	return &namespaceRepository{db: db}
+}
+
+func (r *namespaceRepository) CreateDomain(ctx context.Context, tx *gorm.DB, domain *model.NamespaceDomain) error {
+	if err := tx.WithContext(ctx).Create(domain).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrNamespaceDomainExists)
+		}
+		return fmt.Errorf("failed to create namespace domain: %w", err)
+	}
+	return nil
+}
+
+func (r *namespaceRepository) FindDomain(ctx context.Context, tx *gorm.DB, domain string) (*model.NamespaceDomain, error) {
+	var namespaceDomain model.NamespaceDomain
+	err := tx.WithContext(ctx).Where("domain = ?", domain).First(&namespaceDomain).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrNamespaceDomainNotFound)
+		}
+		return nil, fmt.Errorf("failed to find namespace domain: %w", err)
+	}
+	return &namespaceDomain, nil
+}
+
+func (r *namespaceRepository) ListDomains(ctx context.Context, tx *gorm.DB) ([]model.NamespaceDomain, error) {
+	var domains []model.NamespaceDomain
+	if err := tx.WithContext(ctx).Order("domain ASC").Find(&domains).Error; err != nil {
+		return nil, fmt.Errorf("failed to list namespace domains: %w", err)
+	}
+	return domains, nil
+}
+
+func (r *namespaceRepository) DeleteDomain(ctx context.Context, tx *gorm.DB, domain string) error {
+	result := tx.WithContext(ctx).Where("domain = ?", domain).Delete(&model.NamespaceDomain{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete namespace domain: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrNamespaceDomainNotFound)
+	}
+	return nil
+}
+
+func (r *namespaceRepository) CreateWord(ctx context.Context, tx *gorm.DB, word *model.ReservedWord) error {
+	if err := tx.WithContext(ctx).Create(word).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrReservedWordExists)
+		}
+		return fmt.Errorf("failed to create reserved word: %w", err)
+	}
+	return nil
+}
+
+func (r *namespaceRepository) ListWords(ctx context.Context, tx *gorm.DB) ([]model.ReservedWord, error) {
+	var words []model.ReservedWord
+	if err := tx.WithContext(ctx).Order("word ASC").Find(&words).Error; err != nil {
+		return nil, fmt.Errorf("failed to list reserved words: %w", err)
+	}
+	return words, nil
+}
+
+func (r *namespaceRepository) DeleteWord(ctx context.Context, tx *gorm.DB, skeleton string) error {
+	result := tx.WithContext(ctx).Where("skeleton = ?", skeleton).Delete(&model.ReservedWord{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete reserved word: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrReservedWordNotFound)
+	}
+	return nil
+}
+
+func (r *namespaceRepository) FindMatchingWord(ctx context.Context, tx *gorm.DB, skeleton string) (*model.ReservedWord, error) {
+	var word model.ReservedWord
+	err := tx.WithContext(ctx).
+		Where("(kind = ? AND skeleton = ?) OR (kind = ? AND strpos(?, skeleton) > 0)",
+			constants.ReservedWordKindReserved, skeleton, constants.ReservedWordKindBlocked, skeleton).
+		Order("kind ASC").
+		First(&word).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to match reserved words: %w", err)
+	}
+	return &word, nil
+}
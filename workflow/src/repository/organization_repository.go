@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OrganizationRepository defines the interface for organization membership and invitation data access
+type OrganizationRepository interface {
+	// CreateMember adds a member to an organization; an actor can only be a member once
+	CreateMember(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error
+
+	// FindMember finds the membership of an actor in an organization
+	FindMember(ctx context.Context, tx *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error)
+
+	// LockMember finds the membership of an actor in an organization and locks it for the rest of
+	// the transaction
+	LockMember(ctx context.Context, tx *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error)
+
+	// LockMembersWithRole finds the members of an organization holding a role and locks them for
+	// the rest of the transaction
+	LockMembersWithRole(ctx context.Context, tx *gorm.DB, organizationID uuid.UUID, role string) ([]model.OrganizationMember, error)
+
+	// ListMembers lists the members of an organization, oldest first
+	ListMembers(ctx context.Context, tx *gorm.DB, organizationID uuid.UUID) ([]model.OrganizationMember, error)
+
+	// ListMemberships lists the organizations an actor is a member of, oldest first
+	ListMemberships(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.OrganizationMember, error)
+
+	// UpdateMember saves every field of a membership
+	UpdateMember(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error
+
+	// DeleteMember removes a member from an organization
+	DeleteMember(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error
+
+	// CreateInvitation records an invitation to join an organization
+	CreateInvitation(ctx context.Context, tx *gorm.DB, invitation *model.OrganizationInvitation) error
+
+	// LockInvitation finds an invitation by ID and locks it for the rest of the transaction
+	LockInvitation(ctx context.Context, tx *gorm.DB, invitationID uuid.UUID) (*model.OrganizationInvitation, error)
+
+	// ExistsPendingInvitation checks whether an organization has an invitation for an actor that
+	// is still pending at the given time
+	ExistsPendingInvitation(ctx context.Context, tx *gorm.DB, organizationID, inviteeID uuid.UUID, at time.Time) (bool, error)
+
+	// ListInvitations lists the most recent invitations of an organization, newest first
+	ListInvitations(ctx context.Context, tx *gorm.DB, organizationID uuid.UUID, limit int) ([]model.OrganizationInvitation, error)
+
+	// ListPendingInvitations lists the invitations of an actor still pending at the given time,
+	// newest first
+	ListPendingInvitations(ctx context.Context, tx *gorm.DB, inviteeID uuid.UUID, at time.Time) ([]model.OrganizationInvitation, error)
+
+	// UpdateInvitation saves every field of an invitation
+	UpdateInvitation(ctx context.Context, tx *gorm.DB, invitation *model.OrganizationInvitation) error
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+// pendingInvitation restricts a query to invitations not answered, revoked or expired at a given time
+const pendingInvitation = "accepted_at IS NULL AND declined_at IS NULL AND revoked_at IS NULL AND expires_at > ?"
+
+func (r *organizationRepository) CreateMember(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error {
+	if err := tx.WithContext(ctx).Create(member).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrAlreadyOrganizationMember)
+		}
+		return fmt.Errorf("failed to create organization member: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) FindMember(ctx context.Context, tx *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error) {
+	return r.findMember(tx.WithContext(ctx), organizationID, actorID)
+}
+
+func (r *organizationRepository) LockMember(ctx context.Context, tx *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error) {
+	return r.findMember(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), organizationID, actorID)
+}
+
+func (r *organizationRepository) findMember(query *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error) {
+	var member model.OrganizationMember
+	err := query.Where("organization_id = ? AND actor_id = ?", organizationID, actorID).First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationMemberNotFound)
+		}
+		return nil, fmt.Errorf("failed to find organization member: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *organizationRepository) LockMembersWithRole(ctx context.Context, tx *gorm.DB, organizationID uuid.UUID, role string) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("organization_id = ? AND role = ?", organizationID, role).Find(&members).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find organization members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, tx *gorm.DB, organizationID uuid.UUID) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	if err := tx.WithContext(ctx).Where("organization_id = ?", organizationID).Order("created_at").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) ListMemberships(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	if err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("created_at").Find(&members).Error; err != nil {
+		return nil, fmt.Errorf("failed to list organization memberships: %w", err)
+	}
+	return members, nil
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error {
+	if err := tx.WithContext(ctx).Save(member).Error; err != nil {
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) DeleteMember(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error {
+	err := tx.WithContext(ctx).Where("organization_id = ? AND actor_id = ?", member.OrganizationID, member.ActorID).
+		Delete(&model.OrganizationMember{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) CreateInvitation(ctx context.Context, tx *gorm.DB, invitation *model.OrganizationInvitation) error {
+	if err := tx.WithContext(ctx).Create(invitation).Error; err != nil {
+		return fmt.Errorf("failed to create organization invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *organizationRepository) LockInvitation(ctx context.Context, tx *gorm.DB, invitationID uuid.UUID) (*model.OrganizationInvitation, error) {
+	var invitation model.OrganizationInvitation
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("invitation_id = ?", invitationID).First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationInvitationNotFound)
+		}
+		return nil, fmt.Errorf("failed to find organization invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+func (r *organizationRepository) ExistsPendingInvitation(ctx context.Context, tx *gorm.DB, organizationID, inviteeID uuid.UUID, at time.Time) (bool, error) {
+	var count int64
+	err := tx.WithContext(ctx).Model(&model.OrganizationInvitation{}).
+		Where("organization_id = ? AND invitee_id = ?", organizationID, inviteeID).
+		Where(pendingInvitation, at).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check organization invitations: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *organizationRepository) ListInvitations(ctx context.Context, tx *gorm.DB, organizationID uuid.UUID, limit int) ([]model.OrganizationInvitation, error) {
+	var invitations []model.OrganizationInvitation
+	err := tx.WithContext(ctx).Where("organization_id = ?", organizationID).Order("created_at DESC").Limit(limit).Find(&invitations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *organizationRepository) ListPendingInvitations(ctx context.Context, tx *gorm.DB, inviteeID uuid.UUID, at time.Time) ([]model.OrganizationInvitation, error) {
+	var invitations []model.OrganizationInvitation
+	err := tx.WithContext(ctx).Where("invitee_id = ?", inviteeID).Where(pendingInvitation, at).
+		Order("created_at DESC").Find(&invitations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (r *organizationRepository) UpdateInvitation(ctx context.Context, tx *gorm.DB, invitation *model.OrganizationInvitation) error {
+	if err := tx.WithContext(ctx).Save(invitation).Error; err != nil {
+		return fmt.Errorf("failed to update organization invitation: %w", err)
+	}
+	return nil
+}
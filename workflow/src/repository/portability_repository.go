@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ActorRecords is everything held about an actor across tables, as gathered for a data export.
+// Share grants and access logs include those where the actor is the grantee.
+type ActorRecords struct {
+	Identifiers          []model.Identifier
+	ReleasedIdentifiers  []model.ReleasedIdentifier
+	Integrations         []model.ActorIntegration
+	Keys                 []model.ActorKey
+	DIDServices          []model.DIDService
+	Credentials          []model.Token
+	Documents            []model.Document
+	CredentialOffers     []model.CredentialOffer
+	PresentationRequests []model.PresentationRequest
+	ShareGrants          []model.ShareGrant
+	ShareAccessLogs      []model.ShareAccessLog
+	VerificationLevels   []model.VerificationLevelHistory
+	KeyRecoveries        []model.KeyRecovery
+	AccountDeletions     []model.AccountDeletion
+	DataExports          []model.DataExport
+	VerificationCodes    []model.VerificationCode
+	Memberships          []model.OrganizationMember
+	Invitations          []model.OrganizationInvitation
+	DelegationGrants     []model.DelegationGrant
+	DelegatedActions     []model.DelegatedAction
+}
+
+// PortabilityRepository defines the interface for reading every record held about an actor, to
+// answer data subject access requests. Records are returned oldest first.
+type PortabilityRepository interface {
+	// FindRecords gathers the records of an actor from every table that refers to it
+	FindRecords(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*ActorRecords, error)
+}
+
+type portabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewPortabilityRepository creates a new instance of PortabilityRepository
+func NewPortabilityRepository(db *gorm.DB) PortabilityRepository {
+	return &portabilityRepository{db: db}
+}
+
+// portabilityStep reads the records of one category
+type portabilityStep struct {
+	category string
+	dest     interface{}
+	where    string
+	args     []interface{}
+	order    string
+}
+
+func (r *portabilityRepository) FindRecords(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) (*ActorRecords, error) {
+	records := &ActorRecords{}
+	actor := []interface{}{actorID}
+	ownerOrGrantee := []interface{}{actorID, actorID}
+
+	steps := []portabilityStep{
+		{"identifiers", &records.Identifiers, "entity_id = ? AND entity_type = ?", []interface{}{actorID, constants.EntityTypeActor}, "created_at"},
+		{"releasedIdentifiers", &records.ReleasedIdentifiers, "entity_id = ? AND entity_type = ?", []interface{}{actorID, constants.EntityTypeActor}, "released_at"},
+		{"integrations", &records.Integrations, "actor_id = ?", actor, "linked_at"},
+		{"keys", &records.Keys, "actor_id = ?", actor, "created_at"},
+		{"didServices", &records.DIDServices, "actor_id = ?", actor, "created_at"},
+		{"documents", &records.Documents, "account_id = ?", actor, "uploaded_at"},
+		{"credentialOffers", &records.CredentialOffers, "actor_id = ?", actor, "created_at"},
+		{"presentationRequests", &records.PresentationRequests, "actor_id = ?", actor, "created_at"},
+		{"shareGrants", &records.ShareGrants, "owner_id = ? OR grantee_id = ?", ownerOrGrantee, "created_at"},
+		{"shareAccessLogs", &records.ShareAccessLogs, "owner_id = ? OR grantee_id = ?", ownerOrGrantee, "accessed_at"},
+		{"verificationLevels", &records.VerificationLevels, "actor_id = ?", actor, "created_at"},
+		{"keyRecoveries", &records.KeyRecoveries, "actor_id = ?", actor, "created_at"},
+		{"accountDeletions", &records.AccountDeletions, "actor_id = ?", actor, "created_at"},
+		{"dataExports", &records.DataExports, "actor_id = ?", actor, "created_at"},
+		{"verificationCodes", &records.VerificationCodes, "actor_id = ?", actor, "created_at"},
+		{"organizationMembers", &records.Memberships, "organization_id = ? OR actor_id = ?", ownerOrGrantee, "created_at"},
+		{"organizationInvitations", &records.Invitations, "organization_id = ? OR invitee_id = ?", ownerOrGrantee, "created_at"},
+		{"delegationGrants", &records.DelegationGrants, "principal_id = ? OR delegate_id = ?", ownerOrGrantee, "created_at"},
+		{"delegatedActions", &records.DelegatedActions, "principal_id = ? OR delegate_id = ?", ownerOrGrantee, "performed_at"},
+	}
+	for _, step := range steps {
+		if err := tx.WithContext(ctx).Where(step.where, step.args...).Order(step.order).Find(step.dest).Error; err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", step.category, err)
+		}
+	}
+
+	err := tx.WithContext(ctx).Preload("Documents").Where("account_id = ?", actorID).Order("created_at").Find(&records.Credentials).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	return records, nil
+}
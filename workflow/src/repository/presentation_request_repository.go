@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PresentationRequestRepository defines the interface for OID4VP presentation request data access
+type PresentationRequestRepository interface {
+	// Create creates a new presentation request in the database
+	Create(ctx context.Context, tx *gorm.DB, request *model.PresentationRequest) error
+
+	// FindByID finds a presentation request by ID
+	FindByID(ctx context.Context, tx *gorm.DB, requestID uuid.UUID) (*model.PresentationRequest, error)
+
+	// FindByIDAndActor finds a presentation request by ID created by the given actor
+	FindByIDAndActor(ctx context.Context, tx *gorm.DB, requestID, actorID uuid.UUID) (*model.PresentationRequest, error)
+
+	// LockByID finds a presentation request by ID and locks it for update
+	LockByID(ctx context.Context, tx *gorm.DB, requestID uuid.UUID) (*model.PresentationRequest, error)
+
+	// LockByState finds a presentation request by its state value and locks it, returning nil if absent
+	LockByState(ctx context.Context, tx *gorm.DB, state string) (*model.PresentationRequest, error)
+
+	// Update updates a presentation request in the database
+	Update(ctx context.Context, tx *gorm.DB, request *model.PresentationRequest) error
+
+	// UpdateCallbackStatus sets the callback delivery status of a presentation request
+	UpdateCallbackStatus(ctx context.Context, tx *gorm.DB, requestID uuid.UUID, status string) error
+}
+
+type presentationRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewPresentationRequestRepository creates a new instance of PresentationRequestRepository
+func NewPresentationRequestRepository(db *gorm.DB) PresentationRequestRepository {
+	return &presentationRequestRepository{db: db}
+}
+
+func (r *presentationRequestRepository) Create(ctx context.Context, tx *gorm.DB, request *model.PresentationRequest) error {
+	if err := tx.WithContext(ctx).Create(request).Error; err != nil {
+		return fmt.Errorf("failed to create presentation request: %w", err)
+	}
+	return nil
+}
+
+func (r *presentationRequestRepository) FindByID(ctx context.Context, tx *gorm.DB, requestID uuid.UUID) (*model.PresentationRequest, error) {
+	return r.findOne(tx.WithContext(ctx).Where("request_id = ?", requestID))
+}
+
+func (r *presentationRequestRepository) FindByIDAndActor(ctx context.Context, tx *gorm.DB, requestID, actorID uuid.UUID) (*model.PresentationRequest, error) {
+	return r.findOne(tx.WithContext(ctx).Where("request_id = ? AND actor_id = ?", requestID, actorID))
+}
+
+func (r *presentationRequestRepository) LockByID(ctx context.Context, tx *gorm.DB, requestID uuid.UUID) (*model.PresentationRequest, error) {
+	return r.findOne(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("request_id = ?", requestID))
+}
+
+func (r *presentationRequestRepository) LockByState(ctx context.Context, tx *gorm.DB, state string) (*model.PresentationRequest, error) {
+	var request model.PresentationRequest
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("state = ?", state).First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Unknown state values are reported as protocol errors by the caller
+		}
+		return nil, fmt.Errorf("failed to find presentation request: %w", err)
+	}
+	return &request, nil
+}
+
+func (r *presentationRequestRepository) findOne(query *gorm.DB) (*model.PresentationRequest, error) {
+	var request model.PresentationRequest
+	if err := query.First(&request).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrPresentationRequestNotFound)
+		}
+		return nil, fmt.Errorf("failed to find presentation request: %w", err)
+	}
+	return &request, nil
+}
+
+func (r *presentationRequestRepository) Update(ctx context.Context, tx *gorm.DB, request *model.PresentationRequest) error {
+	if err := tx.WithContext(ctx).Save(request).Error; err != nil {
+		return fmt.Errorf("failed to update presentation request: %w", err)
+	}
+	return nil
+}
+
+func (r *presentationRequestRepository) UpdateCallbackStatus(ctx context.Context, tx *gorm.DB, requestID uuid.UUID, status string) error {
+	err := tx.WithContext(ctx).Model(&model.PresentationRequest{}).
+		Where("request_id = ?", requestID).
+		Update("callback_status", status).Error
+	if err != nil {
+		return fmt.Errorf("failed to update presentation callback status: %w", err)
+	}
+	return nil
+}
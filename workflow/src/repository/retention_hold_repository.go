@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RetentionHoldRepository defines the interface for retention hold data access
+type RetentionHoldRepository interface {
+	// Create places a retention hold on an actor's records
+	Create(ctx context.Context, tx *gorm.DB, hold *model.RetentionHold) error
+
+	// ListByActorID lists the retention holds of an actor, released ones included, newest first
+	ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.RetentionHold, error)
+
+	// HasActive reports whether an actor's records are under a hold that is neither released nor
+	// expired at the given time
+	HasActive(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, at time.Time) (bool, error)
+
+	// Release ends a retention hold at the given time
+	Release(ctx context.Context, tx *gorm.DB, holdID uuid.UUID, at time.Time) (*model.RetentionHold, error)
+}
+
+type retentionHoldRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionHoldRepository creates a new instance of RetentionHoldRepository
+func NewRetentionHoldRepository(db *gorm.DB) RetentionHoldRepository {
+	return &retentionHoldRepository{db: db}
+}
+
+func (r *retentionHoldRepository) Create(ctx context.Context, tx *gorm.DB, hold *model.RetentionHold) error {
+	if err := tx.WithContext(ctx).Create(hold).Error; err != nil {
+		return fmt.Errorf("failed to create retention hold: %w", err)
+	}
+	return nil
+}
+
+func (r *retentionHoldRepository) ListByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.RetentionHold, error) {
+	var holds []model.RetentionHold
+	err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("created_at DESC").Find(&holds).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention holds: %w", err)
+	}
+	return holds, nil
+}
+
+func (r *retentionHoldRepository) HasActive(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, at time.Time) (bool, error) {
+	var count int64
+	err := tx.WithContext(ctx).Model(&model.RetentionHold{}).
+		Where("actor_id = ? AND released_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", actorID, at).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check retention holds: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *retentionHoldRepository) Release(ctx context.Context, tx *gorm.DB, holdID uuid.UUID, at time.Time) (*model.RetentionHold, error) {
+	var hold model.RetentionHold
+	if err := tx.WithContext(ctx).Where("hold_id = ?", holdID).First(&hold).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrRetentionHoldNotFound)
+		}
+		return nil, fmt.Errorf("failed to find retention hold: %w", err)
+	}
+	if hold.ReleasedAt != nil {
+		return &hold, nil
+	}
+
+	if err := tx.WithContext(ctx).Model(&hold).Update("released_at", at).Error; err != nil {
+		return nil, fmt.Errorf("failed to release retention hold: %w", err)
+	}
+	hold.ReleasedAt = &at
+	return &hold, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"app/src/model"
+	"app/src/utils"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShareAccessLogRepository defines the interface for share access log data access
+type ShareAccessLogRepository interface {
+	// Create records an access to shared data
+	Create(ctx context.Context, tx *gorm.DB, entry *model.ShareAccessLog) error
+
+	// List retrieves one page of access log entries matching the filter, fetching one extra row to detect further pages
+	List(ctx context.Context, tx *gorm.DB, filter ShareAccessLogFilter) ([]model.ShareAccessLog, error)
+}
+
+// ShareAccessLogFilter narrows an access log listing to one owner and optionally one grant
+type ShareAccessLogFilter struct {
+	OwnerID uuid.UUID
+	GrantID *uuid.UUID
+	Cursor  *utils.Cursor
+	Limit   int
+}
+
+type shareAccessLogRepository struct {
+	db *gorm.DB
+}
+
+// NewShareAccessLogRepository creates a new instance of ShareAccessLogRepository
+func NewShareAccessLogRepository(db *gorm.DB) ShareAccessLogRepository {
+	return &shareAccessLogRepository{db: db}
+}
+
+func (r *shareAccessLogRepository) Create(ctx context.Context, tx *gorm.DB, entry *model.ShareAccessLog) error {
+	if err := tx.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to record share access: %w", err)
+	}
+	return nil
+}
+
+func (r *shareAccessLogRepository) List(ctx context.Context, tx *gorm.DB, filter ShareAccessLogFilter) ([]model.ShareAccessLog, error) {
+	query := tx.WithContext(ctx).Where("owner_id = ?", filter.OwnerID)
+
+	if filter.GrantID != nil {
+		query = query.Where("grant_id = ?", *filter.GrantID)
+	}
+	if filter.Cursor != nil {
+		query = query.Where("(accessed_at, log_id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	var entries []model.ShareAccessLog
+	err := query.Order("accessed_at DESC, log_id DESC").Limit(filter.Limit + 1).Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve share access log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/utils"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ShareGrantRepository defines the interface for credential share grant data access
+type ShareGrantRepository interface {
+	// Create creates a share grant together with its granted tokens
+	Create(ctx context.Context, tx *gorm.DB, grant *model.ShareGrant) error
+
+	// LockByIDForOwner finds a share grant created by the given owner and locks it for update
+	LockByIDForOwner(ctx context.Context, tx *gorm.DB, grantID, ownerID uuid.UUID) (*model.ShareGrant, error)
+
+	// FindActiveForGrantee finds a grant issued to the given grantee that is neither revoked nor expired at the given time
+	FindActiveForGrantee(ctx context.Context, tx *gorm.DB, grantID, granteeID uuid.UUID, at time.Time) (*model.ShareGrant, error)
+
+	// List retrieves one page of grants matching the filter, fetching one extra row to detect further pages
+	List(ctx context.Context, tx *gorm.DB, filter ShareGrantFilter) ([]model.ShareGrant, error)
+
+	// Revoke marks a share grant as revoked
+	Revoke(ctx context.Context, tx *gorm.DB, grantID uuid.UUID, revokedAt time.Time) error
+}
+
+// ShareGrantFilter narrows a grant listing to grants made by an owner or received by a grantee
+type ShareGrantFilter struct {
+	OwnerID   *uuid.UUID
+	GranteeID *uuid.UUID
+	Status    string
+	At        time.Time
+	Cursor    *utils.Cursor
+	Limit     int
+}
+
+type shareGrantRepository struct {
+	db *gorm.DB
+}
+
+// NewShareGrantRepository creates a new instance of ShareGrantRepository
+func NewShareGrantRepository(db *gorm.DB) ShareGrantRepository {
+	return &shareGrantRepository{db: db}
+}
+
+func (r *shareGrantRepository) Create(ctx context.Context, tx *gorm.DB, grant *model.ShareGrant) error {
+	if err := tx.WithContext(ctx).Create(grant).Error; err != nil {
+		return fmt.Errorf("failed to create share grant: %w", err)
+	}
+	return nil
+}
+
+func (r *shareGrantRepository) LockByIDForOwner(ctx context.Context, tx *gorm.DB, grantID, ownerID uuid.UUID) (*model.ShareGrant, error) {
+	return r.findOne(tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: constants.TableNameShareGrants}}).
+		Where("grant_id = ? AND owner_id = ?", grantID, ownerID))
+}
+
+func (r *shareGrantRepository) FindActiveForGrantee(ctx context.Context, tx *gorm.DB, grantID, granteeID uuid.UUID, at time.Time) (*model.ShareGrant, error) {
+	return r.findOne(tx.WithContext(ctx).
+		Where("grant_id = ? AND grantee_id = ? AND revoked_at IS NULL AND expires_at > ?", grantID, granteeID, at))
+}
+
+func (r *shareGrantRepository) findOne(query *gorm.DB) (*model.ShareGrant, error) {
+	var grant model.ShareGrant
+	if err := query.Preload("Tokens").First(&grant).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrShareGrantNotFound)
+		}
+		return nil, fmt.Errorf("failed to find share grant: %w", err)
+	}
+	return &grant, nil
+}
+
+func (r *shareGrantRepository) List(ctx context.Context, tx *gorm.DB, filter ShareGrantFilter) ([]model.ShareGrant, error) {
+	query := tx.WithContext(ctx)
+
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.GranteeID != nil {
+		query = query.Where("grantee_id = ?", *filter.GranteeID)
+	}
+	switch filter.Status {
+	case constants.ShareGrantStatusActive:
+		query = query.Where("revoked_at IS NULL AND expires_at > ?", filter.At)
+	case constants.ShareGrantStatusExpired:
+		query = query.Where("revoked_at IS NULL AND expires_at <= ?", filter.At)
+	case constants.ShareGrantStatusRevoked:
+		query = query.Where("revoked_at IS NOT NULL")
+	}
+	if filter.Cursor != nil {
+		query = query.Where("(created_at, grant_id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	var grants []model.ShareGrant
+	err := query.Preload("Tokens").Order("created_at DESC, grant_id DESC").Limit(filter.Limit + 1).Find(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve share grants: %w", err)
+	}
+	return grants, nil
+}
+
+func (r *shareGrantRepository) Revoke(ctx context.Context, tx *gorm.DB, grantID uuid.UUID, revokedAt time.Time) error {
+	result := tx.WithContext(ctx).Model(&model.ShareGrant{}).
+		Where("grant_id = ? AND revoked_at IS NULL", grantID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke share grant: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusConflict, constants.ErrShareGrantAlreadyRevoked)
+	}
+	return nil
+}
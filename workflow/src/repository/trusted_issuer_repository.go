@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TrustedIssuerRepository defines the interface for trusted issuer data access operations
+type TrustedIssuerRepository interface {
+	// Create creates a new trusted issuer in the database
+	Create(ctx context.Context, tx *gorm.DB, issuer *model.TrustedIssuer) error
+
+	// Upsert creates a trusted issuer or replaces the existing entry with the same DID
+	Upsert(ctx context.Context, tx *gorm.DB, issuer *model.TrustedIssuer) error
+
+	// FindByID finds a trusted issuer by ID
+	FindByID(ctx context.Context, tx *gorm.DB, issuerID uuid.UUID) (*model.TrustedIssuer, error)
+
+	// FindByDID finds a trusted issuer by its DID, returning nil if it is not registered
+	FindByDID(ctx context.Context, tx *gorm.DB, issuerDID string) (*model.TrustedIssuer, error)
+
+	// FindAll retrieves all trusted issuers, optionally filtered by status
+	FindAll(ctx context.Context, tx *gorm.DB, status string) ([]model.TrustedIssuer, error)
+
+	// Update updates a trusted issuer in the database
+	Update(ctx context.Context, tx *gorm.DB, issuer *model.TrustedIssuer) error
+
+	// Delete deletes a trusted issuer by ID
+	Delete(ctx context.Context, tx *gorm.DB, issuerID uuid.UUID) error
+}
+
+type trustedIssuerRepository struct {
+	db *gorm.DB
+}
+
+// NewTrustedIssuerRepository creates a new instance of TrustedIssuerRepository
+func NewTrustedIssuerRepository(db *gorm.DB) TrustedIssuerRepository {
+	return &trustedIssuerRepository{db: db}
+}
+
+func (r *trustedIssuerRepository) Create(ctx context.Context, tx *gorm.DB, issuer *model.TrustedIssuer) error {
+	if err := tx.WithContext(ctx).Create(issuer).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrTrustedIssuerAlreadyExists)
+		}
+		return fmt.Errorf("failed to create trusted issuer: %w", err)
+	}
+	return nil
+}
+
+func (r *trustedIssuerRepository) Upsert(ctx context.Context, tx *gorm.DB, issuer *model.TrustedIssuer) error {
+	err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "issuer_did"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name", "verification_types", "jurisdictions", "valid_from", "valid_until", "status", "source", "updated_at",
+		}),
+	}).Create(issuer).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert trusted issuer: %w", err)
+	}
+	return nil
+}
+
+func (r *trustedIssuerRepository) FindByID(ctx context.Context, tx *gorm.DB, issuerID uuid.UUID) (*model.TrustedIssuer, error) {
+	var issuer model.TrustedIssuer
+	err := tx.WithContext(ctx).Where("issuer_id = ?", issuerID).First(&issuer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrTrustedIssuerNotFound)
+		}
+		return nil, fmt.Errorf("failed to find trusted issuer: %w", err)
+	}
+	return &issuer, nil
+}
+
+func (r *trustedIssuerRepository) FindByDID(ctx context.Context, tx *gorm.DB, issuerDID string) (*model.TrustedIssuer, error) {
+	var issuer model.TrustedIssuer
+	err := tx.WithContext(ctx).Where("issuer_did = ?", issuerDID).First(&issuer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Unregistered issuers are evaluated by the caller's trust policy
+		}
+		return nil, fmt.Errorf("failed to find trusted issuer by DID: %w", err)
+	}
+	return &issuer, nil
+}
+
+func (r *trustedIssuerRepository) FindAll(ctx context.Context, tx *gorm.DB, status string) ([]model.TrustedIssuer, error) {
+	query := tx.WithContext(ctx)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var issuers []model.TrustedIssuer
+	if err := query.Order("issuer_did").Find(&issuers).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve trusted issuers: %w", err)
+	}
+	return issuers, nil
+}
+
+func (r *trustedIssuerRepository) Update(ctx context.Context, tx *gorm.DB, issuer *model.TrustedIssuer) error {
+	if err := tx.WithContext(ctx).Save(issuer).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrTrustedIssuerAlreadyExists)
+		}
+		return fmt.Errorf("failed to update trusted issuer: %w", err)
+	}
+	return nil
+}
+
+func (r *trustedIssuerRepository) Delete(ctx context.Context, tx *gorm.DB, issuerID uuid.UUID) error {
+	result := tx.WithContext(ctx).Delete(&model.TrustedIssuer{}, "issuer_id = ?", issuerID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete trusted issuer: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrTrustedIssuerNotFound)
+	}
+	return nil
+}
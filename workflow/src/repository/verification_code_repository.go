@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VerificationCodeRepository defines the interface for contact verification message data access
+type VerificationCodeRepository interface {
+	// Create records a verification message sent to an actor
+	Create(ctx context.Context, tx *gorm.DB, code *model.VerificationCode) error
+
+	// FindLatest finds the most recent verification message sent to an actor on a channel
+	FindLatest(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error)
+
+	// LockLatest finds the most recent verification message sent to an actor on a channel and
+	// locks it for the rest of the transaction
+	LockLatest(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error)
+
+	// CountSince counts the verification messages sent to an actor on a channel since a given time
+	CountSince(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string, since time.Time) (int64, error)
+
+	// Update saves every field of a verification code
+	Update(ctx context.Context, tx *gorm.DB, code *model.VerificationCode) error
+}
+
+type verificationCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewVerificationCodeRepository creates a new instance of VerificationCodeRepository
+func NewVerificationCodeRepository(db *gorm.DB) VerificationCodeRepository {
+	return &verificationCodeRepository{db: db}
+}
+
+func (r *verificationCodeRepository) Create(ctx context.Context, tx *gorm.DB, code *model.VerificationCode) error {
+	if err := tx.WithContext(ctx).Create(code).Error; err != nil {
+		return fmt.Errorf("failed to create verification code: %w", err)
+	}
+	return nil
+}
+
+func (r *verificationCodeRepository) FindLatest(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error) {
+	return r.findLatest(tx.WithContext(ctx), actorID, channel)
+}
+
+func (r *verificationCodeRepository) LockLatest(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error) {
+	return r.findLatest(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), actorID, channel)
+}
+
+func (r *verificationCodeRepository) findLatest(query *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error) {
+	var code model.VerificationCode
+	err := query.Where("actor_id = ? AND channel = ?", actorID, channel).Order("created_at DESC").First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrVerificationCodeNotFound)
+		}
+		return nil, fmt.Errorf("failed to find verification code: %w", err)
+	}
+	return &code, nil
+}
+
+func (r *verificationCodeRepository) CountSince(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string, since time.Time) (int64, error) {
+	var count int64
+	err := tx.WithContext(ctx).Model(&model.VerificationCode{}).
+		Where("actor_id = ? AND channel = ? AND created_at >= ?", actorID, channel, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count verification codes: %w", err)
+	}
+	return count, nil
+}
+
+func (r *verificationCodeRepository) Update(ctx context.Context, tx *gorm.DB, code *model.VerificationCode) error {
+	if err := tx.WithContext(ctx).Save(code).Error; err != nil {
+		return fmt.Errorf("failed to update verification code: %w", err)
+	}
+	return nil
+}
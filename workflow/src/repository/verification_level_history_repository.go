@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"app/src/model"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VerificationLevelHistoryRepository defines the interface for verification level history data access
+type VerificationLevelHistoryRepository interface {
+	// Create records a verification level change
+	Create(ctx context.Context, tx *gorm.DB, history *model.VerificationLevelHistory) error
+
+	// FindByActorID retrieves an actor's verification level changes, newest first
+	FindByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.VerificationLevelHistory, error)
+}
+
+type verificationLevelHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewVerificationLevelHistoryRepository creates a new instance of VerificationLevelHistoryRepository
+func NewVerificationLevelHistoryRepository(db *gorm.DB) VerificationLevelHistoryRepository {
+	return &verificationLevelHistoryRepository{db: db}
+}
+
+func (r *verificationLevelHistoryRepository) Create(ctx context.Context, tx *gorm.DB, history *model.VerificationLevelHistory) error {
+	if err := tx.WithContext(ctx).Create(history).Error; err != nil {
+		return fmt.Errorf("failed to record verification level change: %w", err)
+	}
+	return nil
+}
+
+func (r *verificationLevelHistoryRepository) FindByActorID(ctx context.Context, tx *gorm.DB, actorID uuid.UUID) ([]model.VerificationLevelHistory, error) {
+	var history []model.VerificationLevelHistory
+	err := tx.WithContext(ctx).Where("actor_id = ?", actorID).Order("created_at DESC, history_id DESC").Find(&history).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve verification level history: %w", err)
+	}
+	return history, nil
+}
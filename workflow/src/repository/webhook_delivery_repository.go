@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/utils"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository defines the interface for webhook delivery log data access
+type WebhookDeliveryRepository interface {
+	// Create records a new delivery
+	Create(ctx context.Context, tx *gorm.DB, delivery *model.WebhookDelivery) error
+
+	// FindByID finds a delivery by ID
+	FindByID(ctx context.Context, tx *gorm.DB, deliveryID uuid.UUID) (*model.WebhookDelivery, error)
+
+	// FindByIDWithAccess finds a delivery by ID visible to the given access scope
+	FindByIDWithAccess(ctx context.Context, tx *gorm.DB, deliveryID uuid.UUID, access WebhookAccess) (*model.WebhookDelivery, error)
+
+	// List retrieves one page of deliveries matching the filter, fetching one extra row to detect further pages
+	List(ctx context.Context, tx *gorm.DB, filter WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+
+	// Update updates a delivery in the database
+	Update(ctx context.Context, tx *gorm.DB, delivery *model.WebhookDelivery) error
+}
+
+// WebhookDeliveryFilter narrows a delivery listing to an access scope and optional criteria
+type WebhookDeliveryFilter struct {
+	Access         WebhookAccess
+	SubscriptionID *uuid.UUID
+	EventType      string
+	Status         string
+	Cursor         *utils.Cursor
+	Limit          int
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository creates a new instance of WebhookDeliveryRepository
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, tx *gorm.DB, delivery *model.WebhookDelivery) error {
+	if err := tx.WithContext(ctx).Create(delivery).Error; err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookDeliveryRepository) FindByID(ctx context.Context, tx *gorm.DB, deliveryID uuid.UUID) (*model.WebhookDelivery, error) {
+	return r.findOne(tx.WithContext(ctx).Where("delivery_id = ?", deliveryID))
+}
+
+func (r *webhookDeliveryRepository) FindByIDWithAccess(ctx context.Context, tx *gorm.DB, deliveryID uuid.UUID, access WebhookAccess) (*model.WebhookDelivery, error) {
+	return r.findOne(access.apply(tx.WithContext(ctx).Where("delivery_id = ?", deliveryID)))
+}
+
+func (r *webhookDeliveryRepository) findOne(query *gorm.DB) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := query.First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrWebhookDeliveryNotFound)
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) List(ctx context.Context, tx *gorm.DB, filter WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	query := filter.Access.apply(tx.WithContext(ctx))
+
+	if filter.SubscriptionID != nil {
+		query = query.Where("subscription_id = ?", *filter.SubscriptionID)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Cursor != nil {
+		query = query.Where("(created_at, delivery_id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+	}
+
+	var deliveries []model.WebhookDelivery
+	err := query.Order("created_at DESC, delivery_id DESC").Limit(filter.Limit + 1).Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, tx *gorm.DB, delivery *model.WebhookDelivery) error {
+	if err := tx.WithContext(ctx).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepository defines the interface for webhook subscription data access
+type WebhookSubscriptionRepository interface {
+	// Create creates a new webhook subscription
+	Create(ctx context.Context, tx *gorm.DB, subscription *model.WebhookSubscription) error
+
+	// FindByID finds a webhook subscription by ID
+	FindByID(ctx context.Context, tx *gorm.DB, subscriptionID uuid.UUID) (*model.WebhookSubscription, error)
+
+	// List retrieves the subscriptions visible to the given access scope, newest first
+	List(ctx context.Context, tx *gorm.DB, access WebhookAccess) ([]model.WebhookSubscription, error)
+
+	// FindSubscribers returns the subscriptions that receive the event type for the given actor:
+	// the actor's own subscriptions and every partner subscription
+	FindSubscribers(ctx context.Context, tx *gorm.DB, eventType string, actorID uuid.UUID) ([]model.WebhookSubscription, error)
+
+	// Delete deletes a subscription visible to the given access scope
+	Delete(ctx context.Context, tx *gorm.DB, subscriptionID uuid.UUID, access WebhookAccess) error
+}
+
+// WebhookAccess limits webhook data to one scope and, for actor scope, one owner
+type WebhookAccess struct {
+	Scope   string
+	OwnerID *uuid.UUID
+}
+
+// apply restricts query to the rows visible under the access scope
+func (access WebhookAccess) apply(query *gorm.DB) *gorm.DB {
+	query = query.Where("scope = ?", access.Scope)
+	if access.OwnerID != nil {
+		query = query.Where("owner_id = ?", *access.OwnerID)
+	}
+	return query
+}
+
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookSubscriptionRepository creates a new instance of WebhookSubscriptionRepository
+func NewWebhookSubscriptionRepository(db *gorm.DB) WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{db: db}
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, tx *gorm.DB, subscription *model.WebhookSubscription) error {
+	if err := tx.WithContext(ctx).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) FindByID(ctx context.Context, tx *gorm.DB, subscriptionID uuid.UUID) (*model.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+	err := tx.WithContext(ctx).Where("subscription_id = ?", subscriptionID).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrWebhookSubscriptionNotFound)
+		}
+		return nil, fmt.Errorf("failed to find webhook subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+func (r *webhookSubscriptionRepository) List(ctx context.Context, tx *gorm.DB, access WebhookAccess) ([]model.WebhookSubscription, error) {
+	var subscriptions []model.WebhookSubscription
+	err := access.apply(tx.WithContext(ctx)).Order("created_at DESC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookSubscriptionRepository) FindSubscribers(ctx context.Context, tx *gorm.DB, eventType string, actorID uuid.UUID) ([]model.WebhookSubscription, error) {
+	events, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []model.WebhookSubscription
+	err = tx.WithContext(ctx).
+		Where("events @> ?::jsonb", string(events)).
+		Where("(scope = ? AND owner_id = ?) OR scope = ?", constants.WebhookScopeActor, actorID, constants.WebhookScopePartner).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook subscribers: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, tx *gorm.DB, subscriptionID uuid.UUID, access WebhookAccess) error {
+	result := access.apply(tx.WithContext(ctx).Where("subscription_id = ?", subscriptionID)).Delete(&model.WebhookSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrWebhookSubscriptionNotFound)
+	}
+	return nil
+}
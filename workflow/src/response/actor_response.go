@@ -1,7 +1,5 @@
 package response
 
-import "github.com/google/uuid"
-
 // ApiResponse represents the base response structure
 type ApiResponse struct {
 	ID       string            `json:"id" example:"api.actor.create"`
@@ -21,8 +19,8 @@ type ApiResponseParams struct {
 
 // RegistrationSuccessResponse represents the response for successful actor registration
 type RegistrationSuccessResponse struct {
-	DID     uuid.UUID `json:"did" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Message string    `json:"message" example:"Actor registered successfully"`
+	DID     string `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
+	Message string `json:"message" example:"Actor registered successfully"`
 }
 
 // AuthResponse represents the response for successful login
@@ -34,15 +32,18 @@ type AuthResponse struct {
 
 // ActorProfile represents the actor profile information
 type ActorProfile struct {
-	DID                 uuid.UUID `json:"did" example:"123e4567-e89b-12d3-a456-426614174000"`
-	UniversalIdentifier string    `json:"universalIdentifier" example:"actor123"`
-	Email               string    `json:"email" example:"actor@example.com"`
-	FirstName           string    `json:"firstName" example:"John"`
-	LastName            string    `json:"lastName" example:"Doe"`
-	PhoneNumber         *string   `json:"phoneNumber,omitempty" example:"+1234567890"`
-	MasterPublicKey     string    `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
-	VerificationLevel   string    `json:"verificationLevel" example:"Tier0_Unverified"`
-	EntityType          string    `json:"entityType" example:"Individual"`
+	DID                 string  `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
+	UniversalIdentifier string  `json:"universalIdentifier" example:"actor123"`
+	Email               string  `json:"email" example:"actor@example.com"`
+	EmailVerified       bool    `json:"emailVerified" example:"true"`
+	FirstName           string  `json:"firstName" example:"John"`
+	LastName            string  `json:"lastName" example:"Doe"`
+	PhoneNumber         *string `json:"phoneNumber,omitempty" example:"+1234567890"`
+	PhoneVerified       bool    `json:"phoneVerified" example:"false"`
+	MasterPublicKey     string  `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
+	MasterKeyThumbprint *string `json:"masterKeyThumbprint,omitempty" example:"NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"`
+	VerificationLevel   string  `json:"verificationLevel" example:"Tier0_Unverified"`
+	EntityType          string  `json:"entityType" example:"Individual"`
 	// Individual fields
 	Nationality        *string `json:"nationality,omitempty" example:"US"`
 	CountryOfResidence *string `json:"countryOfResidence,omitempty" example:"US"`
@@ -50,11 +51,82 @@ type ActorProfile struct {
 	CountryOfIncorporation *string `json:"countryOfIncorporation,omitempty" example:"US"`
 }
 
-// ResolveResponse represents the response for resolving universal identifier
+// ResolveResponse represents the response for resolving universal identifier. The validity
+// interval is set when a key valid at a given time was requested.
 type ResolveResponse struct {
-	UniversalIdentifier string    `json:"universalIdentifier" example:"user123"`
-	MasterPublicKey     string    `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
-	DID                 uuid.UUID `json:"did" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UniversalIdentifier string  `json:"universalIdentifier" example:"user123"`
+	MasterPublicKey     string  `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
+	DID                 string  `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
+	ValidFrom           *string `json:"validFrom,omitempty" example:"2025-09-01T08:00:00Z"`
+	ValidUntil          *string `json:"validUntil,omitempty" example:"2025-10-15T12:30:00Z"`
+}
+
+// ResolvedIdentifierResponse represents one resolution of a batch. Signature is a JWS of type
+// resolution+jwt signed by the platform key, with the canonical identifier as sub, that callers
+// can cache and verify offline against the platform DID document.
+type ResolvedIdentifierResponse struct {
+	Requested           string `json:"requested" example:"Alice"`
+	UniversalIdentifier string `json:"universalIdentifier" example:"alice@finternet"`
+	MasterPublicKey     string `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE...\n-----END PUBLIC KEY-----"`
+	DID                 string `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
+	Signature           string `json:"signature" example:"eyJhbGciOiJFUzI1NiIsImtpZCI6ImRpZDp3ZWI6bm9kZS5maW50ZXJuZXQuZXhhbXBsZSNpc3N1ZXIta2V5LTEiLCJ0eXAiOiJyZXNvbHV0aW9uK2p3dCJ9..."`
+}
+
+// ResolveBatchResponse represents the response for resolving several universal identifiers.
+// Resolutions are in request order; identifiers nobody holds are listed as requested in notFound.
+type ResolveBatchResponse struct {
+	Resolved []ResolvedIdentifierResponse `json:"resolved"`
+	NotFound []string                     `json:"notFound"`
+}
+
+// RotateKeyResponse represents the response for a master key rotation. Status is completed for
+// a rotation endorsed by the current key and pending for a recovery awaiting its waiting period.
+type RotateKeyResponse struct {
+	Status      string  `json:"status" example:"completed"`
+	DID         string  `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
+	Thumbprint  string  `json:"thumbprint" example:"NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"`
+	EffectiveAt string  `json:"effectiveAt" example:"2025-10-15T12:30:00Z"`
+	RecoveryID  *string `json:"recoveryId,omitempty" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Message     string  `json:"message" example:"Master key rotated"`
+}
+
+// IdentifierResponse represents a universal identifier of an actor
+type IdentifierResponse struct {
+	Identifier string `json:"identifier" example:"alice-business"`
+	IsPrimary  bool   `json:"isPrimary" example:"false"`
+	CreatedAt  string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListIdentifiersResponse represents the universal identifiers of an actor, primary first
+type ListIdentifiersResponse struct {
+	Identifiers []IdentifierResponse `json:"identifiers"`
+}
+
+// ReleasedIdentifierResponse represents a removed universal identifier and when others can claim it
+type ReleasedIdentifierResponse struct {
+	Identifier  string `json:"identifier" example:"alice-business"`
+	AvailableAt string `json:"availableAt" example:"2025-11-22T06:25:25Z"`
+}
+
+// ChallengeResponse represents an issued challenge nonce and the time it expires
+type ChallengeResponse struct {
+	Challenge string `json:"challenge" example:"q2x9Kd3V1d0Xh7mYzR4pLw8sN5bTf6gJc0aE1uHiW2o"`
+	ExpiresAt string `json:"expiresAt" example:"2025-10-15T12:35:00Z"`
+}
+
+// VerificationLevelChange represents one entry of an actor's verification level history
+type VerificationLevelChange struct {
+	PreviousLevel string  `json:"previousLevel" example:"Tier0_Unverified"`
+	NewLevel      string  `json:"newLevel" example:"Tier1_Verified"`
+	Reason        string  `json:"reason" example:"credential_verified"`
+	CredentialID  *string `json:"credentialId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ChangedAt     string  `json:"changedAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// VerificationHistoryResponse represents the response for an actor's verification level history
+type VerificationHistoryResponse struct {
+	VerificationLevel string                    `json:"verificationLevel" example:"Tier1_Verified"`
+	History           []VerificationLevelChange `json:"history"`
 }
 
 // SuccessResponse represents a generic success response
@@ -86,6 +158,30 @@ type ApiResponse_ResolveResponse struct {
 	Response ResolveResponse `json:"response"`
 }
 
+// ApiResponse_ResolveBatchResponse wraps ResolveBatchResponse with ApiResponse
+type ApiResponse_ResolveBatchResponse struct {
+	ApiResponse
+	Response ResolveBatchResponse `json:"response"`
+}
+
+// ApiResponse_RotateKeyResponse wraps RotateKeyResponse with ApiResponse
+type ApiResponse_RotateKeyResponse struct {
+	ApiResponse
+	Response RotateKeyResponse `json:"response"`
+}
+
+// ApiResponse_ChallengeResponse wraps ChallengeResponse with ApiResponse
+type ApiResponse_ChallengeResponse struct {
+	ApiResponse
+	Response ChallengeResponse `json:"response"`
+}
+
+// ApiResponse_VerificationHistory wraps VerificationHistoryResponse with ApiResponse
+type ApiResponse_VerificationHistory struct {
+	ApiResponse
+	Response VerificationHistoryResponse `json:"response"`
+}
+
 // ApiResponse_Success wraps SuccessResponse with ApiResponse
 type ApiResponse_Success struct {
 	ApiResponse
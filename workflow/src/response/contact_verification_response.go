@@ -0,0 +1,21 @@
+package response
+
+// VerificationSentResponse represents a verification message sent to the caller's email address
+// or phone number
+type VerificationSentResponse struct {
+	Channel   string `json:"channel" example:"phone"`
+	Target    string `json:"target" example:"+14155552671"`
+	ExpiresAt string `json:"expiresAt" example:"2025-10-23T06:35:25Z"`
+	Message   string `json:"message" example:"Verification code sent by SMS"`
+}
+
+// ContactVerificationResponse represents the verification status of the caller's email address
+// and phone number
+type ContactVerificationResponse struct {
+	Email           string `json:"email" example:"john.doe@example.com"`
+	EmailVerified   bool   `json:"emailVerified" example:"true"`
+	EmailVerifiedAt string `json:"emailVerifiedAt,omitempty" example:"2025-10-23T06:27:02Z"`
+	PhoneNumber     string `json:"phoneNumber,omitempty" example:"+14155552671"`
+	PhoneVerified   bool   `json:"phoneVerified" example:"false"`
+	PhoneVerifiedAt string `json:"phoneVerifiedAt,omitempty" example:"2025-10-23T06:30:41Z"`
+}
@@ -46,22 +46,36 @@ type Response[T any] struct {
 // AddCredentialSuccessResponse represents the payload returned on successful
 // AddCredential operation.
 type AddCredentialSuccessResponse struct {
-	CredentialID string `json:"credentialId" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Status       string `json:"status" example:"Pending"`
-	Message      string `json:"message" example:"Credential submitted successfully. Verification is in progress."`
+	CredentialID string                       `json:"credentialId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	JobID        string                       `json:"jobId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Status       string                       `json:"status" example:"Pending"`
+	Message      string                       `json:"message" example:"Credential submitted successfully. Verification is in progress."`
+	Documents    []CredentialDocumentResponse `json:"documents"`
 }
 
 // CredentialsSuccessResponse represents a single credential in responses
 type CredentialsSuccessResponse struct {
-	CredentialID string `json:"credentialId" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Type         string `json:"type" example:"VerifiableCredential"`
-	Status       string `json:"status" example:"Pending"`
-	SubmittedAt  string `json:"submittedAt" example:"2025-10-23T06:25:25.191Z"`
+	CredentialID string                       `json:"credentialId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Type         string                       `json:"type" example:"VC"`
+	Status       string                       `json:"status" example:"Pending"`
+	Issuer       string                       `json:"issuer" example:"did:example:issuer"`
+	SubmittedAt  string                       `json:"submittedAt" example:"2025-10-23T06:25:25.191Z"`
+	Documents    []CredentialDocumentResponse `json:"documents"`
+}
+
+// CredentialDocumentResponse summarizes an evidence document linked to a credential
+type CredentialDocumentResponse struct {
+	DocumentID string  `json:"documentId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Role       string  `json:"role" example:"front"`
+	FileName   string  `json:"fileName,omitempty" example:"passport.pdf"`
+	MimeType   *string `json:"mimeType,omitempty" example:"application/pdf"`
+	UploadedAt string  `json:"uploadedAt,omitempty" example:"2025-10-23T06:20:11Z"`
 }
 
 // ListCredentialsSuccessResponse represents the response for listing credentials
 type ListCredentialsSuccessResponse struct {
 	Credentials []CredentialsSuccessResponse `json:"credentials"`
+	NextCursor  string                       `json:"nextCursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
 }
 
 // UploadCredentialResponse represents the response for uploading a credential file
@@ -0,0 +1,44 @@
+package response
+
+// DelegationGrantResponse represents a delegation grant
+type DelegationGrantResponse struct {
+	GrantID             string   `json:"grantId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	PrincipalID         string   `json:"principalId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	PrincipalIdentifier string   `json:"principalIdentifier" example:"sam-doe"`
+	DelegateID          string   `json:"delegateId" example:"123e4567-e89b-12d3-a456-426614174111"`
+	DelegateIdentifier  string   `json:"delegateIdentifier" example:"jane-doe"`
+	Scopes              []string `json:"scopes" example:"credentials:add,documents:upload"`
+	Relationship        *string  `json:"relationship,omitempty" example:"guardian"`
+	Status              string   `json:"status" example:"active"`
+	ValidFrom           string   `json:"validFrom" example:"2025-11-01T00:00:00Z"`
+	ExpiresAt           string   `json:"expiresAt" example:"2026-10-31T23:59:59Z"`
+	RevokedAt           *string  `json:"revokedAt,omitempty" example:"2025-11-02T09:14:00Z"`
+	CreatedAt           string   `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListDelegationGrantsResponse represents one page of delegation grants
+type ListDelegationGrantsResponse struct {
+	Grants     []DelegationGrantResponse `json:"grants"`
+	NextCursor string                    `json:"nextCursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
+
+// DelegatedActionResponse represents one request a delegate made for a principal
+type DelegatedActionResponse struct {
+	ActionID    string `json:"actionId" example:"0193a7c5-0a1b-7c2d-8e3f-4a5b6c7d8e9f"`
+	GrantID     string `json:"grantId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	PrincipalID string `json:"principalId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	DelegateID  string `json:"delegateId" example:"123e4567-e89b-12d3-a456-426614174111"`
+	Scope       string `json:"scope" example:"credentials:add"`
+	Method      string `json:"method" example:"POST"`
+	Path        string `json:"path" example:"/v1/credentials/add"`
+	StatusCode  int    `json:"statusCode" example:"202"`
+	IPAddress   string `json:"ipAddress,omitempty" example:"203.0.113.7"`
+	UserAgent   string `json:"userAgent,omitempty" example:"Mozilla/5.0"`
+	PerformedAt string `json:"performedAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListDelegatedActionsResponse represents one page of delegated actions
+type ListDelegatedActionsResponse struct {
+	Entries    []DelegatedActionResponse `json:"entries"`
+	NextCursor string                    `json:"nextCursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
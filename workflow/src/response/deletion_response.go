@@ -0,0 +1,34 @@
+package response
+
+// AccountDeletionResponse represents an account deletion request and, once the account has been
+// erased, its summary and erasure certificate
+type AccountDeletionResponse struct {
+	DeletionID       string                 `json:"deletionId" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+	ActorID          string                 `json:"actorId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status           string                 `json:"status" example:"pending"`
+	Reason           string                 `json:"reason,omitempty" example:"No longer using the service"`
+	EffectiveAt      string                 `json:"effectiveAt" example:"2025-11-22T06:25:25Z"`
+	CancelledAt      string                 `json:"cancelledAt,omitempty" example:"2025-10-24T06:25:25Z"`
+	CompletedAt      string                 `json:"completedAt,omitempty" example:"2025-11-22T06:25:31Z"`
+	Pseudonymized    bool                   `json:"pseudonymized" example:"false"`
+	Summary          map[string]interface{} `json:"summary,omitempty"`
+	Certificate      string                 `json:"certificate,omitempty" example:"eyJhbGciOiJFUzI1NksiLCJ0eXAiOiJlcmFzdXJlLWNlcnRpZmljYXRlK2p3dCJ9..."`
+	CertificateToken string                 `json:"certificateToken,omitempty" example:"kq3Yb8ZlW2v0bX7nF4cT9rA1sD6eG5hJ"`
+	CreatedAt        string                 `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+	Message          string                 `json:"message,omitempty" example:"Account deletion scheduled; the account is erased after the grace period unless cancelled"`
+}
+
+// RetentionHoldResponse represents a retention hold on an actor's records
+type RetentionHoldResponse struct {
+	HoldID     string `json:"holdId" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+	ActorID    string `json:"actorId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Reason     string `json:"reason" example:"AML record keeping (5 years)"`
+	ExpiresAt  string `json:"expiresAt,omitempty" example:"2030-10-23T00:00:00Z"`
+	ReleasedAt string `json:"releasedAt,omitempty" example:"2026-01-15T09:00:00Z"`
+	CreatedAt  string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListRetentionHoldsResponse represents the retention holds of an actor
+type ListRetentionHoldsResponse struct {
+	Holds []RetentionHoldResponse `json:"holds"`
+}
@@ -0,0 +1,16 @@
+package response
+
+// DIDServiceResponse represents a service endpoint published in an actor's did:web document
+type DIDServiceResponse struct {
+	ID              string `json:"id" example:"linked-domain"`
+	Type            string `json:"type" example:"LinkedDomains"`
+	ServiceEndpoint string `json:"serviceEndpoint" example:"https://alice.example.com"`
+	CreatedAt       string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListDIDServicesResponse represents the caller's did:web identifier and its service endpoints.
+// DID is empty for actors without a universal identifier, which have no did:web document.
+type ListDIDServicesResponse struct {
+	DID      string               `json:"did,omitempty" example:"did:web:node.finternet.example:alice"`
+	Services []DIDServiceResponse `json:"services"`
+}
@@ -27,6 +27,7 @@ type RegistrationRequestExample struct {
 	EntityType          string `json:"entityType" example:"Individual"`
 	Nationality         string `json:"nationality" example:"US"`
 	CountryOfResidence  string `json:"countryOfResidence" example:"US"`
+	Proof               string `json:"proof" example:"eyJhbGciOiJFUzI1NiIsInR5cCI6InJlZ2lzdHJhdGlvbi1wcm9vZitqd3QifQ..."`
 }
 
 // ApiRequest_LoginRequestExample provides an example for login request
@@ -105,8 +106,8 @@ type ApiResponseParamsExample struct {
 
 // RegistrationSuccessResponseExample provides an example for registration success response payload
 type RegistrationSuccessResponseExample struct {
-	DID     uuid.UUID `json:"did" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Message string    `json:"message" example:"Actor registered successfully"`
+	DID     string `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
+	Message string `json:"message" example:"Actor registered successfully"`
 }
 
 // ApiResponse_AuthResponseExample provides an example for auth response
@@ -137,13 +138,16 @@ type ApiResponse_ActorProfileExample struct {
 // ActorProfileExample provides an example for actor profile response payload
 type ActorProfileExample struct {
 	ID                  uuid.UUID `json:"id" example:"e088d183-9eea-4a11-8d5d-74d7ec91bdf5"`
-	DID                 uuid.UUID `json:"did" example:"123e4567-e89b-12d3-a456-426614174000"`
+	DID                 string    `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
 	UniversalIdentifier string    `json:"universalIdentifier" example:"user123"`
 	Email               string    `json:"email" example:"actor@example.com"`
+	EmailVerified       bool      `json:"emailVerified" example:"true"`
 	FirstName           string    `json:"firstName" example:"John"`
 	LastName            string    `json:"lastName" example:"Doe"`
 	PhoneNumber         *string   `json:"phoneNumber" example:"+1234567890"`
+	PhoneVerified       bool      `json:"phoneVerified" example:"false"`
 	MasterPublicKey     string    `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
+	MasterKeyThumbprint *string   `json:"masterKeyThumbprint" example:"NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"`
 	VerificationLevel   string    `json:"verificationLevel" example:"Tier0_Unverified"`
 	EntityType          string    `json:"entityType" example:"Individual"`
 	Nationality         *string   `json:"nationality" example:"US"`
@@ -161,9 +165,9 @@ type ApiResponse_ResolveResponseExample struct {
 
 // ResolveResponseExample provides an example for resolve response payload
 type ResolveResponseExample struct {
-	UniversalIdentifier string    `json:"universalIdentifier" example:"user123"`
-	MasterPublicKey     string    `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
-	DID                 uuid.UUID `json:"did" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UniversalIdentifier string `json:"universalIdentifier" example:"user123"`
+	MasterPublicKey     string `json:"masterPublicKey" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
+	DID                 string `json:"did" example:"did:key:zDnaerDaTF5BXEavCrfRZEk316dpbLsfPDZ3WJ5hRTPFU2169"`
 }
 
 // ApiResponse_SuccessExample provides an example for success response
@@ -67,6 +67,20 @@ type UnauthorizedExample struct {
 	ErrMsg string `json:"errmsg" example:"Unauthorized. The JWT is missing, invalid, or expired"`
 }
 
+type ForbiddenExample struct {
+	MsgID  string `json:"msgid" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Status string `json:"status" example:"failed"`
+	Err    string `json:"err" example:"FORBIDDEN"`
+	ErrMsg string `json:"errmsg" example:"You do not have permission to perform this action"`
+}
+
+type TooManyRequestsExample struct {
+	MsgID  string `json:"msgid" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Status string `json:"status" example:"failed"`
+	Err    string `json:"err" example:"TOO_MANY_REQUESTS"`
+	ErrMsg string `json:"errmsg" example:"Too many verification messages requested; try again later"`
+}
+
 // A sample envelope matching the exact JSON structure provided by the user.
 // Useful for swagger examples or documentation.
 type ErrorEnvelope[T any] struct {
@@ -0,0 +1,28 @@
+package response
+
+// DataExportResponse represents a personal data export. Size and sha256 describe the unencrypted
+// zip bundle and are set once it is ready.
+type DataExportResponse struct {
+	ExportID     string `json:"exportId" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+	Status       string `json:"status" example:"ready"`
+	Size         int64  `json:"size,omitempty" example:"482113"`
+	SHA256       string `json:"sha256,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Error        string `json:"error,omitempty" example:"failed to list login events: HTTP 403"`
+	ExpiresAt    string `json:"expiresAt,omitempty" example:"2025-10-30T06:25:31Z"`
+	DownloadedAt string `json:"downloadedAt,omitempty" example:"2025-10-23T06:40:02Z"`
+	CompletedAt  string `json:"completedAt,omitempty" example:"2025-10-23T06:25:31Z"`
+	CreatedAt    string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+	Message      string `json:"message,omitempty" example:"Data export requested; a webhook is sent and the export is listed as ready once the bundle is prepared"`
+}
+
+// ListDataExportsResponse represents the caller's most recent data exports
+type ListDataExportsResponse struct {
+	Exports []DataExportResponse `json:"exports"`
+}
+
+// DataExportLinkResponse represents a single-use download link for a data export
+type DataExportLinkResponse struct {
+	ExportID  string `json:"exportId" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+	URL       string `json:"url" example:"https://api.example.com/v1/actor/exports/download/q3x9Yb0m2R4L5tW8vZ1cN6pK7jH0sD3fG5aE2uI4oQ8"`
+	ExpiresAt string `json:"expiresAt" example:"2025-10-23T06:40:25Z"`
+}
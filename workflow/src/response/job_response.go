@@ -0,0 +1,17 @@
+package response
+
+// JobResponse represents the progress of a background job
+type JobResponse struct {
+	JobID       string                 `json:"jobId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Type        string                 `json:"type" example:"credential_verification"`
+	Status      string                 `json:"status" example:"queued"`
+	ResourceID  *string                `json:"resourceId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Attempts    int                    `json:"attempts" example:"1"`
+	MaxAttempts int                    `json:"maxAttempts" example:"5"`
+	NextRunAt   *string                `json:"nextRunAt,omitempty" example:"2025-10-23T06:25:45Z"`
+	LastError   *string                `json:"lastError,omitempty" example:"Issuer DID could not be resolved"`
+	Result      map[string]interface{} `json:"result,omitempty"`
+	CreatedAt   string                 `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+	UpdatedAt   string                 `json:"updatedAt" example:"2025-10-23T06:25:35Z"`
+	CompletedAt *string                `json:"completedAt,omitempty" example:"2025-10-23T06:25:36Z"`
+}
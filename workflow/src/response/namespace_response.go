@@ -0,0 +1,28 @@
+package response
+
+// NamespaceDomainResponse represents an identifier domain registered to an organization
+type NamespaceDomainResponse struct {
+	Domain       string `json:"domain" example:"acme"`
+	OwnerActorID string `json:"ownerActorId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Open         bool   `json:"open" example:"false"`
+	CreatedAt    string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListNamespaceDomainsResponse represents the default identifier domain and the registered ones
+type ListNamespaceDomainsResponse struct {
+	DefaultDomain string                    `json:"defaultDomain" example:"finternet"`
+	Domains       []NamespaceDomainResponse `json:"domains"`
+}
+
+// ReservedWordResponse represents a word identifiers may not be (reserved) or contain (blocked)
+type ReservedWordResponse struct {
+	Word      string `json:"word" example:"admin"`
+	Kind      string `json:"kind" example:"reserved"`
+	Reason    string `json:"reason,omitempty" example:"Impersonates platform staff"`
+	CreatedAt string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListReservedWordsResponse represents the reserved and blocked word list
+type ListReservedWordsResponse struct {
+	Words []ReservedWordResponse `json:"words"`
+}
@@ -0,0 +1,105 @@
+package response
+
+// CredentialOfferResponse represents a created OID4VCI credential offer
+type CredentialOfferResponse struct {
+	OfferID            string          `json:"offerId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	CredentialOfferURI string          `json:"credentialOfferUri" example:"openid-credential-offer://?credential_offer=%7B%22credential_issuer%22..."`
+	CredentialOffer    CredentialOffer `json:"credentialOffer"`
+	TxCode             string          `json:"txCode,omitempty" example:"493817"`
+	ExpiresAt          string          `json:"expiresAt" example:"2025-10-23T06:35:25Z"`
+}
+
+// CredentialOffer is the OID4VCI credential offer object handed to the wallet
+type CredentialOffer struct {
+	CredentialIssuer           string                `json:"credential_issuer" example:"https://api.example.com"`
+	CredentialConfigurationIDs []string              `json:"credential_configuration_ids" example:"VerifiedIdentityCredential_jwt_vc_json"`
+	Grants                     CredentialOfferGrants `json:"grants"`
+}
+
+// CredentialOfferGrants lists the grants a wallet may use to redeem an offer
+type CredentialOfferGrants struct {
+	PreAuthorizedCode PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code"`
+}
+
+// PreAuthorizedCodeGrant carries the pre-authorized code and transaction code requirements
+type PreAuthorizedCodeGrant struct {
+	PreAuthorizedCode string  `json:"pre-authorized_code"`
+	TxCode            *TxCode `json:"tx_code,omitempty"`
+}
+
+// TxCode describes the transaction code the wallet must collect from the user
+type TxCode struct {
+	InputMode   string `json:"input_mode"`
+	Length      int    `json:"length"`
+	Description string `json:"description,omitempty"`
+}
+
+// CredentialIssuerMetadata is served at /.well-known/openid-credential-issuer
+type CredentialIssuerMetadata struct {
+	CredentialIssuer                  string                             `json:"credential_issuer"`
+	CredentialEndpoint                string                             `json:"credential_endpoint"`
+	Display                           []DisplayProperties                `json:"display,omitempty"`
+	CredentialConfigurationsSupported map[string]CredentialConfiguration `json:"credential_configurations_supported"`
+}
+
+// CredentialConfiguration describes one credential the issuer can issue
+type CredentialConfiguration struct {
+	Format                               string                      `json:"format"`
+	Scope                                string                      `json:"scope,omitempty"`
+	CryptographicBindingMethodsSupported []string                    `json:"cryptographic_binding_methods_supported"`
+	CredentialSigningAlgValuesSupported  []string                    `json:"credential_signing_alg_values_supported"`
+	ProofTypesSupported                  map[string]ProofTypeSupport `json:"proof_types_supported"`
+	CredentialDefinition                 CredentialDefinition        `json:"credential_definition"`
+	Display                              []DisplayProperties         `json:"display,omitempty"`
+}
+
+// ProofTypeSupport lists the algorithms accepted for a proof type
+type ProofTypeSupport struct {
+	ProofSigningAlgValuesSupported []string `json:"proof_signing_alg_values_supported"`
+}
+
+// CredentialDefinition lists the W3C types of a credential configuration
+type CredentialDefinition struct {
+	Type []string `json:"type"`
+}
+
+// DisplayProperties holds localized display information
+type DisplayProperties struct {
+	Name   string `json:"name"`
+	Locale string `json:"locale,omitempty"`
+}
+
+// AuthorizationServerMetadata is served at /.well-known/oauth-authorization-server
+type AuthorizationServerMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	PreAuthorizedGrantAnonymousAccess bool     `json:"pre-authorized_grant_anonymous_access_supported"`
+}
+
+// OID4VCITokenResponse is returned by the token endpoint
+type OID4VCITokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	CNonce          string `json:"c_nonce"`
+	CNonceExpiresIn int    `json:"c_nonce_expires_in"`
+}
+
+// OID4VCICredentialResponse is returned by the credential endpoint
+type OID4VCICredentialResponse struct {
+	Format          string `json:"format"`
+	Credential      string `json:"credential"`
+	CNonce          string `json:"c_nonce"`
+	CNonceExpiresIn int    `json:"c_nonce_expires_in"`
+}
+
+// OAuthErrorResponse is the error body of OAuth 2.0 and OID4VCI protocol endpoints.
+// A fresh c_nonce is included when a credential request failed with invalid_nonce.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	CNonce           string `json:"c_nonce,omitempty"`
+	CNonceExpiresIn  int    `json:"c_nonce_expires_in,omitempty"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import "app/src/model"
+
+// PresentationRequestResponse represents a created OID4VP authorization request. The callback
+// secret signs the callback payload like webhook deliveries and is only returned here.
+type PresentationRequestResponse struct {
+	RequestID               string `json:"requestId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	AuthorizationRequestURI string `json:"authorizationRequestUri" example:"openid4vp://?client_id=did%3Aweb%3Aapi.example.com&request_uri=https%3A%2F%2Fapi.example.com%2Foid4vp%2Frequest%2F0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	RequestURI              string `json:"requestUri" example:"https://api.example.com/oid4vp/request/0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Status                  string `json:"status" example:"pending"`
+	ExpiresAt               string `json:"expiresAt" example:"2025-10-23T06:35:25Z"`
+	CallbackSecret          string `json:"callbackSecret,omitempty" example:"3q2-7wLk0y9mQn4ZpV1sXc8bR6tJhGfD5eA2uI0oPlM"`
+}
+
+// PresentationStatusResponse represents the polled state of an OID4VP authorization request
+type PresentationStatusResponse struct {
+	RequestID      string                    `json:"requestId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Status         string                    `json:"status" example:"verified"`
+	Result         *model.PresentationResult `json:"result,omitempty"`
+	CallbackStatus *string                   `json:"callbackStatus,omitempty" example:"delivered"`
+	ExpiresAt      string                    `json:"expiresAt" example:"2025-10-23T06:35:25Z"`
+	RetrievedAt    *string                   `json:"retrievedAt,omitempty" example:"2025-10-23T06:26:02Z"`
+	CompletedAt    *string                   `json:"completedAt,omitempty" example:"2025-10-23T06:26:40Z"`
+}
+
+// PresentationCallbackPayload is posted to a verifier's callback URL once a request completes
+type PresentationCallbackPayload struct {
+	RequestID   string                   `json:"requestId"`
+	Status      string                   `json:"status"`
+	Result      model.PresentationResult `json:"result"`
+	CompletedAt string                   `json:"completedAt"`
+}
@@ -0,0 +1,34 @@
+package response
+
+// OrganizationInvitationResponse represents an invitation to join an organization
+type OrganizationInvitationResponse struct {
+	InvitationID           string `json:"invitationId" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+	OrganizationID         string `json:"organizationId" example:"019a0f1e-2d3c-7b4a-8596-a7b8c9d0e1f2"`
+	OrganizationIdentifier string `json:"organizationIdentifier" example:"acme-bank@finternet"`
+	InviteeID              string `json:"inviteeId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	InviteeIdentifier      string `json:"inviteeIdentifier" example:"alice@finternet"`
+	Role                   string `json:"role" example:"operator"`
+	Status                 string `json:"status" example:"pending"`
+	InvitedBy              string `json:"invitedBy" example:"019a0f1e-2d3c-7b4a-8596-a7b8c9d0e1f2"`
+	ExpiresAt              string `json:"expiresAt" example:"2025-10-30T06:25:25Z"`
+	CreatedAt              string `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListOrganizationInvitationsResponse represents a list of organization invitations
+type ListOrganizationInvitationsResponse struct {
+	Invitations []OrganizationInvitationResponse `json:"invitations"`
+}
+
+// OrganizationMemberResponse represents the membership of an actor in an organization
+type OrganizationMemberResponse struct {
+	OrganizationID string `json:"organizationId" example:"019a0f1e-2d3c-7b4a-8596-a7b8c9d0e1f2"`
+	ActorID        string `json:"actorId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Role           string `json:"role" example:"operator"`
+	CreatedAt      string `json:"createdAt" example:"2025-10-23T06:40:02Z"`
+}
+
+// ListOrganizationMembersResponse represents the members of an organization, or the memberships
+// of the caller
+type ListOrganizationMembersResponse struct {
+	Members []OrganizationMemberResponse `json:"members"`
+}
@@ -0,0 +1,69 @@
+package response
+
+// ShareGrantResponse represents a credential share grant
+type ShareGrantResponse struct {
+	GrantID           string   `json:"grantId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	OwnerIdentifier   string   `json:"ownerIdentifier" example:"jane-doe"`
+	GranteeIdentifier string   `json:"granteeIdentifier" example:"acme-bank"`
+	CredentialIDs     []string `json:"credentialIds" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Purpose           *string  `json:"purpose,omitempty" example:"Mortgage application KYC"`
+	Status            string   `json:"status" example:"active"`
+	ExpiresAt         string   `json:"expiresAt" example:"2025-12-31T23:59:59Z"`
+	RevokedAt         *string  `json:"revokedAt,omitempty" example:"2025-11-02T09:14:00Z"`
+	CreatedAt         string   `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListShareGrantsResponse represents one page of share grants
+type ListShareGrantsResponse struct {
+	Grants     []ShareGrantResponse `json:"grants"`
+	NextCursor string               `json:"nextCursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
+
+// ShareAccessLogResponse represents one recorded access to shared data
+type ShareAccessLogResponse struct {
+	LogID        string  `json:"logId" example:"0193a7c5-0a1b-7c2d-8e3f-4a5b6c7d8e9f"`
+	GrantID      string  `json:"grantId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	GranteeID    string  `json:"granteeId" example:"123e4567-e89b-12d3-a456-426614174111"`
+	Action       string  `json:"action" example:"view_credential"`
+	CredentialID *string `json:"credentialId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	DocumentID   *string `json:"documentId,omitempty" example:"123e4567-e89b-12d3-a456-426614174222"`
+	IPAddress    string  `json:"ipAddress,omitempty" example:"203.0.113.7"`
+	UserAgent    string  `json:"userAgent,omitempty" example:"Mozilla/5.0"`
+	AccessedAt   string  `json:"accessedAt" example:"2025-10-24T10:02:11Z"`
+}
+
+// ListShareAccessLogResponse represents one page of the share access log
+type ListShareAccessLogResponse struct {
+	Entries    []ShareAccessLogResponse `json:"entries"`
+	NextCursor string                   `json:"nextCursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
+
+// SharedCredentialResponse represents a credential visible to a grantee
+type SharedCredentialResponse struct {
+	CredentialID         string                       `json:"credentialId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Type                 string                       `json:"type" example:"GovernmentID"`
+	Status               string                       `json:"status" example:"Verified"`
+	Issuer               string                       `json:"issuer" example:"did:web:issuer.example.gov"`
+	SubmittedAt          string                       `json:"submittedAt" example:"2025-10-23T06:25:25Z"`
+	VerifiableCredential interface{}                  `json:"verifiableCredential,omitempty" swaggertype:"object"`
+	CredentialJWT        string                       `json:"credentialJwt,omitempty" example:"eyJhbGciOiJFUzI1NiJ9..."`
+	HasDocument          bool                         `json:"hasDocument" example:"true"`
+	Documents            []CredentialDocumentResponse `json:"documents"`
+}
+
+// ListSharedCredentialsResponse represents the credentials covered by a received grant
+type ListSharedCredentialsResponse struct {
+	GrantID         string                     `json:"grantId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	OwnerIdentifier string                     `json:"ownerIdentifier" example:"jane-doe"`
+	ExpiresAt       string                     `json:"expiresAt" example:"2025-12-31T23:59:59Z"`
+	Credentials     []SharedCredentialResponse `json:"credentials"`
+}
+
+// SharedDocumentResponse represents a time-limited download link for a shared document
+type SharedDocumentResponse struct {
+	DocumentID  string  `json:"documentId" example:"123e4567-e89b-12d3-a456-426614174222"`
+	FileName    string  `json:"fileName" example:"passport.pdf"`
+	MimeType    *string `json:"mimeType,omitempty" example:"application/pdf"`
+	DownloadURL string  `json:"downloadUrl" example:"https://storage.example.com/documents/passport.pdf?X-Amz-Signature=..."`
+	ExpiresAt   string  `json:"expiresAt" example:"2025-10-24T10:17:11Z"`
+}
@@ -0,0 +1,26 @@
+package response
+
+// TrustedIssuerResponse represents a trusted issuer registry entry
+type TrustedIssuerResponse struct {
+	IssuerID          string   `json:"issuerId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	IssuerDID         string   `json:"issuerDid" example:"did:web:issuer.example.gov"`
+	Name              string   `json:"name" example:"Example Government ID Authority"`
+	VerificationTypes []string `json:"verificationTypes" example:"GovernmentID"`
+	Jurisdictions     []string `json:"jurisdictions" example:"US"`
+	ValidFrom         *string  `json:"validFrom,omitempty" example:"2025-01-01T00:00:00Z"`
+	ValidUntil        *string  `json:"validUntil,omitempty" example:"2027-01-01T00:00:00Z"`
+	Status            string   `json:"status" example:"active"`
+	Source            string   `json:"source" example:"manual"`
+	UpdatedAt         string   `json:"updatedAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListTrustedIssuersResponse represents the response for listing trusted issuers
+type ListTrustedIssuersResponse struct {
+	Issuers []TrustedIssuerResponse `json:"issuers"`
+}
+
+// ImportTrustListResponse represents the result of importing a signed trust list
+type ImportTrustListResponse struct {
+	Version  string `json:"version" example:"2025.10"`
+	Imported int    `json:"imported" example:"42"`
+}
@@ -0,0 +1,33 @@
+package response
+
+// ImportWalletResponse reports the documents and credentials recreated from a wallet archive
+type ImportWalletResponse struct {
+	ExportID      string                       `json:"exportId" example:"0193a7d0-5b6c-7d8e-9f0a-1b2c3d4e5f60"`
+	Issuer        string                       `json:"issuer" example:"did:web:node.finternet.example"`
+	SourceActorID string                       `json:"sourceActorId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Documents     []ImportedDocumentResponse   `json:"documents"`
+	Credentials   []ImportedCredentialResponse `json:"credentials"`
+	Skipped       []SkippedCredentialResponse  `json:"skipped"`
+}
+
+// ImportedDocumentResponse maps an archived document to the document created from it
+type ImportedDocumentResponse struct {
+	SourceDocumentID string `json:"sourceDocumentId" example:"0193a7d0-0000-7d8e-9f0a-1b2c3d4e5f60"`
+	DocumentID       string `json:"documentId" example:"0193a7d1-1111-7d8e-9f0a-1b2c3d4e5f60"`
+	FileName         string `json:"fileName" example:"passport.pdf"`
+}
+
+// ImportedCredentialResponse maps an archived credential to the credential created from it.
+// Credentials that are verified again carry the ID of their verification job.
+type ImportedCredentialResponse struct {
+	SourceCredentialID string  `json:"sourceCredentialId" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	CredentialID       string  `json:"credentialId" example:"9b2e4f1a-3c5d-4e6f-8a7b-0c1d2e3f4a5b"`
+	Status             string  `json:"status" example:"Pending"`
+	JobID              *string `json:"jobId,omitempty" example:"0193a7d2-2222-7d8e-9f0a-1b2c3d4e5f60"`
+}
+
+// SkippedCredentialResponse is an archived credential that was not imported
+type SkippedCredentialResponse struct {
+	SourceCredentialID string `json:"sourceCredentialId" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"`
+	Reason             string `json:"reason" example:"Credential issuer is not trusted"`
+}
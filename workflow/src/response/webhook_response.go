@@ -0,0 +1,40 @@
+package response
+
+// WebhookSubscriptionResponse represents a webhook subscription. The signing secret is
+// only returned when the subscription is created.
+type WebhookSubscriptionResponse struct {
+	SubscriptionID string   `json:"subscriptionId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Scope          string   `json:"scope" example:"actor"`
+	URL            string   `json:"url" example:"https://partner.example.com/webhooks/units"`
+	Events         []string `json:"events" example:"credential.status_changed"`
+	Description    *string  `json:"description,omitempty" example:"Loan origination status sync"`
+	Secret         string   `json:"secret,omitempty" example:"3q2-7wLk0y9mQn4ZpV1sXc8bR6tJhGfD5eA2uI0oPlM"`
+	CreatedAt      string   `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+}
+
+// ListWebhookSubscriptionsResponse represents the response for listing webhook subscriptions
+type ListWebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscriptionResponse `json:"subscriptions"`
+}
+
+// WebhookDeliveryResponse represents one entry of the webhook delivery log
+type WebhookDeliveryResponse struct {
+	DeliveryID     string                 `json:"deliveryId" example:"0193a7c5-0a1b-7c2d-8e3f-4a5b6c7d8e9f"`
+	SubscriptionID string                 `json:"subscriptionId" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	EventID        string                 `json:"eventId" example:"0193a7c5-0a1b-7c2d-8e3f-4a5b6c7d0000"`
+	EventType      string                 `json:"eventType" example:"credential.status_changed"`
+	Status         string                 `json:"status" example:"delivered"`
+	Attempts       int                    `json:"attempts" example:"1"`
+	ResponseStatus *int                   `json:"responseStatus,omitempty" example:"200"`
+	LastError      *string                `json:"lastError,omitempty" example:"endpoint returned status 503"`
+	ReplayOf       *string                `json:"replayOf,omitempty" example:"0193a7c5-0a1b-7c2d-8e3f-4a5b6c7d1111"`
+	Payload        map[string]interface{} `json:"payload"`
+	CreatedAt      string                 `json:"createdAt" example:"2025-10-23T06:25:25Z"`
+	DeliveredAt    *string                `json:"deliveredAt,omitempty" example:"2025-10-23T06:25:26Z"`
+}
+
+// ListWebhookDeliveriesResponse represents one page of the webhook delivery log
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	NextCursor string                    `json:"nextCursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
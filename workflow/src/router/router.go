@@ -15,12 +15,27 @@ import (
 
 // Router manages all application routes
 type Router struct {
-	app                   *fiber.App
-	cfg                   *config.Config
-	actorController       *controller.ActorController
-	credentialsController *controller.CredentialController
-	healthCheckController *controller.HealthCheckController
-	authMiddleware        *middleware.AuthMiddleware
+	app                     *fiber.App
+	cfg                     *config.Config
+	actorController         *controller.ActorController
+	credentialsController   *controller.CredentialController
+	healthCheckController   *controller.HealthCheckController
+	trustedIssuerController *controller.TrustedIssuerController
+	oid4vciController       *controller.OID4VCIController
+	oid4vpController        *controller.OID4VPController
+	shareController         *controller.ShareController
+	jobController           *controller.JobController
+	webhookController       *controller.WebhookController
+	walletController        *controller.WalletController
+	didController           *controller.DIDController
+	identifierController    *controller.IdentifierController
+	namespaceController     *controller.NamespaceController
+	deletionController      *controller.DeletionController
+	exportController        *controller.ExportController
+	verificationController  *controller.ContactVerificationController
+	organizationController  *controller.OrganizationController
+	delegationController    *controller.DelegationController
+	authMiddleware          *middleware.AuthMiddleware
 }
 
 // NewRouter creates a new router instance with all dependencies injected
@@ -30,16 +45,46 @@ func NewRouter(
 	actorController *controller.ActorController,
 	credentialsController *controller.CredentialController,
 	healthCheckController *controller.HealthCheckController,
+	trustedIssuerController *controller.TrustedIssuerController,
+	oid4vciController *controller.OID4VCIController,
+	oid4vpController *controller.OID4VPController,
+	shareController *controller.ShareController,
+	jobController *controller.JobController,
+	webhookController *controller.WebhookController,
+	walletController *controller.WalletController,
+	didController *controller.DIDController,
+	identifierController *controller.IdentifierController,
+	namespaceController *controller.NamespaceController,
+	deletionController *controller.DeletionController,
+	exportController *controller.ExportController,
+	verificationController *controller.ContactVerificationController,
+	organizationController *controller.OrganizationController,
+	delegationController *controller.DelegationController,
 	authMiddleware *middleware.AuthMiddleware,
 	middlewareProviders *middleware.MiddlewareProviders,
 ) *Router {
 	r := &Router{
-		app:                   app,
-		cfg:                   cfg,
-		actorController:       actorController,
-		credentialsController: credentialsController,
-		healthCheckController: healthCheckController,
-		authMiddleware:        authMiddleware,
+		app:                     app,
+		cfg:                     cfg,
+		actorController:         actorController,
+		credentialsController:   credentialsController,
+		healthCheckController:   healthCheckController,
+		trustedIssuerController: trustedIssuerController,
+		oid4vciController:       oid4vciController,
+		oid4vpController:        oid4vpController,
+		shareController:         shareController,
+		jobController:           jobController,
+		webhookController:       webhookController,
+		walletController:        walletController,
+		didController:           didController,
+		identifierController:    identifierController,
+		namespaceController:     namespaceController,
+		deletionController:      deletionController,
+		exportController:        exportController,
+		verificationController:  verificationController,
+		organizationController:  organizationController,
+		delegationController:    delegationController,
+		authMiddleware:          authMiddleware,
 	}
 
 	r.setupMiddleware(middlewareProviders)
@@ -64,6 +109,16 @@ func (r *Router) setupRoutes() {
 	r.setupHealthCheckRoutes(v1)
 	r.setupActorRoutes(v1)
 	r.setupCredentialsRoutes(v1)
+	r.setupAdminRoutes(v1)
+	r.setupOID4VCIRoutes(v1)
+	r.setupOID4VPRoutes(v1)
+	r.setupSharingRoutes(v1)
+	r.setupJobRoutes(v1)
+	r.setupWebhookRoutes(v1)
+	r.setupWalletRoutes(v1)
+	r.setupDIDRoutes(v1)
+	r.setupOrganizationRoutes(v1)
+	r.setupDelegationRoutes(v1)
 
 	if !r.cfg.IsProd {
 		r.setupDocsRoutes(v1)
@@ -83,26 +138,220 @@ func (r *Router) setupActorRoutes(v1 fiber.Router) {
 	auth := r.authMiddleware.Authenticate()
 
 	// Public routes
+	actor.Post("/challenge", r.actorController.IssueChallenge)
 	actor.Post("/create", r.actorController.RegisterActor)
 	actor.Post("/login", r.actorController.Login)
+	actor.Post("/loginWithKey", r.actorController.LoginWithKey)
 	actor.Post("/forgotPassword", r.actorController.ForgotPassword)
 	actor.Post("/resolve", r.actorController.ResolveUniversalIdentifier)
+	actor.Post("/resolveBatch", r.actorController.ResolveBatch)
+	actor.Post("/deletion/certificate", r.deletionController.GetCertificate)
+	v1.Get(constants.RouteDataExportDownload, r.exportController.Download)
 
 	// Protected routes
-	actor.Post("/update", auth, r.actorController.UpdateActor)
+	actor.Post("/update", auth, r.authMiddleware.ActOnBehalfOf(constants.DelegationScopeProfileUpdate), r.actorController.UpdateActor)
+	actor.Post("/rotateKey", auth, r.actorController.RotateKey)
 	actor.Post("/getProfile", auth, r.actorController.GetProfile)
+	actor.Post("/verificationHistory", auth, r.actorController.GetVerificationHistory)
 	actor.Post("/signout", auth, r.actorController.Signout)
+	actor.Post("/deletion/request", auth, r.deletionController.RequestDeletion)
+	actor.Post("/deletion/get", auth, r.deletionController.GetDeletion)
+	actor.Post("/deletion/cancel", auth, r.deletionController.CancelDeletion)
+	actor.Post("/exports/request", auth, r.exportController.RequestExport)
+	actor.Post("/exports/list", auth, r.exportController.ListExports)
+	actor.Post("/exports/link", auth, r.exportController.CreateLink)
+
+	identifiers := actor.Group("/identifiers", auth)
+	identifiers.Post("/add", r.identifierController.AddIdentifier)
+	identifiers.Post("/list", r.identifierController.ListIdentifiers)
+	identifiers.Post("/setPrimary", r.identifierController.SetPrimary)
+	identifiers.Post("/remove", r.identifierController.RemoveIdentifier)
+
+	verify := actor.Group("/verify", auth)
+	verify.Post("/email/send", r.verificationController.SendEmailVerification)
+	verify.Post("/email/check", r.verificationController.CheckEmailVerification)
+	verify.Post("/phone/send", r.verificationController.SendPhoneVerification)
+	verify.Post("/phone/confirm", r.verificationController.ConfirmPhoneVerification)
+
+	organizations := actor.Group("/organizations", auth)
+	organizations.Post("/list", r.organizationController.ListMemberships)
+	organizations.Post("/leave", r.organizationController.LeaveOrganization)
+	organizations.Post("/invitations/list", r.organizationController.ListMyInvitations)
+	organizations.Post("/invitations/accept", r.organizationController.AcceptInvitation)
+	organizations.Post("/invitations/decline", r.organizationController.DeclineInvitation)
 }
 
 // setupCredentialsRoutes sets up credentials routes (all protected)
 func (r *Router) setupCredentialsRoutes(v1 fiber.Router) {
 	credentials := v1.Group("/credentials", r.authMiddleware.Authenticate())
 
-	credentials.Post("/add", r.credentialsController.AddCredential)
-	credentials.Post("/list", r.credentialsController.ListCredentials)
-	credentials.Post("/get", r.credentialsController.GetCredential)
-	credentials.Post("/delete", r.credentialsController.DeleteCredential)
-	credentials.Post("/upload", r.credentialsController.UploadFile)
+	// Members can manage the credentials of an organization through the X-Organization-ID header,
+	// and delegates those of their principal through the X-On-Behalf-Of header
+	read := r.authMiddleware.ActAsOrganization(constants.OrganizationRoleViewer)
+	write := r.authMiddleware.ActAsOrganization(constants.OrganizationRoleOperator)
+	onBehalf := r.authMiddleware.ActOnBehalfOf
+
+	credentials.Post("/add", onBehalf(constants.DelegationScopeCredentialsAdd), write, r.credentialsController.AddCredential)
+	credentials.Post("/list", onBehalf(constants.DelegationScopeCredentialsList), read, r.credentialsController.ListCredentials)
+	credentials.Post("/get", onBehalf(constants.DelegationScopeCredentialsList), read, r.credentialsController.GetCredential)
+	credentials.Post("/delete", write, r.credentialsController.DeleteCredential)
+	credentials.Post("/upload", onBehalf(constants.DelegationScopeDocumentsUpload), write, r.credentialsController.UploadFile)
+}
+
+// setupOrganizationRoutes sets up the routes Business actors, or their members acting through the
+// X-Organization-ID header, use to manage members (all protected)
+func (r *Router) setupOrganizationRoutes(v1 fiber.Router) {
+	organizations := v1.Group(constants.RouteOrganizations, r.authMiddleware.Authenticate())
+
+	admin := r.authMiddleware.ActAsOrganization(constants.OrganizationRoleAdmin)
+	viewer := r.authMiddleware.ActAsOrganization(constants.OrganizationRoleViewer)
+
+	organizations.Post("/invitations/create", admin, r.organizationController.CreateInvitation)
+	organizations.Post("/invitations/list", admin, r.organizationController.ListInvitations)
+	organizations.Post("/invitations/revoke", admin, r.organizationController.RevokeInvitation)
+	organizations.Post("/members/list", viewer, r.organizationController.ListMembers)
+	organizations.Post("/members/updateRole", admin, r.organizationController.UpdateMemberRole)
+	organizations.Post("/members/remove", admin, r.organizationController.RemoveMember)
+}
+
+// setupAdminRoutes sets up administrative routes (protected, admin role required)
+func (r *Router) setupAdminRoutes(v1 fiber.Router) {
+	admin := v1.Group("/admin",
+		r.authMiddleware.Authenticate(),
+		r.authMiddleware.RequireRole(r.cfg.AuthAdminRole),
+	)
+
+	trustedIssuers := admin.Group("/trustedIssuers")
+
+	trustedIssuers.Post("/add", r.trustedIssuerController.AddIssuer)
+	trustedIssuers.Post("/list", r.trustedIssuerController.ListIssuers)
+	trustedIssuers.Post("/get", r.trustedIssuerController.GetIssuer)
+	trustedIssuers.Post("/update", r.trustedIssuerController.UpdateIssuer)
+	trustedIssuers.Post("/delete", r.trustedIssuerController.DeleteIssuer)
+	trustedIssuers.Post("/import", r.trustedIssuerController.ImportTrustList)
+
+	credentials := admin.Group("/credentials")
+	credentials.Post("/updateStatus", r.credentialsController.UpdateCredentialStatus)
+
+	domains := admin.Group("/namespaces/domains")
+	domains.Post("/add", r.namespaceController.AddDomain)
+	domains.Post("/list", r.namespaceController.ListDomains)
+	domains.Post("/remove", r.namespaceController.RemoveDomain)
+
+	words := admin.Group("/namespaces/words")
+	words.Post("/add", r.namespaceController.AddWord)
+	words.Post("/list", r.namespaceController.ListWords)
+	words.Post("/remove", r.namespaceController.RemoveWord)
+
+	holds := admin.Group("/retentionHolds")
+	holds.Post("/add", r.deletionController.AddHold)
+	holds.Post("/list", r.deletionController.ListHolds)
+	holds.Post("/release", r.deletionController.ReleaseHold)
+
+	r.mountWebhookRoutes(admin.Group(constants.RouteWebhooks), constants.WebhookScopePartner)
+}
+
+// setupOID4VCIRoutes sets up the credential issuer routes. Discovery documents and the
+// wallet-facing token and credential endpoints are mounted outside /v1 at the issuer URL.
+func (r *Router) setupOID4VCIRoutes(v1 fiber.Router) {
+	r.app.Get(constants.RouteWellKnownCredentialIssuer, r.oid4vciController.IssuerMetadata)
+	r.app.Get(constants.RouteWellKnownOAuthServer, r.oid4vciController.AuthorizationServerMetadata)
+	r.app.Get(constants.RouteWellKnownDID, r.oid4vciController.IssuerDIDDocument)
+
+	protocol := r.app.Group(constants.RouteOID4VCI)
+	protocol.Post(constants.RouteOID4VCIToken, r.oid4vciController.Token)
+	protocol.Post(constants.RouteOID4VCICredential, r.oid4vciController.Credential)
+
+	// Offers are created by authenticated actors; Keycloak login is the authorization step
+	offers := v1.Group(constants.RouteOID4VCI+"/offers", r.authMiddleware.Authenticate())
+	offers.Post("/create", r.oid4vciController.CreateOffer)
+}
+
+// setupOID4VPRoutes sets up the presentation verifier routes. The request object and
+// direct_post endpoints are called by wallets and are mounted outside /v1.
+func (r *Router) setupOID4VPRoutes(v1 fiber.Router) {
+	protocol := r.app.Group(constants.RouteOID4VP)
+	protocol.Get(constants.RouteOID4VPRequestObject, r.oid4vpController.RequestObject)
+	protocol.Post(constants.RouteOID4VPResponse, r.oid4vpController.DirectPost)
+
+	requests := v1.Group(constants.RouteOID4VP+"/requests", r.authMiddleware.Authenticate())
+	requests.Post("/create", r.oid4vpController.CreateRequest)
+	requests.Post("/get", r.oid4vpController.GetRequest)
+}
+
+// setupSharingRoutes sets up credential sharing routes for owners and grantees
+func (r *Router) setupSharingRoutes(v1 fiber.Router) {
+	sharing := v1.Group(constants.RouteSharing, r.authMiddleware.Authenticate())
+
+	grants := sharing.Group("/grants")
+	grants.Post("/create", r.shareController.CreateGrant)
+	grants.Post("/list", r.shareController.ListGrants)
+	grants.Post("/revoke", r.shareController.RevokeGrant)
+	grants.Post("/accessLog", r.shareController.ListAccessLog)
+
+	received := sharing.Group("/received")
+	received.Post("/list", r.shareController.ListReceivedGrants)
+	received.Post("/credentials", r.shareController.ListSharedCredentials)
+	received.Post("/getCredential", r.shareController.GetSharedCredential)
+	received.Post("/getDocument", r.shareController.GetSharedDocument)
+}
+
+// setupDelegationRoutes sets up delegated access routes for principals and delegates. Grants are
+// only managed by the actors themselves, never on behalf of someone else.
+func (r *Router) setupDelegationRoutes(v1 fiber.Router) {
+	delegations := v1.Group(constants.RouteDelegations, r.authMiddleware.Authenticate())
+
+	grants := delegations.Group("/grants")
+	grants.Post("/create", r.delegationController.CreateGrant)
+	grants.Post("/list", r.delegationController.ListGrants)
+	grants.Post("/revoke", r.delegationController.RevokeGrant)
+	grants.Post("/actions", r.delegationController.ListActions)
+
+	received := delegations.Group("/received")
+	received.Post("/list", r.delegationController.ListReceivedGrants)
+	received.Post("/actions", r.delegationController.ListPerformedActions)
+}
+
+// setupJobRoutes sets up background job polling routes
+func (r *Router) setupJobRoutes(v1 fiber.Router) {
+	jobs := v1.Group(constants.RouteJobs, r.authMiddleware.Authenticate())
+	jobs.Post("/get", r.jobController.GetJob)
+}
+
+// setupWebhookRoutes sets up webhook routes for the caller's own subscriptions
+func (r *Router) setupWebhookRoutes(v1 fiber.Router) {
+	webhooks := v1.Group(constants.RouteWebhooks, r.authMiddleware.Authenticate())
+	r.mountWebhookRoutes(webhooks, constants.WebhookScopeActor)
+}
+
+// mountWebhookRoutes mounts the subscription and delivery log routes for one webhook scope
+func (r *Router) mountWebhookRoutes(webhooks fiber.Router, scope string) {
+	subscriptions := webhooks.Group("/subscriptions")
+	subscriptions.Post("/create", r.webhookController.CreateSubscription(scope))
+	subscriptions.Post("/list", r.webhookController.ListSubscriptions(scope))
+	subscriptions.Post("/delete", r.webhookController.DeleteSubscription(scope))
+
+	deliveries := webhooks.Group("/deliveries")
+	deliveries.Post("/list", r.webhookController.ListDeliveries(scope))
+	deliveries.Post("/replay", r.webhookController.ReplayDelivery(scope))
+}
+
+// setupDIDRoutes sets up the actor did:web document routes. Documents are served outside /v1 at
+// the path did:web resolution expects; the platform document is served by the OID4VCI routes.
+func (r *Router) setupDIDRoutes(v1 fiber.Router) {
+	r.app.Get(constants.RouteActorDIDDocument, r.didController.ActorDocument)
+
+	services := v1.Group(constants.RouteDID+"/services", r.authMiddleware.Authenticate())
+	services.Post("/add", r.didController.AddService)
+	services.Post("/list", r.didController.ListServices)
+	services.Post("/remove", r.didController.RemoveService)
+}
+
+// setupWalletRoutes sets up wallet export and import routes (all protected)
+func (r *Router) setupWalletRoutes(v1 fiber.Router) {
+	wallet := v1.Group(constants.RouteWallet, r.authMiddleware.Authenticate())
+	wallet.Post("/export", r.walletController.Export)
+	wallet.Post("/import", r.walletController.Import)
 }
 
 // setupDocsRoutes sets up API documentation routes
@@ -0,0 +1,128 @@
+// Package seal encrypts data at rest with envelope encryption. Every payload is encrypted under its
+// own random data key with AES-256-GCM, and the data key is stored alongside it wrapped by a master
+// key, so that the master key never encrypts bulk data and can be rotated by rewrapping data keys.
+package seal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySize is the size in bytes of master and data keys
+const KeySize = 32
+
+// ErrOpen is returned when sealed data cannot be decrypted: it was sealed under another master key
+// or associated data, or it was modified
+var ErrOpen = errors.New("sealed data cannot be opened")
+
+// Sealer seals and opens payloads under a master key
+type Sealer struct {
+	master cipher.AEAD
+}
+
+// NewSealer creates a sealer for a KeySize-byte master key
+func NewSealer(masterKey []byte) (*Sealer, error) {
+	master, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{master: master}, nil
+}
+
+// GenerateKey returns a random KeySize-byte key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// LoadKeyFile reads a base64-encoded master key from a file
+func LoadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key file: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key file must hold a %d-byte key, got %d bytes", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// Seal encrypts plaintext under a new data key. The associated data, such as the ID of the record
+// the payload belongs to, is authenticated by both the ciphertext and the wrapped key, so that
+// neither can be swapped with those of another record.
+func (s *Sealer) Seal(plaintext, associatedData []byte) (ciphertext, wrappedKey []byte, err error) {
+	dataKey, err := GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ciphertext, err = encrypt(data, plaintext, associatedData); err != nil {
+		return nil, nil, err
+	}
+	if wrappedKey, err = encrypt(s.master, dataKey, associatedData); err != nil {
+		return nil, nil, err
+	}
+	return ciphertext, wrappedKey, nil
+}
+
+// Open decrypts a payload sealed with Seal under the same master key and associated data
+func (s *Sealer) Open(ciphertext, wrappedKey, associatedData []byte) ([]byte, error) {
+	dataKey, err := decrypt(s.master, wrappedKey, associatedData)
+	if err != nil {
+		return nil, err
+	}
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return decrypt(data, ciphertext, associatedData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns the nonce followed by the ciphertext
+func encrypt(aead cipher.AEAD, plaintext, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, associatedData), nil
+}
+
+func decrypt(aead cipher.AEAD, sealed, associatedData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrOpen
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"app/src/model"
+	"app/src/queue"
+	"app/src/utils"
+	"context"
+	"errors"
+)
+
+// AccountErasureHandler processes account erasure jobs, which are scheduled for the end of the
+// deletion grace period. Deletions cancelled in the meantime are skipped.
+type AccountErasureHandler struct {
+	deletions DeletionService
+}
+
+// NewAccountErasureHandler creates a new account erasure handler
+func NewAccountErasureHandler(deletions DeletionService) *AccountErasureHandler {
+	return &AccountErasureHandler{deletions: deletions}
+}
+
+// Handle implements queue.Handler
+func (h *AccountErasureHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("account erasure job has no deletion"))
+	}
+
+	if err := h.deletions.Erase(ctx, *job.ResourceID); err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+	return map[string]interface{}{"deletionId": job.ResourceID.String()}, nil
+}
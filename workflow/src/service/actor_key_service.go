@@ -0,0 +1,306 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/validation"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ActorKeyService defines the interface for master key rotation and the master key history.
+// A rotation endorsed by the current key takes effect immediately; a recovery authorized by the
+// account password for a lost key takes effect after a waiting period, during which a rotation
+// with the current key cancels it.
+type ActorKeyService interface {
+	RotateKey(c *fiber.Ctx, actorID uuid.UUID, req *validation.RotateKeyRequest) (*KeyRotationResult, error)
+
+	// FindKeyValidAt returns the master key of an actor that was valid at the RFC 3339 time at
+	FindKeyValidAt(c *fiber.Ctx, actorID uuid.UUID, at string) (*model.ActorKey, error)
+
+	// CompleteRecovery puts the key of a pending recovery into effect once its waiting period is over
+	CompleteRecovery(ctx context.Context, recoveryID uuid.UUID) error
+}
+
+// KeyRotationResult holds the new current key of a completed rotation, or the pending recovery
+type KeyRotationResult struct {
+	Key      *model.ActorKey
+	Recovery *model.KeyRecovery
+}
+
+type actorKeyService struct {
+	cfg          *config.Config
+	log          *logrus.Logger
+	db           *gorm.DB
+	validate     *validator.Validate
+	authService  AuthService
+	jobs         JobService
+	webhooks     WebhookService
+	resolver     ResolverService
+	actorRepo    repository.ActorRepository
+	actorKeyRepo repository.ActorKeyRepository
+	recoveryRepo repository.KeyRecoveryRepository
+}
+
+// NewActorKeyService creates a new actor key service instance
+func NewActorKeyService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	authService AuthService,
+	jobs JobService,
+	webhooks WebhookService,
+	resolver ResolverService,
+	actorRepo repository.ActorRepository,
+	actorKeyRepo repository.ActorKeyRepository,
+	recoveryRepo repository.KeyRecoveryRepository,
+) ActorKeyService {
+	return &actorKeyService{
+		cfg:          cfg,
+		log:          log,
+		db:           db,
+		validate:     validate,
+		authService:  authService,
+		jobs:         jobs,
+		webhooks:     webhooks,
+		resolver:     resolver,
+		actorRepo:    actorRepo,
+		actorKeyRepo: actorKeyRepo,
+		recoveryRepo: recoveryRepo,
+	}
+}
+
+func (s *actorKeyService) RotateKey(c *fiber.Ctx, actorID uuid.UUID, req *validation.RotateKeyRequest) (*KeyRotationResult, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	newKey, err := keys.NormalizePublicKey(req.NewPublicKey)
+	if err != nil {
+		s.log.Warnf("Rejected new master public key for actor %s: %v", actorID, err)
+		if errors.Is(err, keys.ErrWeakKey) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrWeakMasterPublicKey)
+		}
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidMasterPublicKey)
+	}
+
+	if req.Password != "" {
+		return s.startRecovery(c, actorID, newKey, req.Password)
+	}
+
+	result := &KeyRotationResult{}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		actor, err := s.actorRepo.LockByID(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+		currentKey, err := keys.ParsePublicKey(actor.MasterPublicKey)
+		if err != nil {
+			return fmt.Errorf("current master key of actor %s cannot be parsed: %w", actorID, err)
+		}
+		err = VerifyKeyRotationProof(currentKey, req.Proof, actor.DID, s.cfg.IssuerURL, newKey.Thumbprint, time.Now())
+		if err != nil {
+			s.log.Warnf("Rejected rotation proof for actor %s: %v", actorID, err)
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRotationProof)
+		}
+
+		// Proving possession of the current key shows it is not lost, so a pending recovery is void
+		cancelled, err := s.recoveryRepo.CancelPending(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+		if cancelled {
+			s.log.Infof("Cancelled pending key recovery of actor %s", actorID)
+		}
+
+		result.Key, err = s.rotate(ctx, tx, actor, newKey, constants.ActorKeyEndorsementSignature, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.resolver.InvalidateActor(actorID)
+	return result, nil
+}
+
+// startRecovery schedules a password-authorized key replacement for after the recovery waiting
+// period and notifies the actor's webhook subscribers so an unexpected recovery can be cancelled
+func (s *actorKeyService) startRecovery(c *fiber.Ctx, actorID uuid.UUID, newKey *keys.NormalizedKey, password string) (*KeyRotationResult, error) {
+	ctx := c.Context()
+
+	actor, err := s.actorRepo.FindByID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.authService.Login(actor.Email, password); err != nil {
+		s.log.Warnf("Key recovery for actor %s failed re-authentication: %v", actorID, err)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrInvalidCredentials)
+	}
+
+	recovery := &model.KeyRecovery{
+		ActorID:     actorID,
+		PublicKey:   newKey.PEM,
+		Thumbprint:  newKey.Thumbprint,
+		Status:      constants.KeyRecoveryStatusPending,
+		EffectiveAt: time.Now().UTC().Add(time.Duration(s.cfg.KeyRecoveryDelay) * time.Hour),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkKeyUnused(ctx, tx, newKey.Thumbprint); err != nil {
+			return err
+		}
+		if err := s.recoveryRepo.Create(ctx, tx, recovery); err != nil {
+			return err
+		}
+
+		err := s.jobs.Enqueue(ctx, tx, &model.Job{
+			Type:       constants.JobTypeKeyRecovery,
+			ActorID:    actorID,
+			ResourceID: &recovery.RecoveryID,
+			RunAt:      recovery.EffectiveAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.webhooks.Publish(ctx, tx, WebhookEvent{
+			Type:    constants.WebhookEventKeyRecoveryRequested,
+			ActorID: actorID,
+			Data: map[string]interface{}{
+				"recoveryId":  recovery.RecoveryID.String(),
+				"thumbprint":  recovery.Thumbprint,
+				"effectiveAt": recovery.EffectiveAt.Format(time.RFC3339),
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Key recovery %s scheduled for actor %s at %s", recovery.RecoveryID, actorID, recovery.EffectiveAt.Format(time.RFC3339))
+	return &KeyRotationResult{Recovery: recovery}, nil
+}
+
+func (s *actorKeyService) CompleteRecovery(ctx context.Context, recoveryID uuid.UUID) error {
+	var actorID uuid.UUID
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		recovery, err := s.recoveryRepo.LockByID(ctx, tx, recoveryID)
+		if err != nil {
+			return err
+		}
+		if recovery.Status != constants.KeyRecoveryStatusPending {
+			s.log.Infof("Key recovery %s is %s; nothing to do", recoveryID, recovery.Status)
+			return nil
+		}
+
+		now := time.Now().UTC()
+		if now.Before(recovery.EffectiveAt) {
+			return fmt.Errorf("key recovery %s takes effect at %s", recoveryID, recovery.EffectiveAt.Format(time.RFC3339))
+		}
+
+		actor, err := s.actorRepo.LockByID(ctx, tx, recovery.ActorID)
+		if err != nil {
+			return err
+		}
+		newKey, err := keys.NormalizePublicKey(recovery.PublicKey)
+		if err != nil {
+			return err
+		}
+		if _, err := s.rotate(ctx, tx, actor, newKey, constants.ActorKeyEndorsementRecovery, now); err != nil {
+			return err
+		}
+		actorID = actor.ActorID
+		return s.recoveryRepo.Complete(ctx, tx, recoveryID, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	if actorID != uuid.Nil {
+		s.resolver.InvalidateActor(actorID)
+	}
+	return nil
+}
+
+// rotate makes newKey the actor's master key from now on: the current key's validity ends, the
+// new key is added to the history and the actor's DID becomes the did:key of the new key
+func (s *actorKeyService) rotate(ctx context.Context, tx *gorm.DB, actor *model.Actor, newKey *keys.NormalizedKey, endorsement string, now time.Time) (*model.ActorKey, error) {
+	if err := s.checkKeyUnused(ctx, tx, newKey.Thumbprint); err != nil {
+		return nil, err
+	}
+	newDID, err := did.KeyDID(newKey.Key)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidMasterPublicKey)
+	}
+
+	if err := s.actorKeyRepo.CloseCurrent(ctx, tx, actor.ActorID, now); err != nil {
+		return nil, err
+	}
+	key := &model.ActorKey{
+		ActorID:     actor.ActorID,
+		PublicKey:   newKey.PEM,
+		Thumbprint:  &newKey.Thumbprint,
+		DID:         newDID,
+		Endorsement: endorsement,
+		ValidFrom:   now,
+	}
+	if err := s.actorKeyRepo.Create(ctx, tx, key); err != nil {
+		return nil, err
+	}
+	if err := s.actorRepo.UpdateMasterKey(ctx, tx, actor.ActorID, newKey.PEM, newKey.Thumbprint, newDID); err != nil {
+		return nil, err
+	}
+
+	err = s.webhooks.Publish(ctx, tx, WebhookEvent{
+		Type:    constants.WebhookEventKeyRotated,
+		ActorID: actor.ActorID,
+		Data: map[string]interface{}{
+			"previousDid": actor.DID,
+			"did":         newDID,
+			"thumbprint":  newKey.Thumbprint,
+			"endorsement": endorsement,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s rotated its master key (%s): %s -> %s", actor.ActorID, endorsement, actor.DID, newDID)
+	return key, nil
+}
+
+// checkKeyUnused rejects keys that are or ever were the master key of any actor
+func (s *actorKeyService) checkKeyUnused(ctx context.Context, tx *gorm.DB, thumbprint string) error {
+	used, err := s.actorKeyRepo.ExistsWithThumbprint(ctx, tx, thumbprint)
+	if err != nil {
+		return err
+	}
+	if used {
+		return fiber.NewError(fiber.StatusConflict, constants.ErrMasterPublicKeyAlreadyInUse)
+	}
+	return nil
+}
+
+func (s *actorKeyService) FindKeyValidAt(c *fiber.Ctx, actorID uuid.UUID, at string) (*model.ActorKey, error) {
+	validAt, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+	return s.actorKeyRepo.FindValidAt(c.Context(), s.db, actorID, validAt)
+}
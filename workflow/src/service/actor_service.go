@@ -2,9 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"app/src/config"
 	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
 	"app/src/model"
+	"app/src/namespace"
 	"app/src/repository"
 	"app/src/utils"
 	"app/src/validation"
@@ -20,43 +28,74 @@ import (
 type ActorService interface {
 	RegisterActor(c *fiber.Ctx, req *validation.ApiRequest_RegistrationRequest) (*model.Actor, error)
 	Login(c *fiber.Ctx, req *validation.ApiRequest_LoginRequest) (*AuthTokenResponse, error)
+	LoginWithKey(c *fiber.Ctx, req *validation.ApiRequest_KeyLoginRequest) (*AuthTokenResponse, error)
 	Signout(c *fiber.Ctx, authUserID string) error
 	UpdateActor(c *fiber.Ctx, actorID uuid.UUID, req *validation.ApiRequest_UpdateActorRequest) (*model.Actor, error)
 	GetProfile(c *fiber.Ctx, actorID uuid.UUID) (*model.Actor, error)
 	ForgotPassword(c *fiber.Ctx, req *validation.ApiRequest_ForgotPasswordRequest) error
 	ResolveUniversalIdentifier(c *fiber.Ctx, req *validation.ApiRequest_ResolveRequest) (*model.Actor, error)
 	GetUniversalIdentifier(actorID uuid.UUID) (string, error)
+	BackfillMasterKeys(ctx context.Context, dryRun bool) (*MasterKeyBackfillResult, error)
+}
+
+// MasterKeyBackfillResult summarizes the normalization of stored actor master keys
+type MasterKeyBackfillResult struct {
+	Scanned   int
+	Converted int
+	Unchanged int
+	Failed    []MasterKeyBackfillFailure
+}
+
+// MasterKeyBackfillFailure records an actor whose master key could not be normalized
+type MasterKeyBackfillFailure struct {
+	ActorID uuid.UUID
+	Reason  string
 }
 
 // actorService implements ActorService with constructor-based dependency injection
 type actorService struct {
+	cfg                  *config.Config
 	log                  *logrus.Logger
 	db                   *gorm.DB
 	validate             *validator.Validate
 	authService          AuthService
+	challengeService     ChallengeService
+	namespaceService     NamespaceService
 	actorRepo            repository.ActorRepository
 	identifierRepo       repository.IdentifierRepository
 	actorIntegrationRepo repository.ActorIntegrationRepository
+	actorKeyRepo         repository.ActorKeyRepository
+	verificationCodeRepo repository.VerificationCodeRepository
 }
 
 // NewActorService creates a new actor service instance
 func NewActorService(
+	cfg *config.Config,
 	log *logrus.Logger,
 	db *gorm.DB,
 	validate *validator.Validate,
 	authService AuthService,
+	challengeService ChallengeService,
+	namespaceService NamespaceService,
 	actorRepo repository.ActorRepository,
 	identifierRepo repository.IdentifierRepository,
 	actorIntegrationRepo repository.ActorIntegrationRepository,
+	actorKeyRepo repository.ActorKeyRepository,
+	verificationCodeRepo repository.VerificationCodeRepository,
 ) ActorService {
 	return &actorService{
+		cfg:                  cfg,
 		log:                  log,
 		db:                   db,
 		validate:             validate,
 		authService:          authService,
+		challengeService:     challengeService,
+		namespaceService:     namespaceService,
 		actorRepo:            actorRepo,
 		identifierRepo:       identifierRepo,
 		actorIntegrationRepo: actorIntegrationRepo,
+		actorKeyRepo:         actorKeyRepo,
+		verificationCodeRepo: verificationCodeRepo,
 	}
 }
 
@@ -82,32 +121,77 @@ func (s *actorService) RegisterActor(c *fiber.Ctx, req *validation.ApiRequest_Re
 		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
+	// The key is stored in canonical form and the DID is derived from it, so the same key always
+	// yields the same DID and thumbprint whatever encoding it was submitted in
+	masterKey, actorDID, err := normalizeMasterKey(req.Request.MasterPublicKey)
+	if err != nil {
+		s.log.Warnf("Rejected master public key: %v", err)
+		if errors.Is(err, keys.ErrWeakKey) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrWeakMasterPublicKey)
+		}
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidMasterPublicKey)
+	}
+
+	// The proof shows the registrant holds the private key and binds it to this exact payload
+	nonce, err := s.verifyRegistrationProof(c.Body(), masterKey, req.Request.Proof)
+	if err != nil {
+		return nil, err
+	}
+
 	var actor *model.Actor
-	err := s.db.Transaction(func(tx *gorm.DB) error {
+	var authUserID string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
 		ctx := c.Context()
 
+		if err := s.challengeService.Consume(ctx, tx, nonce, constants.ChallengePurposeRegistration); err != nil {
+			return err
+		}
+
+		// Check the universal identifier against the namespace rules; it is stored in canonical form
+		var universalIdentifier *namespace.Identifier
+		if req.Request.UniversalIdentifier != "" {
+			id, err := s.namespaceService.CheckClaim(ctx, tx, req.Request.UniversalIdentifier, uuid.Nil)
+			if err != nil {
+				return err
+			}
+			universalIdentifier = id
+		}
+
 		// Validate uniqueness constraints
-		if err := s.validateUniquenessConstraints(ctx, tx, req); err != nil {
+		if err := s.validateUniquenessConstraints(ctx, tx, req, masterKey.Thumbprint); err != nil {
 			return err
 		}
 
 		// Create actor
-		actor = s.buildActorFromRequest(req)
+		actor = s.buildActorFromRequest(req, masterKey, actorDID)
 		if err := s.actorRepo.Create(ctx, tx, actor); err != nil {
 			s.log.Errorf("Failed to create actor: %+v", err)
 			return err
 		}
 
+		// Start the master key history
+		err := s.actorKeyRepo.Create(ctx, tx, &model.ActorKey{
+			ActorID:     actor.ActorID,
+			PublicKey:   actor.MasterPublicKey,
+			Thumbprint:  actor.MasterKeyThumbprint,
+			DID:         actor.DID,
+			Endorsement: constants.ActorKeyEndorsementRegistration,
+			ValidFrom:   actor.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
 		// Create identifier if provided
-		if req.Request.UniversalIdentifier != "" {
-			if err := s.createIdentifier(ctx, tx, req.Request.UniversalIdentifier, actor.ActorID); err != nil {
+		if universalIdentifier != nil {
+			if err := s.createIdentifier(ctx, tx, universalIdentifier, actor.ActorID); err != nil {
 				return err
 			}
 		}
 
 		// Create auth user and integration
-		if req.Request.UniversalIdentifier != "" {
-			authUserID, err := s.authService.CreateUser(actor, req.Request.UniversalIdentifier, req.Request.Password)
+		if universalIdentifier != nil {
+			authUserID, err = s.authService.CreateUser(actor, universalIdentifier.String(), req.Request.Password)
 			if err != nil {
 				s.log.Errorf("Failed to create auth user: %+v", err)
 				return err
@@ -118,30 +202,55 @@ func (s *actorService) RegisterActor(c *fiber.Ctx, req *validation.ApiRequest_Re
 				if err := s.createActorIntegration(ctx, tx, actor.ActorID, authUserID); err != nil {
 					return err
 				}
+
+				// Recorded like any verification email, so that it counts towards the rate limits
+				err := s.verificationCodeRepo.Create(ctx, tx, &model.VerificationCode{
+					ActorID:   actor.ActorID,
+					Channel:   constants.VerificationChannelEmail,
+					Target:    actor.Email,
+					ExpiresAt: time.Now().Add(constants.EmailVerificationLinkTTL * time.Minute),
+				})
+				if err != nil {
+					return err
+				}
 			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return actor, err
+	// The email address is unverified until the actor follows the link; a failed send is not fatal
+	// since the actor can request another one
+	if authUserID != "" {
+		if err := s.authService.ExecuteActionsEmail(authUserID, []string{constants.KeycloakActionVerifyEmail}); err != nil {
+			s.log.Warnf("Failed to send verification email to actor %s: %v", actor.ActorID, err)
+		}
+	}
+
+	return actor, nil
 }
 
-// validateUniquenessConstraints validates all uniqueness constraints for actor creation
-func (s *actorService) validateUniquenessConstraints(ctx context.Context, tx *gorm.DB, req *validation.ApiRequest_RegistrationRequest) error {
-	// Check universal identifier
-	if req.Request.UniversalIdentifier != "" {
-		err := s.checkUniqueness(ctx, tx,
-			func(ctx context.Context, tx *gorm.DB) (bool, error) {
-				return s.identifierRepo.ExistsWithIdentifier(ctx, tx, req.Request.UniversalIdentifier)
-			},
-			constants.ErrUniversalIdentifierAlreadyInUse,
-			"Failed to check universal identifier")
-		if err != nil {
-			return err
-		}
+// verifyRegistrationProof checks the registration proof against the raw request body and returns
+// the challenge nonce it signs
+func (s *actorService) verifyRegistrationProof(body []byte, masterKey *keys.NormalizedKey, proof string) (string, error) {
+	payloadHash, err := RegistrationPayloadHash(body)
+	if err != nil {
+		return "", fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+	nonce, err := VerifyRegistrationProof(masterKey.Key, proof, s.cfg.IssuerURL, payloadHash, time.Now())
+	if err != nil {
+		s.log.Warnf("Rejected registration proof: %v", err)
+		return "", fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRegistrationProof)
 	}
+	return nonce, nil
+}
 
+// validateUniquenessConstraints validates the uniqueness constraints for actor creation other than
+// the universal identifier's, which the namespace service checks
+func (s *actorService) validateUniquenessConstraints(ctx context.Context, tx *gorm.DB, req *validation.ApiRequest_RegistrationRequest, masterKeyThumbprint string) error {
 	// Check email
 	if req.Request.Email != "" {
 		err := s.checkUniqueness(ctx, tx,
@@ -155,24 +264,40 @@ func (s *actorService) validateUniquenessConstraints(ctx context.Context, tx *go
 		}
 	}
 
-	// Check master public key
+	// Check master public key by thumbprint, which is the same for every encoding of the key.
+	// Keys rotated away from are in the history too and cannot be registered again.
 	return s.checkUniqueness(ctx, tx,
 		func(ctx context.Context, tx *gorm.DB) (bool, error) {
-			return s.actorRepo.ExistsWithMasterPublicKey(ctx, tx, req.Request.MasterPublicKey)
+			return s.actorKeyRepo.ExistsWithThumbprint(ctx, tx, masterKeyThumbprint)
 		},
 		constants.ErrMasterPublicKeyAlreadyInUse,
 		"Failed to check master public key")
 }
 
+// normalizeMasterKey validates a master public key and derives its canonical form and did:key
+func normalizeMasterKey(masterPublicKey string) (*keys.NormalizedKey, string, error) {
+	masterKey, err := keys.NormalizePublicKey(masterPublicKey)
+	if err != nil {
+		return nil, "", err
+	}
+	actorDID, err := did.KeyDID(masterKey.Key)
+	if err != nil {
+		return nil, "", err
+	}
+	return masterKey, actorDID, nil
+}
+
 // buildActorFromRequest creates an actor model from the registration request
-func (s *actorService) buildActorFromRequest(req *validation.ApiRequest_RegistrationRequest) *model.Actor {
+func (s *actorService) buildActorFromRequest(req *validation.ApiRequest_RegistrationRequest, masterKey *keys.NormalizedKey, actorDID string) *model.Actor {
 	actor := &model.Actor{
-		Email:             req.Request.Email,
-		FirstName:         req.Request.FirstName,
-		LastName:          req.Request.LastName,
-		MasterPublicKey:   req.Request.MasterPublicKey,
-		EntityType:        req.Request.EntityType,
-		VerificationLevel: constants.VerificationLevelUnverified,
+		DID:                 actorDID,
+		Email:               req.Request.Email,
+		FirstName:           req.Request.FirstName,
+		LastName:            req.Request.LastName,
+		MasterPublicKey:     masterKey.PEM,
+		MasterKeyThumbprint: &masterKey.Thumbprint,
+		EntityType:          req.Request.EntityType,
+		VerificationLevel:   constants.VerificationLevelUnverified,
 	}
 
 	// Set optional fields
@@ -184,12 +309,14 @@ func (s *actorService) buildActorFromRequest(req *validation.ApiRequest_Registra
 	return actor
 }
 
-// createIdentifier creates an identifier record
-func (s *actorService) createIdentifier(ctx context.Context, tx *gorm.DB, identifier string, actorID uuid.UUID) error {
+// createIdentifier creates the primary identifier record of a new actor
+func (s *actorService) createIdentifier(ctx context.Context, tx *gorm.DB, identifier *namespace.Identifier, actorID uuid.UUID) error {
 	id := &model.Identifier{
-		Identifier: identifier,
+		Identifier: identifier.String(),
 		EntityType: constants.EntityTypeActor,
 		EntityID:   actorID,
+		IsPrimary:  true,
+		Skeleton:   identifier.Skeleton,
 	}
 	return s.identifierRepo.Create(ctx, tx, id)
 }
@@ -211,35 +338,90 @@ func (s *actorService) Login(c *fiber.Ctx, req *validation.ApiRequest_LoginReque
 
 	s.log.Infof("Login attempt for username: %s", req.Request.Username)
 
+	// Find and validate actor. The username may be any of the actor's identifiers, so the auth
+	// provider is asked by email, which does not change when identifiers do.
+	actor, err := s.findActorByUsername(c.Context(), req.Request.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if actor.Email == "" {
+		s.log.Errorf("Actor missing required email field: %s", actor.ActorID)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrInvalidActorAccount)
+	}
+
 	// Authenticate with auth provider
-	tokenResp, err := s.authService.Login(req.Request.Username, req.Request.Password)
+	tokenResp, err := s.authService.Login(actor.Email, req.Request.Password)
 	if err != nil {
 		s.log.Errorf("Authentication failed: %+v", err)
 		return nil, err
 	}
 
-	// Find and validate actor
-	actor, err := s.findActorByUsername(c.Context(), req.Request.Username)
+	s.log.Infof("Login successful for actor: %s", actor.Email)
+	return tokenResp, nil
+}
+
+func (s *actorService) LoginWithKey(c *fiber.Ctx, req *validation.ApiRequest_KeyLoginRequest) (*AuthTokenResponse, error) {
+	if err := s.validate.Struct(req.Request); err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Key login attempt for username: %s", req.Request.Username)
+	ctx := c.Context()
+
+	actor, err := s.findActorByUsername(ctx, req.Request.Username)
 	if err != nil {
 		return nil, err
 	}
 
-	if actor.Email == "" {
-		s.log.Errorf("Actor missing required email field: %s", actor.ActorID)
+	masterKey, err := keys.ParsePublicKey(actor.MasterPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("master key of actor %s cannot be parsed: %w", actor.ActorID, err)
+	}
+	nonce, err := VerifyLoginProof(masterKey, req.Request.Proof, actor.DID, s.cfg.IssuerURL, time.Now())
+	if err != nil {
+		s.log.Warnf("Rejected login proof for actor %s: %v", actor.ActorID, err)
+		return nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrInvalidLoginProof)
+	}
+	if err := s.challengeService.Consume(ctx, s.db, nonce, constants.ChallengePurposeLogin); err != nil {
+		return nil, err
+	}
+
+	// The session belongs to the linked auth provider user, so the auth middleware accepts it like
+	// one from a password login
+	integration, err := s.actorIntegrationRepo.FindByActorIDAndProvider(ctx, s.db, actor.ActorID, constants.KeycloakProviderName)
+	if err != nil {
+		s.log.Errorf("Actor %s has no auth provider user: %+v", actor.ActorID, err)
 		return nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrInvalidActorAccount)
 	}
 
-	s.log.Infof("Login successful for actor: %s", actor.Email)
+	tokenResp, err := s.authService.ExchangeToken(integration.ExternalUserID)
+	if err != nil {
+		s.log.Errorf("Token exchange failed for actor %s: %+v", actor.ActorID, err)
+		return nil, err
+	}
+
+	s.log.Infof("Key login successful for actor: %s", actor.ActorID)
 	return tokenResp, nil
 }
 
-// findActorByUsername finds an actor by username (universal identifier or email)
+// findActorByUsername finds an actor by username (any of its universal identifiers or email)
 func (s *actorService) findActorByUsername(ctx context.Context, username string) (*model.Actor, error) {
-	// Try by universal identifier first
-	if identifier, err := s.identifierRepo.FindByValue(ctx, s.db, username); err == nil && identifier != nil {
-		if actor, err := s.actorRepo.FindByID(ctx, s.db, identifier.EntityID); err == nil {
+	// Try by universal identifier first, falling back to email only when no identifier matches
+	identifier, err := s.identifierRepo.FindByValue(ctx, s.db, s.namespaceService.Canonical(username))
+	switch {
+	case err == nil:
+		actor, err := s.actorRepo.FindByID(ctx, s.db, identifier.EntityID)
+		if err == nil {
 			return actor, nil
 		}
+		if !utils.IsNotFoundError(err) {
+			s.log.Errorf("Failed to find actor %s: %+v", identifier.EntityID, err)
+			return nil, fmt.Errorf("failed to find actor: %w", err)
+		}
+	case !utils.IsNotFoundError(err):
+		s.log.Errorf("Failed to find identifier: %+v", err)
+		return nil, fmt.Errorf("failed to find identifier: %w", err)
 	}
 
 	// Try by email
@@ -320,11 +502,18 @@ func (s *actorService) updateActorFields(actor *model.Actor, req *validation.Api
 		actor.LastName = req.Request.LastName
 	}
 	if req.Request.PhoneNumber != nil {
+		previous := actor.PhoneNumber
 		if *req.Request.PhoneNumber == "" {
 			actor.PhoneNumber = nil
 		} else {
 			actor.PhoneNumber = req.Request.PhoneNumber
 		}
+
+		// A new number has to be verified again
+		if previous == nil || actor.PhoneNumber == nil || *previous != *actor.PhoneNumber {
+			actor.PhoneVerified = false
+			actor.PhoneVerifiedAt = nil
+		}
 	}
 }
 
@@ -362,7 +551,7 @@ func (s *actorService) ForgotPassword(c *fiber.Ctx, req *validation.ApiRequest_F
 	}
 
 	// Send password reset email
-	if err := s.authService.ExecuteActionsEmail(integration.ExternalUserID, []string{"UPDATE_PASSWORD"}); err != nil {
+	if err := s.authService.ExecuteActionsEmail(integration.ExternalUserID, []string{constants.KeycloakActionUpdatePassword}); err != nil {
 		s.log.Errorf("Failed to send password reset email: %+v", err)
 		return err
 	}
@@ -379,7 +568,7 @@ func (s *actorService) ResolveUniversalIdentifier(c *fiber.Ctx, req *validation.
 	ctx := c.Context()
 
 	// Find identifier
-	identifier, err := s.identifierRepo.FindByValue(ctx, s.db, req.Request.UniversalIdentifier)
+	identifier, err := s.identifierRepo.FindByValue(ctx, s.db, s.namespaceService.Canonical(req.Request.UniversalIdentifier))
 	if err != nil {
 		return nil, err
 	}
@@ -403,3 +592,59 @@ func (s *actorService) GetUniversalIdentifier(actorID uuid.UUID) (string, error)
 	}
 	return identifier.Identifier, nil
 }
+
+// BackfillMasterKeys brings actors registered before master keys were normalized in line with new
+// registrations: the key is stored in canonical PEM form with its thumbprint, and the DID is the
+// did:key of the key. Actors whose key cannot be normalized are left alone and reported. With
+// dryRun set, nothing is written.
+func (s *actorService) BackfillMasterKeys(ctx context.Context, dryRun bool) (*MasterKeyBackfillResult, error) {
+	result := &MasterKeyBackfillResult{}
+
+	afterID := uuid.Nil
+	for {
+		actors, err := s.actorRepo.ListAfter(ctx, s.db, afterID, constants.MasterKeyBackfillBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(actors) == 0 {
+			return result, nil
+		}
+		afterID = actors[len(actors)-1].ActorID
+
+		for i := range actors {
+			result.Scanned++
+			s.backfillMasterKey(ctx, &actors[i], dryRun, result)
+		}
+	}
+}
+
+// backfillMasterKey normalizes the master key of one actor and records the outcome
+func (s *actorService) backfillMasterKey(ctx context.Context, actor *model.Actor, dryRun bool, result *MasterKeyBackfillResult) {
+	masterKey, actorDID, err := normalizeMasterKey(actor.MasterPublicKey)
+	if err != nil {
+		result.Failed = append(result.Failed, MasterKeyBackfillFailure{ActorID: actor.ActorID, Reason: err.Error()})
+		return
+	}
+	if actor.MasterPublicKey == masterKey.PEM && actor.DID == actorDID &&
+		actor.MasterKeyThumbprint != nil && *actor.MasterKeyThumbprint == masterKey.Thumbprint {
+		result.Unchanged++
+		return
+	}
+	if strings.HasPrefix(actor.DID, "did:") && actor.DID != actorDID {
+		s.log.Warnf("Actor %s has DID %s, which does not match its master public key", actor.ActorID, actor.DID)
+	}
+
+	if !dryRun {
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := s.actorRepo.UpdateMasterKey(ctx, tx, actor.ActorID, masterKey.PEM, masterKey.Thumbprint, actorDID); err != nil {
+				return err
+			}
+			return s.actorKeyRepo.UpdateCurrent(ctx, tx, actor.ActorID, masterKey.PEM, masterKey.Thumbprint, actorDID)
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, MasterKeyBackfillFailure{ActorID: actor.ActorID, Reason: err.Error()})
+			return
+		}
+	}
+	result.Converted++
+}
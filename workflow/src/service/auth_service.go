@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,8 +21,22 @@ import (
 type AuthService interface {
 	CreateUser(actor *model.Actor, universalIdentifier, password string) (string, error)
 	Login(username, password string) (*AuthTokenResponse, error)
+	// ExchangeToken obtains a session for an auth provider user without their password, through
+	// token exchange impersonation by this client. Callers must have authenticated the user.
+	ExchangeToken(userID string) (*AuthTokenResponse, error)
 	Logout(userID string) error
 	ExecuteActionsEmail(userID string, actions []string) error
+	// SendEmailVerification clears the verified flag of a user's email, so that it is only set again
+	// once the user follows the link in the verification email sent to them
+	SendEmailVerification(userID string) error
+	// IsEmailVerified reports whether a user has verified their email with the auth provider
+	IsEmailVerified(userID string) (bool, error)
+	// DeleteUser removes a user from the auth provider. Deleting a user that no longer exists
+	// succeeds, so that an interrupted account erasure can be retried.
+	DeleteUser(userID string) error
+	// ListLoginEvents returns the most recent login, logout and token exchange events of a user,
+	// newest first. The realm must store events and the admin user must be allowed to view them.
+	ListLoginEvents(userID string, max int) ([]AuthEvent, error)
 }
 
 // authService implements AuthService using OIDC-compliant auth provider
@@ -63,6 +78,17 @@ type AuthTokenResponse struct {
 	Scope        string `json:"scope,omitempty"`
 }
 
+// AuthEvent represents an event recorded by the auth provider; Time is in Unix milliseconds
+type AuthEvent struct {
+	Time      int64             `json:"time"`
+	Type      string            `json:"type"`
+	ClientID  string            `json:"clientId,omitempty"`
+	SessionID string            `json:"sessionId,omitempty"`
+	IPAddress string            `json:"ipAddress,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
 // AuthErrorResponse represents an error response from the auth provider
 type AuthErrorResponse struct {
 	Error       string `json:"error"`
@@ -97,7 +123,7 @@ func (s *authService) CreateUser(actor *model.Actor, universalIdentifier, passwo
 		FirstName:     actor.FirstName,
 		LastName:      actor.LastName,
 		Enabled:       true,
-		EmailVerified: true,
+		EmailVerified: actor.EmailVerified,
 		Credentials: []AuthCredential{
 			{Type: constants.KeycloakCredentialTypePassword, Value: password, Temporary: false},
 		},
@@ -118,7 +144,7 @@ func (s *authService) CreateUser(actor *model.Actor, universalIdentifier, passwo
 	// Handle user already exists
 	if resp.StatusCode == http.StatusConflict {
 		s.log.Infof("User already exists for: %s, fetching user ID", universalIdentifier)
-		return s.getUserIDByUsername(adminToken, universalIdentifier)
+		return s.getUserIDByUsername(adminToken, universalIdentifier, actor.Email)
 	}
 
 	// Handle creation errors
@@ -187,6 +213,52 @@ func (s *authService) Login(username, password string) (*AuthTokenResponse, erro
 	return &tokenResp, nil
 }
 
+func (s *authService) ExchangeToken(userID string) (*AuthTokenResponse, error) {
+	s.log.Infof("Token exchange for user: %s", userID)
+
+	tokenURL := fmt.Sprintf("%s"+constants.KeycloakPathToken, s.baseURL, s.realm)
+
+	data := url.Values{}
+	data.Set(constants.HTTPParamGrantType, constants.KeycloakGrantTypeTokenExchange)
+	data.Set(constants.HTTPParamClientID, s.clientID)
+	data.Set(constants.HTTPParamClientSecret, s.clientSecret)
+	data.Set(constants.HTTPParamRequestedSubject, userID)
+	data.Set(constants.HTTPParamRequestedTokenType, constants.KeycloakTokenTypeRefresh)
+	data.Set(constants.HTTPParamScope, constants.KeycloakScopeOpenID)
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+
+	req.Header.Set(constants.HTTPHeaderContentType, constants.KeycloakContentTypeForm)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The client is trusted to impersonate users, so a refusal is a configuration error rather
+	// than bad credentials
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleErrorResponse(resp, "failed to exchange token")
+	}
+
+	var tokenResp AuthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode token exchange response: %w", err)
+	}
+
+	if tokenResp.AccessToken == "" {
+		s.log.Errorf("Received empty access token for user: %s", userID)
+		return nil, fmt.Errorf("received empty access token")
+	}
+
+	s.log.Infof("Successfully exchanged token for user: %s", userID)
+	return &tokenResp, nil
+}
+
 func (s *authService) Logout(userID string) error {
 	s.log.Infof("Initiating logout for user: %s", userID)
 
@@ -210,6 +282,69 @@ func (s *authService) Logout(userID string) error {
 	return nil
 }
 
+func (s *authService) DeleteUser(userID string) error {
+	s.log.Infof("Deleting user: %s", userID)
+
+	adminToken, err := s.getAdminToken()
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with auth provider: %w", err)
+	}
+
+	userURL := fmt.Sprintf("%s"+constants.KeycloakPathAdminUser, s.baseURL, s.realm, userID)
+	resp, err := s.doRequest("DELETE", userURL, adminToken, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		s.log.Infof("User %s does not exist; nothing to delete", userID)
+		return nil
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s.handleErrorResponse(resp, "failed to delete user")
+	}
+
+	s.log.Infof("Successfully deleted user: %s", userID)
+	return nil
+}
+
+func (s *authService) ListLoginEvents(userID string, max int) ([]AuthEvent, error) {
+	adminToken, err := s.getAdminToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with auth provider: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("user", userID)
+	query.Set("max", strconv.Itoa(max))
+	for _, eventType := range []string{
+		constants.KeycloakEventLogin,
+		constants.KeycloakEventLoginError,
+		constants.KeycloakEventLogout,
+		constants.KeycloakEventTokenExchange,
+	} {
+		query.Add("type", eventType)
+	}
+	eventsURL := fmt.Sprintf("%s"+constants.KeycloakPathAdminEvents+"?%s", s.baseURL, s.realm, query.Encode())
+
+	resp, err := s.doRequest("GET", eventsURL, adminToken, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleErrorResponse(resp, "failed to list login events")
+	}
+
+	var events []AuthEvent
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, fmt.Errorf("failed to decode login events: %w", err)
+	}
+	return events, nil
+}
+
 func (s *authService) ExecuteActionsEmail(userID string, actions []string) error {
 	s.log.Infof("Executing actions email for user: %s, actions: %v", userID, actions)
 
@@ -239,6 +374,57 @@ func (s *authService) ExecuteActionsEmail(userID string, actions []string) error
 	return nil
 }
 
+func (s *authService) SendEmailVerification(userID string) error {
+	adminToken, err := s.getAdminToken()
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with auth provider: %w", err)
+	}
+
+	jsonData, err := json.Marshal(map[string]bool{"emailVerified": false})
+	if err != nil {
+		return fmt.Errorf("failed to prepare user update request: %w", err)
+	}
+
+	userURL := fmt.Sprintf("%s"+constants.KeycloakPathAdminUser, s.baseURL, s.realm, userID)
+	resp, err := s.doRequest("PUT", userURL, adminToken, bytes.NewBuffer(jsonData), constants.KeycloakContentTypeJSON)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s.handleErrorResponse(resp, "failed to reset email verification")
+	}
+
+	return s.ExecuteActionsEmail(userID, []string{constants.KeycloakActionVerifyEmail})
+}
+
+func (s *authService) IsEmailVerified(userID string) (bool, error) {
+	adminToken, err := s.getAdminToken()
+	if err != nil {
+		return false, fmt.Errorf("failed to authenticate with auth provider: %w", err)
+	}
+
+	userURL := fmt.Sprintf("%s"+constants.KeycloakPathAdminUser, s.baseURL, s.realm, userID)
+	resp, err := s.doRequest("GET", userURL, adminToken, nil, "")
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, s.handleErrorResponse(resp, "failed to get user")
+	}
+
+	var user struct {
+		EmailVerified bool `json:"emailVerified"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return false, fmt.Errorf("failed to decode user: %w", err)
+	}
+	return user.EmailVerified, nil
+}
+
 // getAdminToken retrieves an admin token for API operations
 func (s *authService) getAdminToken() (string, error) {
 	tokenURL := s.baseURL + constants.KeycloakPathMasterToken
@@ -274,8 +460,10 @@ func (s *authService) getAdminToken() (string, error) {
 	return tokenResp.AccessToken, nil
 }
 
-// getUserIDByUsername queries the auth provider to get a user's ID by username
-func (s *authService) getUserIDByUsername(adminToken, username string) (string, error) {
+// getUserIDByUsername queries the auth provider to get a user's ID by username. The user must have
+// the given email: usernames are universal identifiers, which can be released and claimed by
+// another actor while the former holder's user keeps the username.
+func (s *authService) getUserIDByUsername(adminToken, username, email string) (string, error) {
 	apiURL := fmt.Sprintf("%s"+constants.KeycloakPathAdminUsers+"?username=%s&exact=true",
 		s.baseURL, s.realm, url.QueryEscape(username))
 
@@ -303,6 +491,11 @@ func (s *authService) getUserIDByUsername(adminToken, username string) (string,
 		return "", fmt.Errorf("failed to extract user ID from response")
 	}
 
+	if userEmail, _ := users[0]["email"].(string); !strings.EqualFold(userEmail, email) {
+		s.log.Warnf("User %s with username %s belongs to another email", userID, username)
+		return "", fiber.NewError(fiber.StatusConflict, constants.ErrUniversalIdentifierAlreadyInUse)
+	}
+
 	s.log.Infof("Found user ID %s for username: %s", userID, username)
 	return userID, nil
 }
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ChallengeService defines the interface for issuing and consuming single-use nonces that
+// clients sign to prove they hold a private key
+type ChallengeService interface {
+	// Issue creates a challenge for the requested purpose, valid for constants.ChallengeTTL minutes
+	Issue(c *fiber.Ctx, req *validation.ChallengeRequest) (*model.KeyChallenge, error)
+
+	// Consume uses up a challenge inside tx, so it stays usable if tx rolls back
+	Consume(ctx context.Context, tx *gorm.DB, nonce, purpose string) error
+}
+
+type challengeService struct {
+	log           *logrus.Logger
+	db            *gorm.DB
+	validate      *validator.Validate
+	challengeRepo repository.KeyChallengeRepository
+}
+
+// NewChallengeService creates a new challenge service instance
+func NewChallengeService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	challengeRepo repository.KeyChallengeRepository,
+) ChallengeService {
+	return &challengeService{
+		log:           log,
+		db:            db,
+		validate:      validate,
+		challengeRepo: challengeRepo,
+	}
+}
+
+func (s *challengeService) Issue(c *fiber.Ctx, req *validation.ChallengeRequest) (*model.KeyChallenge, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+	purpose := req.Purpose
+	if purpose == "" {
+		purpose = constants.ChallengePurposeRegistration
+	}
+
+	nonce, err := utils.GenerateSecret(constants.ChallengeByteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	challenge := &model.KeyChallenge{
+		Nonce:     nonce,
+		Purpose:   purpose,
+		ExpiresAt: now.Add(constants.ChallengeTTL * time.Minute),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Challenges are issued to anonymous callers, so expired ones are purged as new ones come in
+		if err := s.challengeRepo.DeleteExpired(c.Context(), tx, now); err != nil {
+			return err
+		}
+		return s.challengeRepo.Create(c.Context(), tx, challenge)
+	})
+	if err != nil {
+		s.log.Errorf("Failed to issue challenge: %+v", err)
+		return nil, err
+	}
+	return challenge, nil
+}
+
+func (s *challengeService) Consume(ctx context.Context, tx *gorm.DB, nonce, purpose string) error {
+	return s.challengeRepo.Consume(ctx, tx, nonce, purpose, time.Now().UTC())
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"app/src/adapter"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ContactVerificationService defines the interface for proving ownership of an actor's email
+// address and phone number. Email addresses are verified through the auth provider, which sends a
+// link the actor follows; phone numbers through a one-time code sent by SMS. Verification messages
+// are rate limited per actor and channel.
+type ContactVerificationService interface {
+	// SendEmailVerification sends the caller a link to verify their email address
+	SendEmailVerification(c *fiber.Ctx) (*model.VerificationCode, error)
+
+	// CheckEmailVerification records the caller's email address as verified once they have
+	// followed the link, and returns the caller
+	CheckEmailVerification(c *fiber.Ctx) (*model.Actor, error)
+
+	// SendPhoneVerification sends a one-time code to the caller's phone number
+	SendPhoneVerification(c *fiber.Ctx) (*model.VerificationCode, error)
+
+	// ConfirmPhoneVerification records the caller's phone number as verified when the code matches
+	// the last one sent to it, and returns the caller
+	ConfirmPhoneVerification(c *fiber.Ctx, req *validation.ConfirmPhoneVerificationRequest) (*model.Actor, error)
+}
+
+type contactVerificationService struct {
+	log             *logrus.Logger
+	db              *gorm.DB
+	validate        *validator.Validate
+	authService     AuthService
+	sms             adapter.SMSSender
+	actorRepo       repository.ActorRepository
+	integrationRepo repository.ActorIntegrationRepository
+	codeRepo        repository.VerificationCodeRepository
+}
+
+// NewContactVerificationService creates a new contact verification service instance
+func NewContactVerificationService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	authService AuthService,
+	sms adapter.SMSSender,
+	actorRepo repository.ActorRepository,
+	integrationRepo repository.ActorIntegrationRepository,
+	codeRepo repository.VerificationCodeRepository,
+) ContactVerificationService {
+	return &contactVerificationService{
+		log:             log,
+		db:              db,
+		validate:        validate,
+		authService:     authService,
+		sms:             sms,
+		actorRepo:       actorRepo,
+		integrationRepo: integrationRepo,
+		codeRepo:        codeRepo,
+	}
+}
+
+func (s *contactVerificationService) SendEmailVerification(c *fiber.Ctx) (*model.VerificationCode, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var code *model.VerificationCode
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		// The actor row serializes concurrent requests so they cannot bypass the rate limits
+		actor, err := s.actorRepo.LockByID(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+		if actor.EmailVerified {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrEmailAlreadyVerified)
+		}
+
+		integration, err := s.integrationRepo.FindByActorIDAndProvider(ctx, tx, actorID, constants.KeycloakProviderName)
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+			}
+			return err
+		}
+
+		now := time.Now()
+		if err := s.checkSendLimits(ctx, tx, actorID, constants.VerificationChannelEmail, now); err != nil {
+			return err
+		}
+
+		code = &model.VerificationCode{
+			ActorID:   actorID,
+			Channel:   constants.VerificationChannelEmail,
+			Target:    actor.Email,
+			ExpiresAt: now.Add(constants.EmailVerificationLinkTTL * time.Minute),
+		}
+		if err := s.codeRepo.Create(ctx, tx, code); err != nil {
+			return err
+		}
+
+		// Sent last, so that the message is not counted when it could not be sent
+		return s.authService.SendEmailVerification(integration.ExternalUserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Sent verification email to actor %s", actorID)
+	return code, nil
+}
+
+func (s *contactVerificationService) CheckEmailVerification(c *fiber.Ctx) (*model.Actor, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.Context()
+
+	actor, err := s.actorRepo.FindByID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.EmailVerified {
+		return actor, nil
+	}
+
+	// The flag of the auth provider only proves ownership once a verification email has been sent
+	// to the current address: it used to be set on every user without verification
+	sent, err := s.codeRepo.FindLatest(ctx, s.db, actorID, constants.VerificationChannelEmail)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return actor, nil
+		}
+		return nil, err
+	}
+	if sent.Target != actor.Email {
+		return actor, nil
+	}
+
+	integration, err := s.integrationRepo.FindByActorIDAndProvider(ctx, s.db, actorID, constants.KeycloakProviderName)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+		}
+		return nil, err
+	}
+
+	verified, err := s.authService.IsEmailVerified(integration.ExternalUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		return actor, nil
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if actor, err = s.actorRepo.LockByID(ctx, tx, actorID); err != nil {
+			return err
+		}
+		if actor.EmailVerified {
+			return nil
+		}
+
+		now := time.Now()
+		actor.EmailVerified = true
+		actor.EmailVerifiedAt = &now
+		if err := s.actorRepo.Update(ctx, tx, actor); err != nil {
+			return err
+		}
+
+		sent.ConsumedAt = &now
+		return s.codeRepo.Update(ctx, tx, sent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Email address of actor %s verified", actorID)
+	return actor, nil
+}
+
+func (s *contactVerificationService) SendPhoneVerification(c *fiber.Ctx) (*model.VerificationCode, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var code *model.VerificationCode
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		// The actor row serializes concurrent requests so they cannot bypass the rate limits
+		actor, err := s.actorRepo.LockByID(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+		if actor.PhoneNumber == nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrPhoneNumberNotSet)
+		}
+		if actor.PhoneVerified {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrPhoneNumberAlreadyVerified)
+		}
+
+		now := time.Now()
+		if err := s.checkSendLimits(ctx, tx, actorID, constants.VerificationChannelPhone, now); err != nil {
+			return err
+		}
+
+		secret, err := utils.GenerateNumericCode(constants.VerificationCodeDigits)
+		if err != nil {
+			return fmt.Errorf("failed to generate verification code: %w", err)
+		}
+
+		// Only the latest code can be redeemed, so this supersedes any code sent before
+		code = &model.VerificationCode{
+			ActorID:   actorID,
+			Channel:   constants.VerificationChannelPhone,
+			Target:    *actor.PhoneNumber,
+			CodeHash:  utils.StringPtr(utils.HashSecret(secret)),
+			ExpiresAt: now.Add(constants.VerificationCodeTTL * time.Minute),
+		}
+		if err := s.codeRepo.Create(ctx, tx, code); err != nil {
+			return err
+		}
+
+		// Sent last, so that the message is not counted when it could not be sent
+		message := fmt.Sprintf(constants.SMSVerificationMessage, secret, constants.VerificationCodeTTL)
+		if err := s.sms.Send(ctx, code.Target, message); err != nil {
+			return fmt.Errorf("failed to send verification SMS: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Sent phone verification code to actor %s", actorID)
+	return code, nil
+}
+
+func (s *contactVerificationService) ConfirmPhoneVerification(c *fiber.Ctx, req *validation.ConfirmPhoneVerificationRequest) (*model.Actor, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// An incorrect code must count as an attempt, so it is recorded and reported after commit
+	var actor *model.Actor
+	var rejection error
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		var err error
+		if actor, err = s.actorRepo.LockByID(ctx, tx, actorID); err != nil {
+			return err
+		}
+		if actor.PhoneNumber == nil {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrPhoneNumberNotSet)
+		}
+		if actor.PhoneVerified {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrPhoneNumberAlreadyVerified)
+		}
+
+		code, err := s.codeRepo.LockLatest(ctx, tx, actorID, constants.VerificationChannelPhone)
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidVerificationCode)
+			}
+			return err
+		}
+
+		// A code sent to a number the actor has since replaced proves nothing about the current one
+		now := time.Now()
+		if code.Target != *actor.PhoneNumber {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidVerificationCode)
+		}
+		switch code.Status(now) {
+		case constants.VerificationCodeStatusPending:
+		case constants.VerificationCodeStatusExhausted:
+			return fiber.NewError(fiber.StatusTooManyRequests, constants.ErrVerificationAttemptsExceeded)
+		default:
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidVerificationCode)
+		}
+
+		if code.CodeHash == nil || !secretMatches(*code.CodeHash, req.Code) {
+			code.Attempts++
+			rejection = fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidVerificationCode)
+			return s.codeRepo.Update(ctx, tx, code)
+		}
+
+		code.ConsumedAt = &now
+		if err := s.codeRepo.Update(ctx, tx, code); err != nil {
+			return err
+		}
+
+		actor.PhoneVerified = true
+		actor.PhoneVerifiedAt = &now
+		return s.actorRepo.Update(ctx, tx, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rejection != nil {
+		s.log.Warnf("Incorrect phone verification code for actor %s", actorID)
+		return nil, rejection
+	}
+
+	s.log.Infof("Phone number of actor %s verified", actorID)
+	return actor, nil
+}
+
+// checkSendLimits rejects a verification message when one was sent on the channel too recently,
+// or too many were sent within the last hour
+func (s *contactVerificationService) checkSendLimits(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string, now time.Time) error {
+	latest, err := s.codeRepo.FindLatest(ctx, tx, actorID, channel)
+	if err != nil && !utils.IsNotFoundError(err) {
+		return err
+	}
+	if latest != nil && now.Sub(latest.CreatedAt) < constants.VerificationResendInterval*time.Second {
+		return fiber.NewError(fiber.StatusTooManyRequests, constants.ErrVerificationRateLimited)
+	}
+
+	sent, err := s.codeRepo.CountSince(ctx, tx, actorID, channel, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if sent >= constants.VerificationMaxSendsPerHour {
+		return fiber.NewError(fiber.StatusTooManyRequests, constants.ErrVerificationRateLimited)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CredentialJWTService verifies JWT-encoded verifiable credentials (VC-JWT)
+type CredentialJWTService interface {
+	Verify(ctx context.Context, compact string) (*VerifiedCredentialJWT, error)
+
+	// VerifyHeldBy verifies a VC-JWT like Verify and checks that its subject is one of the DIDs of
+	// the actor holding it. Credentials without a subject are bearer credentials and pass.
+	VerifyHeldBy(ctx context.Context, compact string, actorID uuid.UUID) (*VerifiedCredentialJWT, error)
+}
+
+// VerifiedCredentialJWT holds the registered claims and credential of a verified VC-JWT
+type VerifiedCredentialJWT struct {
+	Issuer     string
+	Subject    string
+	ID         string
+	NotBefore  *time.Time
+	ExpiresAt  *time.Time
+	KeyID      string
+	Algorithm  string
+	Credential map[string]interface{}
+}
+
+// SubjectDID returns the DID the credential is about: the sub claim, or the credentialSubject id
+// when sub is absent. It is empty for bearer credentials.
+func (v *VerifiedCredentialJWT) SubjectDID() string {
+	if v.Subject != "" {
+		return v.Subject
+	}
+	if subject, ok := v.Credential["credentialSubject"].(map[string]interface{}); ok {
+		id, _ := subject["id"].(string)
+		return id
+	}
+	return ""
+}
+
+// credentialJWTAlgorithms lists the JWS algorithms accepted on VC-JWTs
+var credentialJWTAlgorithms = []string{
+	jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg(), jwt.SigningMethodES512.Alg(),
+	jwt.SigningMethodRS256.Alg(), jwt.SigningMethodRS384.Alg(), jwt.SigningMethodRS512.Alg(),
+	jwt.SigningMethodPS256.Alg(), jwt.SigningMethodPS384.Alg(), jwt.SigningMethodPS512.Alg(),
+	jwt.SigningMethodEdDSA.Alg(), keys.AlgES256K,
+}
+
+// credentialJWTTypes lists the accepted values of the JOSE "typ" header
+var credentialJWTTypes = []string{"", "JWT", constants.CredentialFormatVCJWT}
+
+// credentialJWTClaims are the claims of a VC-JWT (VC Data Model 1.1, JWT encoding)
+type credentialJWTClaims struct {
+	jwt.RegisteredClaims
+	VC map[string]interface{} `json:"vc"`
+}
+
+// credentialJWTService implements CredentialJWTService with constructor-based dependency injection
+type credentialJWTService struct {
+	log          *logrus.Logger
+	resolver     did.Resolver
+	didDocuments DIDDocumentService
+}
+
+// NewCredentialJWTService creates a new VC-JWT verification service instance
+func NewCredentialJWTService(log *logrus.Logger, resolver did.Resolver, didDocuments DIDDocumentService) CredentialJWTService {
+	return &credentialJWTService{
+		log:          log,
+		resolver:     resolver,
+		didDocuments: didDocuments,
+	}
+}
+
+func (s *credentialJWTService) Verify(ctx context.Context, compact string) (*VerifiedCredentialJWT, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(credentialJWTAlgorithms),
+		jwt.WithLeeway(constants.JWTClockSkew*time.Second),
+		jwt.WithIssuedAt(),
+	)
+
+	claims := &credentialJWTClaims{}
+	token, err := parser.ParseWithClaims(compact, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.resolveSigningKey(ctx, token, claims)
+	})
+	if err != nil {
+		s.log.Warnf("VC-JWT verification failed: %v", err)
+		if errors.Is(err, did.ErrResolutionFailed) {
+			// The issuer may be temporarily unreachable; this says nothing about the credential
+			return nil, fiber.NewError(fiber.StatusBadGateway, constants.ErrIssuerDIDResolutionFailed)
+		}
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrInvalidCredentialJWT)
+	}
+
+	if err := checkCredentialClaims(claims); err != nil {
+		s.log.Warnf("VC-JWT claims rejected: %v", err)
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrInvalidCredentialJWT)
+	}
+
+	verified := &VerifiedCredentialJWT{
+		Issuer:     claims.Issuer,
+		Subject:    claims.Subject,
+		ID:         claims.ID,
+		Algorithm:  token.Method.Alg(),
+		Credential: claims.VC,
+	}
+	verified.KeyID, _ = token.Header["kid"].(string)
+	if claims.NotBefore != nil {
+		verified.NotBefore = &claims.NotBefore.Time
+	}
+	if claims.ExpiresAt != nil {
+		verified.ExpiresAt = &claims.ExpiresAt.Time
+	}
+
+	return verified, nil
+}
+
+func (s *credentialJWTService) VerifyHeldBy(ctx context.Context, compact string, actorID uuid.UUID) (*VerifiedCredentialJWT, error) {
+	verified, err := s.Verify(ctx, compact)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := verified.SubjectDID()
+	if subject == "" {
+		return verified, nil
+	}
+	holderDIDs, err := s.didDocuments.ActorDIDs(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(holderDIDs, subject) {
+		s.log.Warnf("VC-JWT subject %s is not a DID of actor %s", subject, actorID)
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrCredentialSubjectNotHolder)
+	}
+	return verified, nil
+}
+
+// resolveSigningKey resolves the issuer DID and returns the assertion key referenced by the JWS header
+func (s *credentialJWTService) resolveSigningKey(ctx context.Context, token *jwt.Token, claims *credentialJWTClaims) (interface{}, error) {
+	typ, _ := token.Header["typ"].(string)
+	if !containsFold(credentialJWTTypes, typ) {
+		return nil, fmt.Errorf("unexpected typ header %q", typ)
+	}
+
+	issuer := claims.Issuer
+	if !strings.HasPrefix(issuer, "did:") {
+		return nil, fmt.Errorf("issuer %q is not a DID", issuer)
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if strings.HasPrefix(kid, "did:") {
+		if kidDID, _ := did.SplitURL(kid); kidDID != issuer {
+			return nil, fmt.Errorf("key %q does not belong to issuer %q", kid, issuer)
+		}
+	}
+
+	document, err := s.resolver.Resolve(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	var method *did.VerificationMethod
+	if kid != "" {
+		method, err = document.FindVerificationMethod(kid)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Without a kid the issuer must have exactly one assertion key
+		assertionMethods := document.AssertionMethods()
+		if len(assertionMethods) != 1 {
+			return nil, errors.New("kid header required to select an issuer key")
+		}
+		method = assertionMethods[0]
+	}
+
+	if !document.IsAssertionMethod(method) {
+		return nil, fmt.Errorf("key %q is not an assertion method of %q", method.ID, issuer)
+	}
+
+	return method.PublicKey()
+}
+
+// checkCredentialClaims verifies that the vc claim is consistent with the registered claims
+func checkCredentialClaims(claims *credentialJWTClaims) error {
+	if claims.VC == nil {
+		return errors.New("missing vc claim")
+	}
+
+	if issuer := credentialIssuerID(claims.VC["issuer"]); issuer != "" && issuer != claims.Issuer {
+		return fmt.Errorf("vc.issuer %q does not match iss %q", issuer, claims.Issuer)
+	}
+
+	if subject, ok := claims.VC["credentialSubject"].(map[string]interface{}); ok && claims.Subject != "" {
+		if id, _ := subject["id"].(string); id != "" && id != claims.Subject {
+			return fmt.Errorf("credentialSubject.id %q does not match sub %q", id, claims.Subject)
+		}
+	}
+
+	if id, _ := claims.VC["id"].(string); id != "" && claims.ID != "" && id != claims.ID {
+		return fmt.Errorf("vc.id %q does not match jti %q", id, claims.ID)
+	}
+
+	return nil
+}
+
+// credentialIssuerID extracts the issuer identifier, which may be a string or an object with an id
+func credentialIssuerID(issuer interface{}) string {
+	switch v := issuer.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		id, _ := v["id"].(string)
+		return id
+	default:
+		return ""
+	}
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
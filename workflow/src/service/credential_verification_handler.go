@@ -0,0 +1,169 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/queue"
+	"app/src/repository"
+	"app/src/utils"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CredentialVerifier performs automated verification for one verification type
+type CredentialVerifier interface {
+	// Verify decides the outcome for a pending credential. Returning an error retries the job later.
+	Verify(ctx context.Context, token *model.Token) (*CredentialVerdict, error)
+}
+
+// CredentialVerdict is the outcome of automated verification.
+// An empty Status leaves the credential pending for manual review.
+type CredentialVerdict struct {
+	Status string
+	Reason string
+}
+
+// CredentialVerificationHandler processes credential verification jobs by dispatching each
+// credential to the verifier registered for its verification type
+type CredentialVerificationHandler struct {
+	log             *logrus.Logger
+	db              *gorm.DB
+	credentialsRepo repository.CredentialsRepository
+	levels          VerificationLevelService
+	webhooks        WebhookService
+	verifiers       map[string]CredentialVerifier
+	fallback        CredentialVerifier
+}
+
+// NewCredentialVerificationHandler creates a handler whose fallback verifier checks VC-JWT
+// signatures and leaves other credentials for manual review
+func NewCredentialVerificationHandler(
+	log *logrus.Logger,
+	db *gorm.DB,
+	credentialsRepo repository.CredentialsRepository,
+	levels VerificationLevelService,
+	credentialJWTs CredentialJWTService,
+	webhooks WebhookService,
+) *CredentialVerificationHandler {
+	return &CredentialVerificationHandler{
+		log:             log,
+		db:              db,
+		credentialsRepo: credentialsRepo,
+		levels:          levels,
+		webhooks:        webhooks,
+		verifiers:       make(map[string]CredentialVerifier),
+		fallback:        &proofVerifier{credentialJWTs: credentialJWTs},
+	}
+}
+
+// Register sets the verifier used for credentials of the given verification type
+func (h *CredentialVerificationHandler) Register(verificationType string, verifier CredentialVerifier) {
+	h.verifiers[verificationType] = verifier
+}
+
+// Handle implements queue.Handler
+func (h *CredentialVerificationHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("verification job has no credential"))
+	}
+	tokenID := *job.ResourceID
+
+	token, err := h.credentialsRepo.FindByID(ctx, h.db, tokenID)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+
+	result := map[string]interface{}{"credentialId": tokenID.String()}
+
+	// Untrusted credentials and credentials already decided by an administrator are left alone
+	if token.Status != constants.TokenStatusPending {
+		result["status"] = token.Status
+		return result, nil
+	}
+
+	verifier, ok := h.verifiers[token.TokenType]
+	if !ok {
+		verifier = h.fallback
+	}
+
+	verdict, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if verdict.Reason != "" {
+		result["reason"] = verdict.Reason
+	}
+	if verdict.Status == "" {
+		result["status"] = token.Status
+		return result, nil
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		current, err := h.credentialsRepo.FindByID(ctx, tx, tokenID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(credentialStatusTransitions[current.Status], verdict.Status) {
+			// The status changed while verifying; the newer decision stands
+			verdict.Status = current.Status
+			return nil
+		}
+
+		if err := h.credentialsRepo.UpdateStatus(ctx, tx, tokenID, verdict.Status); err != nil {
+			return err
+		}
+		previousStatus := current.Status
+		current.Status = verdict.Status
+
+		if err := h.webhooks.Publish(ctx, tx, credentialStatusChangedEvent(current, previousStatus)); err != nil {
+			return err
+		}
+		_, err = h.levels.Recompute(ctx, tx, current.AccountID, levelChangeReasons[verdict.Status], &tokenID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Infof("Credential %s verification completed: %s", tokenID, verdict.Status)
+	result["status"] = verdict.Status
+	return result, nil
+}
+
+// proofVerifier verifies VC-JWT credentials against their issuer's DID. Embedded JSON-LD
+// proofs are not checked automatically, so those credentials are left for manual review.
+type proofVerifier struct {
+	credentialJWTs CredentialJWTService
+}
+
+func (v *proofVerifier) Verify(ctx context.Context, token *model.Token) (*CredentialVerdict, error) {
+	if token.TokenStandard != constants.TokenStandardVCJWT {
+		return &CredentialVerdict{Reason: constants.MsgManualReviewRequired}, nil
+	}
+
+	compact, _ := token.Metadata["credentialJwt"].(string)
+	if compact == "" {
+		return &CredentialVerdict{Status: constants.TokenStatusRejected, Reason: constants.ErrInvalidCredentialJWT}, nil
+	}
+
+	// Re-verification also catches credentials that expired or whose issuer key was rotated out,
+	// and imported credentials issued to somebody else
+	if _, err := v.credentialJWTs.VerifyHeldBy(ctx, compact, token.AccountID); err != nil {
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusUnprocessableEntity {
+			return &CredentialVerdict{Status: constants.TokenStatusRejected, Reason: fiberErr.Message}, nil
+		}
+		return nil, fmt.Errorf("failed to verify credential: %w", err)
+	}
+
+	return &CredentialVerdict{Status: constants.TokenStatusVerified}, nil
+}
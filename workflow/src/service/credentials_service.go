@@ -1,11 +1,16 @@
 package service
 
 import (
+	"app/src/adapter"
+	"app/src/config"
 	"app/src/constants"
 	"app/src/model"
 	"app/src/repository"
 	"app/src/utils"
 	"app/src/validation"
+	"context"
+	"slices"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -17,80 +22,220 @@ import (
 
 // CredentialsService defines the interface for credentials business logic operations
 type CredentialsService interface {
-	AddCredential(c *fiber.Ctx, req *validation.AddCredentials) (*model.Token, error)
-	ListCredentials(c *fiber.Ctx) ([]model.Token, error)
+	AddCredential(c *fiber.Ctx, req *validation.AddCredentials) (*model.Token, *model.Job, error)
+	ListCredentials(c *fiber.Ctx, req *validation.ListCredentialsRequest) ([]model.Token, string, error)
 	GetCredential(c *fiber.Ctx, credentialID string) (*model.Token, error)
 	DeleteCredential(c *fiber.Ctx, credentialID string) error
+	UpdateCredentialStatus(c *fiber.Ctx, req *validation.UpdateCredentialStatusRequest) (*model.Token, error)
+}
+
+// credentialStatusTransitions lists the statuses each credential status may move to
+var credentialStatusTransitions = map[string][]string{
+	constants.TokenStatusPending:   {constants.TokenStatusVerified, constants.TokenStatusRejected},
+	constants.TokenStatusUntrusted: {constants.TokenStatusVerified, constants.TokenStatusRejected},
+	constants.TokenStatusVerified:  {constants.TokenStatusRevoked},
+}
+
+// levelChangeReasons maps a credential status to the reason recorded for a resulting level change
+var levelChangeReasons = map[string]string{
+	constants.TokenStatusVerified: constants.LevelChangeCredentialVerified,
+	constants.TokenStatusRejected: constants.LevelChangeCredentialRejected,
+	constants.TokenStatusRevoked:  constants.LevelChangeCredentialRevoked,
+}
+
+// documentRoles lists the roles an evidence document may play in a credential
+var documentRoles = []string{
+	constants.DocumentRoleFront,
+	constants.DocumentRoleBack,
+	constants.DocumentRoleSelfie,
+	constants.DocumentRoleProofOfAddress,
 }
 
 // credentialsService implements CredentialsService with constructor-based dependency injection
 type credentialsService struct {
-	log             *logrus.Logger
-	db              *gorm.DB
-	validate        *validator.Validate
-	credentialsRepo repository.CredentialsRepository
-	documentRepo    repository.DocumentRepository
+	log               *logrus.Logger
+	db                *gorm.DB
+	validate          *validator.Validate
+	credentialsRepo   repository.CredentialsRepository
+	documentRepo      repository.DocumentRepository
+	actorRepo         repository.ActorRepository
+	trustedIssuers    TrustedIssuerService
+	levels            VerificationLevelService
+	credentialJWTs    CredentialJWTService
+	jobs              JobService
+	webhooks          WebhookService
+	orphanedDocuments string
+	storage           *adapter.LazyStorage
 }
 
-// NewCredentialsService creates a new credentials service instance
+// NewCredentialsService creates a new credentials service instance.
+// The storage provider is initialized lazily when orphaned documents are first deleted.
 func NewCredentialsService(
+	cfg *config.Config,
 	log *logrus.Logger,
 	db *gorm.DB,
 	validate *validator.Validate,
 	credentialsRepo repository.CredentialsRepository,
 	documentRepo repository.DocumentRepository,
+	actorRepo repository.ActorRepository,
+	trustedIssuers TrustedIssuerService,
+	levels VerificationLevelService,
+	credentialJWTs CredentialJWTService,
+	jobs JobService,
+	webhooks WebhookService,
+	storage *adapter.LazyStorage,
 ) CredentialsService {
 	return &credentialsService{
-		log:             log,
-		db:              db,
-		validate:        validate,
-		credentialsRepo: credentialsRepo,
-		documentRepo:    documentRepo,
+		log:               log,
+		db:                db,
+		validate:          validate,
+		credentialsRepo:   credentialsRepo,
+		documentRepo:      documentRepo,
+		actorRepo:         actorRepo,
+		trustedIssuers:    trustedIssuers,
+		levels:            levels,
+		credentialJWTs:    credentialJWTs,
+		jobs:              jobs,
+		webhooks:          webhooks,
+		orphanedDocuments: cfg.OrphanedDocuments,
+		storage:           storage,
 	}
 }
 
-func (s *credentialsService) AddCredential(c *fiber.Ctx, req *validation.AddCredentials) (*model.Token, error) {
+func (s *credentialsService) AddCredential(c *fiber.Ctx, req *validation.AddCredentials) (*model.Token, *model.Job, error) {
 	if err := s.validate.Struct(req.AddCredentialRequest); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	actorID := c.Locals("actorID")
-	if actorID == nil {
-		return nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
+	actorUUID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	actorUUID, ok := actorID.(uuid.UUID)
-	if !ok {
-		s.log.Error("actorID type assertion failed")
-		return nil, fiber.NewError(fiber.StatusInternalServerError, "invalid actor ID type")
+	// Verify every evidence document exists and belongs to the actor
+	links, documents, err := s.buildDocumentLinks(c.Context(), actorUUID, req.AddCredentialRequest.Payload.EvidenceDocuments())
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Validate and parse document ID
-	if req.AddCredentialRequest.Payload.DocumentID == "" {
-		return nil, fiber.NewError(fiber.StatusBadRequest, "documentId is required")
+	// Build token from whichever credential encoding was submitted
+	var token *model.Token
+	if req.AddCredentialRequest.Payload.VerifiableCredentialJWT != "" {
+		token, err = s.buildTokenFromJWT(c, req, actorUUID)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		token = s.buildTokenFromRequest(req)
 	}
+	token.AccountID = actorUUID
+	token.Documents = links
 
-	documentID, err := utils.ParseUUID(req.AddCredentialRequest.Payload.DocumentID, "document")
+	if err := s.applyIssuerTrust(c, token, actorUUID); err != nil {
+		return nil, nil, err
+	}
+
+	// The verification job is enqueued atomically with the credential it verifies
+	job := &model.Job{
+		Type:       constants.JobTypeCredentialVerification,
+		ActorID:    actorUUID,
+		ResourceID: &token.TokenID,
+	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.credentialsRepo.Create(c.Context(), tx, token); err != nil {
+			s.log.Errorf("%s: %+v", constants.ErrFailedToCreateToken, err)
+			return err
+		}
+		return s.jobs.Enqueue(c.Context(), tx, job)
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Verify document exists
-	if _, err = s.documentRepo.FindByID(c.Context(), s.db, documentID); err != nil {
-		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrDocumentNotFound)
+	for i := range token.Documents {
+		token.Documents[i].Document = documents[token.Documents[i].DocumentID]
 	}
+	return token, job, nil
+}
 
-	// Build token
-	token := s.buildTokenFromRequest(req)
-	token.AccountID = actorUUID
+// buildDocumentLinks checks that every evidence document exists and belongs to the actor, and
+// returns the links to create with the credential along with the linked documents
+func (s *credentialsService) buildDocumentLinks(ctx context.Context, actorID uuid.UUID, evidence []model.CredentialDocument) ([]model.TokenDocument, map[uuid.UUID]*model.Document, error) {
+	if len(evidence) == 0 {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrDocumentRequired)
+	}
 
-	// Save token - single operation doesn't need transaction
-	if err := s.credentialsRepo.Create(c.Context(), s.db, token); err != nil {
-		s.log.Errorf("%s: %+v", constants.ErrFailedToCreateToken, err)
-		return nil, err
+	links := make([]model.TokenDocument, 0, len(evidence))
+	documentIDs := make([]uuid.UUID, 0, len(evidence))
+	for _, document := range evidence {
+		documentID, err := utils.ParseUUID(document.DocumentID, "document")
+		if err != nil {
+			return nil, nil, err
+		}
+		links = append(links, model.TokenDocument{DocumentID: documentID, Role: document.Role})
+		documentIDs = append(documentIDs, documentID)
 	}
 
-	return token, nil
+	found, err := s.documentRepo.FindByIDsForAccount(ctx, s.db, documentIDs, actorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(found) != len(documentIDs) {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, constants.ErrDocumentNotFound)
+	}
+
+	documents := make(map[uuid.UUID]*model.Document, len(found))
+	for i := range found {
+		documents[found[i].DocumentID] = &found[i]
+	}
+	return links, documents, nil
+}
+
+// applyIssuerTrust evaluates the credential issuer against the trusted issuer registry.
+// Untrusted issuers are rejected or flagged depending on the configured trust policy.
+func (s *credentialsService) applyIssuerTrust(c *fiber.Ctx, token *model.Token, actorID uuid.UUID) error {
+	actor, err := s.actorRepo.FindByID(c.Context(), s.db, actorID)
+	if err != nil {
+		return err
+	}
+
+	return evaluateIssuerTrust(c.Context(), s.log, s.trustedIssuers, token, actorJurisdiction(actor))
+}
+
+// evaluateIssuerTrust records the trust evaluation of a credential's issuer on the token and flags
+// untrusted issuers, or rejects them with ErrUntrustedIssuer when the trust policy says so
+func evaluateIssuerTrust(ctx context.Context, log *logrus.Logger, trustedIssuers TrustedIssuerService, token *model.Token, jurisdiction string) error {
+	evaluation, err := trustedIssuers.Evaluate(ctx, token.IssuerDID, token.TokenType, jurisdiction)
+	if err != nil {
+		log.Errorf("Failed to evaluate issuer trust: %+v", err)
+		return err
+	}
+
+	token.Metadata["trust"] = evaluation
+	if evaluation.Trusted {
+		return nil
+	}
+
+	if trustedIssuers.Policy() == constants.TrustPolicyReject {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrUntrustedIssuer)
+	}
+
+	log.Warnf("Flagging credential from untrusted issuer %s: %s", token.IssuerDID, evaluation.Reason)
+	token.Status = constants.TokenStatusUntrusted
+	return nil
+}
+
+// actorJurisdiction returns the country an actor's credentials are evaluated against:
+// the country of incorporation for businesses and the country of residence otherwise
+func actorJurisdiction(actor *model.Actor) string {
+	country := actor.CountryOfResidence
+	if actor.EntityType == constants.EntityTypeBusiness {
+		country = actor.CountryOfIncorporation
+	}
+	if country == nil {
+		return ""
+	}
+	return *country
 }
 
 // buildTokenFromRequest creates a token from the credential request
@@ -120,18 +265,129 @@ func (s *credentialsService) buildTokenFromRequest(req *validation.AddCredential
 	}
 }
 
-func (s *credentialsService) ListCredentials(c *fiber.Ctx) ([]model.Token, error) {
-	tokens, err := s.credentialsRepo.FindAll(c.Context(), s.db)
+// buildTokenFromJWT verifies a VC-JWT against its issuer's DID and its subject against the DIDs of
+// the actor adding it, and creates a token from its claims. The compact form is kept so the
+// credential can be re-presented unchanged.
+func (s *credentialsService) buildTokenFromJWT(c *fiber.Ctx, req *validation.AddCredentials, actorID uuid.UUID) (*model.Token, error) {
+	compact := req.AddCredentialRequest.Payload.VerifiableCredentialJWT
+
+	verified, err := s.credentialJWTs.VerifyHeldBy(c.Context(), compact, actorID)
 	if err != nil {
-		s.log.Errorf("Failed to retrieve credentials: %+v", err)
 		return nil, err
 	}
 
+	claims := map[string]interface{}{
+		"iss": verified.Issuer,
+	}
+	if verified.Subject != "" {
+		claims["sub"] = verified.Subject
+	}
+	if verified.ID != "" {
+		claims["jti"] = verified.ID
+	}
+	if verified.NotBefore != nil {
+		claims["nbf"] = verified.NotBefore.UTC().Format(time.RFC3339)
+	}
+	if verified.ExpiresAt != nil {
+		claims["exp"] = verified.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	metadata := map[string]interface{}{
+		"format":               constants.CredentialFormatVCJWT,
+		"verifiableCredential": verified.Credential,
+		"credentialJwt":        compact,
+		"claims":               claims,
+		"proof": map[string]interface{}{
+			"alg":        verified.Algorithm,
+			"kid":        verified.KeyID,
+			"verifiedAt": time.Now().UTC().Format(time.RFC3339),
+		},
+		"verificationType": req.AddCredentialRequest.VerificationType,
+	}
+
+	return &model.Token{
+		TokenID:       uuid.New(),
+		TokenType:     req.AddCredentialRequest.VerificationType,
+		IssuerDID:     verified.Issuer,
+		TokenStandard: constants.TokenStandardVCJWT,
+		Status:        constants.StatusPending,
+		Metadata:      datatypes.JSONMap(metadata),
+	}, nil
+}
+
+func (s *credentialsService) ListCredentials(c *fiber.Ctx, req *validation.ListCredentialsRequest) ([]model.Token, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter, err := s.buildCredentialFilter(actorID, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tokens, err := s.credentialsRepo.List(c.Context(), s.db, filter)
+	if err != nil {
+		s.log.Errorf("Failed to retrieve credentials: %+v", err)
+		return nil, "", err
+	}
+
 	if len(tokens) == 0 {
-		return []model.Token{}, nil
+		return []model.Token{}, "", nil
+	}
+
+	// One extra row was fetched to detect whether another page exists
+	nextCursor := ""
+	if len(tokens) > filter.Limit {
+		tokens = tokens[:filter.Limit]
+		last := tokens[len(tokens)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.TokenID)
 	}
 
-	return tokens, nil
+	return tokens, nextCursor, nil
+}
+
+// buildCredentialFilter converts a list request into a repository filter scoped to the actor
+func (s *credentialsService) buildCredentialFilter(actorID uuid.UUID, req *validation.ListCredentialsRequest) (repository.CredentialFilter, error) {
+	filter := repository.CredentialFilter{
+		AccountID:        actorID,
+		Status:           req.Status,
+		VerificationType: req.VerificationType,
+		Issuer:           req.Issuer,
+		Limit:            utils.PageLimit(req.Limit),
+	}
+
+	cursor, err := utils.DecodeCursor(req.Cursor)
+	if err != nil {
+		return filter, err
+	}
+	filter.Cursor = cursor
+
+	if req.CreatedFrom != "" {
+		createdFrom, err := time.Parse(time.RFC3339, req.CreatedFrom)
+		if err != nil {
+			return filter, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+		filter.CreatedFrom = &createdFrom
+	}
+
+	if req.CreatedTo != "" {
+		createdTo, err := time.Parse(time.RFC3339, req.CreatedTo)
+		if err != nil {
+			return filter, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+		filter.CreatedTo = &createdTo
+	}
+
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidDateRange)
+	}
+
+	return filter, nil
 }
 
 func (s *credentialsService) GetCredential(c *fiber.Ctx, credentialID string) (*model.Token, error) {
@@ -140,7 +396,12 @@ func (s *credentialsService) GetCredential(c *fiber.Ctx, credentialID string) (*
 		return nil, err
 	}
 
-	token, err := s.credentialsRepo.FindByID(c.Context(), s.db, tokenID)
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.credentialsRepo.FindByIDForAccount(c.Context(), s.db, tokenID, actorID)
 	if err != nil {
 		s.log.Errorf("Failed to retrieve credential: %+v", err)
 		return nil, err
@@ -155,11 +416,126 @@ func (s *credentialsService) DeleteCredential(c *fiber.Ctx, credentialID string)
 		return err
 	}
 
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		if err := s.credentialsRepo.Delete(c.Context(), tx, tokenID); err != nil {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var removed []model.Document
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		token, err := s.credentialsRepo.FindByIDForAccount(c.Context(), tx, tokenID, actorID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.credentialsRepo.Delete(c.Context(), tx, tokenID, actorID); err != nil {
 			s.log.Errorf("Failed to delete credential: %+v", err)
 			return err
 		}
-		return nil
+
+		if removed, err = s.removeOrphanedDocuments(c.Context(), tx, token); err != nil {
+			return err
+		}
+
+		// Deleting a verified credential may drop the actor to a lower level
+		_, err = s.levels.Recompute(c.Context(), tx, actorID, constants.LevelChangeCredentialDeleted, &tokenID)
+		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteStoredFiles(c.Context(), removed)
+	return nil
+}
+
+// removeOrphanedDocuments deletes the records of the deleted credential's documents that no other
+// credential links to, when the orphaned document policy says so, and returns them
+func (s *credentialsService) removeOrphanedDocuments(ctx context.Context, tx *gorm.DB, token *model.Token) ([]model.Document, error) {
+	if s.orphanedDocuments != constants.OrphanedDocumentPolicyDelete || len(token.Documents) == 0 {
+		return nil, nil
+	}
+
+	documentIDs := make([]uuid.UUID, 0, len(token.Documents))
+	for _, link := range token.Documents {
+		documentIDs = append(documentIDs, link.DocumentID)
+	}
+	linked, err := s.credentialsRepo.FindLinkedDocumentIDs(ctx, tx, documentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []model.Document
+	for _, link := range token.Documents {
+		if link.Document == nil || slices.Contains(linked, link.DocumentID) {
+			continue
+		}
+		if err := s.documentRepo.Delete(ctx, tx, link.DocumentID); err != nil {
+			return nil, err
+		}
+		removed = append(removed, *link.Document)
+	}
+	return removed, nil
+}
+
+// deleteStoredFiles removes the stored content of deleted documents. The records are already
+// gone, so failures are only logged.
+func (s *credentialsService) deleteStoredFiles(ctx context.Context, documents []model.Document) {
+	if len(documents) == 0 {
+		return
+	}
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		s.log.Warnf("Storage provider unavailable, orphaned document files kept: %v", err)
+		return
+	}
+	for _, document := range documents {
+		if err := storageProvider.Delete(ctx, document.StoragePath); err != nil {
+			s.log.Warnf("Failed to delete file of orphaned document %s: %v", document.DocumentID, err)
+		}
+	}
+}
+
+func (s *credentialsService) UpdateCredentialStatus(c *fiber.Ctx, req *validation.UpdateCredentialStatusRequest) (*model.Token, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	tokenID, err := utils.ParseUUID(req.CredentialID, "credential")
+	if err != nil {
+		return nil, err
+	}
+
+	var token *model.Token
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		token, err = s.credentialsRepo.FindByID(ctx, tx, tokenID)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(credentialStatusTransitions[token.Status], req.Status) {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrInvalidStatusTransition)
+		}
+
+		if err := s.credentialsRepo.UpdateStatus(ctx, tx, tokenID, req.Status); err != nil {
+			return err
+		}
+		previousStatus := token.Status
+		token.Status = req.Status
+
+		if err := s.webhooks.Publish(ctx, tx, credentialStatusChangedEvent(token, previousStatus)); err != nil {
+			return err
+		}
+
+		_, err = s.levels.Recompute(ctx, tx, token.AccountID, levelChangeReasons[req.Status], &tokenID)
+		return err
+	})
+	if err != nil {
+		s.log.Errorf("Failed to update credential status: %+v", err)
+		return nil, err
+	}
+
+	return token, nil
 }
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"app/src/model"
+	"app/src/queue"
+	"app/src/utils"
+	"context"
+	"errors"
+)
+
+// DataExportHandler processes data export jobs. An export whose job fails for the last time is
+// marked failed, so that the actor can request a new one.
+type DataExportHandler struct {
+	exports ExportService
+}
+
+// NewDataExportHandler creates a new data export handler
+func NewDataExportHandler(exports ExportService) *DataExportHandler {
+	return &DataExportHandler{exports: exports}
+}
+
+// Handle implements queue.Handler
+func (h *DataExportHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("data export job has no export"))
+	}
+
+	if err := h.exports.Build(ctx, *job.ResourceID); err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		if queue.IsPermanent(err) || job.Attempts >= job.MaxAttempts {
+			if failErr := h.exports.Fail(ctx, *job.ResourceID, err); failErr != nil {
+				return nil, errors.Join(err, failErr)
+			}
+		}
+		return nil, err
+	}
+	return map[string]interface{}{"exportId": job.ResourceID.String()}, nil
+}
+
+// DataExportExpiryHandler processes data export expiry jobs, which are scheduled for the end of
+// the retention period of a prepared bundle
+type DataExportExpiryHandler struct {
+	exports ExportService
+}
+
+// NewDataExportExpiryHandler creates a new data export expiry handler
+func NewDataExportExpiryHandler(exports ExportService) *DataExportExpiryHandler {
+	return &DataExportExpiryHandler{exports: exports}
+}
+
+// Handle implements queue.Handler
+func (h *DataExportExpiryHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("data export expiry job has no export"))
+	}
+
+	if err := h.exports.Expire(ctx, *job.ResourceID); err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+	return map[string]interface{}{"exportId": job.ResourceID.String()}, nil
+}
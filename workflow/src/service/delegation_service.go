@@ -0,0 +1,267 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DelegationService defines the interface for delegated access between actors.
+// A principal authorizes a delegate, for example a guardian or an accountant, to perform scoped
+// operations for them during a time window. Delegates act by sending the X-On-Behalf-Of header,
+// which the auth middleware checks against the grants; every request made that way is audited
+// with both identities.
+type DelegationService interface {
+	CreateGrant(c *fiber.Ctx, req *validation.CreateDelegationGrantRequest) (*model.DelegationGrant, error)
+	ListGrants(c *fiber.Ctx, req *validation.ListDelegationGrantsRequest) ([]model.DelegationGrant, string, error)
+	RevokeGrant(c *fiber.Ctx, req *validation.DelegationGrantIDRequest) (*model.DelegationGrant, error)
+	ListActions(c *fiber.Ctx, req *validation.ListDelegatedActionsRequest) ([]model.DelegatedAction, string, error)
+	ListReceivedGrants(c *fiber.Ctx, req *validation.ListDelegationGrantsRequest) ([]model.DelegationGrant, string, error)
+	ListPerformedActions(c *fiber.Ctx, req *validation.ListDelegatedActionsRequest) ([]model.DelegatedAction, string, error)
+}
+
+type delegationService struct {
+	log            *logrus.Logger
+	db             *gorm.DB
+	validate       *validator.Validate
+	grantRepo      repository.DelegationGrantRepository
+	actionRepo     repository.DelegatedActionRepository
+	identifierRepo repository.IdentifierRepository
+	namespaces     NamespaceService
+}
+
+// NewDelegationService creates a new delegation service instance
+func NewDelegationService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	grantRepo repository.DelegationGrantRepository,
+	actionRepo repository.DelegatedActionRepository,
+	identifierRepo repository.IdentifierRepository,
+	namespaces NamespaceService,
+) DelegationService {
+	return &delegationService{
+		log:            log,
+		db:             db,
+		validate:       validate,
+		grantRepo:      grantRepo,
+		actionRepo:     actionRepo,
+		identifierRepo: identifierRepo,
+		namespaces:     namespaces,
+	}
+}
+
+func (s *delegationService) CreateGrant(c *fiber.Ctx, req *validation.CreateDelegationGrantRequest) (*model.DelegationGrant, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	principalID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	validFrom := now
+	if req.ValidFrom != "" {
+		if validFrom, err = time.Parse(time.RFC3339, req.ValidFrom); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidDelegationWindow)
+		}
+	}
+	expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+	if err != nil || !expiresAt.After(now) || !expiresAt.After(validFrom) ||
+		expiresAt.After(now.AddDate(0, 0, constants.MaxDelegationDuration)) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidDelegationWindow)
+	}
+
+	grant := &model.DelegationGrant{
+		PrincipalID: principalID,
+		Scopes:      req.Scopes,
+		ValidFrom:   validFrom,
+		ExpiresAt:   expiresAt,
+	}
+	if req.Relationship != "" {
+		grant.Relationship = &req.Relationship
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		delegate, err := s.identifierRepo.FindByValue(c.Context(), tx, s.namespaces.Canonical(req.Delegate))
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusNotFound, constants.ErrDelegateNotFound)
+			}
+			return err
+		}
+		if delegate.EntityType != constants.EntityTypeActor {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrDelegateNotFound)
+		}
+		if delegate.EntityID == principalID {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrCannotDelegateToSelf)
+		}
+		grant.DelegateID = delegate.EntityID
+		grant.DelegateIdentifier = delegate.Identifier
+
+		principal, err := s.identifierRepo.FindByActorID(c.Context(), tx, principalID)
+		if err != nil {
+			return err
+		}
+		if principal != nil {
+			grant.PrincipalIdentifier = principal.Identifier
+		}
+
+		return s.grantRepo.Create(c.Context(), tx, grant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s delegated %v to actor %s until %s", principalID, grant.Scopes, grant.DelegateID, grant.ExpiresAt.Format(time.RFC3339))
+	return grant, nil
+}
+
+func (s *delegationService) ListGrants(c *fiber.Ctx, req *validation.ListDelegationGrantsRequest) ([]model.DelegationGrant, string, error) {
+	principalID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.listGrants(c, req, repository.DelegationGrantFilter{PrincipalID: &principalID})
+}
+
+func (s *delegationService) ListReceivedGrants(c *fiber.Ctx, req *validation.ListDelegationGrantsRequest) ([]model.DelegationGrant, string, error) {
+	delegateID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.listGrants(c, req, repository.DelegationGrantFilter{DelegateID: &delegateID})
+}
+
+// listGrants retrieves one page of grants for the side of the grant set in the filter
+func (s *delegationService) listGrants(c *fiber.Ctx, req *validation.ListDelegationGrantsRequest, filter repository.DelegationGrantFilter) ([]model.DelegationGrant, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	cursor, err := utils.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.Status = req.Status
+	filter.At = time.Now().UTC()
+	filter.Cursor = cursor
+	filter.Limit = utils.PageLimit(req.Limit)
+
+	grants, err := s.grantRepo.List(c.Context(), s.db, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One extra row was fetched to detect whether another page exists
+	nextCursor := ""
+	if len(grants) > filter.Limit {
+		grants = grants[:filter.Limit]
+		last := grants[len(grants)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.GrantID)
+	}
+
+	return grants, nextCursor, nil
+}
+
+func (s *delegationService) RevokeGrant(c *fiber.Ctx, req *validation.DelegationGrantIDRequest) (*model.DelegationGrant, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	principalID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	grantID, err := utils.ParseUUID(req.GrantID, "grant")
+	if err != nil {
+		return nil, err
+	}
+
+	var grant *model.DelegationGrant
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		grant, err = s.grantRepo.LockByIDForPrincipal(c.Context(), tx, grantID, principalID)
+		if err != nil {
+			return err
+		}
+		if grant.RevokedAt != nil {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrDelegationGrantAlreadyRevoked)
+		}
+
+		revokedAt := time.Now().UTC()
+		if err := s.grantRepo.Revoke(c.Context(), tx, grantID, revokedAt); err != nil {
+			return err
+		}
+		grant.RevokedAt = &revokedAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s revoked delegation grant %s to actor %s", principalID, grant.GrantID, grant.DelegateID)
+	return grant, nil
+}
+
+func (s *delegationService) ListActions(c *fiber.Ctx, req *validation.ListDelegatedActionsRequest) ([]model.DelegatedAction, string, error) {
+	principalID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.listActions(c, req, repository.DelegatedActionFilter{PrincipalID: &principalID})
+}
+
+func (s *delegationService) ListPerformedActions(c *fiber.Ctx, req *validation.ListDelegatedActionsRequest) ([]model.DelegatedAction, string, error) {
+	delegateID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.listActions(c, req, repository.DelegatedActionFilter{DelegateID: &delegateID})
+}
+
+// listActions retrieves one page of delegated actions for the side of the grant set in the filter
+func (s *delegationService) listActions(c *fiber.Ctx, req *validation.ListDelegatedActionsRequest, filter repository.DelegatedActionFilter) ([]model.DelegatedAction, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	cursor, err := utils.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.Cursor = cursor
+	filter.Limit = utils.PageLimit(req.Limit)
+
+	if req.GrantID != "" {
+		grantID, err := utils.ParseUUID(req.GrantID, "grant")
+		if err != nil {
+			return nil, "", err
+		}
+		filter.GrantID = &grantID
+	}
+
+	actions, err := s.actionRepo.List(c.Context(), s.db, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(actions) > filter.Limit {
+		actions = actions[:filter.Limit]
+		last := actions[len(actions)-1]
+		nextCursor = utils.EncodeCursor(last.PerformedAt, last.ActionID)
+	}
+
+	return actions, nextCursor, nil
+}
@@ -0,0 +1,519 @@
+package service
+
+import (
+	"app/src/adapter"
+	"app/src/config"
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DeletionService defines the interface for account deletion (right to erasure). A deletion is
+// requested by the actor, takes effect after a grace period during which it can be cancelled,
+// and then erases the account across all repositories and the document storage. Records under a
+// retention hold are kept with the actor row pseudonymized. Every erasure produces a certificate
+// signed by the platform key.
+type DeletionService interface {
+	// RequestDeletion schedules the erasure of the caller's account. It also returns the token
+	// the erasure certificate is fetched with, which is not stored and cannot be shown again.
+	RequestDeletion(c *fiber.Ctx, req *validation.RequestDeletionRequest) (*model.AccountDeletion, string, error)
+	CancelDeletion(c *fiber.Ctx) (*model.AccountDeletion, error)
+
+	// GetDeletion returns the caller's most recent account deletion
+	GetDeletion(c *fiber.Ctx) (*model.AccountDeletion, error)
+
+	// GetCertificate returns a completed account deletion with its erasure certificate. The
+	// certificate names the erased actor, so it requires the certificate token.
+	GetCertificate(c *fiber.Ctx, req *validation.DeletionCertificateRequest) (*model.AccountDeletion, error)
+
+	// Erase erases the account of a pending deletion once its grace period is over
+	Erase(ctx context.Context, deletionID uuid.UUID) error
+
+	AddHold(c *fiber.Ctx, req *validation.AddRetentionHoldRequest) (*model.RetentionHold, error)
+	ListHolds(c *fiber.Ctx, req *validation.ListRetentionHoldsRequest) ([]model.RetentionHold, error)
+	ReleaseHold(c *fiber.Ctx, req *validation.RetentionHoldRequest) (*model.RetentionHold, error)
+}
+
+// ErasureCertificateClaims are the claims of an erasure certificate: the platform states that the
+// account of the subject DID was erased at the time of issue, and how many records of each
+// category were deleted. Pseudonymized is set when records under retention hold were kept.
+type ErasureCertificateClaims struct {
+	jwt.RegisteredClaims
+	ActorID       string           `json:"actor_id"`
+	RequestedAt   *jwt.NumericDate `json:"requested_at"`
+	Pseudonymized bool             `json:"pseudonymized"`
+	Erased        map[string]int64 `json:"erased"`
+}
+
+type deletionService struct {
+	cfg             *config.Config
+	log             *logrus.Logger
+	db              *gorm.DB
+	validate        *validator.Validate
+	signer          *keys.Signer
+	authService     AuthService
+	jobs            JobService
+	webhooks        WebhookService
+	resolver        ResolverService
+	storage         *adapter.LazyStorage
+	actorRepo       repository.ActorRepository
+	integrationRepo repository.ActorIntegrationRepository
+	identifierRepo  repository.IdentifierRepository
+	deletionRepo    repository.AccountDeletionRepository
+	holdRepo        repository.RetentionHoldRepository
+	erasureRepo     repository.ErasureRepository
+}
+
+// NewDeletionService creates a new account deletion service instance
+func NewDeletionService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	signer *keys.Signer,
+	authService AuthService,
+	jobs JobService,
+	webhooks WebhookService,
+	resolver ResolverService,
+	storage *adapter.LazyStorage,
+	actorRepo repository.ActorRepository,
+	integrationRepo repository.ActorIntegrationRepository,
+	identifierRepo repository.IdentifierRepository,
+	deletionRepo repository.AccountDeletionRepository,
+	holdRepo repository.RetentionHoldRepository,
+	erasureRepo repository.ErasureRepository,
+) DeletionService {
+	return &deletionService{
+		cfg:             cfg,
+		log:             log,
+		db:              db,
+		validate:        validate,
+		signer:          signer,
+		authService:     authService,
+		jobs:            jobs,
+		webhooks:        webhooks,
+		resolver:        resolver,
+		storage:         storage,
+		actorRepo:       actorRepo,
+		integrationRepo: integrationRepo,
+		identifierRepo:  identifierRepo,
+		deletionRepo:    deletionRepo,
+		holdRepo:        holdRepo,
+		erasureRepo:     erasureRepo,
+	}
+}
+
+func (s *deletionService) RequestDeletion(c *fiber.Ctx, req *validation.RequestDeletionRequest) (*model.AccountDeletion, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	ctx := c.Context()
+
+	actor, err := s.actorRepo.FindByID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := s.authService.Login(actor.Email, req.Password); err != nil {
+		s.log.Warnf("Account deletion for actor %s failed re-authentication: %v", actorID, err)
+		return nil, "", fiber.NewError(fiber.StatusUnauthorized, constants.ErrInvalidCredentials)
+	}
+
+	deletion := &model.AccountDeletion{
+		ActorID:     actorID,
+		Status:      constants.AccountDeletionStatusPending,
+		EffectiveAt: time.Now().UTC().AddDate(0, 0, s.cfg.ErasureGraceDays),
+	}
+	if req.Reason != "" {
+		deletion.Reason = &req.Reason
+	}
+
+	// The actor cannot log in once the account is erased, so the certificate is fetched with a token
+	certificateToken, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, "", err
+	}
+	tokenHash := utils.HashSecret(certificateToken)
+	deletion.CertificateTokenHash = &tokenHash
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.deletionRepo.Create(ctx, tx, deletion); err != nil {
+			return err
+		}
+
+		err := s.jobs.Enqueue(ctx, tx, &model.Job{
+			Type:       constants.JobTypeAccountErasure,
+			ActorID:    actorID,
+			ResourceID: &deletion.DeletionID,
+			RunAt:      deletion.EffectiveAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.webhooks.Publish(ctx, tx, WebhookEvent{
+			Type:    constants.WebhookEventDeletionRequested,
+			ActorID: actorID,
+			Data: map[string]interface{}{
+				"deletionId":  deletion.DeletionID.String(),
+				"effectiveAt": deletion.EffectiveAt.Format(time.RFC3339),
+			},
+		})
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.log.Infof("Account deletion %s scheduled for actor %s at %s", deletion.DeletionID, actorID, deletion.EffectiveAt.Format(time.RFC3339))
+	return deletion, certificateToken, nil
+}
+
+func (s *deletionService) CancelDeletion(c *fiber.Ctx) (*model.AccountDeletion, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.Context()
+
+	var deletion *model.AccountDeletion
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the deletion waits for an erasure in progress, after which it is no longer pending
+		deletion, err = s.deletionRepo.LockPendingByActorID(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := s.deletionRepo.Cancel(ctx, tx, deletion.DeletionID, now); err != nil {
+			return err
+		}
+		deletion.Status = constants.AccountDeletionStatusCancelled
+		deletion.CancelledAt = &now
+
+		return s.webhooks.Publish(ctx, tx, WebhookEvent{
+			Type:    constants.WebhookEventDeletionCancelled,
+			ActorID: actorID,
+			Data:    map[string]interface{}{"deletionId": deletion.DeletionID.String()},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s cancelled account deletion %s", actorID, deletion.DeletionID)
+	return deletion, nil
+}
+
+func (s *deletionService) GetDeletion(c *fiber.Ctx) (*model.AccountDeletion, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.deletionRepo.FindLatestByActorID(c.Context(), s.db, actorID)
+}
+
+func (s *deletionService) GetCertificate(c *fiber.Ctx, req *validation.DeletionCertificateRequest) (*model.AccountDeletion, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	deletionID, err := utils.ParseUUID(req.DeletionID, "deletion")
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := s.deletionRepo.FindByID(c.Context(), s.db, deletionID)
+	if err != nil {
+		return nil, err
+	}
+	// A wrong token is reported like a missing certificate, so deletion IDs cannot be probed
+	if deletion.CertificateTokenHash == nil || !secretMatches(*deletion.CertificateTokenHash, req.CertificateToken) {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrErasureCertificateNotFound)
+	}
+	if deletion.Certificate == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrErasureCertificateNotFound)
+	}
+	return deletion, nil
+}
+
+func (s *deletionService) Erase(ctx context.Context, deletionID uuid.UUID) error {
+	var erased *model.AccountDeletion
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		deletion, err := s.deletionRepo.LockByID(ctx, tx, deletionID)
+		if err != nil {
+			return err
+		}
+		if deletion.Status != constants.AccountDeletionStatusPending {
+			s.log.Infof("Account deletion %s is %s; nothing to do", deletionID, deletion.Status)
+			return nil
+		}
+
+		now := time.Now().UTC()
+		if now.Before(deletion.EffectiveAt) {
+			return fmt.Errorf("account deletion %s takes effect at %s", deletionID, deletion.EffectiveAt.Format(time.RFC3339))
+		}
+
+		actor, err := s.actorRepo.LockByID(ctx, tx, deletion.ActorID)
+		if err != nil {
+			return err
+		}
+		held, err := s.holdRepo.HasActive(ctx, tx, actor.ActorID, now)
+		if err != nil {
+			return err
+		}
+
+		summary, err := s.erase(ctx, tx, actor, held, now)
+		if err != nil {
+			return err
+		}
+
+		certificate, err := s.signCertificate(deletion, actor, held, summary, now)
+		if err != nil {
+			return err
+		}
+
+		deletion.Pseudonymized = held
+		deletion.Summary = datatypes.JSONMap{}
+		for category, count := range summary {
+			deletion.Summary[category] = count
+		}
+		deletion.Certificate = &certificate
+		deletion.CompletedAt = &now
+		if err := s.deletionRepo.Complete(ctx, tx, deletion); err != nil {
+			return err
+		}
+
+		// Partner subscribers are told so they can erase their copies; the actor's own
+		// subscriptions are already gone
+		err = s.webhooks.Publish(ctx, tx, WebhookEvent{
+			Type:    constants.WebhookEventAccountErased,
+			ActorID: actor.ActorID,
+			Data: map[string]interface{}{
+				"deletionId":    deletionID.String(),
+				"pseudonymized": held,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		erased = deletion
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if erased != nil {
+		s.resolver.InvalidateActor(erased.ActorID)
+		s.log.Infof("Erased account of actor %s (deletion %s, pseudonymized: %t)", erased.ActorID, deletionID, erased.Pseudonymized)
+	}
+	return nil
+}
+
+// erase removes the actor's records and returns how many were removed per category. The auth
+// provider user is deleted first: if anything after it fails the transaction is rolled back and
+// the job retried, and deleting the user again succeeds. Stored files are deleted before their
+// records for the same reason.
+func (s *deletionService) erase(ctx context.Context, tx *gorm.DB, actor *model.Actor, held bool, now time.Time) (map[string]int64, error) {
+	summary := map[string]int64{}
+
+	integration, err := s.integrationRepo.FindByActorIDAndProvider(ctx, tx, actor.ActorID, constants.KeycloakProviderName)
+	if err != nil && !utils.IsNotFoundError(err) {
+		return nil, err
+	}
+	if integration != nil {
+		if err := s.authService.DeleteUser(integration.ExternalUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	identifiers, err := s.identifierRepo.ListByActorID(ctx, tx, actor.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	availableAt := now.AddDate(0, 0, s.cfg.ReleaseCoolingOff)
+	for i := range identifiers {
+		if _, err := s.identifierRepo.Release(ctx, tx, &identifiers[i], now, availableAt); err != nil {
+			return nil, err
+		}
+	}
+	summary["identifiers"] = int64(len(identifiers))
+
+	exports, err := s.erasureRepo.FindDataExports(ctx, tx, actor.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	var bundles []string
+	for _, export := range exports {
+		if export.StoragePath != nil {
+			bundles = append(bundles, *export.StoragePath)
+		}
+	}
+	if err := s.deleteStoredFiles(ctx, bundles); err != nil {
+		return nil, err
+	}
+
+	counts, err := s.erasureRepo.DeleteAccountData(ctx, tx, actor.ActorID, constants.JobTypeAccountErasure)
+	if err != nil {
+		return nil, err
+	}
+	for category, count := range counts {
+		summary[category] = count
+	}
+
+	if held {
+		email := fmt.Sprintf("erased-%s@%s", actor.ActorID, constants.ErasedEmailDomain)
+		if err := s.erasureRepo.PseudonymizeActor(ctx, tx, actor.ActorID, email); err != nil {
+			return nil, err
+		}
+		return summary, nil
+	}
+
+	documents, err := s.erasureRepo.FindDocuments(ctx, tx, actor.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	storagePaths := make([]string, 0, len(documents))
+	for _, document := range documents {
+		storagePaths = append(storagePaths, document.StoragePath)
+	}
+	if err := s.deleteStoredFiles(ctx, storagePaths); err != nil {
+		return nil, err
+	}
+	summary["storedFiles"] = int64(len(documents))
+
+	counts, err = s.erasureRepo.DeleteRetainedData(ctx, tx, actor.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	for category, count := range counts {
+		summary[category] = count
+	}
+	return summary, nil
+}
+
+// deleteStoredFiles removes stored files such as document contents and data export bundles.
+// Files already gone, for example deleted by an earlier attempt, are skipped.
+func (s *deletionService) deleteStoredFiles(ctx context.Context, storagePaths []string) error {
+	if len(storagePaths) == 0 {
+		return nil
+	}
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		return err
+	}
+	for _, storagePath := range storagePaths {
+		exists, err := storageProvider.Exists(ctx, storagePath)
+		if err != nil {
+			return fmt.Errorf("failed to check stored file %s: %w", storagePath, err)
+		}
+		if !exists {
+			continue
+		}
+		if err := storageProvider.Delete(ctx, storagePath); err != nil {
+			return fmt.Errorf("failed to delete stored file %s: %w", storagePath, err)
+		}
+	}
+	return nil
+}
+
+// signCertificate issues the platform's signed statement that the account was erased
+func (s *deletionService) signCertificate(deletion *model.AccountDeletion, actor *model.Actor, pseudonymized bool, summary map[string]int64, now time.Time) (string, error) {
+	return s.signer.Sign(ErasureCertificateClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   s.cfg.IssuerURL,
+			Subject:  actor.DID,
+			ID:       deletion.DeletionID.String(),
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+		ActorID:       actor.ActorID.String(),
+		RequestedAt:   jwt.NewNumericDate(deletion.CreatedAt),
+		Pseudonymized: pseudonymized,
+		Erased:        summary,
+	}, map[string]interface{}{"typ": constants.ErasureCertificateJWTType})
+}
+
+func (s *deletionService) AddHold(c *fiber.Ctx, req *validation.AddRetentionHoldRequest) (*model.RetentionHold, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ParseUUID(req.ActorID, "actor")
+	if err != nil {
+		return nil, err
+	}
+
+	hold := &model.RetentionHold{ActorID: actorID, Reason: strings.TrimSpace(req.Reason)}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil || !expiresAt.After(time.Now()) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRetentionHoldExpiry)
+		}
+		expiresAt = expiresAt.UTC()
+		hold.ExpiresAt = &expiresAt
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := s.actorRepo.FindByID(c.Context(), tx, actorID); err != nil {
+			return err
+		}
+		return s.holdRepo.Create(c.Context(), tx, hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Retention hold %s placed on actor %s: %s", hold.HoldID, actorID, hold.Reason)
+	return hold, nil
+}
+
+func (s *deletionService) ListHolds(c *fiber.Ctx, req *validation.ListRetentionHoldsRequest) ([]model.RetentionHold, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ParseUUID(req.ActorID, "actor")
+	if err != nil {
+		return nil, err
+	}
+	return s.holdRepo.ListByActorID(c.Context(), s.db, actorID)
+}
+
+func (s *deletionService) ReleaseHold(c *fiber.Ctx, req *validation.RetentionHoldRequest) (*model.RetentionHold, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	holdID, err := utils.ParseUUID(req.HoldID, "retention hold")
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := s.holdRepo.Release(c.Context(), s.db, holdID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Retention hold %s on actor %s released", holdID, hold.ActorID)
+	return hold, nil
+}
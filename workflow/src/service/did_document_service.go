@@ -0,0 +1,260 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DIDDocumentService defines the interface for the did:web documents hosted for actors. An actor's
+// did:web is did:web:<issuer host>:<universal identifier>, served at /<universal identifier>/did.json.
+// Documents are built on every request, so key rotations and service changes show up immediately.
+type DIDDocumentService interface {
+	// ActorDocument builds the did:web document of the actor with the given universal identifier
+	ActorDocument(c *fiber.Ctx, identifier string) (*did.Document, error)
+
+	// AddService publishes a service endpoint in the caller's document
+	AddService(c *fiber.Ctx, req *validation.AddDIDServiceRequest) (*model.DIDService, error)
+
+	// ListServices returns the caller's did:web identifier and service endpoints
+	ListServices(c *fiber.Ctx) (*DIDServiceList, error)
+
+	// RemoveService removes a service endpoint from the caller's document
+	RemoveService(c *fiber.Ctx, req *validation.DIDServiceIDRequest) error
+
+	// ActorDIDs returns every DID an actor is known by: the did:key of each master key it has
+	// held and the did:web of each universal identifier it holds
+	ActorDIDs(ctx context.Context, actorID uuid.UUID) ([]string, error)
+}
+
+// DIDServiceList holds an actor's did:web identifier, empty without a universal identifier, and
+// its service endpoints
+type DIDServiceList struct {
+	DID      string
+	Services []model.DIDService
+}
+
+type didDocumentService struct {
+	cfg            *config.Config
+	log            *logrus.Logger
+	db             *gorm.DB
+	validate       *validator.Validate
+	actorRepo      repository.ActorRepository
+	identifierRepo repository.IdentifierRepository
+	actorKeyRepo   repository.ActorKeyRepository
+	serviceRepo    repository.DIDServiceRepository
+}
+
+// NewDIDDocumentService creates a new DID document service instance
+func NewDIDDocumentService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	actorRepo repository.ActorRepository,
+	identifierRepo repository.IdentifierRepository,
+	actorKeyRepo repository.ActorKeyRepository,
+	serviceRepo repository.DIDServiceRepository,
+) DIDDocumentService {
+	return &didDocumentService{
+		cfg:            cfg,
+		log:            log,
+		db:             db,
+		validate:       validate,
+		actorRepo:      actorRepo,
+		identifierRepo: identifierRepo,
+		actorKeyRepo:   actorKeyRepo,
+		serviceRepo:    serviceRepo,
+	}
+}
+
+func (s *didDocumentService) ActorDocument(c *fiber.Ctx, identifier string) (*did.Document, error) {
+	ctx := c.Context()
+
+	entry, err := s.identifierRepo.FindByValue(ctx, s.db, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if entry.EntityType != constants.EntityTypeActor {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+	}
+	actor, err := s.actorRepo.FindByID(ctx, s.db, entry.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	keyHistory, err := s.actorKeyRepo.ListByActorID(ctx, s.db, actor.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.serviceRepo.ListByActorID(ctx, s.db, actor.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	webDID, err := did.WebDIDFromURL(s.cfg.IssuerURL, entry.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	return BuildActorDIDDocument(webDID, actor, keyHistory, services)
+}
+
+// BuildActorDIDDocument assembles the did:web document of an actor. Every key of the history is
+// listed so signatures made before a rotation can still be verified, but only the current key is
+// authorized for authentication and assertions. The actor's did:key is given as alsoKnownAs.
+func BuildActorDIDDocument(webDID string, actor *model.Actor, keyHistory []model.ActorKey, services []model.DIDService) (*did.Document, error) {
+	document := &did.Document{
+		Context: []string{did.ContextDIDv1, did.ContextMultikey},
+		ID:      webDID,
+	}
+	if actor.DID != "" {
+		document.AlsoKnownAs = []string{actor.DID}
+	}
+
+	for _, actorKey := range keyHistory {
+		publicKey, err := keys.ParsePublicKey(actorKey.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %s of actor %s cannot be parsed: %w", actorKey.KeyID, actor.ActorID, err)
+		}
+		method, err := did.NewMultikeyMethod(webDID, publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %s of actor %s cannot be encoded: %w", actorKey.KeyID, actor.ActorID, err)
+		}
+		document.VerificationMethod = append(document.VerificationMethod, *method)
+
+		if actorKey.ValidUntil == nil {
+			ref := did.MethodReference{Reference: method.ID}
+			document.Authentication = append(document.Authentication, ref)
+			document.AssertionMethod = append(document.AssertionMethod, ref)
+		}
+	}
+
+	for _, service := range services {
+		document.Service = append(document.Service, did.Service{
+			ID:              webDID + "#" + service.Fragment,
+			Type:            service.Type,
+			ServiceEndpoint: service.Endpoint,
+		})
+	}
+
+	return document, nil
+}
+
+func (s *didDocumentService) AddService(c *fiber.Ctx, req *validation.AddDIDServiceRequest) (*model.DIDService, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// The document is served from our domain, so production endpoints must use TLS
+	if endpoint, err := url.Parse(req.ServiceEndpoint); err != nil || (s.cfg.IsProd && endpoint.Scheme != "https") {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInsecureDIDServiceEndpoint)
+	}
+
+	service := &model.DIDService{
+		ActorID:  actorID,
+		Fragment: req.ID,
+		Type:     req.Type,
+		Endpoint: req.ServiceEndpoint,
+	}
+	if err := s.serviceRepo.Create(c.Context(), s.db, service); err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s published DID service %s", actorID, service.Fragment)
+	return service, nil
+}
+
+func (s *didDocumentService) ListServices(c *fiber.Ctx) (*DIDServiceList, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	webDID, err := s.actorWebDID(c, actorID)
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.serviceRepo.ListByActorID(c.Context(), s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+	return &DIDServiceList{DID: webDID, Services: services}, nil
+}
+
+func (s *didDocumentService) RemoveService(c *fiber.Ctx, req *validation.DIDServiceIDRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if err := s.serviceRepo.Delete(c.Context(), s.db, actorID, req.ID); err != nil {
+		return err
+	}
+
+	s.log.Infof("Actor %s removed DID service %s", actorID, req.ID)
+	return nil
+}
+
+func (s *didDocumentService) ActorDIDs(ctx context.Context, actorID uuid.UUID) ([]string, error) {
+	actor, err := s.actorRepo.FindByID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+	keyHistory, err := s.actorKeyRepo.ListByActorID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+	identifiers, err := s.identifierRepo.ListByActorID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	dids := make([]string, 0, len(keyHistory)+len(identifiers)+1)
+	if actor.DID != "" {
+		dids = append(dids, actor.DID)
+	}
+	for _, key := range keyHistory {
+		if key.DID != actor.DID {
+			dids = append(dids, key.DID)
+		}
+	}
+	for _, identifier := range identifiers {
+		webDID, err := did.WebDIDFromURL(s.cfg.IssuerURL, identifier.Identifier)
+		if err != nil {
+			return nil, err
+		}
+		dids = append(dids, webDID)
+	}
+	return dids, nil
+}
+
+// actorWebDID returns the did:web of an actor, or an empty string if it has no universal identifier
+func (s *didDocumentService) actorWebDID(c *fiber.Ctx, actorID uuid.UUID) (string, error) {
+	entry, err := s.identifierRepo.FindByActorID(c.Context(), s.db, actorID)
+	if err != nil || entry == nil {
+		return "", err
+	}
+	return did.WebDIDFromURL(s.cfg.IssuerURL, entry.Identifier)
+}
@@ -0,0 +1,682 @@
+package service
+
+import (
+	"app/src/adapter"
+	"app/src/archive"
+	"app/src/config"
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/seal"
+	"app/src/utils"
+	"app/src/validation"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ExportService defines the interface for personal data exports (data portability). An export is
+// prepared by a background job that gathers everything held about the actor, including the login
+// history kept by the auth provider and the stored document files, into a zip bundle. The bundle
+// is stored encrypted until the end of the retention period and handed out through short-lived,
+// single-use download links.
+type ExportService interface {
+	RequestExport(c *fiber.Ctx) (*model.DataExport, error)
+
+	// ListExports returns the caller's most recent data exports, newest first
+	ListExports(c *fiber.Ctx) ([]model.DataExport, error)
+
+	// CreateLink issues a download link for a ready export, replacing any link issued before
+	CreateLink(c *fiber.Ctx, req *validation.DataExportRequest) (*DataExportLink, error)
+
+	// Download returns the decrypted bundle a download link was issued for and invalidates the link
+	Download(c *fiber.Ctx, token string) (*DataExportBundle, error)
+
+	// Build prepares the bundle of a pending export
+	Build(ctx context.Context, exportID uuid.UUID) error
+
+	// Fail marks a pending export as failed with the error that stopped it
+	Fail(ctx context.Context, exportID uuid.UUID, cause error) error
+
+	// Expire deletes the stored bundle of a ready export at the end of its retention period
+	Expire(ctx context.Context, exportID uuid.UUID) error
+}
+
+// DataExportLink is a single-use download link for a data export
+type DataExportLink struct {
+	Export    *model.DataExport
+	URL       string
+	ExpiresAt time.Time
+}
+
+// DataExportBundle is a decrypted data export ready for download
+type DataExportBundle struct {
+	FileName string
+	Data     []byte
+}
+
+// dataExportRecords is the machine-readable content of a data export, stored as export.json.
+// Document files are stored alongside under documents/<documentId>/.
+type dataExportRecords struct {
+	Format               string                         `json:"format"`
+	Version              int                            `json:"version"`
+	ExportID             string                         `json:"exportId"`
+	ExportedAt           time.Time                      `json:"exportedAt"`
+	Profile              *model.Actor                   `json:"profile"`
+	Identifiers          []model.Identifier             `json:"identifiers"`
+	ReleasedIdentifiers  []model.ReleasedIdentifier     `json:"releasedIdentifiers"`
+	Integrations         []model.ActorIntegration       `json:"integrations"`
+	KeyHistory           []model.ActorKey               `json:"keyHistory"`
+	DIDServices          []model.DIDService             `json:"didServices"`
+	Credentials          []model.Token                  `json:"credentials"`
+	Documents            []model.Document               `json:"documents"`
+	MissingDocuments     []uuid.UUID                    `json:"missingDocuments,omitempty"`
+	CredentialOffers     []model.CredentialOffer        `json:"credentialOffers"`
+	PresentationRequests []model.PresentationRequest    `json:"presentationRequests"`
+	ShareGrants          []model.ShareGrant             `json:"shareGrants"`
+	Memberships          []model.OrganizationMember     `json:"organizationMemberships"`
+	Invitations          []model.OrganizationInvitation `json:"organizationInvitations"`
+	DelegationGrants     []model.DelegationGrant        `json:"delegationGrants"`
+	AuditEvents          dataExportAuditEvents          `json:"auditEvents"`
+	LoginHistory         []AuthEvent                    `json:"loginHistory"`
+}
+
+// dataExportAuditEvents are the records of what happened to the account
+type dataExportAuditEvents struct {
+	VerificationLevelChanges []model.VerificationLevelHistory `json:"verificationLevelChanges"`
+	ShareAccess              []model.ShareAccessLog           `json:"shareAccess"`
+	KeyRecoveries            []model.KeyRecovery              `json:"keyRecoveries"`
+	AccountDeletions         []model.AccountDeletion          `json:"accountDeletions"`
+	DataExports              []model.DataExport               `json:"dataExports"`
+	VerificationMessages     []model.VerificationCode         `json:"verificationMessages"`
+	DelegatedActions         []model.DelegatedAction          `json:"delegatedActions"`
+}
+
+// dataExportManifest lists every file of a data export except the manifest and its signature
+type dataExportManifest struct {
+	Format     string          `json:"format"`
+	Version    int             `json:"version"`
+	ExportID   string          `json:"exportId"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	Issuer     string          `json:"issuer"`
+	ActorID    string          `json:"actorId"`
+	Files      []archive.Entry `json:"files"`
+}
+
+type exportService struct {
+	cfg             *config.Config
+	log             *logrus.Logger
+	db              *gorm.DB
+	validate        *validator.Validate
+	signer          *keys.Signer
+	sealer          *seal.Sealer
+	authService     AuthService
+	jobs            JobService
+	webhooks        WebhookService
+	storage         *adapter.LazyStorage
+	actorRepo       repository.ActorRepository
+	integrationRepo repository.ActorIntegrationRepository
+	exportRepo      repository.DataExportRepository
+	portabilityRepo repository.PortabilityRepository
+}
+
+// NewExportService creates a new data export service instance
+func NewExportService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	signer *keys.Signer,
+	sealer *seal.Sealer,
+	authService AuthService,
+	jobs JobService,
+	webhooks WebhookService,
+	storage *adapter.LazyStorage,
+	actorRepo repository.ActorRepository,
+	integrationRepo repository.ActorIntegrationRepository,
+	exportRepo repository.DataExportRepository,
+	portabilityRepo repository.PortabilityRepository,
+) ExportService {
+	return &exportService{
+		cfg:             cfg,
+		log:             log,
+		db:              db,
+		validate:        validate,
+		signer:          signer,
+		sealer:          sealer,
+		authService:     authService,
+		jobs:            jobs,
+		webhooks:        webhooks,
+		storage:         storage,
+		actorRepo:       actorRepo,
+		integrationRepo: integrationRepo,
+		exportRepo:      exportRepo,
+		portabilityRepo: portabilityRepo,
+	}
+}
+
+func (s *exportService) RequestExport(c *fiber.Ctx) (*model.DataExport, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.Context()
+
+	export := &model.DataExport{ActorID: actorID, Status: constants.DataExportStatusPending}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.exportRepo.Create(ctx, tx, export); err != nil {
+			return err
+		}
+		return s.jobs.Enqueue(ctx, tx, &model.Job{
+			Type:       constants.JobTypeDataExport,
+			ActorID:    actorID,
+			ResourceID: &export.ExportID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Data export %s requested by actor %s", export.ExportID, actorID)
+	return export, nil
+}
+
+func (s *exportService) ListExports(c *fiber.Ctx) ([]model.DataExport, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.exportRepo.ListByActorID(c.Context(), s.db, actorID, constants.DataExportListLimit)
+}
+
+func (s *exportService) CreateLink(c *fiber.Ctx, req *validation.DataExportRequest) (*DataExportLink, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	exportID, err := utils.ParseUUID(req.ExportID, "data export")
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+	tokenHash := utils.HashSecret(token)
+	expiresAt := time.Now().UTC().Add(time.Duration(s.cfg.ExportLinkTTL) * time.Minute)
+
+	var export *model.DataExport
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		export, err = s.exportRepo.LockByID(c.Context(), tx, exportID)
+		if err != nil {
+			return err
+		}
+		if export.ActorID != actorID {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrDataExportNotFound)
+		}
+		if export.Status != constants.DataExportStatusReady {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrDataExportNotReady)
+		}
+
+		export.DownloadTokenHash = &tokenHash
+		export.LinkExpiresAt = &expiresAt
+		return s.exportRepo.Update(c.Context(), tx, export)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Download link for data export %s issued to actor %s", exportID, actorID)
+	return &DataExportLink{
+		Export:    export,
+		URL:       s.cfg.IssuerURL + constants.RouteGroupV1 + strings.Replace(constants.RouteDataExportDownload, ":token", token, 1),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *exportService) Download(c *fiber.Ctx, token string) (*DataExportBundle, error) {
+	if token == "" {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrDataExportLinkInvalid)
+	}
+	ctx := c.Context()
+
+	var bundle *DataExportBundle
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		export, err := s.exportRepo.LockByDownloadTokenHash(ctx, tx, utils.HashSecret(token))
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if export.Status != constants.DataExportStatusReady || export.LinkExpiresAt == nil || !now.Before(*export.LinkExpiresAt) {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrDataExportLinkInvalid)
+		}
+
+		// The bundle is read before the link is used up, so a failed read leaves the link valid
+		data, err := s.openBundle(ctx, export)
+		if err != nil {
+			return err
+		}
+
+		export.DownloadTokenHash = nil
+		export.LinkExpiresAt = nil
+		export.DownloadedAt = &now
+		if err := s.exportRepo.Update(ctx, tx, export); err != nil {
+			return err
+		}
+
+		s.log.Infof("Data export %s of actor %s downloaded from %s", export.ExportID, export.ActorID, c.IP())
+		bundle = &DataExportBundle{
+			FileName: fmt.Sprintf("data-export-%s-%s.zip", export.ActorID, export.CreatedAt.UTC().Format("20060102")),
+			Data:     data,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// openBundle downloads and decrypts the stored bundle of an export
+func (s *exportService) openBundle(ctx context.Context, export *model.DataExport) ([]byte, error) {
+	if export.StoragePath == nil || export.WrappedKey == nil {
+		return nil, fmt.Errorf("data export %s has no stored bundle", export.ExportID)
+	}
+	wrappedKey, err := base64.StdEncoding.DecodeString(*export.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key of data export %s: %w", export.ExportID, err)
+	}
+
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := storageProvider.Download(ctx, *export.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download data export %s: %w", export.ExportID, err)
+	}
+	defer reader.Close()
+
+	sealed, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download data export %s: %w", export.ExportID, err)
+	}
+
+	data, err := s.sealer.Open(sealed, wrappedKey, []byte(export.ExportID.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data export %s: %w", export.ExportID, err)
+	}
+	return data, nil
+}
+
+func (s *exportService) Build(ctx context.Context, exportID uuid.UUID) error {
+	export, err := s.exportRepo.FindByID(ctx, s.db, exportID)
+	if err != nil {
+		return err
+	}
+	if export.Status != constants.DataExportStatusPending {
+		s.log.Infof("Data export %s is %s; nothing to do", exportID, export.Status)
+		return nil
+	}
+
+	actor, err := s.actorRepo.FindByID(ctx, s.db, export.ActorID)
+	if err != nil {
+		return err
+	}
+	records, err := s.portabilityRepo.FindRecords(ctx, s.db, actor.ActorID)
+	if err != nil {
+		return err
+	}
+	logins, err := s.loginHistory(ctx, actor.ActorID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	data, err := s.writeBundle(ctx, export, actor, records, logins, now)
+	if err != nil {
+		return err
+	}
+
+	sealed, wrappedKey, err := s.sealer.Seal(data, []byte(exportID.String()))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt data export %s: %w", exportID, err)
+	}
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		return err
+	}
+	storagePath := path.Join(constants.DataExportStorageDir, exportID.String())
+	opts := &adapter.UploadOptions{
+		ContentType: constants.DataExportSealedType,
+		Metadata:    map[string]string{"actor-id": actor.ActorID.String()},
+	}
+	if _, err := storageProvider.Upload(ctx, storagePath, bytes.NewReader(sealed), int64(len(sealed)), opts); err != nil {
+		return fmt.Errorf("failed to store data export %s: %w", exportID, err)
+	}
+
+	digest := sha256.Sum256(data)
+	size := int64(len(data))
+	sum := hex.EncodeToString(digest[:])
+	key := base64.StdEncoding.EncodeToString(wrappedKey)
+	expiresAt := now.Add(time.Duration(s.cfg.ExportRetention) * time.Hour)
+
+	ready := false
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		export, err := s.exportRepo.LockByID(ctx, tx, exportID)
+		if err != nil {
+			return err
+		}
+		if export.Status != constants.DataExportStatusPending {
+			return nil
+		}
+
+		export.Status = constants.DataExportStatusReady
+		export.StoragePath = &storagePath
+		export.Size = &size
+		export.SHA256 = &sum
+		export.WrappedKey = &key
+		export.ExpiresAt = &expiresAt
+		export.CompletedAt = &now
+		if err := s.exportRepo.Update(ctx, tx, export); err != nil {
+			return err
+		}
+
+		err = s.jobs.Enqueue(ctx, tx, &model.Job{
+			Type:       constants.JobTypeDataExportExpiry,
+			ActorID:    export.ActorID,
+			ResourceID: &export.ExportID,
+			RunAt:      expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.webhooks.Publish(ctx, tx, WebhookEvent{
+			Type:    constants.WebhookEventDataExportReady,
+			ActorID: export.ActorID,
+			Data: map[string]interface{}{
+				"exportId":  exportID.String(),
+				"expiresAt": expiresAt.Format(time.RFC3339),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		ready = true
+		return nil
+	})
+	if err != nil || !ready {
+		// The bundle is not kept for an export that failed, or was erased with the account, while
+		// it was prepared; a retry uploads it again
+		if deleteErr := s.deleteBundle(ctx, storagePath); deleteErr != nil {
+			s.log.Warnf("Failed to delete unused bundle of data export %s: %v", exportID, deleteErr)
+		}
+		return err
+	}
+
+	s.log.Infof("Prepared data export %s of actor %s: %d bytes, %d documents", exportID, actor.ActorID, size, len(records.Documents))
+	return nil
+}
+
+// loginHistory returns the actor's login events from the auth provider. Actors without an auth
+// provider user have none.
+func (s *exportService) loginHistory(ctx context.Context, actorID uuid.UUID) ([]AuthEvent, error) {
+	integration, err := s.integrationRepo.FindByActorIDAndProvider(ctx, s.db, actorID, constants.KeycloakProviderName)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return []AuthEvent{}, nil
+		}
+		return nil, err
+	}
+
+	events, err := s.authService.ListLoginEvents(integration.ExternalUserID, constants.DataExportMaxLoginEvents)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []AuthEvent{}
+	}
+	return events, nil
+}
+
+// writeBundle writes the zip bundle of an export: export.json, the document files, and a manifest
+// of their hashes signed with the platform key
+func (s *exportService) writeBundle(ctx context.Context, export *model.DataExport, actor *model.Actor, records *repository.ActorRecords, logins []AuthEvent, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := archive.NewWriter(&buf, now)
+
+	var missing []uuid.UUID
+	for i := range records.Documents {
+		found, err := s.exportDocumentFile(ctx, writer, &records.Documents[i])
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			missing = append(missing, records.Documents[i].DocumentID)
+		}
+	}
+	if len(missing) > 0 {
+		s.log.Warnf("Data export %s: %d documents of actor %s have no stored file", export.ExportID, len(missing), actor.ActorID)
+	}
+
+	content, err := json.MarshalIndent(dataExportRecords{
+		Format:               constants.DataExportFormat,
+		Version:              constants.DataExportVersion,
+		ExportID:             export.ExportID.String(),
+		ExportedAt:           now,
+		Profile:              actor,
+		Identifiers:          records.Identifiers,
+		ReleasedIdentifiers:  records.ReleasedIdentifiers,
+		Integrations:         records.Integrations,
+		KeyHistory:           records.Keys,
+		DIDServices:          records.DIDServices,
+		Credentials:          records.Credentials,
+		Documents:            records.Documents,
+		MissingDocuments:     missing,
+		CredentialOffers:     records.CredentialOffers,
+		PresentationRequests: records.PresentationRequests,
+		ShareGrants:          records.ShareGrants,
+		Memberships:          records.Memberships,
+		Invitations:          records.Invitations,
+		DelegationGrants:     records.DelegationGrants,
+		AuditEvents: dataExportAuditEvents{
+			VerificationLevelChanges: records.VerificationLevels,
+			ShareAccess:              records.ShareAccessLogs,
+			KeyRecoveries:            records.KeyRecoveries,
+			AccountDeletions:         records.AccountDeletions,
+			DataExports:              records.DataExports,
+			VerificationMessages:     records.VerificationCodes,
+			DelegatedActions:         records.DelegatedActions,
+		},
+		LoginHistory: logins,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writer.Add(constants.DataExportRecordsPath, content); err != nil {
+		return nil, err
+	}
+
+	issuer, _ := did.SplitURL(s.signer.KeyID())
+	manifest, err := json.MarshalIndent(dataExportManifest{
+		Format:     constants.DataExportFormat,
+		Version:    constants.DataExportVersion,
+		ExportID:   export.ExportID.String(),
+		ExportedAt: now,
+		Issuer:     issuer,
+		ActorID:    actor.ActorID.String(),
+		Files:      writer.Entries(),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(manifest)
+	signature, err := s.signer.Sign(walletManifestClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   issuer,
+			Subject:  actor.DID,
+			ID:       export.ExportID.String(),
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+		ManifestSHA256: hex.EncodeToString(digest[:]),
+	}, map[string]interface{}{"typ": constants.DataExportManifestJWTType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign data export manifest: %w", err)
+	}
+
+	if err := writer.AddUnlisted(constants.DataExportManifestPath, manifest); err != nil {
+		return nil, err
+	}
+	if err := writer.AddUnlisted(constants.DataExportSignaturePath, []byte(signature)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write data export: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportDocumentFile adds the stored file of a document under its original name, reporting false
+// when the file no longer exists in storage
+func (s *exportService) exportDocumentFile(ctx context.Context, writer *archive.Writer, document *model.Document) (bool, error) {
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		return false, err
+	}
+
+	exists, err := storageProvider.Exists(ctx, document.StoragePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to check file of document %s: %w", document.DocumentID, err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	reader, err := storageProvider.Download(ctx, document.StoragePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to download document %s: %w", document.DocumentID, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return false, fmt.Errorf("failed to download document %s: %w", document.DocumentID, err)
+	}
+
+	name := path.Join(constants.DataExportDocumentsDir, document.DocumentID.String(), exportFileName(document.FileName))
+	return true, writer.Add(name, content)
+}
+
+// exportFileName reduces an uploaded file name to a single safe path element
+func exportFileName(fileName string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		return constants.DataExportFallbackName
+	}
+	return name
+}
+
+func (s *exportService) Fail(ctx context.Context, exportID uuid.UUID, cause error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		export, err := s.exportRepo.LockByID(ctx, tx, exportID)
+		if err != nil {
+			return err
+		}
+		if export.Status != constants.DataExportStatusPending {
+			return nil
+		}
+
+		message := cause.Error()
+		if len(message) > constants.JobErrorMaxLength {
+			message = message[:constants.JobErrorMaxLength]
+		}
+		now := time.Now().UTC()
+		export.Status = constants.DataExportStatusFailed
+		export.Error = &message
+		export.CompletedAt = &now
+		if err := s.exportRepo.Update(ctx, tx, export); err != nil {
+			return err
+		}
+
+		s.log.Warnf("Data export %s of actor %s failed: %s", exportID, export.ActorID, message)
+		return nil
+	})
+}
+
+func (s *exportService) Expire(ctx context.Context, exportID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		export, err := s.exportRepo.LockByID(ctx, tx, exportID)
+		if err != nil {
+			return err
+		}
+		if export.Status != constants.DataExportStatusReady {
+			return nil
+		}
+
+		// The bundle is deleted before the record is updated; a retry after a failed update finds
+		// it already gone
+		if export.StoragePath != nil {
+			if err := s.deleteBundle(ctx, *export.StoragePath); err != nil {
+				return err
+			}
+		}
+
+		export.Status = constants.DataExportStatusExpired
+		export.StoragePath = nil
+		export.WrappedKey = nil
+		export.DownloadTokenHash = nil
+		export.LinkExpiresAt = nil
+		if err := s.exportRepo.Update(ctx, tx, export); err != nil {
+			return err
+		}
+
+		s.log.Infof("Data export %s of actor %s expired", exportID, export.ActorID)
+		return nil
+	})
+}
+
+// deleteBundle removes a stored bundle, skipping bundles already gone
+func (s *exportService) deleteBundle(ctx context.Context, storagePath string) error {
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		return err
+	}
+	exists, err := storageProvider.Exists(ctx, storagePath)
+	if err != nil {
+		return fmt.Errorf("failed to check data export %s: %w", storagePath, err)
+	}
+	if !exists {
+		return nil
+	}
+	if err := storageProvider.Delete(ctx, storagePath); err != nil {
+		return fmt.Errorf("failed to delete data export %s: %w", storagePath, err)
+	}
+	return nil
+}
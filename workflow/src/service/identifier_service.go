@@ -0,0 +1,198 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IdentifierService defines the interface for managing the universal identifiers (aliases) of
+// the caller. Any identifier can be used to log in and resolve the actor; the primary one is
+// shown on the profile. Removed identifiers are reserved for the remover during a cooling-off
+// period before anyone else can claim them.
+type IdentifierService interface {
+	AddIdentifier(c *fiber.Ctx, req *validation.IdentifierRequest) (*model.Identifier, error)
+	ListIdentifiers(c *fiber.Ctx) ([]model.Identifier, error)
+	SetPrimary(c *fiber.Ctx, req *validation.IdentifierRequest) (*model.Identifier, error)
+	RemoveIdentifier(c *fiber.Ctx, req *validation.IdentifierRequest) (*model.ReleasedIdentifier, error)
+}
+
+type identifierService struct {
+	cfg            *config.Config
+	log            *logrus.Logger
+	db             *gorm.DB
+	validate       *validator.Validate
+	namespaces     NamespaceService
+	resolver       ResolverService
+	actorRepo      repository.ActorRepository
+	identifierRepo repository.IdentifierRepository
+}
+
+// NewIdentifierService creates a new identifier service instance
+func NewIdentifierService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	namespaces NamespaceService,
+	resolver ResolverService,
+	actorRepo repository.ActorRepository,
+	identifierRepo repository.IdentifierRepository,
+) IdentifierService {
+	return &identifierService{
+		cfg:            cfg,
+		log:            log,
+		db:             db,
+		validate:       validate,
+		namespaces:     namespaces,
+		resolver:       resolver,
+		actorRepo:      actorRepo,
+		identifierRepo: identifierRepo,
+	}
+}
+
+func (s *identifierService) AddIdentifier(c *fiber.Ctx, req *validation.IdentifierRequest) (*model.Identifier, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var identifier *model.Identifier
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		// Serialize identifier changes of the actor so the limit and the primary flag hold
+		if _, err := s.actorRepo.LockByID(ctx, tx, actorID); err != nil {
+			return err
+		}
+
+		existing, err := s.identifierRepo.ListByActorID(ctx, tx, actorID)
+		if err != nil {
+			return err
+		}
+		if len(existing) >= constants.MaxIdentifiersPerActor {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrTooManyIdentifiers)
+		}
+
+		id, err := s.namespaces.CheckClaim(ctx, tx, req.Identifier, actorID)
+		if err != nil {
+			return err
+		}
+
+		identifier = &model.Identifier{
+			Identifier: id.String(),
+			EntityType: constants.EntityTypeActor,
+			EntityID:   actorID,
+			// Actors registered without an identifier get their first one as primary
+			IsPrimary: len(existing) == 0,
+			Skeleton:  id.Skeleton,
+		}
+		return s.identifierRepo.Create(ctx, tx, identifier)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s added identifier %s", actorID, identifier.Identifier)
+	return identifier, nil
+}
+
+func (s *identifierService) ListIdentifiers(c *fiber.Ctx) ([]model.Identifier, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.identifierRepo.ListByActorID(c.Context(), s.db, actorID)
+}
+
+func (s *identifierService) SetPrimary(c *fiber.Ctx, req *validation.IdentifierRequest) (*model.Identifier, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var identifier *model.Identifier
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		if _, err := s.actorRepo.LockByID(ctx, tx, actorID); err != nil {
+			return err
+		}
+		if err := s.identifierRepo.SetPrimary(ctx, tx, actorID, s.namespaces.Canonical(req.Identifier)); err != nil {
+			return err
+		}
+
+		identifier, err = s.identifierRepo.FindByActorID(ctx, tx, actorID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s made %s its primary identifier", actorID, identifier.Identifier)
+	return identifier, nil
+}
+
+func (s *identifierService) RemoveIdentifier(c *fiber.Ctx, req *validation.IdentifierRequest) (*model.ReleasedIdentifier, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var released *model.ReleasedIdentifier
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		if _, err := s.actorRepo.LockByID(ctx, tx, actorID); err != nil {
+			return err
+		}
+
+		identifier, err := s.identifierRepo.FindByValue(ctx, tx, s.namespaces.Canonical(req.Identifier))
+		if utils.IsNotFoundError(err) {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrIdentifierNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		// Identifiers of other actors are reported as not found so they cannot be probed
+		if identifier.EntityType != constants.EntityTypeActor || identifier.EntityID != actorID {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrIdentifierNotFound)
+		}
+		if identifier.IsPrimary {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrPrimaryIdentifierRemoval)
+		}
+
+		now := time.Now().UTC()
+		availableAt := now.AddDate(0, 0, s.cfg.ReleaseCoolingOff)
+		released, err = s.identifierRepo.Release(ctx, tx, identifier, now, availableAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.resolver.InvalidateIdentifiers(released.Identifier)
+	s.log.Infof("Actor %s released identifier %s until %s", actorID, released.Identifier, released.AvailableAt.Format(time.RFC3339))
+	return released, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// JobService defines the interface for enqueuing background jobs and reporting their progress
+type JobService interface {
+	// Enqueue adds a job to the queue inside tx, so it only becomes visible if tx commits
+	Enqueue(ctx context.Context, tx *gorm.DB, job *model.Job) error
+	GetJob(c *fiber.Ctx, req *validation.JobIDRequest) (*model.Job, error)
+}
+
+type jobService struct {
+	cfg      *config.Config
+	db       *gorm.DB
+	validate *validator.Validate
+	jobRepo  repository.JobRepository
+}
+
+// NewJobService creates a new job service instance
+func NewJobService(
+	cfg *config.Config,
+	db *gorm.DB,
+	validate *validator.Validate,
+	jobRepo repository.JobRepository,
+) JobService {
+	return &jobService{
+		cfg:      cfg,
+		db:       db,
+		validate: validate,
+		jobRepo:  jobRepo,
+	}
+}
+
+func (s *jobService) Enqueue(ctx context.Context, tx *gorm.DB, job *model.Job) error {
+	job.Status = constants.JobStatusQueued
+	job.Attempts = 0
+	if job.MaxAttempts == 0 {
+		job.MaxAttempts = s.cfg.JobMaxAttempts
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now().UTC()
+	}
+	return s.jobRepo.Create(ctx, tx, job)
+}
+
+func (s *jobService) GetJob(c *fiber.Ctx, req *validation.JobIDRequest) (*model.Job, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := utils.ParseUUID(req.JobID, "job")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.jobRepo.FindByIDForActor(c.Context(), s.db, jobID, actorID)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/utils"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeyRotationClaims are the claims of a rotation proof JWT signed with the current master key
+type KeyRotationClaims struct {
+	jwt.RegisteredClaims
+	NewKeyThumbprint string `json:"new_key_thumbprint"`
+}
+
+// RegistrationProofClaims are the claims of a registration proof JWT signed with the master key
+// being registered. Nonce is an issued challenge; PayloadHash is RegistrationPayloadHash of the
+// registration request.
+type RegistrationProofClaims struct {
+	jwt.RegisteredClaims
+	Nonce       string `json:"nonce"`
+	PayloadHash string `json:"payload_hash"`
+}
+
+// LoginProofClaims are the claims of a login proof JWT signed with an actor's master key. Nonce is
+// an issued login challenge.
+type LoginProofClaims struct {
+	jwt.RegisteredClaims
+	Nonce string `json:"nonce"`
+}
+
+// VerifyKeyRotationProof checks that compact is a fresh proof JWT signed with the current master
+// key, issued for the actor's DID to the given audience and naming the thumbprint of the new key
+func VerifyKeyRotationProof(currentKey crypto.PublicKey, compact, actorDID, audience, newKeyThumbprint string, now time.Time) error {
+	claims := &KeyRotationClaims{}
+	err := parseKeyProof(currentKey, compact, constants.KeyRotationProofJWTType, audience, constants.KeyRotationProofMaxAge*time.Minute, now, claims,
+		jwt.WithSubject(actorDID))
+	if err != nil {
+		return err
+	}
+	if claims.NewKeyThumbprint != newKeyThumbprint {
+		return errors.New("proof endorses a different key")
+	}
+	return nil
+}
+
+// VerifyRegistrationProof checks that compact is a proof JWT signed with the master key being
+// registered, issued to the given audience over the registration payload hash, and returns the
+// challenge nonce it signs. The caller must still consume the nonce.
+func VerifyRegistrationProof(masterKey crypto.PublicKey, compact, audience, payloadHash string, now time.Time) (string, error) {
+	claims := &RegistrationProofClaims{}
+	err := parseKeyProof(masterKey, compact, constants.RegistrationProofJWTType, audience, constants.ChallengeTTL*time.Minute, now, claims)
+	if err != nil {
+		return "", err
+	}
+	if claims.Nonce == "" {
+		return "", errors.New("missing nonce claim")
+	}
+	if claims.PayloadHash != payloadHash {
+		return "", errors.New("proof signs a different registration payload")
+	}
+	return claims.Nonce, nil
+}
+
+// VerifyLoginProof checks that compact is a fresh proof JWT signed with the actor's master key,
+// issued for the actor's DID to the given audience, and returns the challenge nonce it signs. The
+// caller must still consume the nonce.
+func VerifyLoginProof(masterKey crypto.PublicKey, compact, actorDID, audience string, now time.Time) (string, error) {
+	claims := &LoginProofClaims{}
+	err := parseKeyProof(masterKey, compact, constants.LoginProofJWTType, audience, constants.ChallengeTTL*time.Minute, now, claims,
+		jwt.WithSubject(actorDID))
+	if err != nil {
+		return "", err
+	}
+	if claims.Nonce == "" {
+		return "", errors.New("missing nonce claim")
+	}
+	return claims.Nonce, nil
+}
+
+// RegistrationPayloadHash returns the base64url SHA-256 of the canonical JSON (RFC 8785) of the
+// request object of a registration envelope, without its proof member
+func RegistrationPayloadHash(body []byte) (string, error) {
+	var envelope struct {
+		Request map[string]json.RawMessage `json:"request"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", err
+	}
+	if envelope.Request == nil {
+		return "", errors.New("missing request object")
+	}
+	delete(envelope.Request, "proof")
+
+	payload, err := json.Marshal(envelope.Request)
+	if err != nil {
+		return "", err
+	}
+	canonical, err := utils.CanonicalJSON(payload)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(digest[:]), nil
+}
+
+// parseKeyProof verifies a proof JWT of the given typ signed with key and issued to audience no
+// longer than maxAge ago, decoding its claims
+func parseKeyProof(key crypto.PublicKey, compact, typ, audience string, maxAge time.Duration, now time.Time, claims jwt.Claims, options ...jwt.ParserOption) error {
+	parser := jwt.NewParser(append([]jwt.ParserOption{
+		jwt.WithValidMethods(credentialJWTAlgorithms),
+		jwt.WithAudience(audience),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(constants.JWTClockSkew * time.Second),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	}, options...)...)
+
+	_, err := parser.ParseWithClaims(compact, claims, func(token *jwt.Token) (interface{}, error) {
+		if header, _ := token.Header["typ"].(string); header != typ {
+			return nil, fmt.Errorf("unexpected typ header %q", header)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return errors.New("missing iat claim")
+	}
+	if now.Sub(issuedAt.Time) > maxAge {
+		return errors.New("proof is too old")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"app/src/model"
+	"app/src/queue"
+	"app/src/utils"
+	"context"
+	"errors"
+)
+
+// KeyRecoveryHandler processes key recovery jobs, which are scheduled for the end of the
+// recovery waiting period. Recoveries cancelled in the meantime are skipped.
+type KeyRecoveryHandler struct {
+	keys ActorKeyService
+}
+
+// NewKeyRecoveryHandler creates a new key recovery handler
+func NewKeyRecoveryHandler(keys ActorKeyService) *KeyRecoveryHandler {
+	return &KeyRecoveryHandler{keys: keys}
+}
+
+// Handle implements queue.Handler
+func (h *KeyRecoveryHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("key recovery job has no recovery"))
+	}
+
+	if err := h.keys.CompleteRecovery(ctx, *job.ResourceID); err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+	return map[string]interface{}{"recoveryId": job.ResourceID.String()}, nil
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/namespace"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// NamespaceService defines the interface for the universal identifier namespace: the identifier
+// syntax, the domains registered to organizations and the reserved and blocked word list.
+// Identifiers are compared in canonical form and are unique by their confusable skeleton.
+type NamespaceService interface {
+	// Canonical returns the canonical form of an identifier for lookups
+	Canonical(identifier string) string
+
+	// DefaultDomain returns the domain of identifiers given without one
+	DefaultDomain() string
+
+	// CheckClaim checks that claimantID may claim an identifier and returns it in canonical form.
+	// The identifier must follow the syntax, be in the default domain or a domain open to or owned
+	// by the claimant, avoid reserved and blocked words, and must not look like an identifier that
+	// is held or in its cooling-off period. claimantID is uuid.Nil at registration.
+	CheckClaim(ctx context.Context, tx *gorm.DB, identifier string, claimantID uuid.UUID) (*namespace.Identifier, error)
+
+	AddDomain(c *fiber.Ctx, req *validation.AddNamespaceDomainRequest) (*model.NamespaceDomain, error)
+	ListDomains(c *fiber.Ctx) ([]model.NamespaceDomain, error)
+	RemoveDomain(c *fiber.Ctx, req *validation.NamespaceDomainRequest) error
+
+	AddWord(c *fiber.Ctx, req *validation.AddReservedWordRequest) (*model.ReservedWord, error)
+	ListWords(c *fiber.Ctx) ([]model.ReservedWord, error)
+	RemoveWord(c *fiber.Ctx, req *validation.ReservedWordRequest) error
+
+	// BackfillIdentifiers brings identifiers stored before the namespace was introduced in line with
+	// new claims, then makes identifiers unique by their skeleton
+	BackfillIdentifiers(ctx context.Context, dryRun bool) (*IdentifierBackfillResult, error)
+}
+
+// IdentifierBackfillResult summarizes the canonicalization of stored identifiers. Enforced reports
+// whether identifiers are now required to be unique by their skeleton.
+type IdentifierBackfillResult struct {
+	Scanned   int
+	Converted int
+	Unchanged int
+	Failed    []IdentifierBackfillFailure
+	Enforced  bool
+}
+
+// IdentifierBackfillFailure records an identifier that could not be canonicalized
+type IdentifierBackfillFailure struct {
+	Identifier string
+	Reason     string
+}
+
+type namespaceService struct {
+	log            *logrus.Logger
+	db             *gorm.DB
+	validate       *validator.Validate
+	rules          *namespace.Rules
+	actorRepo      repository.ActorRepository
+	identifierRepo repository.IdentifierRepository
+	namespaceRepo  repository.NamespaceRepository
+}
+
+// NewNamespaceService creates a new namespace service instance
+func NewNamespaceService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	actorRepo repository.ActorRepository,
+	identifierRepo repository.IdentifierRepository,
+	namespaceRepo repository.NamespaceRepository,
+) NamespaceService {
+	return &namespaceService{
+		log:      log,
+		db:       db,
+		validate: validate,
+		rules: &namespace.Rules{
+			DefaultDomain: cfg.IdentifierDomain,
+			// The pattern is checked when the configuration is loaded
+			LocalPattern: regexp.MustCompile(cfg.IdentifierPattern),
+		},
+		actorRepo:      actorRepo,
+		identifierRepo: identifierRepo,
+		namespaceRepo:  namespaceRepo,
+	}
+}
+
+func (s *namespaceService) Canonical(identifier string) string {
+	return s.rules.Canonical(identifier)
+}
+
+func (s *namespaceService) DefaultDomain() string {
+	return s.rules.DefaultDomain
+}
+
+func (s *namespaceService) CheckClaim(ctx context.Context, tx *gorm.DB, identifier string, claimantID uuid.UUID) (*namespace.Identifier, error) {
+	id, err := s.rules.Parse(identifier)
+	if err != nil {
+		s.log.Warnf("Rejected universal identifier %q: %v", identifier, err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidUniversalIdentifier)
+	}
+
+	if err := s.checkDomain(ctx, tx, id.Domain, claimantID); err != nil {
+		return nil, err
+	}
+
+	word, err := s.namespaceRepo.FindMatchingWord(ctx, tx, namespace.WordSkeleton(id.Local))
+	if err != nil {
+		return nil, err
+	}
+	if word != nil {
+		s.log.Warnf("Rejected universal identifier %s: matches %s word %q", id, word.Kind, word.Word)
+		if word.Kind == constants.ReservedWordKindBlocked {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrIdentifierBlocked)
+		}
+		return nil, fiber.NewError(fiber.StatusConflict, constants.ErrIdentifierReserved)
+	}
+
+	taken, err := s.identifierRepo.ExistsWithSkeleton(ctx, tx, id.Skeleton)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.ErrUniversalIdentifierAlreadyInUse)
+	}
+
+	reserved, err := s.identifierRepo.IsReserved(ctx, tx, id.Skeleton, claimantID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.ErrUniversalIdentifierCoolingOff)
+	}
+
+	return id, nil
+}
+
+// checkDomain checks that claimantID may claim identifiers in domain
+func (s *namespaceService) checkDomain(ctx context.Context, tx *gorm.DB, domain string, claimantID uuid.UUID) error {
+	if domain == s.rules.DefaultDomain {
+		return nil
+	}
+
+	namespaceDomain, err := s.namespaceRepo.FindDomain(ctx, tx, domain)
+	if utils.IsNotFoundError(err) {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrUnknownIdentifierDomain)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !namespaceDomain.Open && namespaceDomain.OwnerActorID != claimantID {
+		return fiber.NewError(fiber.StatusForbidden, constants.ErrIdentifierDomainNotOwned)
+	}
+	return nil
+}
+
+func (s *namespaceService) AddDomain(c *fiber.Ctx, req *validation.AddNamespaceDomainRequest) (*model.NamespaceDomain, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	domain := namespace.Normalize(req.Domain)
+	if !namespace.ValidDomain(domain) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidNamespaceDomain)
+	}
+	if domain == s.rules.DefaultDomain {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.ErrNamespaceDomainExists)
+	}
+
+	ownerID, err := uuid.Parse(req.OwnerActorID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+	if _, err := s.actorRepo.FindByID(c.Context(), s.db, ownerID); err != nil {
+		return nil, err
+	}
+
+	namespaceDomain := &model.NamespaceDomain{
+		Domain:       domain,
+		OwnerActorID: ownerID,
+		Open:         req.Open,
+	}
+	if err := s.namespaceRepo.CreateDomain(c.Context(), s.db, namespaceDomain); err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Registered identifier domain %s to actor %s (open: %t)", domain, ownerID, req.Open)
+	return namespaceDomain, nil
+}
+
+func (s *namespaceService) ListDomains(c *fiber.Ctx) ([]model.NamespaceDomain, error) {
+	return s.namespaceRepo.ListDomains(c.Context(), s.db)
+}
+
+// RemoveDomain stops new claims in a domain. Identifiers already claimed in it are kept.
+func (s *namespaceService) RemoveDomain(c *fiber.Ctx, req *validation.NamespaceDomainRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	domain := namespace.Normalize(req.Domain)
+	if err := s.namespaceRepo.DeleteDomain(c.Context(), s.db, domain); err != nil {
+		return err
+	}
+
+	s.log.Infof("Removed identifier domain %s", domain)
+	return nil
+}
+
+func (s *namespaceService) AddWord(c *fiber.Ctx, req *validation.AddReservedWordRequest) (*model.ReservedWord, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	skeleton := namespace.WordSkeleton(req.Word)
+	if skeleton == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	// Existing identifiers are not affected; the word only applies to new claims
+	word := &model.ReservedWord{
+		Skeleton: skeleton,
+		Word:     namespace.Normalize(req.Word),
+		Kind:     req.Kind,
+		Reason:   utils.StringPtr(req.Reason),
+	}
+	if err := s.namespaceRepo.CreateWord(c.Context(), s.db, word); err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Added %s word %q", word.Kind, word.Word)
+	return word, nil
+}
+
+func (s *namespaceService) ListWords(c *fiber.Ctx) ([]model.ReservedWord, error) {
+	return s.namespaceRepo.ListWords(c.Context(), s.db)
+}
+
+func (s *namespaceService) RemoveWord(c *fiber.Ctx, req *validation.ReservedWordRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := s.namespaceRepo.DeleteWord(c.Context(), s.db, namespace.WordSkeleton(req.Word)); err != nil {
+		return err
+	}
+
+	s.log.Infof("Removed reserved word %q", req.Word)
+	return nil
+}
+
+// BackfillIdentifiers canonicalizes the identifiers and released identifiers stored before the
+// namespace was introduced, qualifying them with the default domain, and computes their skeletons.
+// Identifiers that look like one seen before are left alone and reported, as they must be renamed
+// by hand. Once no identifier fails, identifiers are made unique by their skeleton. With dryRun
+// set, nothing is written. The skeletons of all identifiers are kept in memory while it runs.
+func (s *namespaceService) BackfillIdentifiers(ctx context.Context, dryRun bool) (*IdentifierBackfillResult, error) {
+	result := &IdentifierBackfillResult{}
+
+	held := map[string]string{}
+	converted := map[string]bool{}
+	after := ""
+	for {
+		identifiers, err := s.identifierRepo.ListAfter(ctx, s.db, after, constants.IdentifierBackfillBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(identifiers) == 0 {
+			break
+		}
+		after = identifiers[len(identifiers)-1].Identifier
+
+		for i := range identifiers {
+			identifier := &identifiers[i]
+			if converted[identifier.Identifier] {
+				continue
+			}
+			result.Scanned++
+			id := s.rules.Legacy(identifier.Identifier)
+			if other, ok := held[id.Skeleton]; ok {
+				result.Failed = append(result.Failed, IdentifierBackfillFailure{
+					Identifier: identifier.Identifier,
+					Reason:     fmt.Sprintf("looks like %s", other),
+				})
+				continue
+			}
+			held[id.Skeleton] = identifier.Identifier
+
+			s.backfillIdentifier(identifier.Identifier, identifier.Skeleton, id, dryRun, result, converted,
+				func(canonical, skeleton string) error {
+					return s.identifierRepo.UpdateCanonical(ctx, s.db, identifier.Identifier, canonical, skeleton)
+				})
+		}
+	}
+
+	converted = map[string]bool{}
+	after = ""
+	for {
+		released, err := s.identifierRepo.ListReleasedAfter(ctx, s.db, after, constants.IdentifierBackfillBatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(released) == 0 {
+			break
+		}
+		after = released[len(released)-1].Identifier
+
+		for i := range released {
+			identifier := &released[i]
+			if converted[identifier.Identifier] {
+				continue
+			}
+			result.Scanned++
+			s.backfillIdentifier(identifier.Identifier, identifier.Skeleton, s.rules.Legacy(identifier.Identifier), dryRun, result, converted,
+				func(canonical, skeleton string) error {
+					return s.identifierRepo.UpdateReleasedCanonical(ctx, s.db, identifier.Identifier, canonical, skeleton)
+				})
+		}
+	}
+
+	if dryRun || len(result.Failed) > 0 {
+		return result, nil
+	}
+	if err := s.identifierRepo.EnforceSkeletons(ctx, s.db); err != nil {
+		return result, err
+	}
+	result.Enforced = true
+	return result, nil
+}
+
+// backfillIdentifier stores one identifier in canonical form with its skeleton through update and
+// records the outcome. Converted identifiers are added to converted, as they move in the listing
+// and can be listed again.
+func (s *namespaceService) backfillIdentifier(identifier, skeleton string, id *namespace.Identifier, dryRun bool,
+	result *IdentifierBackfillResult, converted map[string]bool, update func(canonical, skeleton string) error) {
+	if identifier == id.String() && skeleton == id.Skeleton {
+		result.Unchanged++
+		return
+	}
+
+	if !dryRun {
+		if err := update(id.String(), id.Skeleton); err != nil {
+			result.Failed = append(result.Failed, IdentifierBackfillFailure{Identifier: identifier, Reason: err.Error()})
+			return
+		}
+		converted[id.String()] = true
+	}
+	result.Converted++
+}
@@ -0,0 +1,589 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OID4VCIService implements the credential issuer side of OpenID for Verifiable Credential Issuance
+// using the pre-authorized code flow. Offers are created by authenticated actors; the token and
+// credential endpoints are called by wallets and report failures as ProtocolError.
+type OID4VCIService interface {
+	IssuerMetadata() *response.CredentialIssuerMetadata
+	AuthorizationServerMetadata() *response.AuthorizationServerMetadata
+	IssuerDIDDocument() (*did.Document, error)
+	CreateOffer(c *fiber.Ctx, req *validation.CreateCredentialOfferRequest) (*response.CredentialOfferResponse, error)
+	ExchangeToken(c *fiber.Ctx, req *validation.OID4VCITokenRequest) (*response.OID4VCITokenResponse, error)
+	IssueCredential(c *fiber.Ctx, accessToken string, req *validation.OID4VCICredentialRequest) (*response.OID4VCICredentialResponse, error)
+}
+
+// ProtocolError is an OAuth 2.0 style error returned to wallets as {error, error_description}
+type ProtocolError struct {
+	Status      int
+	Code        string
+	Description string
+
+	// CNonce carries a fresh nonce the wallet must use when retrying after invalid_nonce
+	CNonce *string
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func newProtocolError(status int, code, description string) *ProtocolError {
+	return &ProtocolError{Status: status, Code: code, Description: description}
+}
+
+// credentialConfiguration describes a credential type this issuer can issue
+type credentialConfiguration struct {
+	format  string
+	types   []string
+	display string
+}
+
+// supportedCredentialConfigurations lists the credentials offered through OID4VCI
+var supportedCredentialConfigurations = map[string]credentialConfiguration{
+	constants.CredentialConfigVerifiedIdentity: {
+		format:  constants.OID4VCIFormatJWTVCJSON,
+		types:   []string{"VerifiableCredential", constants.CredentialTypeVerifiedIdentity},
+		display: "Verified Identity",
+	},
+}
+
+// proofJWTClaims are the claims of an OID4VCI key proof JWT
+type proofJWTClaims struct {
+	jwt.RegisteredClaims
+	Nonce string `json:"nonce"`
+}
+
+// issuedCredentialClaims are the claims of a VC-JWT issued by the platform
+type issuedCredentialClaims struct {
+	jwt.RegisteredClaims
+	VC map[string]interface{} `json:"vc"`
+}
+
+// oid4vciService implements OID4VCIService with constructor-based dependency injection
+type oid4vciService struct {
+	log             *logrus.Logger
+	db              *gorm.DB
+	validate        *validator.Validate
+	cfg             *config.Config
+	signer          *keys.Signer
+	resolver        did.Resolver
+	offerRepo       repository.CredentialOfferRepository
+	actorRepo       repository.ActorRepository
+	credentialsRepo repository.CredentialsRepository
+	issuerDID       string
+}
+
+// NewOID4VCIService creates a new OID4VCI issuer service instance
+func NewOID4VCIService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	cfg *config.Config,
+	signer *keys.Signer,
+	resolver did.Resolver,
+	offerRepo repository.CredentialOfferRepository,
+	actorRepo repository.ActorRepository,
+	credentialsRepo repository.CredentialsRepository,
+) (OID4VCIService, error) {
+	issuerDID, err := did.WebDIDFromURL(cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oid4vciService{
+		log:             log,
+		db:              db,
+		validate:        validate,
+		cfg:             cfg,
+		signer:          signer,
+		resolver:        resolver,
+		offerRepo:       offerRepo,
+		actorRepo:       actorRepo,
+		credentialsRepo: credentialsRepo,
+		issuerDID:       issuerDID,
+	}, nil
+}
+
+func (s *oid4vciService) IssuerMetadata() *response.CredentialIssuerMetadata {
+	configurations := make(map[string]response.CredentialConfiguration, len(supportedCredentialConfigurations))
+	for id, configuration := range supportedCredentialConfigurations {
+		configurations[id] = response.CredentialConfiguration{
+			Format:                               configuration.format,
+			CryptographicBindingMethodsSupported: []string{"did:key", "did:jwk", "did:web"},
+			CredentialSigningAlgValuesSupported:  []string{s.signer.Algorithm()},
+			ProofTypesSupported: map[string]response.ProofTypeSupport{
+				constants.OID4VCIProofTypeJWT: {ProofSigningAlgValuesSupported: credentialJWTAlgorithms},
+			},
+			CredentialDefinition: response.CredentialDefinition{Type: configuration.types},
+			Display:              []response.DisplayProperties{{Name: configuration.display, Locale: "en-US"}},
+		}
+	}
+
+	return &response.CredentialIssuerMetadata{
+		CredentialIssuer:                  s.cfg.IssuerURL,
+		CredentialEndpoint:                s.cfg.IssuerURL + constants.RouteOID4VCI + constants.RouteOID4VCICredential,
+		Display:                           []response.DisplayProperties{{Name: s.cfg.IssuerName, Locale: "en-US"}},
+		CredentialConfigurationsSupported: configurations,
+	}
+}
+
+func (s *oid4vciService) AuthorizationServerMetadata() *response.AuthorizationServerMetadata {
+	return &response.AuthorizationServerMetadata{
+		Issuer:                            s.cfg.IssuerURL,
+		TokenEndpoint:                     s.cfg.IssuerURL + constants.RouteOID4VCI + constants.RouteOID4VCIToken,
+		GrantTypesSupported:               []string{constants.OID4VCIGrantPreAuthorizedCode},
+		ResponseTypesSupported:            []string{},
+		PreAuthorizedGrantAnonymousAccess: true,
+	}
+}
+
+func (s *oid4vciService) IssuerDIDDocument() (*did.Document, error) {
+	jwk, err := s.signer.PublicJWK()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode issuer key: %w", err)
+	}
+
+	ref := []did.MethodReference{{Reference: s.signer.KeyID()}}
+	return &did.Document{
+		Context: []string{"https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/jws-2020/v1"},
+		ID:      s.issuerDID,
+		VerificationMethod: []did.VerificationMethod{{
+			ID:           s.signer.KeyID(),
+			Type:         did.TypeJSONWebKey2020,
+			Controller:   s.issuerDID,
+			PublicKeyJwk: jwk,
+		}},
+		Authentication:  ref,
+		AssertionMethod: ref,
+	}, nil
+}
+
+func (s *oid4vciService) CreateOffer(c *fiber.Ctx, req *validation.CreateCredentialOfferRequest) (*response.CredentialOfferResponse, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	configurationIDs := req.CredentialConfigurationIDs
+	if len(configurationIDs) == 0 {
+		configurationIDs = []string{constants.CredentialConfigVerifiedIdentity}
+	}
+	for _, id := range configurationIDs {
+		if _, ok := supportedCredentialConfigurations[id]; !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrUnsupportedCredentialConfiguration)
+		}
+	}
+
+	code, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := &model.CredentialOffer{
+		ActorID:                    actorID,
+		CredentialConfigurationIDs: datatypes.JSONSlice[string](configurationIDs),
+		PreAuthorizedCodeHash:      utils.HashSecret(code),
+		Status:                     constants.CredentialOfferStatusOffered,
+		ExpiresAt:                  time.Now().Add(constants.OID4VCIOfferTTL * time.Minute),
+	}
+
+	var txCode string
+	if req.RequireTxCode {
+		if txCode, err = utils.GenerateNumericCode(constants.OID4VCITxCodeLength); err != nil {
+			return nil, err
+		}
+		offer.TxCodeHash = utils.StringPtr(utils.HashSecret(txCode))
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.offerRepo.Create(c.Context(), tx, offer)
+	}); err != nil {
+		s.log.Errorf("Failed to create credential offer: %+v", err)
+		return nil, err
+	}
+
+	credentialOffer := response.CredentialOffer{
+		CredentialIssuer:           s.cfg.IssuerURL,
+		CredentialConfigurationIDs: configurationIDs,
+		Grants: response.CredentialOfferGrants{
+			PreAuthorizedCode: response.PreAuthorizedCodeGrant{PreAuthorizedCode: code},
+		},
+	}
+	if req.RequireTxCode {
+		credentialOffer.Grants.PreAuthorizedCode.TxCode = &response.TxCode{
+			InputMode:   "numeric",
+			Length:      constants.OID4VCITxCodeLength,
+			Description: "Enter the code shown in the app",
+		}
+	}
+
+	// The offer is passed by value since it carries the pre-authorized code
+	encoded, err := json.Marshal(credentialOffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode credential offer: %w", err)
+	}
+
+	return &response.CredentialOfferResponse{
+		OfferID:            offer.OfferID.String(),
+		CredentialOfferURI: constants.OID4VCIOfferScheme + "?credential_offer=" + url.QueryEscape(string(encoded)),
+		CredentialOffer:    credentialOffer,
+		TxCode:             txCode,
+		ExpiresAt:          offer.ExpiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (s *oid4vciService) ExchangeToken(c *fiber.Ctx, req *validation.OID4VCITokenRequest) (*response.OID4VCITokenResponse, error) {
+	if req.GrantType != constants.OID4VCIGrantPreAuthorizedCode {
+		return nil, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrUnsupportedGrantType, "only the pre-authorized code grant is supported")
+	}
+	if req.PreAuthorizedCode == "" {
+		return nil, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "pre-authorized_code is required")
+	}
+
+	txCode := req.TxCode
+	if txCode == "" {
+		txCode = req.UserPin
+	}
+
+	accessToken, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failed transaction code attempts must be persisted, so protocol errors are
+	// recorded here and returned after the transaction commits
+	var protocolErr *ProtocolError
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		offer, err := s.offerRepo.LockByPreAuthorizedCodeHash(c.Context(), tx, utils.HashSecret(req.PreAuthorizedCode))
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if offer == nil || offer.Status != constants.CredentialOfferStatusOffered || now.After(offer.ExpiresAt) {
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidGrant, "pre-authorized code is invalid, expired or already used")
+			return nil
+		}
+
+		if offer.TxCodeHash != nil && !secretMatches(*offer.TxCodeHash, txCode) {
+			offer.TxCodeAttempts++
+			if offer.TxCodeAttempts >= constants.OID4VCIMaxTxCodeAttempts {
+				offer.Status = constants.CredentialOfferStatusRevoked
+				s.log.Warnf("Credential offer %s revoked after %d failed transaction code attempts", offer.OfferID, offer.TxCodeAttempts)
+			}
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidGrant, "transaction code is invalid")
+			return s.offerRepo.Update(c.Context(), tx, offer)
+		}
+
+		accessTokenExpiresAt := now.Add(constants.OID4VCIAccessTokenTTL * time.Minute)
+		nonceExpiresAt := now.Add(constants.OID4VCINonceTTL * time.Minute)
+
+		offer.Status = constants.CredentialOfferStatusRedeemed
+		offer.RedeemedAt = &now
+		offer.AccessTokenHash = utils.StringPtr(utils.HashSecret(accessToken))
+		offer.AccessTokenExpiresAt = &accessTokenExpiresAt
+		offer.CNonce = &nonce
+		offer.CNonceExpiresAt = &nonceExpiresAt
+		return s.offerRepo.Update(c.Context(), tx, offer)
+	})
+	if err != nil {
+		s.log.Errorf("Failed to exchange pre-authorized code: %+v", err)
+		return nil, err
+	}
+	if protocolErr != nil {
+		return nil, protocolErr
+	}
+
+	return &response.OID4VCITokenResponse{
+		AccessToken:     accessToken,
+		TokenType:       constants.OID4VCITokenTypeBearer,
+		ExpiresIn:       constants.OID4VCIAccessTokenTTL * 60,
+		CNonce:          nonce,
+		CNonceExpiresIn: constants.OID4VCINonceTTL * 60,
+	}, nil
+}
+
+func (s *oid4vciService) IssueCredential(c *fiber.Ctx, accessToken string, req *validation.OID4VCICredentialRequest) (*response.OID4VCICredentialResponse, error) {
+	if accessToken == "" {
+		return nil, newProtocolError(fiber.StatusUnauthorized, constants.OAuthErrInvalidToken, "access token is required")
+	}
+	if err := s.validate.Struct(req); err != nil {
+		return nil, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "proof with proof_type and jwt is required")
+	}
+	if req.Proof.ProofType != constants.OID4VCIProofTypeJWT {
+		return nil, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidProof, "only jwt proofs are supported")
+	}
+
+	nextNonce, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.Context()
+	tokenHash := utils.HashSecret(accessToken)
+
+	// Resolving the holder DID can fetch a did:web document, so the proof is verified before the
+	// offer is locked. The access token is checked first so that unknown callers cause no fetch.
+	offer, err := s.offerRepo.FindByAccessTokenHash(ctx, s.db, tokenHash)
+	if err != nil {
+		s.log.Errorf("Failed to find credential offer: %+v", err)
+		return nil, err
+	}
+	if !accessTokenUsable(offer, time.Now()) {
+		return nil, newProtocolError(fiber.StatusUnauthorized, constants.OAuthErrInvalidToken, "access token is invalid or expired")
+	}
+	holder, proofNonce, proofErr := s.verifyProof(ctx, req.Proof.JWT, time.Now())
+
+	// A failed nonce check rotates the nonce, which must be committed before reporting the error
+	var (
+		protocolErr *ProtocolError
+		credential  string
+	)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		offer, err := s.offerRepo.LockByAccessTokenHash(ctx, tx, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !accessTokenUsable(offer, now) {
+			protocolErr = newProtocolError(fiber.StatusUnauthorized, constants.OAuthErrInvalidToken, "access token is invalid or expired")
+			return nil
+		}
+
+		configuration, perr := resolveRequestedConfiguration(req, offer.CredentialConfigurationIDs)
+		if perr != nil {
+			protocolErr = perr
+			return nil
+		}
+
+		nonceExpiresAt := now.Add(constants.OID4VCINonceTTL * time.Minute)
+		expectedNonce := offer.CNonce
+		nonceValid := expectedNonce != nil && offer.CNonceExpiresAt != nil && now.Before(*offer.CNonceExpiresAt)
+
+		// Every proof attempt consumes the current nonce
+		offer.CNonce = &nextNonce
+		offer.CNonceExpiresAt = &nonceExpiresAt
+		if err := s.offerRepo.Update(ctx, tx, offer); err != nil {
+			return err
+		}
+
+		if proofErr != nil {
+			s.log.Warnf("OID4VCI proof rejected for offer %s: %v", offer.OfferID, proofErr)
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidProof, "proof is invalid")
+			return nil
+		}
+		if !nonceValid || subtle.ConstantTimeCompare([]byte(proofNonce), []byte(*expectedNonce)) != 1 {
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidNonce, "proof nonce is missing, stale or does not match c_nonce")
+			protocolErr.CNonce = &nextNonce
+			return nil
+		}
+
+		credential, err = s.issueCredential(ctx, tx, offer.ActorID, holder, configuration, now)
+		return err
+	})
+	if err != nil {
+		s.log.Errorf("Failed to issue credential: %+v", err)
+		return nil, err
+	}
+	if protocolErr != nil {
+		return nil, protocolErr
+	}
+
+	return &response.OID4VCICredentialResponse{
+		Format:          constants.OID4VCIFormatJWTVCJSON,
+		Credential:      credential,
+		CNonce:          nextNonce,
+		CNonceExpiresIn: constants.OID4VCINonceTTL * 60,
+	}, nil
+}
+
+// accessTokenUsable reports whether an offer found by access token can still issue credentials
+func accessTokenUsable(offer *model.CredentialOffer, now time.Time) bool {
+	return offer != nil && offer.Status == constants.CredentialOfferStatusRedeemed &&
+		offer.AccessTokenExpiresAt != nil && now.Before(*offer.AccessTokenExpiresAt)
+}
+
+// resolveRequestedConfiguration matches a credential request to one of the configurations of its offer
+func resolveRequestedConfiguration(req *validation.OID4VCICredentialRequest, offered []string) (credentialConfiguration, *ProtocolError) {
+	if req.CredentialConfigurationID != "" {
+		configuration, ok := supportedCredentialConfigurations[req.CredentialConfigurationID]
+		if !ok || !slices.Contains(offered, req.CredentialConfigurationID) {
+			return credentialConfiguration{}, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrUnsupportedCredentialType, "credential configuration was not offered")
+		}
+		return configuration, nil
+	}
+
+	if req.Format == "" {
+		return credentialConfiguration{}, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "credential_configuration_id or format is required")
+	}
+	if req.Format != constants.OID4VCIFormatJWTVCJSON {
+		return credentialConfiguration{}, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrUnsupportedCredentialFormat, "credential format is not supported")
+	}
+
+	for _, id := range offered {
+		configuration, ok := supportedCredentialConfigurations[id]
+		if !ok || configuration.format != req.Format {
+			continue
+		}
+		if req.CredentialDefinition == nil || containsAll(req.CredentialDefinition.Type, configuration.types[1:]) {
+			return configuration, nil
+		}
+	}
+	return credentialConfiguration{}, newProtocolError(fiber.StatusBadRequest, constants.OAuthErrUnsupportedCredentialType, "credential type was not offered")
+}
+
+// verifyProof checks an OID4VCI key proof JWT and returns the holder DID and the proof nonce.
+// The holder key is taken from a DID URL kid or from an embedded jwk header.
+func (s *oid4vciService) verifyProof(ctx context.Context, compact string, now time.Time) (string, string, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(credentialJWTAlgorithms),
+		jwt.WithAudience(s.cfg.IssuerURL),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(constants.JWTClockSkew*time.Second),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+
+	var holder string
+	claims := &proofJWTClaims{}
+	_, err := parser.ParseWithClaims(compact, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != constants.OID4VCIProofJWTType {
+			return nil, fmt.Errorf("unexpected typ header %q", typ)
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		rawJWK, hasJWK := token.Header["jwk"]
+		switch {
+		case kid != "" && hasJWK:
+			return nil, errors.New("kid and jwk headers are mutually exclusive")
+		case strings.HasPrefix(kid, "did:"):
+			holderDID, _ := did.SplitURL(kid)
+			document, err := s.resolver.Resolve(ctx, holderDID)
+			if err != nil {
+				return nil, err
+			}
+			method, err := document.FindVerificationMethod(kid)
+			if err != nil {
+				return nil, err
+			}
+			holder = document.ID
+			return method.PublicKey()
+		case hasJWK:
+			encoded, err := json.Marshal(rawJWK)
+			if err != nil {
+				return nil, err
+			}
+			var jwk keys.JWK
+			if err := json.Unmarshal(encoded, &jwk); err != nil {
+				return nil, err
+			}
+			if holder, err = did.JWKDID(&jwk); err != nil {
+				return nil, err
+			}
+			return jwk.PublicKey()
+		default:
+			return nil, errors.New("proof must identify the holder key with a DID URL kid or a jwk header")
+		}
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	if claims.IssuedAt == nil {
+		return "", "", errors.New("missing iat claim")
+	}
+	if now.Sub(claims.IssuedAt.Time) > constants.OID4VCIProofMaxAge*time.Minute {
+		return "", "", errors.New("proof is too old")
+	}
+
+	return holder, claims.Nonce, nil
+}
+
+// issueCredential signs a VC-JWT attesting the actor's current verification state, bound to the holder DID
+func (s *oid4vciService) issueCredential(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, holder string, configuration credentialConfiguration, now time.Time) (string, error) {
+	actor, err := s.actorRepo.FindByID(ctx, tx, actorID)
+	if err != nil {
+		return "", err
+	}
+
+	verifiedTypes, err := s.credentialsRepo.FindVerifiedTypes(ctx, tx, actorID)
+	if err != nil {
+		return "", err
+	}
+
+	credentialID := "urn:uuid:" + uuid.Must(uuid.NewV7()).String()
+	expiresAt := now.AddDate(0, 0, constants.IssuedCredentialValidity)
+
+	claims := issuedCredentialClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuerDID,
+			Subject:   holder,
+			ID:        credentialID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		VC: map[string]interface{}{
+			"@context":       []string{"https://www.w3.org/2018/credentials/v1"},
+			"id":             credentialID,
+			"type":           configuration.types,
+			"issuer":         s.issuerDID,
+			"issuanceDate":   now.UTC().Format(time.RFC3339),
+			"expirationDate": expiresAt.UTC().Format(time.RFC3339),
+			"credentialSubject": map[string]interface{}{
+				"id":                      holder,
+				"verificationLevel":       actor.VerificationLevel,
+				"entityType":              actor.EntityType,
+				"verifiedCredentialTypes": verifiedTypes,
+			},
+		},
+	}
+
+	credential, err := s.signer.Sign(claims, map[string]interface{}{"typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign credential: %w", err)
+	}
+
+	s.log.Infof("Issued %s %s to actor %s bound to %s", configuration.types[len(configuration.types)-1], credentialID, actorID, holder)
+	return credential, nil
+}
+
+// secretMatches compares a presented secret against its stored digest in constant time
+func secretMatches(digest, presented string) bool {
+	return subtle.ConstantTimeCompare([]byte(digest), []byte(utils.HashSecret(presented))) == 1
+}
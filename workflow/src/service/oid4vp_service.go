@@ -0,0 +1,558 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/netguard"
+	"app/src/pex"
+	"app/src/repository"
+	"app/src/response"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OID4VPService implements the verifier side of OpenID for Verifiable Presentations.
+// Authorization requests are passed by reference as signed request objects and wallets
+// answer with direct_post; the outcome is evaluated against the Presentation Exchange definition.
+type OID4VPService interface {
+	CreateRequest(c *fiber.Ctx, req *validation.CreatePresentationRequest) (*response.PresentationRequestResponse, error)
+	GetRequest(c *fiber.Ctx, req *validation.PresentationRequestIDRequest) (*model.PresentationRequest, error)
+	RequestObject(c *fiber.Ctx, requestID string) (string, error)
+	HandleResponse(c *fiber.Ctx, req *validation.OID4VPDirectPostRequest) error
+}
+
+// presentationJWTClaims are the claims of a JWT-encoded verifiable presentation
+type presentationJWTClaims struct {
+	jwt.RegisteredClaims
+	Nonce string                 `json:"nonce"`
+	VP    map[string]interface{} `json:"vp"`
+}
+
+// verifiedPresentation is a VP-JWT whose signature, audience and nonce have been checked
+type verifiedPresentation struct {
+	holder  string
+	payload map[string]interface{}
+}
+
+// oid4vpService implements OID4VPService with constructor-based dependency injection
+type oid4vpService struct {
+	log                  *logrus.Logger
+	db                   *gorm.DB
+	validate             *validator.Validate
+	cfg                  *config.Config
+	signer               *keys.Signer
+	resolver             did.Resolver
+	credentialJWTService CredentialJWTService
+	presentationRepo     repository.PresentationRequestRepository
+	jobs                 JobService
+	clientID             string
+}
+
+// NewOID4VPService creates a new OID4VP verifier service instance. The verifier
+// identifies itself with the platform did:web and signs request objects with the platform key.
+func NewOID4VPService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	cfg *config.Config,
+	signer *keys.Signer,
+	resolver did.Resolver,
+	credentialJWTService CredentialJWTService,
+	presentationRepo repository.PresentationRequestRepository,
+	jobs JobService,
+) (OID4VPService, error) {
+	clientID, err := did.WebDIDFromURL(cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oid4vpService{
+		log:                  log,
+		db:                   db,
+		validate:             validate,
+		cfg:                  cfg,
+		signer:               signer,
+		resolver:             resolver,
+		credentialJWTService: credentialJWTService,
+		presentationRepo:     presentationRepo,
+		jobs:                 jobs,
+		clientID:             clientID,
+	}, nil
+}
+
+func (s *oid4vpService) CreateRequest(c *fiber.Ctx, req *validation.CreatePresentationRequest) (*response.PresentationRequestResponse, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+	if err := req.PresentationDefinition.Validate(); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s: %v", constants.ErrInvalidPresentationDefinition, err))
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var callbackURL, callbackSecret *string
+	if req.CallbackURL != "" {
+		parsed, err := url.Parse(req.CallbackURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Hostname() == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+		}
+		// Callbacks are sent from inside our network, so they must not point back into it
+		if err := netguard.CheckHost(c.Context(), parsed.Hostname()); err != nil {
+			s.log.Warnf("Rejected presentation callback URL %s: %v", req.CallbackURL, err)
+			return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrCallbackURLNotPublic)
+		}
+		secret, err := utils.GenerateSecret(constants.WebhookSecretBytes)
+		if err != nil {
+			return nil, err
+		}
+		callbackURL, callbackSecret = &req.CallbackURL, &secret
+	}
+
+	state, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := utils.GenerateSecret(constants.SecretByteLength)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &model.PresentationRequest{
+		ActorID:                actorID,
+		State:                  state,
+		Nonce:                  nonce,
+		ClientID:               s.clientID,
+		PresentationDefinition: datatypes.NewJSONType(req.PresentationDefinition),
+		CallbackURL:            callbackURL,
+		CallbackSecret:         callbackSecret,
+		Status:                 constants.PresentationStatusPending,
+		Result:                 datatypes.NewJSONType(model.PresentationResult{}),
+		ExpiresAt:              time.Now().Add(constants.OID4VPRequestTTL * time.Minute),
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.presentationRepo.Create(c.Context(), tx, request)
+	}); err != nil {
+		s.log.Errorf("Failed to create presentation request: %+v", err)
+		return nil, err
+	}
+
+	requestURI := s.cfg.IssuerURL + constants.RouteOID4VP + strings.Replace(constants.RouteOID4VPRequestObject, ":requestId", request.RequestID.String(), 1)
+	query := url.Values{}
+	query.Set("client_id", s.clientID)
+	query.Set("request_uri", requestURI)
+
+	presentationResponse := &response.PresentationRequestResponse{
+		RequestID:               request.RequestID.String(),
+		AuthorizationRequestURI: constants.OID4VPAuthorizationScheme + "?" + query.Encode(),
+		RequestURI:              requestURI,
+		Status:                  request.Status,
+		ExpiresAt:               request.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+	if callbackSecret != nil {
+		presentationResponse.CallbackSecret = *callbackSecret
+	}
+	return presentationResponse, nil
+}
+
+func (s *oid4vpService) GetRequest(c *fiber.Ctx, req *validation.PresentationRequestIDRequest) (*model.PresentationRequest, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, err := utils.ParseUUID(req.RequestID, "request")
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := s.presentationRepo.FindByIDAndActor(c.Context(), s.db, requestID, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unanswered requests past their expiry are reported as expired without a write
+	if isPresentationOpen(request) && time.Now().After(request.ExpiresAt) {
+		request.Status = constants.PresentationStatusExpired
+	}
+	return request, nil
+}
+
+func (s *oid4vpService) RequestObject(c *fiber.Ctx, requestID string) (string, error) {
+	id, err := uuid.Parse(requestID)
+	if err != nil {
+		return "", newProtocolError(fiber.StatusNotFound, constants.OAuthErrInvalidRequest, "unknown request")
+	}
+
+	var requestObject string
+	var protocolErr *ProtocolError
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		request, err := s.presentationRepo.LockByID(c.Context(), tx, id)
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				protocolErr = newProtocolError(fiber.StatusNotFound, constants.OAuthErrInvalidRequest, "unknown request")
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		if !isPresentationOpen(request) || now.After(request.ExpiresAt) {
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "request is no longer active")
+			return nil
+		}
+
+		if requestObject, err = s.signRequestObject(request, now); err != nil {
+			return err
+		}
+
+		request.Status = constants.PresentationStatusRetrieved
+		request.RetrievedAt = &now
+		return s.presentationRepo.Update(c.Context(), tx, request)
+	})
+	if err != nil {
+		s.log.Errorf("Failed to serve request object: %+v", err)
+		return "", err
+	}
+	if protocolErr != nil {
+		return "", protocolErr
+	}
+
+	return requestObject, nil
+}
+
+// signRequestObject builds the signed authorization request (JAR) for a presentation request
+func (s *oid4vpService) signRequestObject(request *model.PresentationRequest, now time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":                     s.clientID,
+		"aud":                     constants.OID4VPSelfIssuedAudience,
+		"iat":                     now.Unix(),
+		"exp":                     request.ExpiresAt.Unix(),
+		"client_id":               s.clientID,
+		"client_id_scheme":        constants.OID4VPClientIDSchemeDID,
+		"response_type":           constants.OID4VPResponseTypeVPToken,
+		"response_mode":           constants.OID4VPResponseModeDirect,
+		"response_uri":            s.cfg.IssuerURL + constants.RouteOID4VP + constants.RouteOID4VPResponse,
+		"nonce":                   request.Nonce,
+		"state":                   request.State,
+		"presentation_definition": request.PresentationDefinition.Data(),
+		"client_metadata": map[string]interface{}{
+			"client_name": s.cfg.IssuerName,
+			"vp_formats": map[string]interface{}{
+				constants.OID4VPFormatJWTVPJSON:  map[string]interface{}{"alg": credentialJWTAlgorithms},
+				constants.OID4VCIFormatJWTVCJSON: map[string]interface{}{"alg": credentialJWTAlgorithms},
+			},
+		},
+	}
+
+	requestObject, err := s.signer.Sign(claims, map[string]interface{}{"typ": constants.OID4VPRequestObjectType})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign request object: %w", err)
+	}
+	return requestObject, nil
+}
+
+func (s *oid4vpService) HandleResponse(c *fiber.Ctx, req *validation.OID4VPDirectPostRequest) error {
+	if req.State == "" {
+		return newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "state is required")
+	}
+	if req.Error == "" && (req.VPToken == "" || req.PresentationSubmission == "") {
+		return newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "vp_token and presentation_submission are required")
+	}
+
+	// The outcome is recorded even when the presentation is rejected, so protocol
+	// errors are returned only after the transaction commits
+	var protocolErr *ProtocolError
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		request, err := s.presentationRepo.LockByState(c.Context(), tx, req.State)
+		if err != nil {
+			return err
+		}
+		if request == nil || !isPresentationOpen(request) {
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "state is unknown or the request was already answered")
+			return nil
+		}
+
+		now := time.Now()
+		switch {
+		case now.After(request.ExpiresAt):
+			request.Status = constants.PresentationStatusExpired
+			protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OAuthErrInvalidRequest, "request has expired")
+		case req.Error != "":
+			request.Status = constants.PresentationStatusRejected
+			request.Result = datatypes.NewJSONType(model.PresentationResult{
+				Errors: []string{strings.TrimSpace(req.Error + ": " + req.ErrorDescription)},
+			})
+		default:
+			result, err := s.evaluate(c.Context(), request, req.VPToken, req.PresentationSubmission)
+			if err != nil {
+				s.log.Warnf("Presentation for request %s rejected: %v", request.RequestID, err)
+				result.Errors = append(result.Errors, err.Error())
+				request.Status = constants.PresentationStatusRejected
+				protocolErr = newProtocolError(fiber.StatusBadRequest, constants.OID4VPErrInvalidSubmission, err.Error())
+			} else {
+				request.Status = constants.PresentationStatusVerified
+			}
+			request.Result = datatypes.NewJSONType(result)
+		}
+
+		request.CompletedAt = &now
+		if request.CallbackURL != nil {
+			request.CallbackStatus = utils.StringPtr(constants.CallbackStatusPending)
+		}
+		if err := s.presentationRepo.Update(c.Context(), tx, request); err != nil {
+			return err
+		}
+		if request.CallbackURL == nil {
+			return nil
+		}
+		return s.jobs.Enqueue(c.Context(), tx, &model.Job{
+			Type:       constants.JobTypePresentationCallback,
+			ActorID:    request.ActorID,
+			ResourceID: &request.RequestID,
+		})
+	})
+	if err != nil {
+		s.log.Errorf("Failed to record presentation response: %+v", err)
+		return err
+	}
+
+	if protocolErr != nil {
+		return protocolErr
+	}
+	return nil
+}
+
+// evaluate verifies the vp_token and matches the submitted credentials to the presentation definition
+func (s *oid4vpService) evaluate(ctx context.Context, request *model.PresentationRequest, vpToken, submissionJSON string) (model.PresentationResult, error) {
+	var result model.PresentationResult
+
+	var submission pex.PresentationSubmission
+	if err := json.Unmarshal([]byte(submissionJSON), &submission); err != nil {
+		return result, fmt.Errorf("presentation_submission is not valid JSON: %w", err)
+	}
+
+	definition := request.PresentationDefinition.Data()
+	if err := definition.CheckCoverage(&submission); err != nil {
+		return result, err
+	}
+
+	// A vp_token carries a single presentation or a JSON array of presentations
+	var root interface{} = vpToken
+	if strings.HasPrefix(strings.TrimSpace(vpToken), "[") {
+		if err := json.Unmarshal([]byte(vpToken), &root); err != nil {
+			return result, fmt.Errorf("vp_token is not valid JSON: %w", err)
+		}
+	}
+
+	presentations := make(map[string]*verifiedPresentation)
+	for i := range submission.DescriptorMap {
+		entry := &submission.DescriptorMap[i]
+		descriptor, _ := definition.InputDescriptor(entry.ID)
+
+		presentation, err := s.presentationAt(ctx, request, root, entry, presentations)
+		if err != nil {
+			return result, fmt.Errorf("descriptor %q: %w", entry.ID, err)
+		}
+		if result.Holder != "" && result.Holder != presentation.holder {
+			return result, errors.New("presentations were signed by different holders")
+		}
+		result.Holder = presentation.holder
+
+		credential, err := s.credentialAt(ctx, presentation, entry.PathNested, descriptor)
+		if err != nil {
+			return result, fmt.Errorf("descriptor %q: %w", entry.ID, err)
+		}
+		result.Credentials = append(result.Credentials, *credential)
+	}
+
+	return result, nil
+}
+
+// presentationAt verifies the VP-JWT selected by a descriptor map entry
+func (s *oid4vpService) presentationAt(ctx context.Context, request *model.PresentationRequest, root interface{}, entry *pex.Descriptor, cache map[string]*verifiedPresentation) (*verifiedPresentation, error) {
+	if entry.Format != constants.OID4VPFormatJWTVPJSON && entry.Format != constants.OID4VPFormatJWTVP {
+		return nil, fmt.Errorf("credentials must be submitted inside a %s presentation", constants.OID4VPFormatJWTVPJSON)
+	}
+	if entry.PathNested == nil {
+		return nil, errors.New("path_nested is required to locate the credential")
+	}
+
+	value, err := pex.Query(root, entry.Path)
+	if err != nil {
+		return nil, err
+	}
+	compact, ok := value.(string)
+	if !ok {
+		return nil, errors.New("presentation is not a JWT")
+	}
+
+	if presentation, ok := cache[compact]; ok {
+		return presentation, nil
+	}
+	presentation, err := s.verifyPresentation(ctx, request, compact)
+	if err != nil {
+		return nil, err
+	}
+	cache[compact] = presentation
+	return presentation, nil
+}
+
+// credentialAt verifies the VC-JWT nested in a presentation and matches it to the input descriptor
+func (s *oid4vpService) credentialAt(ctx context.Context, presentation *verifiedPresentation, nested *pex.Descriptor, descriptor *pex.InputDescriptor) (*model.PresentedCredential, error) {
+	if nested.Format != constants.OID4VCIFormatJWTVCJSON && nested.Format != constants.OID4VPFormatJWTVC {
+		return nil, fmt.Errorf("unsupported credential format %q", nested.Format)
+	}
+	if nested.PathNested != nil {
+		return nil, errors.New("credentials nested more than one level are not supported")
+	}
+
+	// Nested paths may address the JWT payload ($.vp.verifiableCredential) or the vp claim itself
+	value, err := pex.Query(presentation.payload, nested.Path)
+	if errors.Is(err, pex.ErrPathNotFound) {
+		value, err = pex.Query(presentation.payload["vp"], nested.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	compact, ok := value.(string)
+	if !ok {
+		return nil, errors.New("credential is not a JWT")
+	}
+
+	verified, err := s.credentialJWTService.Verify(ctx, compact)
+	if err != nil {
+		return nil, errors.New("credential signature or claims are invalid")
+	}
+	if err := descriptor.CheckHolderBinding(verified.SubjectDID(), presentation.holder); err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"iss": verified.Issuer,
+		"sub": verified.Subject,
+		"jti": verified.ID,
+		"vc":  verified.Credential,
+	}
+	fields, err := descriptor.Match(payload)
+	if err != nil {
+		// Definitions may also address the credential directly ($.credentialSubject...)
+		var directErr error
+		if fields, directErr = descriptor.Match(verified.Credential); directErr != nil {
+			return nil, err
+		}
+	}
+
+	return &model.PresentedCredential{
+		InputDescriptorID: descriptor.ID,
+		Issuer:            verified.Issuer,
+		Subject:           verified.SubjectDID(),
+		Types:             credentialTypes(verified.Credential),
+		Fields:            fields,
+	}, nil
+}
+
+// verifyPresentation checks a VP-JWT signed with an authentication key of the holder DID,
+// addressed to this verifier and bound to the request nonce
+func (s *oid4vpService) verifyPresentation(ctx context.Context, request *model.PresentationRequest, compact string) (*verifiedPresentation, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(credentialJWTAlgorithms),
+		jwt.WithAudience(request.ClientID),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(constants.JWTClockSkew*time.Second),
+	)
+
+	var holder string
+	claims := &presentationJWTClaims{}
+	_, err := parser.ParseWithClaims(compact, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if !strings.HasPrefix(kid, "did:") {
+			return nil, errors.New("kid header must be a DID URL")
+		}
+
+		holder, _ = did.SplitURL(kid)
+		if claims.Issuer != "" && claims.Issuer != holder {
+			return nil, fmt.Errorf("key %q does not belong to %q", kid, claims.Issuer)
+		}
+
+		document, err := s.resolver.Resolve(ctx, holder)
+		if err != nil {
+			return nil, err
+		}
+		method, err := document.FindVerificationMethod(kid)
+		if err != nil {
+			return nil, err
+		}
+		if !document.IsAuthenticationMethod(method) {
+			return nil, fmt.Errorf("key %q is not an authentication method of %q", kid, holder)
+		}
+		return method.PublicKey()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("presentation could not be verified: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(request.Nonce)) != 1 {
+		return nil, errors.New("presentation nonce does not match the request")
+	}
+	if claims.VP == nil {
+		return nil, errors.New("missing vp claim")
+	}
+	if vpHolder, _ := claims.VP["holder"].(string); vpHolder != "" && vpHolder != holder {
+		return nil, fmt.Errorf("vp.holder %q does not match the signing DID", vpHolder)
+	}
+
+	return &verifiedPresentation{
+		holder:  holder,
+		payload: map[string]interface{}{"iss": holder, "nonce": claims.Nonce, "vp": claims.VP},
+	}, nil
+}
+
+// isPresentationOpen reports whether a request may still be retrieved or answered
+func isPresentationOpen(request *model.PresentationRequest) bool {
+	return request.Status == constants.PresentationStatusPending || request.Status == constants.PresentationStatusRetrieved
+}
+
+// credentialTypes returns the type values of a W3C credential
+func credentialTypes(credential map[string]interface{}) []string {
+	switch value := credential["type"].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		types := make([]string, 0, len(value))
+		for _, t := range value {
+			if name, ok := t.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
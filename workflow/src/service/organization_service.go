@@ -0,0 +1,488 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// OrganizationService defines the interface for managing the members of Business actors
+// (organizations). Organization methods act for the organization of the caller: the Business actor
+// itself, or the organization named in the X-Organization-ID header by one of its members. Member
+// methods act for the Individual actor that was invited.
+type OrganizationService interface {
+	// Invite invites an Individual actor to join the organization with a role
+	Invite(c *fiber.Ctx, req *validation.CreateOrganizationInvitationRequest) (*model.OrganizationInvitation, error)
+
+	// ListInvitations lists the most recent invitations of the organization
+	ListInvitations(c *fiber.Ctx) ([]model.OrganizationInvitation, error)
+
+	// RevokeInvitation revokes a pending invitation of the organization
+	RevokeInvitation(c *fiber.Ctx, req *validation.OrganizationInvitationRequest) (*model.OrganizationInvitation, error)
+
+	// ListMembers lists the members of the organization
+	ListMembers(c *fiber.Ctx) ([]model.OrganizationMember, error)
+
+	// UpdateMemberRole changes the role of a member of the organization
+	UpdateMemberRole(c *fiber.Ctx, req *validation.UpdateOrganizationMemberRequest) (*model.OrganizationMember, error)
+
+	// RemoveMember removes a member from the organization
+	RemoveMember(c *fiber.Ctx, req *validation.OrganizationMemberRequest) error
+
+	// ListMyInvitations lists the pending invitations of the caller
+	ListMyInvitations(c *fiber.Ctx) ([]model.OrganizationInvitation, error)
+
+	// AcceptInvitation accepts a pending invitation of the caller, making them a member
+	AcceptInvitation(c *fiber.Ctx, req *validation.OrganizationInvitationRequest) (*model.OrganizationMember, error)
+
+	// DeclineInvitation declines a pending invitation of the caller
+	DeclineInvitation(c *fiber.Ctx, req *validation.OrganizationInvitationRequest) (*model.OrganizationInvitation, error)
+
+	// ListMemberships lists the organizations the caller is a member of
+	ListMemberships(c *fiber.Ctx) ([]model.OrganizationMember, error)
+
+	// LeaveOrganization ends the membership of the caller in an organization
+	LeaveOrganization(c *fiber.Ctx, req *validation.OrganizationRequest) error
+}
+
+type organizationService struct {
+	log              *logrus.Logger
+	db               *gorm.DB
+	validate         *validator.Validate
+	namespaceService NamespaceService
+	actorRepo        repository.ActorRepository
+	identifierRepo   repository.IdentifierRepository
+	orgRepo          repository.OrganizationRepository
+}
+
+// NewOrganizationService creates a new organization service instance
+func NewOrganizationService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	namespaceService NamespaceService,
+	actorRepo repository.ActorRepository,
+	identifierRepo repository.IdentifierRepository,
+	orgRepo repository.OrganizationRepository,
+) OrganizationService {
+	return &organizationService{
+		log:              log,
+		db:               db,
+		validate:         validate,
+		namespaceService: namespaceService,
+		actorRepo:        actorRepo,
+		identifierRepo:   identifierRepo,
+		orgRepo:          orgRepo,
+	}
+}
+
+// organizationCaller is the organization a request acts for, and the actor behind it
+type organizationCaller struct {
+	organizationID uuid.UUID
+	memberID       uuid.UUID
+	role           string
+}
+
+// organizationFromContext resolves the organization of the caller. A member acting through the
+// X-Organization-ID header holds their member role; a Business actor acting for itself is an owner.
+func (s *organizationService) organizationFromContext(c *fiber.Ctx) (*organizationCaller, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if role, ok := c.Locals("organizationRole").(string); ok {
+		memberID, ok := c.Locals("memberID").(uuid.UUID)
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
+		}
+		return &organizationCaller{organizationID: actorID, memberID: memberID, role: role}, nil
+	}
+
+	actor, err := s.actorRepo.FindByID(c.Context(), s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.EntityType != constants.EntityTypeBusiness {
+		return nil, fiber.NewError(fiber.StatusForbidden, constants.ErrNotOrganization)
+	}
+	return &organizationCaller{organizationID: actorID, memberID: actorID, role: constants.OrganizationRoleOwner}, nil
+}
+
+func (s *organizationService) Invite(c *fiber.Ctx, req *validation.CreateOrganizationInvitationRequest) (*model.OrganizationInvitation, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	caller, err := s.organizationFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	if !model.CanManageOrganizationRole(caller.role, req.Role) {
+		return nil, fiber.NewError(fiber.StatusForbidden, constants.ErrOrganizationRoleNotAllowed)
+	}
+
+	var invitation *model.OrganizationInvitation
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		identifier, err := s.identifierRepo.FindByValue(ctx, tx, s.namespaceService.Canonical(req.Identifier))
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusNotFound, constants.ErrInviteeNotFound)
+			}
+			return err
+		}
+		if identifier.EntityType != constants.EntityTypeActor {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrInviteeNotFound)
+		}
+
+		invitee, err := s.actorRepo.FindByID(ctx, tx, identifier.EntityID)
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusNotFound, constants.ErrInviteeNotFound)
+			}
+			return err
+		}
+		if invitee.EntityType != constants.EntityTypeIndividual {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrInviteeNotIndividual)
+		}
+
+		if _, err := s.orgRepo.FindMember(ctx, tx, caller.organizationID, invitee.ActorID); err == nil {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrAlreadyOrganizationMember)
+		} else if !utils.IsNotFoundError(err) {
+			return err
+		}
+
+		now := time.Now()
+		pending, err := s.orgRepo.ExistsPendingInvitation(ctx, tx, caller.organizationID, invitee.ActorID, now)
+		if err != nil {
+			return err
+		}
+		if pending {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrOrganizationInvitationPending)
+		}
+
+		organizationIdentifier := ""
+		if primary, err := s.identifierRepo.FindByActorID(ctx, tx, caller.organizationID); err != nil {
+			return err
+		} else if primary != nil {
+			organizationIdentifier = primary.Identifier
+		}
+
+		invitation = &model.OrganizationInvitation{
+			OrganizationID:         caller.organizationID,
+			OrganizationIdentifier: organizationIdentifier,
+			InviteeID:              invitee.ActorID,
+			InviteeIdentifier:      identifier.Identifier,
+			Role:                   req.Role,
+			InvitedBy:              caller.memberID,
+			ExpiresAt:              now.Add(constants.OrganizationInvitationTTL * 24 * time.Hour),
+		}
+		return s.orgRepo.CreateInvitation(ctx, tx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s invited actor %s to organization %s as %s",
+		caller.memberID, invitation.InviteeID, caller.organizationID, invitation.Role)
+	return invitation, nil
+}
+
+func (s *organizationService) ListInvitations(c *fiber.Ctx) ([]model.OrganizationInvitation, error) {
+	caller, err := s.organizationFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListInvitations(c.Context(), s.db, caller.organizationID, constants.OrganizationInvitationListLimit)
+}
+
+func (s *organizationService) RevokeInvitation(c *fiber.Ctx, req *validation.OrganizationInvitationRequest) (*model.OrganizationInvitation, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	caller, err := s.organizationFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var invitation *model.OrganizationInvitation
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		var err error
+		invitation, err = s.orgRepo.LockInvitation(ctx, tx, uuid.MustParse(req.InvitationID))
+		if err != nil {
+			return err
+		}
+		// Invitations of other organizations are reported as missing rather than forbidden
+		if invitation.OrganizationID != caller.organizationID {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationInvitationNotFound)
+		}
+		if !model.CanManageOrganizationRole(caller.role, invitation.Role) {
+			return fiber.NewError(fiber.StatusForbidden, constants.ErrOrganizationRoleNotAllowed)
+		}
+
+		now := time.Now()
+		if invitation.Status(now) != constants.OrganizationInvitationStatusPending {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrOrganizationInvitationClosed)
+		}
+
+		invitation.RevokedAt = &now
+		return s.orgRepo.UpdateInvitation(ctx, tx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s revoked organization invitation %s", caller.memberID, invitation.InvitationID)
+	return invitation, nil
+}
+
+func (s *organizationService) ListMembers(c *fiber.Ctx) ([]model.OrganizationMember, error) {
+	caller, err := s.organizationFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMembers(c.Context(), s.db, caller.organizationID)
+}
+
+func (s *organizationService) UpdateMemberRole(c *fiber.Ctx, req *validation.UpdateOrganizationMemberRequest) (*model.OrganizationMember, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	caller, err := s.organizationFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *model.OrganizationMember
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		var err error
+		member, err = s.orgRepo.LockMember(ctx, tx, caller.organizationID, uuid.MustParse(req.ActorID))
+		if err != nil {
+			return err
+		}
+		// Both the role held and the role granted must be within the reach of the caller
+		if !model.CanManageOrganizationRole(caller.role, member.Role) || !model.CanManageOrganizationRole(caller.role, req.Role) {
+			return fiber.NewError(fiber.StatusForbidden, constants.ErrOrganizationRoleNotAllowed)
+		}
+
+		if req.Role != constants.OrganizationRoleOwner {
+			if err := s.keepAnotherOwner(ctx, tx, member); err != nil {
+				return err
+			}
+		}
+
+		member.Role = req.Role
+		return s.orgRepo.UpdateMember(ctx, tx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s changed the role of actor %s in organization %s to %s",
+		caller.memberID, member.ActorID, caller.organizationID, member.Role)
+	return member, nil
+}
+
+func (s *organizationService) RemoveMember(c *fiber.Ctx, req *validation.OrganizationMemberRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	caller, err := s.organizationFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	actorID := uuid.MustParse(req.ActorID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		member, err := s.orgRepo.LockMember(ctx, tx, caller.organizationID, actorID)
+		if err != nil {
+			return err
+		}
+		if !model.CanManageOrganizationRole(caller.role, member.Role) {
+			return fiber.NewError(fiber.StatusForbidden, constants.ErrOrganizationRoleNotAllowed)
+		}
+		if err := s.keepAnotherOwner(ctx, tx, member); err != nil {
+			return err
+		}
+		return s.orgRepo.DeleteMember(ctx, tx, member)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Infof("Actor %s removed actor %s from organization %s", caller.memberID, actorID, caller.organizationID)
+	return nil
+}
+
+// keepAnotherOwner refuses to let an owner give up the role when no other member holds it, so the
+// members of an organization never lose the last of its owners
+func (s *organizationService) keepAnotherOwner(ctx context.Context, tx *gorm.DB, member *model.OrganizationMember) error {
+	if member.Role != constants.OrganizationRoleOwner {
+		return nil
+	}
+
+	owners, err := s.orgRepo.LockMembersWithRole(ctx, tx, member.OrganizationID, constants.OrganizationRoleOwner)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		if owner.ActorID != member.ActorID {
+			return nil
+		}
+	}
+	return fiber.NewError(fiber.StatusConflict, constants.ErrLastOrganizationOwner)
+}
+
+func (s *organizationService) ListMyInvitations(c *fiber.Ctx) ([]model.OrganizationInvitation, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListPendingInvitations(c.Context(), s.db, actorID, time.Now())
+}
+
+func (s *organizationService) AcceptInvitation(c *fiber.Ctx, req *validation.OrganizationInvitationRequest) (*model.OrganizationMember, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var member *model.OrganizationMember
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		invitation, err := s.lockOwnInvitation(c, tx, actorID, req.InvitationID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		invitation.AcceptedAt = &now
+		if err := s.orgRepo.UpdateInvitation(ctx, tx, invitation); err != nil {
+			return err
+		}
+
+		member = &model.OrganizationMember{
+			OrganizationID: invitation.OrganizationID,
+			ActorID:        actorID,
+			Role:           invitation.Role,
+			InvitationID:   &invitation.InvitationID,
+		}
+		return s.orgRepo.CreateMember(ctx, tx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s joined organization %s as %s", actorID, member.OrganizationID, member.Role)
+	return member, nil
+}
+
+func (s *organizationService) DeclineInvitation(c *fiber.Ctx, req *validation.OrganizationInvitationRequest) (*model.OrganizationInvitation, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var invitation *model.OrganizationInvitation
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if invitation, err = s.lockOwnInvitation(c, tx, actorID, req.InvitationID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		invitation.DeclinedAt = &now
+		return s.orgRepo.UpdateInvitation(c.Context(), tx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s declined organization invitation %s", actorID, invitation.InvitationID)
+	return invitation, nil
+}
+
+// lockOwnInvitation locks a pending invitation addressed to the actor. Invitations addressed to
+// other actors are reported as missing.
+func (s *organizationService) lockOwnInvitation(c *fiber.Ctx, tx *gorm.DB, actorID uuid.UUID, invitationID string) (*model.OrganizationInvitation, error) {
+	invitation, err := s.orgRepo.LockInvitation(c.Context(), tx, uuid.MustParse(invitationID))
+	if err != nil {
+		return nil, err
+	}
+	if invitation.InviteeID != actorID {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationInvitationNotFound)
+	}
+	if invitation.Status(time.Now()) != constants.OrganizationInvitationStatusPending {
+		return nil, fiber.NewError(fiber.StatusConflict, constants.ErrOrganizationInvitationClosed)
+	}
+	return invitation, nil
+}
+
+func (s *organizationService) ListMemberships(c *fiber.Ctx) ([]model.OrganizationMember, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListMemberships(c.Context(), s.db, actorID)
+}
+
+func (s *organizationService) LeaveOrganization(c *fiber.Ctx, req *validation.OrganizationRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	organizationID := uuid.MustParse(req.OrganizationID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		member, err := s.orgRepo.LockMember(ctx, tx, organizationID, actorID)
+		if err != nil {
+			return err
+		}
+		if err := s.keepAnotherOwner(ctx, tx, member); err != nil {
+			return err
+		}
+		return s.orgRepo.DeleteMember(ctx, tx, member)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.Infof("Actor %s left organization %s", actorID, organizationID)
+	return nil
+}
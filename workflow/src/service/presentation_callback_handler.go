@@ -0,0 +1,132 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/netguard"
+	"app/src/queue"
+	"app/src/repository"
+	"app/src/response"
+	"app/src/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// PresentationCallbackHandler posts the outcome of completed OID4VP requests to the verifier's
+// callback URL. Payloads are signed like webhook deliveries, with the secret returned when the
+// request was created. Failed attempts are retried by the job queue with exponential backoff; the
+// callback is marked failed once the job gives up.
+type PresentationCallbackHandler struct {
+	log              *logrus.Logger
+	db               *gorm.DB
+	presentationRepo repository.PresentationRequestRepository
+	client           *http.Client
+}
+
+// NewPresentationCallbackHandler creates a new presentation callback handler
+func NewPresentationCallbackHandler(
+	log *logrus.Logger,
+	db *gorm.DB,
+	presentationRepo repository.PresentationRequestRepository,
+) *PresentationCallbackHandler {
+	return &PresentationCallbackHandler{
+		log:              log,
+		db:               db,
+		presentationRepo: presentationRepo,
+		// Callback URLs are chosen by verifiers, so internal addresses are refused on every
+		// connection as for webhooks
+		client: netguard.NewClient(constants.WebhookDeliveryTimeout * time.Second),
+	}
+}
+
+// Handle implements queue.Handler
+func (h *PresentationCallbackHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("presentation callback job has no request"))
+	}
+
+	request, err := h.presentationRepo.FindByID(ctx, h.db, *job.ResourceID)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+	if request.CallbackURL == nil || request.CompletedAt == nil {
+		return nil, queue.Permanent(fmt.Errorf("presentation request %s has no callback to send", request.RequestID))
+	}
+
+	status, sendErr := h.send(ctx, request)
+	switch {
+	case sendErr == nil:
+		h.record(ctx, request, constants.CallbackStatusDelivered)
+	case queue.IsPermanent(sendErr) || job.Attempts >= job.MaxAttempts:
+		h.record(ctx, request, constants.CallbackStatusFailed)
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	return map[string]interface{}{
+		"requestId":      request.RequestID.String(),
+		"responseStatus": status,
+	}, nil
+}
+
+// send posts the signed outcome to the callback URL and returns the response status
+func (h *PresentationCallbackHandler) send(ctx context.Context, request *model.PresentationRequest) (int, error) {
+	body, err := json.Marshal(response.PresentationCallbackPayload{
+		RequestID:   request.RequestID.String(),
+		Status:      request.Status,
+		Result:      request.Result.Data(),
+		CompletedAt: request.CompletedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return 0, queue.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *request.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, queue.Permanent(err)
+	}
+
+	req.Header.Set(constants.HTTPHeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(constants.HTTPHeaderWebhookEvent, constants.PresentationCallbackEvent)
+	// Requests created before callbacks were signed have no secret
+	if request.CallbackSecret != nil {
+		timestamp := time.Now().Unix()
+		req.Header.Set(constants.HTTPHeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(constants.HTTPHeaderWebhookSignature, utils.WebhookSignature(*request.CallbackSecret, timestamp, body))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		if errors.Is(err, netguard.ErrForbiddenAddress) {
+			return 0, queue.Permanent(err)
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// record stores the final callback status on the presentation request
+func (h *PresentationCallbackHandler) record(ctx context.Context, request *model.PresentationRequest, status string) {
+	if err := h.presentationRepo.UpdateCallbackStatus(ctx, h.db, request.RequestID, status); err != nil {
+		h.log.Errorf("Failed to record callback status for request %s: %v", request.RequestID, err)
+	}
+}
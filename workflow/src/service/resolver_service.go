@@ -0,0 +1,189 @@
+package service
+
+import (
+	"app/src/cache"
+	"app/src/config"
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/validation"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ResolverService defines the interface for resolving universal identifiers in bulk. Resolutions
+// are signed by the platform key and kept in an in-process LRU cache, which is invalidated when an
+// identifier is released or its actor's master key changes.
+type ResolverService interface {
+	ResolveBatch(c *fiber.Ctx, req *validation.ResolveBatchRequest) (*ResolveBatchResult, error)
+
+	// InvalidateIdentifiers drops the cached resolutions of the given canonical identifiers
+	InvalidateIdentifiers(identifiers ...string)
+
+	// InvalidateActor drops the cached resolutions of every identifier of an actor
+	InvalidateActor(actorID uuid.UUID)
+}
+
+// ResolveBatchResult holds the resolutions of a batch in request order and the requested
+// identifiers nobody holds
+type ResolveBatchResult struct {
+	Resolved []BatchResolution
+	NotFound []string
+}
+
+// BatchResolution is the resolution of one requested identifier
+type BatchResolution struct {
+	Requested string
+	*SignedResolution
+}
+
+// SignedResolution is a resolved identifier with the JWS over it
+type SignedResolution struct {
+	model.ResolvedIdentifier
+	Signature string
+}
+
+// ResolutionClaims are the claims of a signed resolution: the platform states that at the time of
+// issue the subject identifier was held by the actor with the DID and master public key given
+type ResolutionClaims struct {
+	jwt.RegisteredClaims
+	DID             string `json:"did"`
+	MasterPublicKey string `json:"master_public_key"`
+}
+
+type resolverService struct {
+	cfg            *config.Config
+	log            *logrus.Logger
+	db             *gorm.DB
+	validate       *validator.Validate
+	signer         *keys.Signer
+	namespaces     NamespaceService
+	identifierRepo repository.IdentifierRepository
+	cache          *cache.LRU[string, *SignedResolution]
+
+	// generation counts invalidations, so a resolution read before an invalidation is not cached
+	// after it
+	generation atomic.Uint64
+}
+
+// NewResolverService creates a new resolver service instance
+func NewResolverService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	signer *keys.Signer,
+	namespaces NamespaceService,
+	identifierRepo repository.IdentifierRepository,
+) ResolverService {
+	return &resolverService{
+		cfg:            cfg,
+		log:            log,
+		db:             db,
+		validate:       validate,
+		signer:         signer,
+		namespaces:     namespaces,
+		identifierRepo: identifierRepo,
+		cache:          cache.NewLRU[string, *SignedResolution](cfg.ResolveCacheSize, time.Duration(cfg.ResolveCacheTTL)*time.Second),
+	}
+}
+
+func (s *resolverService) ResolveBatch(c *fiber.Ctx, req *validation.ResolveBatchRequest) (*ResolveBatchResult, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	canonical := make([]string, len(req.UniversalIdentifiers))
+	found := make(map[string]*SignedResolution, len(req.UniversalIdentifiers))
+	var misses []string
+	for i, requested := range req.UniversalIdentifiers {
+		canonical[i] = s.namespaces.Canonical(requested)
+		if _, seen := found[canonical[i]]; seen {
+			continue
+		}
+		resolution, ok := s.cache.Get(canonical[i])
+		found[canonical[i]] = resolution
+		if !ok {
+			misses = append(misses, canonical[i])
+		}
+	}
+
+	if len(misses) > 0 {
+		generation := s.generation.Load()
+		resolved, err := s.identifierRepo.ResolveMany(c.Context(), s.db, misses)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, identifier := range resolved {
+			resolution, err := s.sign(identifier)
+			if err != nil {
+				return nil, err
+			}
+			found[identifier.Identifier] = resolution
+			if s.generation.Load() == generation {
+				s.cache.Add(identifier.Identifier, resolution)
+			}
+		}
+	}
+
+	result := &ResolveBatchResult{
+		Resolved: make([]BatchResolution, 0, len(req.UniversalIdentifiers)),
+		NotFound: []string{},
+	}
+	for i, requested := range req.UniversalIdentifiers {
+		if resolution := found[canonical[i]]; resolution != nil {
+			result.Resolved = append(result.Resolved, BatchResolution{Requested: requested, SignedResolution: resolution})
+		} else {
+			result.NotFound = append(result.NotFound, requested)
+		}
+	}
+
+	s.log.Infof("Resolved %d of %d identifiers (%d from cache)",
+		len(result.Resolved), len(req.UniversalIdentifiers), len(found)-len(misses))
+	return result, nil
+}
+
+// sign issues the platform's signed statement of a resolution
+func (s *resolverService) sign(identifier model.ResolvedIdentifier) (*SignedResolution, error) {
+	now := time.Now()
+	signature, err := s.signer.Sign(ResolutionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.cfg.IssuerURL,
+			Subject:   identifier.Identifier,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(constants.ResolutionProofValidity * time.Second)),
+		},
+		DID:             identifier.DID,
+		MasterPublicKey: identifier.MasterPublicKey,
+	}, map[string]interface{}{"typ": constants.ResolutionJWTType})
+	if err != nil {
+		return nil, err
+	}
+	return &SignedResolution{ResolvedIdentifier: identifier, Signature: signature}, nil
+}
+
+func (s *resolverService) InvalidateIdentifiers(identifiers ...string) {
+	s.generation.Add(1)
+	for _, identifier := range identifiers {
+		s.cache.Remove(identifier)
+	}
+}
+
+func (s *resolverService) InvalidateActor(actorID uuid.UUID) {
+	s.generation.Add(1)
+	removed := s.cache.RemoveFunc(func(_ string, resolution *SignedResolution) bool {
+		return resolution.ActorID == actorID
+	})
+	if removed > 0 {
+		s.log.Debugf("Dropped %d cached resolutions of actor %s", removed, actorID)
+	}
+}
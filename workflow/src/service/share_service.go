@@ -0,0 +1,444 @@
+package service
+
+import (
+	"app/src/adapter"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ShareService defines the interface for consent-based credential sharing.
+// Owners grant another actor time-bound, revocable read access to selected credentials;
+// every read by the grantee is recorded in the owner's access log.
+type ShareService interface {
+	CreateGrant(c *fiber.Ctx, req *validation.CreateShareGrantRequest) (*model.ShareGrant, error)
+	ListGrants(c *fiber.Ctx, req *validation.ListShareGrantsRequest) ([]model.ShareGrant, string, error)
+	RevokeGrant(c *fiber.Ctx, req *validation.ShareGrantIDRequest) (*model.ShareGrant, error)
+	ListAccessLog(c *fiber.Ctx, req *validation.ListShareAccessLogRequest) ([]model.ShareAccessLog, string, error)
+	ListReceivedGrants(c *fiber.Ctx, req *validation.ListShareGrantsRequest) ([]model.ShareGrant, string, error)
+	ListSharedCredentials(c *fiber.Ctx, req *validation.ShareGrantIDRequest) (*model.ShareGrant, []model.Token, error)
+	GetSharedCredential(c *fiber.Ctx, req *validation.SharedCredentialRequest) (*model.Token, error)
+	GetSharedDocument(c *fiber.Ctx, req *validation.SharedDocumentRequest) (*SharedDocument, error)
+}
+
+// SharedDocument is a document linked to a shared credential together with a time-limited download URL
+type SharedDocument struct {
+	Document    *model.Document
+	DownloadURL string
+	ExpiresAt   time.Time
+}
+
+type shareService struct {
+	log             *logrus.Logger
+	db              *gorm.DB
+	validate        *validator.Validate
+	grantRepo       repository.ShareGrantRepository
+	accessLogRepo   repository.ShareAccessLogRepository
+	credentialsRepo repository.CredentialsRepository
+	documentRepo    repository.DocumentRepository
+	identifierRepo  repository.IdentifierRepository
+	namespaces      NamespaceService
+	storage         *adapter.LazyStorage
+}
+
+// NewShareService creates a new share service instance.
+// The storage provider is initialized lazily on the first document download.
+func NewShareService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	grantRepo repository.ShareGrantRepository,
+	accessLogRepo repository.ShareAccessLogRepository,
+	credentialsRepo repository.CredentialsRepository,
+	documentRepo repository.DocumentRepository,
+	identifierRepo repository.IdentifierRepository,
+	namespaces NamespaceService,
+	storage *adapter.LazyStorage,
+) ShareService {
+	return &shareService{
+		log:             log,
+		db:              db,
+		validate:        validate,
+		grantRepo:       grantRepo,
+		accessLogRepo:   accessLogRepo,
+		credentialsRepo: credentialsRepo,
+		documentRepo:    documentRepo,
+		identifierRepo:  identifierRepo,
+		namespaces:      namespaces,
+		storage:         storage,
+	}
+}
+
+func (s *shareService) CreateGrant(c *fiber.Ctx, req *validation.CreateShareGrantRequest) (*model.ShareGrant, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	ownerID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+	if err != nil || !expiresAt.After(now) || expiresAt.After(now.AddDate(0, 0, constants.MaxShareDuration)) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidShareExpiry)
+	}
+
+	tokenIDs := make([]uuid.UUID, 0, len(req.CredentialIDs))
+	for _, credentialID := range req.CredentialIDs {
+		tokenID, err := utils.ParseUUID(credentialID, "credential")
+		if err != nil {
+			return nil, err
+		}
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+
+	grant := &model.ShareGrant{
+		OwnerID:   ownerID,
+		ExpiresAt: expiresAt,
+	}
+	if req.Purpose != "" {
+		grant.Purpose = &req.Purpose
+	}
+	for _, tokenID := range tokenIDs {
+		grant.Tokens = append(grant.Tokens, model.ShareGrantToken{TokenID: tokenID})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		grantee, err := s.identifierRepo.FindByValue(c.Context(), tx, s.namespaces.Canonical(req.Grantee))
+		if err != nil {
+			if utils.IsNotFoundError(err) {
+				return fiber.NewError(fiber.StatusNotFound, constants.ErrGranteeNotFound)
+			}
+			return err
+		}
+		if grantee.EntityType != constants.EntityTypeActor {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrGranteeNotFound)
+		}
+		if grantee.EntityID == ownerID {
+			return fiber.NewError(fiber.StatusBadRequest, constants.ErrCannotShareWithSelf)
+		}
+		grant.GranteeID = grantee.EntityID
+		grant.GranteeIdentifier = grantee.Identifier
+
+		// Only the caller's own credentials can be shared
+		tokens, err := s.credentialsRepo.FindByIDsForAccount(c.Context(), tx, tokenIDs, ownerID)
+		if err != nil {
+			return err
+		}
+		if len(tokens) != len(tokenIDs) {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
+		}
+
+		owner, err := s.identifierRepo.FindByActorID(c.Context(), tx, ownerID)
+		if err != nil {
+			return err
+		}
+		if owner != nil {
+			grant.OwnerIdentifier = owner.Identifier
+		}
+
+		return s.grantRepo.Create(c.Context(), tx, grant)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+func (s *shareService) ListGrants(c *fiber.Ctx, req *validation.ListShareGrantsRequest) ([]model.ShareGrant, string, error) {
+	ownerID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.listGrants(c, req, repository.ShareGrantFilter{OwnerID: &ownerID})
+}
+
+func (s *shareService) ListReceivedGrants(c *fiber.Ctx, req *validation.ListShareGrantsRequest) ([]model.ShareGrant, string, error) {
+	granteeID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return s.listGrants(c, req, repository.ShareGrantFilter{GranteeID: &granteeID})
+}
+
+// listGrants retrieves one page of grants for the side of the grant set in the filter
+func (s *shareService) listGrants(c *fiber.Ctx, req *validation.ListShareGrantsRequest, filter repository.ShareGrantFilter) ([]model.ShareGrant, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	cursor, err := utils.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.Status = req.Status
+	filter.At = time.Now().UTC()
+	filter.Cursor = cursor
+	filter.Limit = utils.PageLimit(req.Limit)
+
+	grants, err := s.grantRepo.List(c.Context(), s.db, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One extra row was fetched to detect whether another page exists
+	nextCursor := ""
+	if len(grants) > filter.Limit {
+		grants = grants[:filter.Limit]
+		last := grants[len(grants)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.GrantID)
+	}
+
+	return grants, nextCursor, nil
+}
+
+func (s *shareService) RevokeGrant(c *fiber.Ctx, req *validation.ShareGrantIDRequest) (*model.ShareGrant, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	ownerID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	grantID, err := utils.ParseUUID(req.GrantID, "grant")
+	if err != nil {
+		return nil, err
+	}
+
+	var grant *model.ShareGrant
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		grant, err = s.grantRepo.LockByIDForOwner(c.Context(), tx, grantID, ownerID)
+		if err != nil {
+			return err
+		}
+		if grant.RevokedAt != nil {
+			return fiber.NewError(fiber.StatusConflict, constants.ErrShareGrantAlreadyRevoked)
+		}
+
+		revokedAt := time.Now().UTC()
+		if err := s.grantRepo.Revoke(c.Context(), tx, grantID, revokedAt); err != nil {
+			return err
+		}
+		grant.RevokedAt = &revokedAt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+func (s *shareService) ListAccessLog(c *fiber.Ctx, req *validation.ListShareAccessLogRequest) ([]model.ShareAccessLog, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	ownerID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursor, err := utils.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter := repository.ShareAccessLogFilter{
+		OwnerID: ownerID,
+		Cursor:  cursor,
+		Limit:   utils.PageLimit(req.Limit),
+	}
+	if req.GrantID != "" {
+		grantID, err := utils.ParseUUID(req.GrantID, "grant")
+		if err != nil {
+			return nil, "", err
+		}
+		filter.GrantID = &grantID
+	}
+
+	entries, err := s.accessLogRepo.List(c.Context(), s.db, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+		last := entries[len(entries)-1]
+		nextCursor = utils.EncodeCursor(last.AccessedAt, last.LogID)
+	}
+
+	return entries, nextCursor, nil
+}
+
+func (s *shareService) ListSharedCredentials(c *fiber.Ctx, req *validation.ShareGrantIDRequest) (*model.ShareGrant, []model.Token, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, nil, err
+	}
+
+	grantID, err := utils.ParseUUID(req.GrantID, "grant")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var grant *model.ShareGrant
+	var tokens []model.Token
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		grant, err = s.findActiveGrant(c, tx, grantID)
+		if err != nil {
+			return err
+		}
+
+		// Credentials deleted by the owner since the grant was made are no longer shared
+		tokens, err = s.credentialsRepo.FindByIDsForAccount(c.Context(), tx, grant.TokenIDs(), grant.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		return s.recordAccess(c, tx, grant, constants.ShareAccessList, nil, nil)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return grant, tokens, nil
+}
+
+func (s *shareService) GetSharedCredential(c *fiber.Ctx, req *validation.SharedCredentialRequest) (*model.Token, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	var token *model.Token
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		grant, shared, err := s.findSharedCredential(c, tx, req)
+		if err != nil {
+			return err
+		}
+		token = shared
+
+		return s.recordAccess(c, tx, grant, constants.ShareAccessCredential, &token.TokenID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *shareService) GetSharedDocument(c *fiber.Ctx, req *validation.SharedDocumentRequest) (*SharedDocument, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	documentID, err := utils.ParseUUID(req.DocumentID, "document")
+	if err != nil {
+		return nil, err
+	}
+
+	var document *model.Document
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		grant, token, err := s.findSharedCredential(c, tx, &req.SharedCredentialRequest)
+		if err != nil {
+			return err
+		}
+
+		// Documents are shared only through the credentials they are linked to
+		if !slices.ContainsFunc(token.Documents, func(link model.TokenDocument) bool { return link.DocumentID == documentID }) {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrDocumentNotFound)
+		}
+		document, err = s.documentRepo.FindByIDForAccount(c.Context(), tx, documentID, grant.OwnerID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusNotFound, constants.ErrDocumentNotFound)
+		}
+
+		return s.recordAccess(c, tx, grant, constants.ShareAccessDocument, &token.TokenID, &document.DocumentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		s.log.Errorf("%+v", err)
+		return nil, fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToCreateStorageProvider)
+	}
+
+	expiry := constants.SharedDocumentURLExpiry * time.Minute
+	downloadURL, err := storageProvider.PresignedURL(c.Context(), document.StoragePath, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign shared document URL: %w", err)
+	}
+
+	return &SharedDocument{
+		Document:    document,
+		DownloadURL: downloadURL,
+		ExpiresAt:   time.Now().UTC().Add(expiry),
+	}, nil
+}
+
+// findActiveGrant loads a grant issued to the caller that is neither revoked nor expired
+func (s *shareService) findActiveGrant(c *fiber.Ctx, tx *gorm.DB, grantID uuid.UUID) (*model.ShareGrant, error) {
+	granteeID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	return s.grantRepo.FindActiveForGrantee(c.Context(), tx, grantID, granteeID, time.Now().UTC())
+}
+
+// findSharedCredential loads a credential covered by an active grant issued to the caller
+func (s *shareService) findSharedCredential(c *fiber.Ctx, tx *gorm.DB, req *validation.SharedCredentialRequest) (*model.ShareGrant, *model.Token, error) {
+	grantID, err := utils.ParseUUID(req.GrantID, "grant")
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenID, err := utils.ParseUUID(req.CredentialID, "credential")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grant, err := s.findActiveGrant(c, tx, grantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !slices.Contains(grant.TokenIDs(), tokenID) {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
+	}
+
+	token, err := s.credentialsRepo.FindByIDForAccount(c.Context(), tx, tokenID, grant.OwnerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return grant, token, nil
+}
+
+// recordAccess appends an entry to the owner's access log for a read made under a grant
+func (s *shareService) recordAccess(c *fiber.Ctx, tx *gorm.DB, grant *model.ShareGrant, action string, tokenID, documentID *uuid.UUID) error {
+	return s.accessLogRepo.Create(c.Context(), tx, &model.ShareAccessLog{
+		GrantID:    grant.GrantID,
+		OwnerID:    grant.OwnerID,
+		GranteeID:  grant.GranteeID,
+		Action:     action,
+		TokenID:    tokenID,
+		DocumentID: documentID,
+		IPAddress:  c.IP(),
+		UserAgent:  utils.Truncate(c.Get(constants.HTTPHeaderUserAgent), 512),
+	})
+}
@@ -0,0 +1,409 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// TrustedIssuerService defines the interface for the trusted issuer registry
+type TrustedIssuerService interface {
+	AddIssuer(c *fiber.Ctx, req *validation.TrustedIssuerRequest) (*model.TrustedIssuer, error)
+	UpdateIssuer(c *fiber.Ctx, req *validation.UpdateTrustedIssuerRequest) (*model.TrustedIssuer, error)
+	GetIssuer(c *fiber.Ctx, req *validation.TrustedIssuerIDRequest) (*model.TrustedIssuer, error)
+	ListIssuers(c *fiber.Ctx, req *validation.ListTrustedIssuersRequest) ([]model.TrustedIssuer, error)
+	DeleteIssuer(c *fiber.Ctx, req *validation.TrustedIssuerIDRequest) error
+	ImportTrustList(c *fiber.Ctx, content []byte) (*validation.TrustList, error)
+	Evaluate(ctx context.Context, issuerDID, verificationType, jurisdiction string) (*TrustEvaluation, error)
+	Policy() string
+}
+
+// TrustEvaluation records whether an issuer may attest a verification type
+type TrustEvaluation struct {
+	Trusted     bool       `json:"trusted"`
+	Reason      string     `json:"reason"`
+	IssuerID    *uuid.UUID `json:"issuerId,omitempty"`
+	EvaluatedAt time.Time  `json:"evaluatedAt"`
+}
+
+// trustListAlgorithms lists the JWS algorithms accepted on signed trust lists
+var trustListAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// trustedIssuerService implements TrustedIssuerService with constructor-based dependency injection
+type trustedIssuerService struct {
+	log               *logrus.Logger
+	db                *gorm.DB
+	validate          *validator.Validate
+	trustedIssuerRepo repository.TrustedIssuerRepository
+	policy            string
+	trustListKeyFile  string
+	trustListKey      interface{}
+	trustListKeyOnce  sync.Once
+	trustListKeyErr   error
+}
+
+// NewTrustedIssuerService creates a new trusted issuer service instance
+func NewTrustedIssuerService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	trustedIssuerRepo repository.TrustedIssuerRepository,
+) TrustedIssuerService {
+	return &trustedIssuerService{
+		log:               log,
+		db:                db,
+		validate:          validate,
+		trustedIssuerRepo: trustedIssuerRepo,
+		policy:            cfg.TrustPolicy,
+		trustListKeyFile:  cfg.TrustListKeyFile,
+	}
+}
+
+func (s *trustedIssuerService) Policy() string {
+	return s.policy
+}
+
+func (s *trustedIssuerService) AddIssuer(c *fiber.Ctx, req *validation.TrustedIssuerRequest) (*model.TrustedIssuer, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	validFrom, validUntil, err := parseValidityWindow(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := &model.TrustedIssuer{
+		IssuerDID:         req.IssuerDID,
+		Name:              req.Name,
+		VerificationTypes: datatypes.NewJSONSlice(req.VerificationTypes),
+		Jurisdictions:     datatypes.NewJSONSlice(normalizeJurisdictions(req.Jurisdictions)),
+		ValidFrom:         validFrom,
+		ValidUntil:        validUntil,
+		Status:            constants.TrustedIssuerStatusActive,
+		Source:            constants.TrustedIssuerSourceManual,
+	}
+
+	if err := s.trustedIssuerRepo.Create(c.Context(), s.db, issuer); err != nil {
+		s.log.Errorf("Failed to create trusted issuer: %+v", err)
+		return nil, err
+	}
+
+	s.log.Infof("Registered trusted issuer %s for types %v", issuer.IssuerDID, req.VerificationTypes)
+	return issuer, nil
+}
+
+func (s *trustedIssuerService) UpdateIssuer(c *fiber.Ctx, req *validation.UpdateTrustedIssuerRequest) (*model.TrustedIssuer, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	issuerID, err := utils.ParseUUID(req.IssuerID, "issuer")
+	if err != nil {
+		return nil, err
+	}
+
+	var issuer *model.TrustedIssuer
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		ctx := c.Context()
+
+		issuer, err = s.trustedIssuerRepo.FindByID(ctx, tx, issuerID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.applyIssuerUpdate(issuer, req); err != nil {
+			return err
+		}
+
+		return s.trustedIssuerRepo.Update(ctx, tx, issuer)
+	})
+
+	return issuer, err
+}
+
+// applyIssuerUpdate copies the provided fields of an update request onto the issuer
+func (s *trustedIssuerService) applyIssuerUpdate(issuer *model.TrustedIssuer, req *validation.UpdateTrustedIssuerRequest) error {
+	if req.Name != "" {
+		issuer.Name = req.Name
+	}
+	if req.VerificationTypes != nil {
+		issuer.VerificationTypes = datatypes.NewJSONSlice(req.VerificationTypes)
+	}
+	if req.Jurisdictions != nil {
+		issuer.Jurisdictions = datatypes.NewJSONSlice(normalizeJurisdictions(req.Jurisdictions))
+	}
+	if req.Status != "" {
+		issuer.Status = req.Status
+	}
+
+	// An empty string clears a bound, an omitted field leaves it untouched
+	if req.ValidFrom != nil {
+		validFrom, err := parseOptionalTime(*req.ValidFrom)
+		if err != nil {
+			return err
+		}
+		issuer.ValidFrom = validFrom
+	}
+	if req.ValidUntil != nil {
+		validUntil, err := parseOptionalTime(*req.ValidUntil)
+		if err != nil {
+			return err
+		}
+		issuer.ValidUntil = validUntil
+	}
+
+	if issuer.ValidFrom != nil && issuer.ValidUntil != nil && !issuer.ValidFrom.Before(*issuer.ValidUntil) {
+		return fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidValidityWindow)
+	}
+
+	return nil
+}
+
+func (s *trustedIssuerService) GetIssuer(c *fiber.Ctx, req *validation.TrustedIssuerIDRequest) (*model.TrustedIssuer, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	issuerID, err := utils.ParseUUID(req.IssuerID, "issuer")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.trustedIssuerRepo.FindByID(c.Context(), s.db, issuerID)
+}
+
+func (s *trustedIssuerService) ListIssuers(c *fiber.Ctx, req *validation.ListTrustedIssuersRequest) ([]model.TrustedIssuer, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	issuers, err := s.trustedIssuerRepo.FindAll(c.Context(), s.db, req.Status)
+	if err != nil {
+		s.log.Errorf("Failed to retrieve trusted issuers: %+v", err)
+		return nil, err
+	}
+
+	return issuers, nil
+}
+
+func (s *trustedIssuerService) DeleteIssuer(c *fiber.Ctx, req *validation.TrustedIssuerIDRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	issuerID, err := utils.ParseUUID(req.IssuerID, "issuer")
+	if err != nil {
+		return err
+	}
+
+	return s.trustedIssuerRepo.Delete(c.Context(), s.db, issuerID)
+}
+
+func (s *trustedIssuerService) ImportTrustList(c *fiber.Ctx, content []byte) (*validation.TrustList, error) {
+	trustList, err := s.verifyTrustList(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build every entry before writing so a malformed entry aborts the whole import
+	issuers := make([]*model.TrustedIssuer, 0, len(trustList.Issuers))
+	for _, entry := range trustList.Issuers {
+		validFrom, validUntil, err := parseValidityWindow(entry.ValidFrom, entry.ValidUntil)
+		if err != nil {
+			return nil, err
+		}
+
+		issuers = append(issuers, &model.TrustedIssuer{
+			IssuerID:          uuid.Must(uuid.NewV7()),
+			IssuerDID:         entry.IssuerDID,
+			Name:              entry.Name,
+			VerificationTypes: datatypes.NewJSONSlice(entry.VerificationTypes),
+			Jurisdictions:     datatypes.NewJSONSlice(normalizeJurisdictions(entry.Jurisdictions)),
+			ValidFrom:         validFrom,
+			ValidUntil:        validUntil,
+			Status:            constants.TrustedIssuerStatusActive,
+			Source:            constants.TrustedIssuerSourceTrustList,
+		})
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, issuer := range issuers {
+			if err := s.trustedIssuerRepo.Upsert(c.Context(), tx, issuer); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Errorf("Failed to import trust list: %+v", err)
+		return nil, err
+	}
+
+	s.log.Infof("Imported trust list version %s with %d issuers", trustList.Version, len(issuers))
+	return trustList, nil
+}
+
+// verifyTrustList checks the JWS signature of a trust-list file and decodes its payload.
+// Both the compact and the JSON serializations of JWS are accepted.
+func (s *trustedIssuerService) verifyTrustList(content []byte) (*validation.TrustList, error) {
+	key, err := s.getTrustListKey()
+	if err != nil {
+		return nil, err
+	}
+
+	jws, err := jose.ParseSigned(strings.TrimSpace(string(content)), trustListAlgorithms)
+	if err != nil {
+		s.log.Warnf("Failed to parse trust list: %v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidTrustList)
+	}
+
+	payload, err := jws.Verify(key)
+	if err != nil {
+		s.log.Warnf("Trust list signature verification failed: %v", err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidTrustList)
+	}
+
+	var trustList validation.TrustList
+	if err := json.Unmarshal(payload, &trustList); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidTrustList)
+	}
+
+	if err := s.validate.Struct(&trustList); err != nil {
+		return nil, err
+	}
+
+	return &trustList, nil
+}
+
+// getTrustListKey loads the trust-list verification key lazily on first use
+func (s *trustedIssuerService) getTrustListKey() (interface{}, error) {
+	s.trustListKeyOnce.Do(func() {
+		if s.trustListKeyFile == "" {
+			s.trustListKeyErr = fiber.NewError(fiber.StatusInternalServerError, constants.ErrTrustListKeyNotConfigured)
+			return
+		}
+
+		pemBytes, err := os.ReadFile(s.trustListKeyFile)
+		if err != nil {
+			s.trustListKeyErr = fmt.Errorf("failed to read trust list key: %w", err)
+			return
+		}
+
+		block, _ := pem.Decode(pemBytes)
+		if block == nil {
+			s.trustListKeyErr = fmt.Errorf("failed to decode trust list key: no PEM block found")
+			return
+		}
+
+		s.trustListKey, s.trustListKeyErr = x509.ParsePKIXPublicKey(block.Bytes)
+	})
+	return s.trustListKey, s.trustListKeyErr
+}
+
+func (s *trustedIssuerService) Evaluate(ctx context.Context, issuerDID, verificationType, jurisdiction string) (*TrustEvaluation, error) {
+	issuer, err := s.trustedIssuerRepo.FindByDID(ctx, s.db, issuerDID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	evaluation := &TrustEvaluation{EvaluatedAt: now}
+	if issuer == nil {
+		evaluation.Reason = constants.TrustReasonUnknownIssuer
+		return evaluation, nil
+	}
+
+	evaluation.IssuerID = &issuer.IssuerID
+	evaluation.Reason = evaluateIssuer(issuer, verificationType, jurisdiction, now)
+	evaluation.Trusted = evaluation.Reason == constants.TrustReasonTrusted
+	return evaluation, nil
+}
+
+// evaluateIssuer returns the trust reason for a registered issuer at the given instant
+func evaluateIssuer(issuer *model.TrustedIssuer, verificationType, jurisdiction string, at time.Time) string {
+	switch {
+	case issuer.Status != constants.TrustedIssuerStatusActive:
+		return constants.TrustReasonSuspended
+	case !slices.Contains(issuer.VerificationTypes, verificationType):
+		return constants.TrustReasonTypeNotAllowed
+	case issuer.ValidFrom != nil && at.Before(*issuer.ValidFrom):
+		return constants.TrustReasonNotYetValid
+	case issuer.ValidUntil != nil && !at.Before(*issuer.ValidUntil):
+		return constants.TrustReasonExpired
+	case len(issuer.Jurisdictions) > 0 && !slices.Contains(issuer.Jurisdictions, strings.ToUpper(jurisdiction)):
+		return constants.TrustReasonOutOfJurisdiction
+	default:
+		return constants.TrustReasonTrusted
+	}
+}
+
+// parseValidityWindow parses optional RFC 3339 bounds and checks their order
+func parseValidityWindow(from, until string) (*time.Time, *time.Time, error) {
+	validFrom, err := parseOptionalTime(from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validUntil, err := parseOptionalTime(until)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if validFrom != nil && validUntil != nil && !validFrom.Before(*validUntil) {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidValidityWindow)
+	}
+
+	return validFrom, validUntil, nil
+}
+
+// parseOptionalTime parses an RFC 3339 timestamp, returning nil for an empty string
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidRequestBody)
+	}
+
+	parsed = parsed.UTC()
+	return &parsed, nil
+}
+
+// normalizeJurisdictions upper-cases ISO country codes so lookups are case-insensitive
+func normalizeJurisdictions(jurisdictions []string) []string {
+	normalized := make([]string, 0, len(jurisdictions))
+	for _, j := range jurisdictions {
+		normalized = append(normalized, strings.ToUpper(j))
+	}
+	return normalized
+}
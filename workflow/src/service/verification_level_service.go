@@ -0,0 +1,247 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// VerificationLevelService defines the interface for computing actor verification levels
+type VerificationLevelService interface {
+	// Recompute re-evaluates an actor's level inside tx and records a history entry when it changes.
+	// It returns nil history when the level is unchanged.
+	Recompute(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, reason string, tokenID *uuid.UUID) (*model.VerificationLevelHistory, error)
+	GetHistory(c *fiber.Ctx, actorID uuid.UUID) ([]model.VerificationLevelHistory, error)
+}
+
+// VerificationRule grants a level once every required verification type has a verified credential.
+// An empty Country matches actors in any country that has no rules of its own.
+type VerificationRule struct {
+	EntityType    string   `json:"entityType"`
+	Country       string   `json:"country,omitempty"`
+	Level         string   `json:"level"`
+	RequiredTypes []string `json:"requiredTypes"`
+}
+
+// VerificationRuleSet is an ordered collection of verification rules
+type VerificationRuleSet []VerificationRule
+
+// DefaultVerificationRules apply when no rules file is configured
+var DefaultVerificationRules = VerificationRuleSet{
+	{
+		EntityType:    constants.EntityTypeIndividual,
+		Level:         constants.VerificationLevelVerified,
+		RequiredTypes: []string{constants.VerificationTypeGovernmentID, constants.VerificationTypeProofOfAddress},
+	},
+	{
+		EntityType:    constants.EntityTypeBusiness,
+		Level:         constants.VerificationLevelVerified,
+		RequiredTypes: []string{constants.VerificationTypeBusinessRegistration},
+	},
+	{
+		EntityType:    constants.EntityTypeBusiness,
+		Level:         constants.VerificationLevelInstitutionVerified,
+		RequiredTypes: []string{constants.VerificationTypeBusinessRegistration, constants.VerificationTypeInstitutionAttestation},
+	},
+}
+
+// verificationLevelRank orders the levels from lowest to highest
+var verificationLevelRank = map[string]int{
+	constants.VerificationLevelUnverified:          0,
+	constants.VerificationLevelVerified:            1,
+	constants.VerificationLevelInstitutionVerified: 2,
+}
+
+// LoadVerificationRules reads a JSON rule set from path, falling back to the defaults when path is empty
+func LoadVerificationRules(path string) (VerificationRuleSet, error) {
+	if path == "" {
+		return DefaultVerificationRules, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read verification rules: %w", err)
+	}
+
+	var rules VerificationRuleSet
+	if err := json.Unmarshal(content, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse verification rules: %w", err)
+	}
+
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Validate checks that every rule names a known entity type and level and requires at least one type
+func (rules VerificationRuleSet) Validate() error {
+	for i, rule := range rules {
+		if rule.EntityType != constants.EntityTypeIndividual && rule.EntityType != constants.EntityTypeBusiness {
+			return fmt.Errorf("verification rule %d: unknown entity type %q", i, rule.EntityType)
+		}
+		if rank, ok := verificationLevelRank[rule.Level]; !ok || rank == 0 {
+			return fmt.Errorf("verification rule %d: invalid level %q", i, rule.Level)
+		}
+		if len(rule.RequiredTypes) == 0 {
+			return fmt.Errorf("verification rule %d: no required verification types", i)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the highest level whose rule is satisfied by the verified credential types.
+// Rules for the actor's country replace the country-independent rules of the same entity type.
+func (rules VerificationRuleSet) Evaluate(entityType, country string, verifiedTypes []string) string {
+	country = strings.ToUpper(country)
+
+	applicable := rules.matching(entityType, country)
+	if len(applicable) == 0 {
+		applicable = rules.matching(entityType, "")
+	}
+
+	level := constants.VerificationLevelUnverified
+	for _, rule := range applicable {
+		if verificationLevelRank[rule.Level] <= verificationLevelRank[level] {
+			continue
+		}
+		if containsAll(verifiedTypes, rule.RequiredTypes) {
+			level = rule.Level
+		}
+	}
+	return level
+}
+
+// matching returns the rules for an entity type and exact country code
+func (rules VerificationRuleSet) matching(entityType, country string) VerificationRuleSet {
+	var matched VerificationRuleSet
+	for _, rule := range rules {
+		if rule.EntityType == entityType && strings.ToUpper(rule.Country) == country {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// containsAll reports whether every required value is present in values
+func containsAll(values, required []string) bool {
+	for _, r := range required {
+		if !slices.Contains(values, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// verificationLevelService implements VerificationLevelService with constructor-based dependency injection
+type verificationLevelService struct {
+	log             *logrus.Logger
+	db              *gorm.DB
+	rules           VerificationRuleSet
+	actorRepo       repository.ActorRepository
+	credentialsRepo repository.CredentialsRepository
+	historyRepo     repository.VerificationLevelHistoryRepository
+	webhooks        WebhookService
+}
+
+// NewVerificationLevelService creates a new verification level service instance.
+// It fails when the configured rules file cannot be loaded.
+func NewVerificationLevelService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	actorRepo repository.ActorRepository,
+	credentialsRepo repository.CredentialsRepository,
+	historyRepo repository.VerificationLevelHistoryRepository,
+	webhooks WebhookService,
+) (VerificationLevelService, error) {
+	rules, err := LoadVerificationRules(cfg.RulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verificationLevelService{
+		log:             log,
+		db:              db,
+		rules:           rules,
+		actorRepo:       actorRepo,
+		credentialsRepo: credentialsRepo,
+		historyRepo:     historyRepo,
+		webhooks:        webhooks,
+	}, nil
+}
+
+func (s *verificationLevelService) Recompute(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, reason string, tokenID *uuid.UUID) (*model.VerificationLevelHistory, error) {
+	// Lock the actor so concurrent credential changes are evaluated one after another
+	actor, err := s.actorRepo.LockByID(ctx, tx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiedTypes, err := s.credentialsRepo.FindVerifiedTypes(ctx, tx, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	level := s.rules.Evaluate(actor.EntityType, actorJurisdiction(actor), verifiedTypes)
+	if level == actor.VerificationLevel {
+		return nil, nil
+	}
+
+	if err := s.actorRepo.UpdateVerificationLevel(ctx, tx, actorID, level); err != nil {
+		return nil, err
+	}
+
+	history := &model.VerificationLevelHistory{
+		ActorID:       actorID,
+		PreviousLevel: actor.VerificationLevel,
+		NewLevel:      level,
+		Reason:        reason,
+		TokenID:       tokenID,
+	}
+	if err := s.historyRepo.Create(ctx, tx, history); err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"previousLevel": history.PreviousLevel,
+		"newLevel":      history.NewLevel,
+		"reason":        history.Reason,
+	}
+	if tokenID != nil {
+		data["tokenId"] = tokenID.String()
+	}
+	err = s.webhooks.Publish(ctx, tx, WebhookEvent{
+		Type:    constants.WebhookEventVerificationLevelChanged,
+		ActorID: actorID,
+		Data:    data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Infof("Actor %s verification level changed from %s to %s (%s)", actorID, actor.VerificationLevel, level, reason)
+	return history, nil
+}
+
+func (s *verificationLevelService) GetHistory(c *fiber.Ctx, actorID uuid.UUID) ([]model.VerificationLevelHistory, error) {
+	history, err := s.historyRepo.FindByActorID(c.Context(), s.db, actorID)
+	if err != nil {
+		s.log.Errorf("Failed to retrieve verification level history: %+v", err)
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,729 @@
+package service
+
+import (
+	"app/src/adapter"
+	"app/src/archive"
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/utils"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WalletService defines the interface for moving an actor's credentials between nodes and wallets.
+// An export is a zip archive of the actor's credentials, their original VC payloads and linked
+// documents, described by a manifest of SHA-256 hashes that is signed with the platform key.
+type WalletService interface {
+	Export(c *fiber.Ctx) (*WalletExport, error)
+
+	// Import verifies an archive produced by Export on any node and recreates its documents and
+	// credentials under the caller. Imported credentials are verified again rather than trusted.
+	Import(c *fiber.Ctx, data []byte) (*WalletImport, error)
+}
+
+// WalletExport is a signed wallet archive ready for download
+type WalletExport struct {
+	FileName string
+	Data     []byte
+}
+
+// WalletImport reports the outcome of an import
+type WalletImport struct {
+	ExportID      string
+	Issuer        string
+	SourceActorID string
+	Documents     []ImportedDocument
+	Credentials   []ImportedCredential
+	Skipped       []SkippedCredential
+}
+
+// ImportedDocument maps an archived document to the document recreated from it
+type ImportedDocument struct {
+	SourceID uuid.UUID
+	Document *model.Document
+}
+
+// ImportedCredential maps an archived credential to the credential recreated from it.
+// Job is nil for credentials imported in a final state.
+type ImportedCredential struct {
+	SourceID uuid.UUID
+	Token    *model.Token
+	Job      *model.Job
+}
+
+// SkippedCredential is an archived credential that was not imported
+type SkippedCredential struct {
+	SourceID uuid.UUID
+	Reason   string
+}
+
+// walletManifest lists every file of a wallet archive except the manifest and its signature
+type walletManifest struct {
+	Format     string          `json:"format"`
+	Version    int             `json:"version"`
+	ExportID   string          `json:"exportId"`
+	ExportedAt time.Time       `json:"exportedAt"`
+	Issuer     string          `json:"issuer"`
+	ActorID    string          `json:"actorId"`
+	Files      []archive.Entry `json:"files"`
+}
+
+// walletManifestClaims are the claims of the JWT signing a manifest
+type walletManifestClaims struct {
+	jwt.RegisteredClaims
+	ManifestSHA256 string `json:"manifest_sha256"`
+}
+
+// walletDocument is the archived record of a document; its content is stored alongside
+type walletDocument struct {
+	DocumentID uuid.UUID `json:"documentId"`
+	FileName   string    `json:"fileName"`
+	MimeType   *string   `json:"mimeType,omitempty"`
+	UploadedAt time.Time `json:"uploadedAt"`
+}
+
+// walletToken is the archived record of a credential; its original payload is stored alongside
+type walletToken struct {
+	CredentialID     uuid.UUID              `json:"credentialId"`
+	VerificationType string                 `json:"verificationType"`
+	TokenStandard    string                 `json:"tokenStandard"`
+	IssuerDID        string                 `json:"issuerDid"`
+	Status           string                 `json:"status"`
+	Documents        []walletTokenDocument  `json:"documents,omitempty"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	Metadata         map[string]interface{} `json:"metadata"`
+}
+
+// walletTokenDocument is the archived link between a credential and one of its evidence documents
+type walletTokenDocument struct {
+	DocumentID uuid.UUID `json:"documentId"`
+	Role       string    `json:"role"`
+}
+
+// importMetadataDropped lists token metadata evaluated by the exporting node, which the
+// importing node recomputes, and the document reference of credentials added before documents
+// were linked
+var importMetadataDropped = []string{"trust", "proof", "documentId", "import"}
+
+// walletFinalStatuses are credential statuses that are imported as they are; a node may
+// trust another node's rejection or revocation, but never its verification
+var walletFinalStatuses = []string{constants.TokenStatusRejected, constants.TokenStatusRevoked}
+
+type walletService struct {
+	log             *logrus.Logger
+	db              *gorm.DB
+	signer          *keys.Signer
+	resolver        did.Resolver
+	credentialsRepo repository.CredentialsRepository
+	documentRepo    repository.DocumentRepository
+	actorRepo       repository.ActorRepository
+	trustedIssuers  TrustedIssuerService
+	jobs            JobService
+	webhooks        WebhookService
+	storage         *adapter.LazyStorage
+}
+
+// NewWalletService creates a new wallet service instance.
+// The storage provider is initialized lazily on the first export or import with documents.
+func NewWalletService(
+	log *logrus.Logger,
+	db *gorm.DB,
+	signer *keys.Signer,
+	resolver did.Resolver,
+	credentialsRepo repository.CredentialsRepository,
+	documentRepo repository.DocumentRepository,
+	actorRepo repository.ActorRepository,
+	trustedIssuers TrustedIssuerService,
+	jobs JobService,
+	webhooks WebhookService,
+	storage *adapter.LazyStorage,
+) WalletService {
+	return &walletService{
+		log:             log,
+		db:              db,
+		signer:          signer,
+		resolver:        resolver,
+		credentialsRepo: credentialsRepo,
+		documentRepo:    documentRepo,
+		actorRepo:       actorRepo,
+		trustedIssuers:  trustedIssuers,
+		jobs:            jobs,
+		webhooks:        webhooks,
+		storage:         storage,
+	}
+}
+
+func (s *walletService) Export(c *fiber.Ctx) (*WalletExport, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.Context()
+
+	tokens, err := s.credentialsRepo.FindAllForAccount(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	documentIDs := make([]uuid.UUID, 0, len(tokens))
+	for i := range tokens {
+		for _, link := range tokens[i].Documents {
+			if !slices.Contains(documentIDs, link.DocumentID) {
+				documentIDs = append(documentIDs, link.DocumentID)
+			}
+		}
+	}
+
+	var documents []model.Document
+	if len(documentIDs) > 0 {
+		if documents, err = s.documentRepo.FindByIDsForAccount(ctx, s.db, documentIDs, actorID); err != nil {
+			return nil, err
+		}
+	}
+
+	exportID, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+
+	var buf bytes.Buffer
+	writer := archive.NewWriter(&buf, now)
+
+	for i := range documents {
+		if err := s.exportDocument(ctx, writer, &documents[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range tokens {
+		if err := exportToken(writer, &tokens[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	issuer, _ := did.SplitURL(s.signer.KeyID())
+	manifest, err := json.MarshalIndent(walletManifest{
+		Format:     constants.WalletArchiveFormat,
+		Version:    constants.WalletArchiveVersion,
+		ExportID:   exportID.String(),
+		ExportedAt: now,
+		Issuer:     issuer,
+		ActorID:    actorID.String(),
+		Files:      writer.Entries(),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(manifest)
+	signature, err := s.signer.Sign(walletManifestClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   issuer,
+			Subject:  actorID.String(),
+			ID:       exportID.String(),
+			IssuedAt: jwt.NewNumericDate(now),
+		},
+		ManifestSHA256: hex.EncodeToString(digest[:]),
+	}, map[string]interface{}{"typ": constants.WalletManifestJWTType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign wallet manifest: %w", err)
+	}
+
+	if err := writer.AddUnlisted(constants.WalletManifestPath, manifest); err != nil {
+		return nil, err
+	}
+	if err := writer.AddUnlisted(constants.WalletSignaturePath, []byte(signature)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write wallet archive: %w", err)
+	}
+
+	s.log.Infof("Exported wallet of actor %s: %d credentials, %d documents", actorID, len(tokens), len(documents))
+	return &WalletExport{
+		FileName: fmt.Sprintf("wallet-%s-%s.zip", actorID, now.Format("20060102")),
+		Data:     buf.Bytes(),
+	}, nil
+}
+
+// exportDocument adds a document record and its content downloaded from storage
+func (s *walletService) exportDocument(ctx context.Context, writer *archive.Writer, document *model.Document) error {
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		s.log.Errorf("Storage provider unavailable: %v", err)
+		return fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToCreateStorageProvider)
+	}
+
+	reader, err := storageProvider.Download(ctx, document.StoragePath)
+	if err != nil {
+		return fmt.Errorf("failed to download document %s: %w", document.DocumentID, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to download document %s: %w", document.DocumentID, err)
+	}
+
+	record, err := json.MarshalIndent(walletDocument{
+		DocumentID: document.DocumentID,
+		FileName:   document.FileName,
+		MimeType:   document.MimeType,
+		UploadedAt: document.UploadedAt.UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join("documents", document.DocumentID.String())
+	if err := writer.Add(path.Join(dir, constants.WalletDocumentMetadataName), record); err != nil {
+		return err
+	}
+	return writer.Add(path.Join(dir, constants.WalletDocumentContentName), content)
+}
+
+// exportToken adds a credential record and its original VC payload
+func exportToken(writer *archive.Writer, token *model.Token) error {
+	record, err := json.MarshalIndent(walletToken{
+		CredentialID:     token.TokenID,
+		VerificationType: token.TokenType,
+		TokenStandard:    token.TokenStandard,
+		IssuerDID:        token.IssuerDID,
+		Status:           token.Status,
+		Documents:        exportTokenDocuments(token.Documents),
+		CreatedAt:        token.CreatedAt.UTC(),
+		Metadata:         token.Metadata,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join("credentials", token.TokenID.String())
+	if err := writer.Add(path.Join(dir, constants.WalletTokenMetadataName), record); err != nil {
+		return err
+	}
+
+	// VC-JWTs are kept in compact form so their signature can be checked again
+	if compact, _ := token.Metadata["credentialJwt"].(string); compact != "" {
+		return writer.Add(path.Join(dir, constants.WalletCredentialJWTName), []byte(compact))
+	}
+	if vc, ok := token.Metadata["verifiableCredential"]; ok {
+		payload, err := json.MarshalIndent(vc, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writer.Add(path.Join(dir, constants.WalletCredentialJSONName), payload)
+	}
+	return nil
+}
+
+// exportTokenDocuments returns the archived form of a credential's document links
+func exportTokenDocuments(links []model.TokenDocument) []walletTokenDocument {
+	documents := make([]walletTokenDocument, 0, len(links))
+	for _, link := range links {
+		documents = append(documents, walletTokenDocument{DocumentID: link.DocumentID, Role: link.Role})
+	}
+	return documents
+}
+
+func (s *walletService) Import(c *fiber.Ctx, data []byte) (*WalletImport, error) {
+	actorID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx := c.Context()
+
+	contents, manifest, err := s.openArchive(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, tokens, err := parseWalletContents(contents, manifest)
+	if err != nil {
+		s.log.Warnf("Wallet archive %s rejected: %v", manifest.ExportID, err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidWalletArchive)
+	}
+
+	actor, err := s.actorRepo.FindByID(ctx, s.db, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WalletImport{
+		ExportID:      manifest.ExportID,
+		Issuer:        manifest.Issuer,
+		SourceActorID: manifest.ActorID,
+	}
+
+	// Documents are stored first; they are removed again if the records cannot be saved
+	documentIDs := make(map[uuid.UUID]uuid.UUID, len(documents))
+	var uploaded []string
+	for _, document := range documents {
+		content, _ := contents.File(path.Join("documents", document.DocumentID.String(), constants.WalletDocumentContentName))
+		imported, err := s.uploadDocument(ctx, actorID, &document, content)
+		if err != nil {
+			s.removeUploaded(ctx, uploaded)
+			return nil, err
+		}
+		uploaded = append(uploaded, imported.StoragePath)
+		documentIDs[document.DocumentID] = imported.DocumentID
+		result.Documents = append(result.Documents, ImportedDocument{SourceID: document.DocumentID, Document: imported})
+	}
+
+	for i := range tokens {
+		token, reason := s.importToken(ctx, contents, &tokens[i], actorID, actorJurisdiction(actor), manifest, documentIDs)
+		if reason != "" {
+			result.Skipped = append(result.Skipped, SkippedCredential{SourceID: tokens[i].CredentialID, Reason: reason})
+			continue
+		}
+
+		imported := ImportedCredential{SourceID: tokens[i].CredentialID, Token: token}
+		if !slices.Contains(walletFinalStatuses, token.Status) {
+			imported.Job = &model.Job{
+				Type:       constants.JobTypeCredentialVerification,
+				ActorID:    actorID,
+				ResourceID: &token.TokenID,
+			}
+		}
+		result.Credentials = append(result.Credentials, imported)
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, imported := range result.Documents {
+			if err := s.documentRepo.Create(ctx, tx, imported.Document); err != nil {
+				return err
+			}
+			err := s.webhooks.Publish(ctx, tx, WebhookEvent{
+				Type:    constants.WebhookEventDocumentUploaded,
+				ActorID: actorID,
+				Data: map[string]interface{}{
+					"documentId": imported.Document.DocumentID.String(),
+					"fileName":   imported.Document.FileName,
+					"imported":   true,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, imported := range result.Credentials {
+			if err := s.credentialsRepo.Create(ctx, tx, imported.Token); err != nil {
+				return err
+			}
+			if imported.Job == nil {
+				continue
+			}
+			if err := s.jobs.Enqueue(ctx, tx, imported.Job); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Errorf("Failed to save imported wallet: %+v", err)
+		s.removeUploaded(ctx, uploaded)
+		return nil, err
+	}
+
+	s.log.Infof("Imported wallet export %s from %s into actor %s: %d credentials, %d documents, %d skipped",
+		manifest.ExportID, manifest.Issuer, actorID, len(result.Credentials), len(result.Documents), len(result.Skipped))
+	return result, nil
+}
+
+// openArchive reads an archive, verifies the manifest signature and checks every file against the manifest
+func (s *walletService) openArchive(ctx context.Context, data []byte) (*archive.Archive, *walletManifest, error) {
+	contents, err := archive.Read(data, archive.Limits{
+		MaxFiles: constants.WalletArchiveMaxFiles,
+		MaxBytes: constants.WalletArchiveMaxExpanded,
+	})
+	if err != nil {
+		s.log.Warnf("Wallet archive rejected: %v", err)
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidWalletArchive)
+	}
+
+	manifestBytes, ok := contents.File(constants.WalletManifestPath)
+	if !ok {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidWalletArchive)
+	}
+	signature, ok := contents.File(constants.WalletSignaturePath)
+	if !ok {
+		return nil, nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrInvalidWalletArchiveSignature)
+	}
+
+	claims, err := s.verifyManifestSignature(ctx, string(signature))
+	if err != nil {
+		s.log.Warnf("Wallet archive signature rejected: %v", err)
+		if errors.Is(err, did.ErrResolutionFailed) {
+			return nil, nil, fiber.NewError(fiber.StatusBadGateway, constants.ErrIssuerDIDResolutionFailed)
+		}
+		return nil, nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrInvalidWalletArchiveSignature)
+	}
+
+	digest := sha256.Sum256(manifestBytes)
+	if !strings.EqualFold(claims.ManifestSHA256, hex.EncodeToString(digest[:])) {
+		return nil, nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrInvalidWalletArchiveSignature)
+	}
+
+	var manifest walletManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidWalletArchive)
+	}
+	if manifest.Format != constants.WalletArchiveFormat || manifest.Version != constants.WalletArchiveVersion {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrUnsupportedWalletArchive)
+	}
+	if manifest.Issuer != claims.Issuer || manifest.ExportID != claims.ID {
+		return nil, nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrInvalidWalletArchiveSignature)
+	}
+
+	if err := contents.Verify(manifest.Files, constants.WalletManifestPath, constants.WalletSignaturePath); err != nil {
+		s.log.Warnf("Wallet archive %s failed integrity check: %v", manifest.ExportID, err)
+		return nil, nil, fiber.NewError(fiber.StatusUnprocessableEntity, constants.ErrWalletArchiveIntegrity)
+	}
+
+	return contents, &manifest, nil
+}
+
+// verifyManifestSignature verifies the manifest JWT against an assertion key of the exporting
+// node's DID. Archives exported by this node are checked against the platform key directly.
+func (s *walletService) verifyManifestSignature(ctx context.Context, compact string) (*walletManifestClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(credentialJWTAlgorithms),
+		jwt.WithLeeway(constants.JWTClockSkew*time.Second),
+		jwt.WithIssuedAt(),
+	)
+
+	claims := &walletManifestClaims{}
+	_, err := parser.ParseWithClaims(compact, claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != constants.WalletManifestJWTType {
+			return nil, fmt.Errorf("unexpected typ header %q", typ)
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		if kidDID, _ := did.SplitURL(kid); !strings.HasPrefix(claims.Issuer, "did:") || kidDID != claims.Issuer {
+			return nil, fmt.Errorf("key %q does not belong to issuer %q", kid, claims.Issuer)
+		}
+		if kid == s.signer.KeyID() {
+			return s.signer.PublicKey(), nil
+		}
+
+		document, err := s.resolver.Resolve(ctx, claims.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		method, err := document.FindVerificationMethod(kid)
+		if err != nil {
+			return nil, err
+		}
+		if !document.IsAssertionMethod(method) {
+			return nil, fmt.Errorf("key %q is not an assertion method of %q", method.ID, claims.Issuer)
+		}
+		return method.PublicKey()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// parseWalletContents reads the document and credential records of a verified archive
+func parseWalletContents(contents *archive.Archive, manifest *walletManifest) ([]walletDocument, []walletToken, error) {
+	var documents []walletDocument
+	var tokens []walletToken
+
+	for _, entry := range manifest.Files {
+		parts := strings.Split(entry.Path, "/")
+		if len(parts) != 3 {
+			return nil, nil, fmt.Errorf("unexpected path %q", entry.Path)
+		}
+		content, _ := contents.File(entry.Path)
+
+		switch {
+		case parts[0] == "documents" && parts[2] == constants.WalletDocumentMetadataName:
+			var document walletDocument
+			if err := json.Unmarshal(content, &document); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", entry.Path, err)
+			}
+			if document.DocumentID.String() != parts[1] {
+				return nil, nil, fmt.Errorf("%s: document ID does not match its path", entry.Path)
+			}
+			if _, ok := contents.File(path.Join("documents", parts[1], constants.WalletDocumentContentName)); !ok {
+				return nil, nil, fmt.Errorf("%s: document content is missing", entry.Path)
+			}
+			documents = append(documents, document)
+		case parts[0] == "credentials" && parts[2] == constants.WalletTokenMetadataName:
+			var token walletToken
+			if err := json.Unmarshal(content, &token); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", entry.Path, err)
+			}
+			if token.CredentialID.String() != parts[1] {
+				return nil, nil, fmt.Errorf("%s: credential ID does not match its path", entry.Path)
+			}
+			for _, link := range token.Documents {
+				if !slices.Contains(documentRoles, link.Role) {
+					return nil, nil, fmt.Errorf("%s: unknown document role %q", entry.Path, link.Role)
+				}
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	// Import in the order the credentials were originally submitted
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].CreatedAt.Before(tokens[j].CreatedAt) })
+	return documents, tokens, nil
+}
+
+// importToken builds the credential recreated from an archived record. It returns a reason
+// instead when the credential cannot be imported.
+func (s *walletService) importToken(
+	ctx context.Context,
+	contents *archive.Archive,
+	record *walletToken,
+	actorID uuid.UUID,
+	jurisdiction string,
+	manifest *walletManifest,
+	documentIDs map[uuid.UUID]uuid.UUID,
+) (*model.Token, string) {
+	metadata := make(datatypes.JSONMap, len(record.Metadata)+2)
+	for key, value := range record.Metadata {
+		if !slices.Contains(importMetadataDropped, key) {
+			metadata[key] = value
+		}
+	}
+
+	// The original payload replaces whatever the record claims about it
+	dir := path.Join("credentials", record.CredentialID.String())
+	if compact, ok := contents.File(path.Join(dir, constants.WalletCredentialJWTName)); ok {
+		issuer, err := unverifiedJWTIssuer(string(compact))
+		if err != nil || issuer != record.IssuerDID || record.TokenStandard != constants.TokenStandardVCJWT {
+			return nil, constants.ErrInvalidCredentialJWT
+		}
+		metadata["credentialJwt"] = string(compact)
+	} else if payload, ok := contents.File(path.Join(dir, constants.WalletCredentialJSONName)); ok {
+		var vc map[string]interface{}
+		if err := json.Unmarshal(payload, &vc); err != nil || record.TokenStandard != constants.TokenStandardVC {
+			return nil, constants.ErrInvalidWalletArchive
+		}
+		metadata["verifiableCredential"] = vc
+	} else {
+		return nil, constants.ErrInvalidWalletArchive
+	}
+
+	metadata["import"] = map[string]interface{}{
+		"exportId":           manifest.ExportID,
+		"issuer":             manifest.Issuer,
+		"sourceCredentialId": record.CredentialID.String(),
+		"sourceStatus":       record.Status,
+	}
+
+	token := &model.Token{
+		TokenID:       uuid.New(),
+		AccountID:     actorID,
+		TokenType:     record.VerificationType,
+		IssuerDID:     record.IssuerDID,
+		TokenStandard: record.TokenStandard,
+		Status:        constants.TokenStatusPending,
+		Metadata:      metadata,
+	}
+	for _, link := range record.Documents {
+		if documentID, ok := documentIDs[link.DocumentID]; ok {
+			token.Documents = append(token.Documents, model.TokenDocument{DocumentID: documentID, Role: link.Role})
+		}
+	}
+	if slices.Contains(walletFinalStatuses, record.Status) {
+		token.Status = record.Status
+	}
+
+	if err := evaluateIssuerTrust(ctx, s.log, s.trustedIssuers, token, jurisdiction); err != nil {
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusUnprocessableEntity {
+			return nil, fiberErr.Message
+		}
+		return nil, constants.ErrFailedToCreateToken
+	}
+
+	return token, ""
+}
+
+// uploadDocument stores the content of an archived document under a new storage key
+func (s *walletService) uploadDocument(ctx context.Context, actorID uuid.UUID, record *walletDocument, content []byte) (*model.Document, error) {
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		s.log.Errorf("Storage provider unavailable: %v", err)
+		return nil, fiber.NewError(fiber.StatusInternalServerError, constants.ErrFailedToCreateStorageProvider)
+	}
+
+	storageKey, fileExt := utils.BuildStorageKey(record.FileName)
+	opts := &adapter.UploadOptions{
+		Metadata: map[string]string{
+			"original-filename": record.FileName,
+			"uploaded-at":       time.Now().Format(time.RFC3339),
+			"actor-id":          actorID.String(),
+		},
+	}
+	if _, err := storageProvider.Upload(ctx, storageKey, bytes.NewReader(content), int64(len(content)), opts); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("%s: %v", constants.ErrFailedToUploadFile, err))
+	}
+
+	mimeType := fileExt
+	if record.MimeType != nil {
+		mimeType = *record.MimeType
+	}
+
+	return &model.Document{
+		DocumentID:  uuid.Must(uuid.NewV7()),
+		AccountID:   actorID,
+		FileName:    record.FileName,
+		StoragePath: storageKey,
+		MimeType:    &mimeType,
+		UploadedAt:  time.Now(),
+	}, nil
+}
+
+// removeUploaded deletes stored files of an import that failed
+func (s *walletService) removeUploaded(ctx context.Context, storageKeys []string) {
+	if len(storageKeys) == 0 {
+		return
+	}
+	storageProvider, err := s.storage.Provider()
+	if err != nil {
+		return
+	}
+	for _, key := range storageKeys {
+		if err := storageProvider.Delete(ctx, key); err != nil {
+			s.log.Warnf("Failed to remove %s after failed wallet import: %v", key, err)
+		}
+	}
+}
+
+// unverifiedJWTIssuer returns the iss claim of a compact JWT without checking its signature.
+// The verification job checks the signature later.
+func unverifiedJWTIssuer(compact string) (string, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(compact, claims); err != nil {
+		return "", err
+	}
+	return claims.GetIssuer()
+}
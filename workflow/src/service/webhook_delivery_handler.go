@@ -0,0 +1,154 @@
+package service
+
+import (
+	"app/src/constants"
+	"app/src/model"
+	"app/src/netguard"
+	"app/src/queue"
+	"app/src/repository"
+	"app/src/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryHandler sends webhook deliveries. Failed attempts are retried by the job
+// queue with exponential backoff; the delivery is marked failed once the job gives up.
+type WebhookDeliveryHandler struct {
+	log              *logrus.Logger
+	db               *gorm.DB
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	client           *http.Client
+}
+
+// NewWebhookDeliveryHandler creates a new webhook delivery handler
+func NewWebhookDeliveryHandler(
+	log *logrus.Logger,
+	db *gorm.DB,
+	subscriptionRepo repository.WebhookSubscriptionRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+) *WebhookDeliveryHandler {
+	return &WebhookDeliveryHandler{
+		log:              log,
+		db:               db,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		// The client refuses internal addresses, also when a subscription host is changed to
+		// resolve to one after it was registered, and does not follow redirects, which could move a
+		// signed payload to a host the subscriber did not register
+		client: netguard.NewClient(constants.WebhookDeliveryTimeout * time.Second),
+	}
+}
+
+// Handle implements queue.Handler
+func (h *WebhookDeliveryHandler) Handle(ctx context.Context, job *model.Job) (map[string]interface{}, error) {
+	if job.ResourceID == nil {
+		return nil, queue.Permanent(errors.New("webhook job has no delivery"))
+	}
+
+	delivery, err := h.deliveryRepo.FindByID(ctx, h.db, *job.ResourceID)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+
+	subscription, err := h.subscriptionRepo.FindByID(ctx, h.db, delivery.SubscriptionID)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			err = queue.Permanent(err)
+			h.record(ctx, delivery, job.Attempts, nil, err, true)
+		}
+		return nil, err
+	}
+
+	status, sendErr := h.send(ctx, subscription, delivery)
+	lastAttempt := queue.IsPermanent(sendErr) || job.Attempts >= job.MaxAttempts
+	h.record(ctx, delivery, job.Attempts, status, sendErr, lastAttempt)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	return map[string]interface{}{
+		"deliveryId":     delivery.DeliveryID.String(),
+		"responseStatus": *status,
+	}, nil
+}
+
+// send posts the signed event to the subscription URL and returns the response status
+func (h *WebhookDeliveryHandler) send(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) (*int, error) {
+	body, err := json.Marshal(delivery.Payload)
+	if err != nil {
+		return nil, queue.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, queue.Permanent(err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set(constants.HTTPHeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(constants.HTTPHeaderWebhookID, delivery.EventID.String())
+	req.Header.Set(constants.HTTPHeaderWebhookDelivery, delivery.DeliveryID.String())
+	req.Header.Set(constants.HTTPHeaderWebhookEvent, delivery.EventType)
+	req.Header.Set(constants.HTTPHeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(constants.HTTPHeaderWebhookSignature, utils.WebhookSignature(subscription.Secret, timestamp, body))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		if errors.Is(err, netguard.ErrForbiddenAddress) {
+			return nil, queue.Permanent(err)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// The response body is not kept: the delivery log is readable by the subscriber, and the
+	// body would echo whatever the endpoint returned
+	status := resp.StatusCode
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return &status, fmt.Errorf("endpoint returned status %d", status)
+	}
+	return &status, nil
+}
+
+// record stores the outcome of an attempt on the delivery log entry
+func (h *WebhookDeliveryHandler) record(ctx context.Context, delivery *model.WebhookDelivery, attempts int, status *int, sendErr error, lastAttempt bool) {
+	delivery.Attempts = attempts
+	delivery.ResponseStatus = status
+
+	switch {
+	case sendErr == nil:
+		now := time.Now().UTC()
+		delivery.Status = constants.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	case lastAttempt:
+		delivery.Status = constants.WebhookDeliveryFailed
+		delivery.LastError = errorMessage(sendErr)
+	default:
+		delivery.LastError = errorMessage(sendErr)
+	}
+
+	if err := h.deliveryRepo.Update(ctx, h.db, delivery); err != nil {
+		h.log.Errorf("Failed to record webhook delivery %s: %v", delivery.DeliveryID, err)
+	}
+}
+
+// errorMessage returns the error text truncated to the stored length
+func errorMessage(err error) *string {
+	message := utils.Truncate(err.Error(), constants.JobErrorMaxLength)
+	return &message
+}
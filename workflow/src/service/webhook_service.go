@@ -0,0 +1,313 @@
+package service
+
+import (
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/netguard"
+	"app/src/repository"
+	"app/src/utils"
+	"app/src/validation"
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookService defines the interface for webhook subscriptions and event publication.
+// Subscription management is scoped: actors manage their own actor-scoped subscriptions,
+// administrators manage partner-scoped subscriptions that receive events about every actor.
+type WebhookService interface {
+	CreateSubscription(c *fiber.Ctx, scope string, req *validation.CreateWebhookSubscriptionRequest) (*model.WebhookSubscription, error)
+	ListSubscriptions(c *fiber.Ctx, scope string) ([]model.WebhookSubscription, error)
+	DeleteSubscription(c *fiber.Ctx, scope string, req *validation.WebhookSubscriptionIDRequest) error
+	ListDeliveries(c *fiber.Ctx, scope string, req *validation.ListWebhookDeliveriesRequest) ([]model.WebhookDelivery, string, error)
+	ReplayDelivery(c *fiber.Ctx, scope string, req *validation.WebhookDeliveryIDRequest) (*model.WebhookDelivery, error)
+
+	// Publish records a delivery for every subscriber of the event and enqueues it inside tx,
+	// so notifications are only sent for changes that commit
+	Publish(ctx context.Context, tx *gorm.DB, event WebhookEvent) error
+}
+
+// WebhookEvent is a change notification about one actor
+type WebhookEvent struct {
+	Type    string
+	ActorID uuid.UUID
+	Data    map[string]interface{}
+}
+
+type webhookService struct {
+	cfg              *config.Config
+	log              *logrus.Logger
+	db               *gorm.DB
+	validate         *validator.Validate
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	jobs             JobService
+}
+
+// NewWebhookService creates a new webhook service instance
+func NewWebhookService(
+	cfg *config.Config,
+	log *logrus.Logger,
+	db *gorm.DB,
+	validate *validator.Validate,
+	subscriptionRepo repository.WebhookSubscriptionRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	jobs JobService,
+) WebhookService {
+	return &webhookService{
+		cfg:              cfg,
+		log:              log,
+		db:               db,
+		validate:         validate,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		jobs:             jobs,
+	}
+}
+
+func (s *webhookService) CreateSubscription(c *fiber.Ctx, scope string, req *validation.CreateWebhookSubscriptionRequest) (*model.WebhookSubscription, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	ownerID, err := utils.ActorIDFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Event data includes personal information, so production endpoints must use TLS
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || endpoint.Hostname() == "" || (s.cfg.IsProd && endpoint.Scheme != "https") {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInsecureWebhookURL)
+	}
+	// Deliveries are sent from inside our network, so endpoints must not point back into it. The
+	// delivery client checks the address again on every connection.
+	if err := netguard.CheckHost(c.Context(), endpoint.Hostname()); err != nil {
+		s.log.Warnf("Rejected webhook URL %s: %v", req.URL, err)
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrWebhookURLNotPublic)
+	}
+
+	secret, err := utils.GenerateSecret(constants.WebhookSecretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &model.WebhookSubscription{
+		OwnerID: ownerID,
+		Scope:   scope,
+		URL:     req.URL,
+		Events:  datatypes.JSONSlice[string](req.Events),
+		Secret:  secret,
+	}
+	if req.Description != "" {
+		subscription.Description = &req.Description
+	}
+
+	if err := s.subscriptionRepo.Create(c.Context(), s.db, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *webhookService) ListSubscriptions(c *fiber.Ctx, scope string) ([]model.WebhookSubscription, error) {
+	access, err := webhookAccess(c, scope)
+	if err != nil {
+		return nil, err
+	}
+	return s.subscriptionRepo.List(c.Context(), s.db, access)
+}
+
+func (s *webhookService) DeleteSubscription(c *fiber.Ctx, scope string, req *validation.WebhookSubscriptionIDRequest) error {
+	if err := s.validate.Struct(req); err != nil {
+		return err
+	}
+
+	access, err := webhookAccess(c, scope)
+	if err != nil {
+		return err
+	}
+
+	subscriptionID, err := utils.ParseUUID(req.SubscriptionID, "subscription")
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionRepo.Delete(c.Context(), s.db, subscriptionID, access)
+}
+
+func (s *webhookService) ListDeliveries(c *fiber.Ctx, scope string, req *validation.ListWebhookDeliveriesRequest) ([]model.WebhookDelivery, string, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, "", err
+	}
+
+	access, err := webhookAccess(c, scope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursor, err := utils.DecodeCursor(req.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter := repository.WebhookDeliveryFilter{
+		Access:    access,
+		EventType: req.EventType,
+		Status:    req.Status,
+		Cursor:    cursor,
+		Limit:     utils.PageLimit(req.Limit),
+	}
+	if req.SubscriptionID != "" {
+		subscriptionID, err := utils.ParseUUID(req.SubscriptionID, "subscription")
+		if err != nil {
+			return nil, "", err
+		}
+		filter.SubscriptionID = &subscriptionID
+	}
+
+	deliveries, err := s.deliveryRepo.List(c.Context(), s.db, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One extra row was fetched to detect whether another page exists
+	nextCursor := ""
+	if len(deliveries) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+		last := deliveries[len(deliveries)-1]
+		nextCursor = utils.EncodeCursor(last.CreatedAt, last.DeliveryID)
+	}
+
+	return deliveries, nextCursor, nil
+}
+
+func (s *webhookService) ReplayDelivery(c *fiber.Ctx, scope string, req *validation.WebhookDeliveryIDRequest) (*model.WebhookDelivery, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	access, err := webhookAccess(c, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := utils.ParseUUID(req.DeliveryID, "delivery")
+	if err != nil {
+		return nil, err
+	}
+
+	var replay *model.WebhookDelivery
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		original, err := s.deliveryRepo.FindByIDWithAccess(c.Context(), tx, deliveryID, access)
+		if err != nil {
+			return err
+		}
+
+		// The subscription must still exist; its current URL and secret are used
+		subscription, err := s.subscriptionRepo.FindByID(c.Context(), tx, original.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		replay, err = s.enqueueDelivery(c.Context(), tx, subscription, original.EventID, original.EventType, original.Payload)
+		if err != nil {
+			return err
+		}
+		replay.ReplayOf = &original.DeliveryID
+		return s.deliveryRepo.Update(c.Context(), tx, replay)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return replay, nil
+}
+
+func (s *webhookService) Publish(ctx context.Context, tx *gorm.DB, event WebhookEvent) error {
+	subscriptions, err := s.subscriptionRepo.FindSubscribers(ctx, tx, event.Type, event.ActorID)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	eventID, err := uuid.NewV7()
+	if err != nil {
+		return err
+	}
+
+	payload := datatypes.JSONMap{
+		"id":        eventID.String(),
+		"type":      event.Type,
+		"actorId":   event.ActorID.String(),
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+		"data":      event.Data,
+	}
+
+	for i := range subscriptions {
+		if _, err := s.enqueueDelivery(ctx, tx, &subscriptions[i], eventID, event.Type, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueDelivery records a pending delivery of an event to a subscription and enqueues the job sending it
+func (s *webhookService) enqueueDelivery(ctx context.Context, tx *gorm.DB, subscription *model.WebhookSubscription, eventID uuid.UUID, eventType string, payload datatypes.JSONMap) (*model.WebhookDelivery, error) {
+	delivery := &model.WebhookDelivery{
+		SubscriptionID: subscription.SubscriptionID,
+		OwnerID:        subscription.OwnerID,
+		Scope:          subscription.Scope,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         constants.WebhookDeliveryPending,
+	}
+	if err := s.deliveryRepo.Create(ctx, tx, delivery); err != nil {
+		return nil, err
+	}
+
+	err := s.jobs.Enqueue(ctx, tx, &model.Job{
+		Type:       constants.JobTypeWebhookDelivery,
+		ActorID:    subscription.OwnerID,
+		ResourceID: &delivery.DeliveryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// credentialStatusChangedEvent describes a credential moving from previousStatus to its current status
+func credentialStatusChangedEvent(token *model.Token, previousStatus string) WebhookEvent {
+	return WebhookEvent{
+		Type:    constants.WebhookEventCredentialStatusChanged,
+		ActorID: token.AccountID,
+		Data: map[string]interface{}{
+			"credentialId":     token.TokenID.String(),
+			"verificationType": token.TokenType,
+			"previousStatus":   previousStatus,
+			"status":           token.Status,
+		},
+	}
+}
+
+// webhookAccess limits actor-scoped requests to the caller's own webhooks.
+// Partner-scoped webhooks are shared by all administrators.
+func webhookAccess(c *fiber.Ctx, scope string) (repository.WebhookAccess, error) {
+	access := repository.WebhookAccess{Scope: scope}
+	if scope == constants.WebhookScopeActor {
+		ownerID, err := utils.ActorIDFromContext(c)
+		if err != nil {
+			return access, err
+		}
+		access.OwnerID = &ownerID
+	}
+	return access, nil
+}
@@ -27,6 +27,20 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 	return responseBuilder.InternalServerError(c, constants.MsgInternalServerError)
 }
 
+// ErrorStatus returns the HTTP status ErrorHandler responds with for an error
+func ErrorStatus(err error) int {
+	if errorsMap := validation.CustomErrorMessages(err); len(errorsMap) > 0 {
+		return fiber.StatusBadRequest
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	return fiber.StatusInternalServerError
+}
+
 // NotFoundHandler handles 404 errors
 func NotFoundHandler(c *fiber.Ctx) error {
 	return Response.NotFound(c, constants.MsgEndpointNotFound)
@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"app/src/constants"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -40,3 +44,32 @@ func ParseUUID(id string, entityName string) (uuid.UUID, error) {
 	}
 	return parsed, nil
 }
+
+// ActorIDFromContext returns the authenticated actor ID set by the auth middleware
+func ActorIDFromContext(c *fiber.Ctx) (uuid.UUID, error) {
+	actorID, ok := c.Locals("actorID").(uuid.UUID)
+	if !ok {
+		return uuid.Nil, fiber.NewError(fiber.StatusUnauthorized, constants.ErrUnauthorized)
+	}
+	return actorID, nil
+}
+
+// Truncate shortens s to at most n bytes without splitting a UTF-8 sequence
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
+
+// BuildStorageKey generates a unique storage key from filename
+func BuildStorageKey(filename string) (storageKey, fileExt string) {
+	fileExt = strings.TrimPrefix(filepath.Ext(filename), ".")
+	if fileExt == "" {
+		fileExt = "bin"
+	}
+
+	baseName := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	storageKey = fmt.Sprintf("/%s_%d.%s", baseName, time.Now().UnixNano(), fileExt)
+	return
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"app/src/constants"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Cursor marks the position of the last item returned in a keyset-paginated listing
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// EncodeCursor encodes a cursor into an opaque URL-safe string
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor decodes an opaque cursor string, returning nil for an empty cursor
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidCursor)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidCursor)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidCursor)
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, constants.ErrInvalidCursor)
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// PageLimit clamps a requested page size to the configured bounds
+func PageLimit(requested int) int {
+	if requested <= 0 {
+		return constants.DefaultPageSize
+	}
+	if requested > constants.MaxPageSize {
+		return constants.MaxPageSize
+	}
+	return requested
+}
@@ -228,10 +228,16 @@ func mapStatusToErrorCode(statusCode int) string {
 		return constants.ErrCodeBadRequest
 	case fiber.StatusUnauthorized:
 		return constants.ErrCodeUnauthorized
+	case fiber.StatusForbidden:
+		return constants.ErrCodeForbidden
 	case fiber.StatusNotFound:
 		return constants.ErrCodeNotFound
 	case fiber.StatusConflict:
 		return constants.ErrCodeConflict
+	case fiber.StatusUnprocessableEntity:
+		return constants.ErrCodeUnprocessableEntity
+	case fiber.StatusTooManyRequests:
+		return constants.ErrCodeTooManyRequests
 	case fiber.StatusInternalServerError:
 		return constants.ErrCodeInternalServerError
 	default:
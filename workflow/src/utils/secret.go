@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// GenerateSecret returns a URL-safe random string built from n random bytes
+func GenerateSecret(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// GenerateNumericCode returns a random decimal code with the given number of digits
+func GenerateNumericCode(digits int) (string, error) {
+	var code strings.Builder
+	for i := 0; i < digits; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate code: %w", err)
+		}
+		code.WriteString(digit.String())
+	}
+	return code.String(), nil
+}
+
+// HashSecret returns the hex-encoded SHA-256 digest used to store secrets at rest
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"app/src/constants"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// WebhookSignature returns the signature header value for a webhook body: the hex HMAC-SHA256,
+// keyed with the subscription secret, of the Unix timestamp and the body joined by a dot.
+// Receivers recompute it and reject stale timestamps to prevent replays.
+func WebhookSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return constants.WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -7,19 +7,21 @@ import (
 
 // RegistrationRequest represents the request payload for actor registration
 type RegistrationRequest struct {
-	UniversalIdentifier string `json:"universalIdentifier" validate:"required" example:"actor123"`
+	UniversalIdentifier string `json:"universalIdentifier" validate:"required,max=255" example:"alice@finternet"`
 	Email               string `json:"email" validate:"required,email" example:"actor@example.com"`
 	Password            string `json:"password" validate:"required,min=8,password" example:"password123"`
 	FirstName           string `json:"firstName" validate:"required" example:"John"`
 	LastName            string `json:"lastName" validate:"required" example:"Doe"`
 	PhoneNumber         string `json:"phoneNumber,omitempty" validate:"omitempty,e164" example:"+1234567890"`
-	MasterPublicKey     string `json:"masterPublicKey" validate:"required" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
+	MasterPublicKey     string `json:"masterPublicKey" validate:"required,max=8192" example:"-----BEGIN PUBLIC KEY-----\nMIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA...\n-----END PUBLIC KEY-----"`
 	EntityType          string `json:"entityType" validate:"required,oneof=Individual Business" example:"Individual"`
 	// Individual fields
 	Nationality        string `json:"nationality,omitempty" validate:"omitempty,len=2" example:"US"`
 	CountryOfResidence string `json:"countryOfResidence,omitempty" validate:"omitempty,len=2" example:"US"`
 	// Business fields
 	CountryOfIncorporation string `json:"countryOfIncorporation,omitempty" validate:"omitempty,len=2" example:"US"`
+	// Proof is a JWT signed with the master key over an issued challenge and the hash of the other fields
+	Proof string `json:"proof" validate:"required,jwt" example:"eyJhbGciOiJFUzI1NiIsInR5cCI6InJlZ2lzdHJhdGlvbi1wcm9vZitqd3QifQ..."`
 }
 
 // ValidateEntityTypeFields validates entity type specific required fields
@@ -48,6 +50,24 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required" example:"password123"`
 }
 
+// KeyLoginRequest represents the request payload for logging in with the master key. Proof is a
+// JWT signed with the master key over a login challenge.
+type KeyLoginRequest struct {
+	Username string `json:"username" validate:"required" example:"alice@finternet"`
+	Proof    string `json:"proof" validate:"required,jwt" example:"eyJhbGciOiJFUzI1NiIsInR5cCI6ImxvZ2luLXByb29mK2p3dCJ9..."`
+}
+
+// ChallengeRequest represents the request payload for issuing a challenge. Purpose defaults to
+// registration.
+type ChallengeRequest struct {
+	Purpose string `json:"purpose,omitempty" validate:"omitempty,oneof=registration login" example:"login"`
+}
+
+// IdentifierRequest represents a request addressing one universal identifier of the caller
+type IdentifierRequest struct {
+	Identifier string `json:"identifier" validate:"required,max=255" example:"alice-business"`
+}
+
 // UpdateActorRequest represents the request payload for updating actor profile
 type UpdateActorRequest struct {
 	FirstName   string  `json:"firstName,omitempty" validate:"omitempty" example:"Jane"`
@@ -60,9 +80,25 @@ type ForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email" example:"actor@example.com"`
 }
 
-// ResolveRequest represents the request payload for resolving universal identifier
+// ResolveRequest represents the request payload for resolving universal identifier. With At set,
+// the master key that was valid at that time is returned instead of the current one.
 type ResolveRequest struct {
-	UniversalIdentifier string `json:"universalIdentifier" validate:"required" example:"actor123"`
+	UniversalIdentifier string `json:"universalIdentifier" validate:"required,max=255" example:"alice@finternet"`
+	At                  string `json:"at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T00:00:00Z"`
+}
+
+// ResolveBatchRequest represents the request payload for resolving several universal identifiers
+type ResolveBatchRequest struct {
+	UniversalIdentifiers []string `json:"universalIdentifiers" validate:"required,min=1,max=100,dive,required,max=255" example:"alice@finternet,bob@finternet"`
+}
+
+// RotateKeyRequest represents the request payload for replacing the master key. The new key is
+// endorsed either by a proof JWT signed with the current key, which takes effect immediately, or
+// by the account password for a lost key, which takes effect after the recovery waiting period.
+type RotateKeyRequest struct {
+	NewPublicKey string `json:"newPublicKey" validate:"required,max=8192" example:"-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE...\n-----END PUBLIC KEY-----"`
+	Proof        string `json:"proof,omitempty" validate:"required_without=Password,excluded_with=Password,omitempty,jwt" example:"eyJhbGciOiJFUzI1NiIsInR5cCI6ImtleS1yb3RhdGlvbitqd3QifQ..."`
+	Password     string `json:"password,omitempty" validate:"required_without=Proof,excluded_with=Proof,omitempty,max=256" example:"password123"`
 }
 
 // ApiRequest wrapper for all requests
@@ -90,6 +126,18 @@ type ApiRequest_LoginRequest struct {
 	Request LoginRequest `json:"request"`
 }
 
+// ApiRequest_KeyLoginRequest wraps KeyLoginRequest with ApiRequest
+type ApiRequest_KeyLoginRequest struct {
+	ApiRequest
+	Request KeyLoginRequest `json:"request"`
+}
+
+// ApiRequest_ChallengeRequest wraps ChallengeRequest with ApiRequest
+type ApiRequest_ChallengeRequest struct {
+	ApiRequest
+	Request ChallengeRequest `json:"request"`
+}
+
 // ApiRequest_UpdateActorRequest wraps UpdateActorRequest with ApiRequest
 type ApiRequest_UpdateActorRequest struct {
 	ApiRequest
@@ -108,6 +156,18 @@ type ApiRequest_ResolveRequest struct {
 	Request ResolveRequest `json:"request"`
 }
 
+// ApiRequest_ResolveBatchRequest wraps ResolveBatchRequest with ApiRequest
+type ApiRequest_ResolveBatchRequest struct {
+	ApiRequest
+	Request ResolveBatchRequest `json:"request"`
+}
+
+// ApiRequest_RotateKeyRequest wraps RotateKeyRequest with ApiRequest
+type ApiRequest_RotateKeyRequest struct {
+	ApiRequest
+	Request RotateKeyRequest `json:"request"`
+}
+
 // ApiRequest_Empty represents an empty request body
 type ApiRequest_Empty struct {
 	ApiRequest
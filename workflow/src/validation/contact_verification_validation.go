@@ -0,0 +1,7 @@
+package validation
+
+// ConfirmPhoneVerificationRequest represents the request for confirming the caller's phone number
+// with the one-time code sent to it
+type ConfirmPhoneVerificationRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6" example:"482913"`
+}
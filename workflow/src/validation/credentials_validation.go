@@ -15,10 +15,19 @@ type VerifiableCredential struct {
 	Proof             map[string]interface{} `json:"proof"`
 }
 
-// CredentialPayload represents the payload structure for validation
+// CredentialPayload represents the payload structure for validation.
+// A credential is submitted either as a JSON-LD object or as a compact VC-JWT.
 type CredentialPayload struct {
-	VerifiableCredential VerifiableCredential `json:"verifiableCredential"`
-	DocumentID           string               `json:"documentId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	VerifiableCredential    *VerifiableCredential `json:"verifiableCredential,omitempty"`
+	VerifiableCredentialJWT string                `json:"verifiableCredentialJwt,omitempty" example:"eyJhbGciOiJFUzI1NiIsImtpZCI6ImRpZDp3ZWI6aXNzdWVyLmV4YW1wbGUuZ292I2tleS0xIiwidHlwIjoidmMrand0In0..."`
+	DocumentID              string                `json:"documentId,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Documents               []CredentialDocument  `json:"documents,omitempty"`
+}
+
+// CredentialDocument references an uploaded document and its role in the credential
+type CredentialDocument struct {
+	DocumentID string `json:"documentId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Role       string `json:"role" example:"front"`
 }
 
 // AddCredentialRequest represents the request structure for adding credentials
@@ -27,8 +36,16 @@ type AddCredentialRequest struct {
 	Payload          CredentialPayload `json:"payload"`
 }
 
-// ListCredentialsRequest represents the request for listing credentials
-type ListCredentialsRequest struct{}
+// ListCredentialsRequest represents the request for listing the caller's credentials
+type ListCredentialsRequest struct {
+	Limit            int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor           string `json:"cursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+	Status           string `json:"status,omitempty" example:"Pending"`
+	VerificationType string `json:"verificationType,omitempty" example:"VC"`
+	Issuer           string `json:"issuer,omitempty" example:"did:example:issuer"`
+	CreatedFrom      string `json:"createdFrom,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T00:00:00Z"`
+	CreatedTo        string `json:"createdTo,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-11-01T00:00:00Z"`
+}
 
 // GetCredentialRequest represents the request for getting a credential
 type GetCredentialRequest struct {
@@ -40,35 +57,65 @@ type DeleteCredentialRequest struct {
 	CredentialID string `json:"credentialId" example:"123e4567-e89b-12d3-a456-426614174000"`
 }
 
+// UpdateCredentialStatusRequest represents an administrative verification decision on a credential
+type UpdateCredentialStatusRequest struct {
+	CredentialID string `json:"credentialId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Status       string `json:"status" validate:"required,oneof=Verified Rejected Revoked" example:"Verified"`
+}
+
 type AddCredentials struct {
 	model.AddCredentialRequest
 }
 
 func ValidateAddCredentials(Req AddCredentialRequest) model.AddCredentialRequest {
+	return model.AddCredentialRequest{
+		VerificationType: Req.VerificationType,
+		Payload: model.CredentialPayload{
+			VerifiableCredential:    convertVerifiableCredential(Req.Payload.VerifiableCredential),
+			VerifiableCredentialJWT: Req.Payload.VerifiableCredentialJWT,
+			DocumentID:              Req.Payload.DocumentID,
+			Documents:               convertCredentialDocuments(Req.Payload.Documents),
+		},
+	}
+}
+
+// convertCredentialDocuments converts evidence document references to their model form
+func convertCredentialDocuments(documents []CredentialDocument) []model.CredentialDocument {
+	if documents == nil {
+		return nil
+	}
+
+	converted := make([]model.CredentialDocument, 0, len(documents))
+	for _, document := range documents {
+		converted = append(converted, model.CredentialDocument{DocumentID: document.DocumentID, Role: document.Role})
+	}
+	return converted
+}
+
+// convertVerifiableCredential converts a JSON-LD credential to its model form, keeping nil when absent
+func convertVerifiableCredential(vc *VerifiableCredential) *model.VerifiableCredential {
+	if vc == nil {
+		return nil
+	}
+
 	// Convert validation structures to model structures
 	credentialSubject := make(map[string]interface{})
-	for k, v := range Req.Payload.VerifiableCredential.CredentialSubject {
+	for k, v := range vc.CredentialSubject {
 		credentialSubject[k] = v
 	}
 
 	proof := make(map[string]interface{})
-	for k, v := range Req.Payload.VerifiableCredential.Proof {
+	for k, v := range vc.Proof {
 		proof[k] = v
 	}
 
-	return model.AddCredentialRequest{
-		VerificationType: Req.VerificationType,
-		Payload: model.CredentialPayload{
-			VerifiableCredential: model.VerifiableCredential{
-				Context:           Req.Payload.VerifiableCredential.Context,
-				ID:                Req.Payload.VerifiableCredential.ID,
-				Type:              Req.Payload.VerifiableCredential.Type,
-				Issuer:            Req.Payload.VerifiableCredential.Issuer,
-				IssuanceDate:      Req.Payload.VerifiableCredential.IssuanceDate,
-				CredentialSubject: credentialSubject,
-				Proof:             proof,
-			},
-			DocumentID: Req.Payload.DocumentID,
-		},
+	return &model.VerifiableCredential{
+		Context:           vc.Context,
+		ID:                vc.ID,
+		Type:              vc.Type,
+		Issuer:            vc.Issuer,
+		IssuanceDate:      vc.IssuanceDate,
+		CredentialSubject: credentialSubject,
+		Proof:             proof,
 	}
 }
@@ -38,3 +38,12 @@ func E164(field validator.FieldLevel) bool {
 
 	return false
 }
+
+// Fragment checks that a value can be used unescaped as a DID URL fragment
+func Fragment(field validator.FieldLevel) bool {
+	value, ok := field.Field().Interface().(string)
+	if !ok {
+		return false
+	}
+	return regexp.MustCompile(constants.RegexFragment).MatchString(value)
+}
@@ -0,0 +1,32 @@
+package validation
+
+// CreateDelegationGrantRequest represents the request for authorizing another actor to perform
+// scoped operations for the caller during a time window
+type CreateDelegationGrantRequest struct {
+	Delegate     string   `json:"delegate" validate:"required,max=255" example:"jane-doe"`
+	Scopes       []string `json:"scopes" validate:"required,min=1,max=4,unique,dive,oneof=profile:update credentials:add credentials:list documents:upload" example:"credentials:add,documents:upload"`
+	Relationship string   `json:"relationship,omitempty" validate:"omitempty,oneof=guardian accountant representative" example:"guardian"`
+	ValidFrom    string   `json:"validFrom,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-11-01T00:00:00Z"`
+	ExpiresAt    string   `json:"expiresAt" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2026-10-31T23:59:59Z"`
+}
+
+// ListDelegationGrantsRequest represents the request for listing delegation grants made or
+// received by the caller
+type ListDelegationGrantsRequest struct {
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor string `json:"cursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=scheduled active revoked expired" example:"active"`
+}
+
+// DelegationGrantIDRequest represents a request addressing a single delegation grant
+type DelegationGrantIDRequest struct {
+	GrantID string `json:"grantId" validate:"required,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+}
+
+// ListDelegatedActionsRequest represents the request for reviewing the actions delegates performed
+// for the caller, or the caller performed as a delegate
+type ListDelegatedActionsRequest struct {
+	GrantID string `json:"grantId,omitempty" validate:"omitempty,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Limit   int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor  string `json:"cursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
@@ -0,0 +1,33 @@
+package validation
+
+// RequestDeletionRequest represents the request for deleting the caller's account. The password
+// is required again so that a stolen session alone cannot erase an account.
+type RequestDeletionRequest struct {
+	Password string `json:"password" validate:"required,max=255" example:"SecurePass123!"`
+	Reason   string `json:"reason" validate:"omitempty,max=255" example:"No longer using the service"`
+}
+
+// DeletionCertificateRequest represents the request for the erasure certificate of a deletion,
+// authorized by the certificate token returned when the deletion was requested
+type DeletionCertificateRequest struct {
+	DeletionID       string `json:"deletionId" validate:"required,uuid" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+	CertificateToken string `json:"certificateToken" validate:"required" example:"kq3Yb8ZlW2v0bX7nF4cT9rA1sD6eG5hJ"`
+}
+
+// AddRetentionHoldRequest represents the request for placing a retention hold on an actor's
+// records. Without expiresAt the hold lasts until it is released.
+type AddRetentionHoldRequest struct {
+	ActorID   string `json:"actorId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Reason    string `json:"reason" validate:"required,max=255" example:"AML record keeping (5 years)"`
+	ExpiresAt string `json:"expiresAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2030-10-23T00:00:00Z"`
+}
+
+// ListRetentionHoldsRequest represents the request for the retention holds of an actor
+type ListRetentionHoldsRequest struct {
+	ActorID string `json:"actorId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// RetentionHoldRequest represents a request addressing one retention hold
+type RetentionHoldRequest struct {
+	HoldID string `json:"holdId" validate:"required,uuid" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+}
@@ -0,0 +1,14 @@
+package validation
+
+// AddDIDServiceRequest represents the request for publishing a service endpoint in the caller's
+// did:web document. ID is the service ID relative to the DID.
+type AddDIDServiceRequest struct {
+	ID              string `json:"id" validate:"required,max=64,fragment" example:"linked-domain"`
+	Type            string `json:"type" validate:"required,max=100" example:"LinkedDomains"`
+	ServiceEndpoint string `json:"serviceEndpoint" validate:"required,url,max=2048" example:"https://alice.example.com"`
+}
+
+// DIDServiceIDRequest represents a request addressing a single service endpoint
+type DIDServiceIDRequest struct {
+	ID string `json:"id" validate:"required,max=64,fragment" example:"linked-domain"`
+}
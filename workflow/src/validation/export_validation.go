@@ -0,0 +1,6 @@
+package validation
+
+// DataExportRequest represents a request addressing one of the caller's data exports
+type DataExportRequest struct {
+	ExportID string `json:"exportId" validate:"required,uuid" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+}
@@ -0,0 +1,6 @@
+package validation
+
+// JobIDRequest represents a request addressing a single background job
+type JobIDRequest struct {
+	JobID string `json:"jobId" validate:"required,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+}
@@ -0,0 +1,26 @@
+package validation
+
+// AddNamespaceDomainRequest represents the request for registering an identifier domain to an
+// organization. Open domains let anyone claim identifiers in them.
+type AddNamespaceDomainRequest struct {
+	Domain       string `json:"domain" validate:"required,max=253" example:"acme"`
+	OwnerActorID string `json:"ownerActorId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Open         bool   `json:"open" example:"false"`
+}
+
+// NamespaceDomainRequest represents a request addressing a registered identifier domain
+type NamespaceDomainRequest struct {
+	Domain string `json:"domain" validate:"required,max=253" example:"acme"`
+}
+
+// AddReservedWordRequest represents the request for reserving or blocking a word in identifiers
+type AddReservedWordRequest struct {
+	Word   string `json:"word" validate:"required,max=64" example:"admin"`
+	Kind   string `json:"kind" validate:"required,oneof=reserved blocked" example:"reserved"`
+	Reason string `json:"reason" validate:"omitempty,max=255" example:"Impersonates platform staff"`
+}
+
+// ReservedWordRequest represents a request addressing a reserved or blocked word
+type ReservedWordRequest struct {
+	Word string `json:"word" validate:"required,max=64" example:"admin"`
+}
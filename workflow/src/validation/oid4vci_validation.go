@@ -0,0 +1,34 @@
+package validation
+
+// CreateCredentialOfferRequest represents the request for creating an OID4VCI credential offer
+type CreateCredentialOfferRequest struct {
+	CredentialConfigurationIDs []string `json:"credentialConfigurationIds,omitempty" validate:"omitempty,dive,required" example:"VerifiedIdentityCredential_jwt_vc_json"`
+	RequireTxCode              bool     `json:"requireTxCode,omitempty" example:"true"`
+}
+
+// OID4VCITokenRequest represents the form parameters of the OID4VCI token endpoint
+type OID4VCITokenRequest struct {
+	GrantType         string `form:"grant_type" validate:"required"`
+	PreAuthorizedCode string `form:"pre-authorized_code" validate:"required"`
+	TxCode            string `form:"tx_code"`
+	UserPin           string `form:"user_pin"` // Name of tx_code in earlier drafts
+}
+
+// OID4VCICredentialRequest represents the body of the OID4VCI credential endpoint
+type OID4VCICredentialRequest struct {
+	CredentialConfigurationID string                       `json:"credential_configuration_id,omitempty"`
+	Format                    string                       `json:"format,omitempty"`
+	CredentialDefinition      *OID4VCICredentialDefinition `json:"credential_definition,omitempty"`
+	Proof                     *OID4VCIProof                `json:"proof" validate:"required"`
+}
+
+// OID4VCICredentialDefinition identifies a credential by its types
+type OID4VCICredentialDefinition struct {
+	Type []string `json:"type"`
+}
+
+// OID4VCIProof carries the wallet's proof of possession of the holder key
+type OID4VCIProof struct {
+	ProofType string `json:"proof_type" validate:"required"`
+	JWT       string `json:"jwt" validate:"required_if=ProofType jwt"`
+}
@@ -0,0 +1,24 @@
+package validation
+
+import "app/src/pex"
+
+// CreatePresentationRequest represents the request for creating an OID4VP authorization request
+type CreatePresentationRequest struct {
+	PresentationDefinition pex.PresentationDefinition `json:"presentationDefinition" validate:"required"`
+	CallbackURL            string                     `json:"callbackUrl,omitempty" validate:"omitempty,url,max=2048" example:"https://partner.example.com/presentations/callback"`
+}
+
+// PresentationRequestIDRequest represents a request addressing a single presentation request
+type PresentationRequestIDRequest struct {
+	RequestID string `json:"requestId" validate:"required,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+}
+
+// OID4VPDirectPostRequest represents the form parameters a wallet posts to the response URI.
+// A wallet declining the request sends error and error_description instead of a vp_token.
+type OID4VPDirectPostRequest struct {
+	VPToken                string `form:"vp_token"`
+	PresentationSubmission string `form:"presentation_submission"`
+	State                  string `form:"state"`
+	Error                  string `form:"error"`
+	ErrorDescription       string `form:"error_description"`
+}
@@ -0,0 +1,29 @@
+package validation
+
+// CreateOrganizationInvitationRequest represents the request for inviting an Individual actor,
+// named by universal identifier, to join the organization with a role
+type CreateOrganizationInvitationRequest struct {
+	Identifier string `json:"identifier" validate:"required,max=255" example:"alice@finternet"`
+	Role       string `json:"role" validate:"required,oneof=owner admin operator viewer" example:"operator"`
+}
+
+// OrganizationInvitationRequest represents a request addressing one organization invitation
+type OrganizationInvitationRequest struct {
+	InvitationID string `json:"invitationId" validate:"required,uuid" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+}
+
+// OrganizationMemberRequest represents a request addressing one member of the organization
+type OrganizationMemberRequest struct {
+	ActorID string `json:"actorId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// UpdateOrganizationMemberRequest represents the request for changing the role of a member
+type UpdateOrganizationMemberRequest struct {
+	ActorID string `json:"actorId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Role    string `json:"role" validate:"required,oneof=owner admin operator viewer" example:"admin"`
+}
+
+// OrganizationRequest represents a request addressing one organization the caller is a member of
+type OrganizationRequest struct {
+	OrganizationID string `json:"organizationId" validate:"required,uuid" example:"019a1b2c-3d4e-7f60-8a9b-0c1d2e3f4a5b"`
+}
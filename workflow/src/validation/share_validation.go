@@ -0,0 +1,40 @@
+package validation
+
+// CreateShareGrantRequest represents the request for sharing credentials with another actor
+type CreateShareGrantRequest struct {
+	Grantee       string   `json:"grantee" validate:"required,max=255" example:"acme-bank"`
+	CredentialIDs []string `json:"credentialIds" validate:"required,min=1,max=100,unique,dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ExpiresAt     string   `json:"expiresAt" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2025-12-31T23:59:59Z"`
+	Purpose       string   `json:"purpose,omitempty" validate:"omitempty,max=255" example:"Mortgage application KYC"`
+}
+
+// ListShareGrantsRequest represents the request for listing grants made or received by the caller
+type ListShareGrantsRequest struct {
+	Limit  int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor string `json:"cursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=active revoked expired" example:"active"`
+}
+
+// ShareGrantIDRequest represents a request addressing a single share grant
+type ShareGrantIDRequest struct {
+	GrantID string `json:"grantId" validate:"required,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+}
+
+// ListShareAccessLogRequest represents the request for reviewing accesses to the caller's shared data
+type ListShareAccessLogRequest struct {
+	GrantID string `json:"grantId,omitempty" validate:"omitempty,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	Limit   int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor  string `json:"cursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
+
+// SharedCredentialRequest represents a grantee request for one credential covered by a grant
+type SharedCredentialRequest struct {
+	GrantID      string `json:"grantId" validate:"required,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	CredentialID string `json:"credentialId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// SharedDocumentRequest represents a grantee request for one evidence document of a shared credential
+type SharedDocumentRequest struct {
+	SharedCredentialRequest
+	DocumentID string `json:"documentId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174222"`
+}
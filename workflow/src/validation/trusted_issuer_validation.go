@@ -0,0 +1,49 @@
+package validation
+
+// TrustedIssuerRequest represents the request payload for registering a trusted issuer
+type TrustedIssuerRequest struct {
+	IssuerDID         string   `json:"issuerDid" validate:"required,startswith=did:" example:"did:web:issuer.example.gov"`
+	Name              string   `json:"name" validate:"required" example:"Example Government ID Authority"`
+	VerificationTypes []string `json:"verificationTypes" validate:"required,min=1,dive,required" example:"GovernmentID"`
+	Jurisdictions     []string `json:"jurisdictions,omitempty" validate:"omitempty,dive,len=2" example:"US"`
+	ValidFrom         string   `json:"validFrom,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-01-01T00:00:00Z"`
+	ValidUntil        string   `json:"validUntil,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2027-01-01T00:00:00Z"`
+}
+
+// UpdateTrustedIssuerRequest represents the request payload for updating a trusted issuer
+type UpdateTrustedIssuerRequest struct {
+	IssuerID          string   `json:"issuerId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name              string   `json:"name,omitempty" example:"Example Government ID Authority"`
+	VerificationTypes []string `json:"verificationTypes,omitempty" validate:"omitempty,min=1,dive,required" example:"GovernmentID"`
+	Jurisdictions     []string `json:"jurisdictions,omitempty" validate:"omitempty,dive,len=2" example:"US"`
+	ValidFrom         *string  `json:"validFrom,omitempty" validate:"omitempty" example:"2025-01-01T00:00:00Z"`
+	ValidUntil        *string  `json:"validUntil,omitempty" validate:"omitempty" example:"2027-01-01T00:00:00Z"`
+	Status            string   `json:"status,omitempty" validate:"omitempty,oneof=active suspended" example:"active"`
+}
+
+// ListTrustedIssuersRequest represents the request for listing trusted issuers
+type ListTrustedIssuersRequest struct {
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=active suspended" example:"active"`
+}
+
+// TrustedIssuerIDRequest represents a request addressing a single trusted issuer
+type TrustedIssuerIDRequest struct {
+	IssuerID string `json:"issuerId" validate:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// TrustListEntry represents one issuer entry inside a signed trust-list file
+type TrustListEntry struct {
+	IssuerDID         string   `json:"issuerDid" validate:"required,startswith=did:"`
+	Name              string   `json:"name" validate:"required"`
+	VerificationTypes []string `json:"verificationTypes" validate:"required,min=1,dive,required"`
+	Jurisdictions     []string `json:"jurisdictions,omitempty" validate:"omitempty,dive,len=2"`
+	ValidFrom         string   `json:"validFrom,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ValidUntil        string   `json:"validUntil,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// TrustList represents the verified payload of a signed trust-list file
+type TrustList struct {
+	Version  string           `json:"version" validate:"required"`
+	IssuedAt string           `json:"issuedAt" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Issuers  []TrustListEntry `json:"issuers" validate:"required,dive"`
+}
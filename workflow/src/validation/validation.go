@@ -20,6 +20,7 @@ var customMessages = map[string]string{
 	constants.ValidationTagNameOneOf:    constants.ValidationMsgOneOf,
 	constants.ValidationTagNamePassword: constants.ValidationMsgPassword,
 	constants.ValidationTagNameE164:     constants.ValidationMsgE164,
+	constants.ValidationTagNameFragment: constants.ValidationMsgFragment,
 }
 
 func CustomErrorMessages(err error) map[string]string {
@@ -73,5 +74,9 @@ func NewValidator() *validator.Validate {
 		return nil
 	}
 
+	if err := validate.RegisterValidation(constants.ValidationTagNameFragment, Fragment); err != nil {
+		return nil
+	}
+
 	return validate
 }
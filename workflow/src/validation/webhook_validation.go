@@ -0,0 +1,27 @@
+package validation
+
+// CreateWebhookSubscriptionRequest represents the request for registering a webhook endpoint
+type CreateWebhookSubscriptionRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048" example:"https://partner.example.com/webhooks/units"`
+	Events      []string `json:"events" validate:"required,min=1,unique,dive,oneof=credential.status_changed actor.verification_level_changed document.uploaded actor.key_rotated actor.key_recovery_requested actor.deletion_requested actor.deletion_cancelled actor.erased actor.data_export_ready" example:"credential.status_changed"`
+	Description string   `json:"description,omitempty" validate:"omitempty,max=255" example:"Loan origination status sync"`
+}
+
+// WebhookSubscriptionIDRequest represents a request addressing a single webhook subscription
+type WebhookSubscriptionIDRequest struct {
+	SubscriptionID string `json:"subscriptionId" validate:"required,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+}
+
+// ListWebhookDeliveriesRequest represents the request for reviewing the webhook delivery log
+type ListWebhookDeliveriesRequest struct {
+	SubscriptionID string `json:"subscriptionId,omitempty" validate:"omitempty,uuid" example:"0193a7c4-1f2e-7b3a-9c4d-5e6f7a8b9c0d"`
+	EventType      string `json:"eventType,omitempty" example:"credential.status_changed"`
+	Status         string `json:"status,omitempty" validate:"omitempty,oneof=pending delivered failed" example:"failed"`
+	Limit          int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100" example:"20"`
+	Cursor         string `json:"cursor,omitempty" example:"MjAyNS0xMC0yM1QwNjoyNToyNS4xOTFafDEyM2U0NTY3LWU4OWItMTJkMy1hNDU2LTQyNjYxNDE3NDAwMA"`
+}
+
+// WebhookDeliveryIDRequest represents a request addressing a single webhook delivery
+type WebhookDeliveryIDRequest struct {
+	DeliveryID string `json:"deliveryId" validate:"required,uuid" example:"0193a7c5-0a1b-7c2d-8e3f-4a5b6c7d8e9f"`
+}
@@ -0,0 +1,55 @@
+package adapter_test
+
+import (
+	"errors"
+	"testing"
+
+	"app/src/adapter"
+	"app/src/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingConfig counts provider creations and fails when err is set
+type countingConfig struct {
+	created int
+	err     error
+}
+
+func (c *countingConfig) CreateProvider() (adapter.StorageProvider, error) {
+	c.created++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &adapter.MinIOAdapter{}, nil
+}
+
+func TestLazyStorage(t *testing.T) {
+	t.Run("creates the provider once", func(t *testing.T) {
+		cfg := &countingConfig{}
+		storage := adapter.NewLazyStorage(adapter.NewStorageFactory(cfg))
+		assert.Zero(t, cfg.created)
+
+		first, err := storage.Provider()
+		require.NoError(t, err)
+		second, err := storage.Provider()
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, cfg.created)
+	})
+
+	t.Run("keeps the creation error", func(t *testing.T) {
+		cfg := &countingConfig{err: errors.New("bucket unreachable")}
+		storage := adapter.NewLazyStorage(adapter.NewStorageFactory(cfg))
+
+		for i := 0; i < 2; i++ {
+			_, err := storage.Provider()
+			require.Error(t, err)
+			assert.ErrorIs(t, err, cfg.err)
+			assert.Contains(t, err.Error(), constants.ErrFailedToCreateStorageProvider)
+		}
+		assert.Equal(t, 1, cfg.created)
+	})
+}
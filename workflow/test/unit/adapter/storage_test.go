@@ -3,6 +3,7 @@ package adapter_test
 import (
 	"bytes"
 	"context"
+	"io"
 	"testing"
 
 	"app/src/adapter"
@@ -45,6 +46,16 @@ func testStorageOperations(t *testing.T, storage adapter.StorageProvider) {
 		assert.True(t, exists)
 	})
 
+	t.Run("Download file", func(t *testing.T) {
+		reader, err := storage.Download(ctx, testKey)
+		require.NoError(t, err)
+		defer reader.Close()
+
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, testContent, content)
+	})
+
 	t.Run("Delete file", func(t *testing.T) {
 		err := storage.Delete(ctx, testKey)
 		require.NoError(t, err)
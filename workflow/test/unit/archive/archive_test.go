@@ -0,0 +1,114 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+	"time"
+
+	"app/src/archive"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var limits = archive.Limits{MaxFiles: 10, MaxBytes: 1 << 20}
+
+// build writes an archive with the given listed files and an unlisted manifest
+func build(t *testing.T, files map[string]string) ([]byte, []archive.Entry) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := archive.NewWriter(&buf, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC))
+	for name, content := range files {
+		require.NoError(t, w.Add(name, []byte(content)))
+	}
+	require.NoError(t, w.AddUnlisted("manifest.json", []byte("{}")))
+	require.NoError(t, w.Close())
+	return buf.Bytes(), w.Entries()
+}
+
+// rawZip writes a zip archive without going through archive.Writer
+func rawZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	data, entries := build(t, map[string]string{
+		"credentials/a/token.json": `{"status":"Verified"}`,
+		"documents/b/content":      "passport scan",
+	})
+	require.Len(t, entries, 2)
+
+	a, err := archive.Read(data, limits)
+	require.NoError(t, err)
+	require.NoError(t, a.Verify(entries, "manifest.json"))
+
+	content, ok := a.File("documents/b/content")
+	require.True(t, ok)
+	assert.Equal(t, "passport scan", string(content))
+}
+
+func TestVerify(t *testing.T) {
+	data, entries := build(t, map[string]string{"documents/b/content": "passport scan"})
+	a, err := archive.Read(data, limits)
+	require.NoError(t, err)
+
+	t.Run("modified file", func(t *testing.T) {
+		tampered := append([]archive.Entry(nil), entries...)
+		tampered[0].SHA256 = "00" + tampered[0].SHA256[2:]
+		assert.ErrorIs(t, a.Verify(tampered, "manifest.json"), archive.ErrIntegrity)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		missing := append(append([]archive.Entry(nil), entries...), archive.Entry{Path: "documents/c/content"})
+		assert.ErrorIs(t, a.Verify(missing, "manifest.json"), archive.ErrIntegrity)
+	})
+
+	t.Run("unlisted file", func(t *testing.T) {
+		assert.ErrorIs(t, a.Verify(entries), archive.ErrIntegrity)
+	})
+}
+
+func TestReadRejectsUnsafeArchives(t *testing.T) {
+	t.Run("path traversal", func(t *testing.T) {
+		_, err := archive.Read(rawZip(t, map[string]string{"../etc/passwd": "x"}), limits)
+		assert.ErrorIs(t, err, archive.ErrInvalid)
+	})
+
+	t.Run("absolute path", func(t *testing.T) {
+		_, err := archive.Read(rawZip(t, map[string]string{"/etc/passwd": "x"}), limits)
+		assert.ErrorIs(t, err, archive.ErrInvalid)
+	})
+
+	t.Run("too many files", func(t *testing.T) {
+		files := map[string]string{}
+		for _, name := range []string{"a", "b", "c"} {
+			files[name] = name
+		}
+		_, err := archive.Read(rawZip(t, files), archive.Limits{MaxFiles: 2, MaxBytes: 1 << 20})
+		assert.ErrorIs(t, err, archive.ErrInvalid)
+	})
+
+	t.Run("uncompressed size over limit", func(t *testing.T) {
+		data := rawZip(t, map[string]string{"big": string(bytes.Repeat([]byte("a"), 4096))})
+		_, err := archive.Read(data, archive.Limits{MaxFiles: 10, MaxBytes: 1024})
+		assert.ErrorIs(t, err, archive.ErrInvalid)
+	})
+
+	t.Run("not a zip", func(t *testing.T) {
+		_, err := archive.Read([]byte("not a zip"), limits)
+		assert.ErrorIs(t, err, archive.ErrInvalid)
+	})
+}
@@ -0,0 +1,75 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := cache.NewLRU[string, int](2, time.Minute)
+
+	lru.Add("a", 1)
+	lru.Add("b", 2)
+	_, _ = lru.Get("a") // b is now the least recently used
+	lru.Add("c", 3)
+
+	_, ok := lru.Get("b")
+	assert.False(t, ok)
+	value, ok := lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRUUpdateRefreshesEntry(t *testing.T) {
+	lru := cache.NewLRU[string, int](2, time.Minute)
+
+	lru.Add("a", 1)
+	lru.Add("b", 2)
+	lru.Add("a", 10)
+	lru.Add("c", 3)
+
+	value, ok := lru.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	_, ok = lru.Get("b")
+	assert.False(t, ok)
+}
+
+func TestLRUExpiry(t *testing.T) {
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	lru := cache.NewLRU[string, int](10, time.Minute)
+	lru.SetClock(func() time.Time { return now })
+
+	lru.Add("a", 1)
+	now = now.Add(59 * time.Second)
+	_, ok := lru.Get("a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = lru.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, lru.Len())
+}
+
+func TestLRURemove(t *testing.T) {
+	lru := cache.NewLRU[string, int](10, time.Minute)
+	lru.Add("a", 1)
+	lru.Add("b", 2)
+	lru.Add("c", 3)
+
+	lru.Remove("a")
+	removed := lru.RemoveFunc(func(_ string, value int) bool { return value > 2 })
+
+	assert.Equal(t, 1, removed)
+	_, ok := lru.Get("a")
+	assert.False(t, ok)
+	_, ok = lru.Get("c")
+	assert.False(t, ok)
+	_, ok = lru.Get("b")
+	assert.True(t, ok)
+}
@@ -0,0 +1,151 @@
+package did_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"app/src/did"
+	"app/src/keys"
+	"app/src/netguard"
+
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDIDKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	identifier := "z" + base58.Encode(append([]byte{0xed, 0x01}, pub...))
+	didKey := "did:key:" + identifier
+
+	document, err := did.NewResolver().Resolve(context.Background(), didKey)
+	require.NoError(t, err)
+	assert.Equal(t, didKey, document.ID)
+
+	method, err := document.FindVerificationMethod("#" + identifier)
+	require.NoError(t, err)
+	assert.True(t, document.IsAssertionMethod(method))
+
+	key, err := method.PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+}
+
+func TestResolveDIDJWK(t *testing.T) {
+	jwk := `{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`
+	didJWK := "did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(jwk))
+
+	document, err := did.NewResolver().Resolve(context.Background(), didJWK)
+	require.NoError(t, err)
+
+	methods := document.AssertionMethods()
+	require.Len(t, methods, 1)
+	assert.Equal(t, didJWK+"#0", methods[0].ID)
+
+	_, err = methods[0].PublicKey()
+	assert.NoError(t, err)
+}
+
+func TestResolveRejectsInvalidDIDs(t *testing.T) {
+	resolver := did.NewResolver()
+
+	_, err := resolver.Resolve(context.Background(), "not-a-did")
+	assert.ErrorIs(t, err, did.ErrInvalidDID)
+
+	_, err = resolver.Resolve(context.Background(), "did:example:123")
+	assert.ErrorIs(t, err, did.ErrUnsupportedMethod)
+
+	_, err = resolver.Resolve(context.Background(), "did:key:zInvalid")
+	assert.ErrorIs(t, err, did.ErrInvalidDID)
+}
+
+func TestWebDocumentURL(t *testing.T) {
+	tests := map[string]string{
+		"example.com":              "https://example.com/.well-known/did.json",
+		"example.com%3A8443":       "https://example.com:8443/.well-known/did.json",
+		"example.com:users:alice":  "https://example.com/users/alice/did.json",
+		"issuer.example.gov:id:v1": "https://issuer.example.gov/id/v1/did.json",
+	}
+
+	for identifier, want := range tests {
+		got, err := did.WebDocumentURL(identifier)
+		require.NoError(t, err, identifier)
+		assert.Equal(t, want, got)
+	}
+
+	for _, identifier := range []string{"user@example.com", "127.0.0.1%3A8080", "169.254.169.254", "%5B%3A%3A1%5D%3A8443:users"} {
+		_, err := did.WebDocumentURL(identifier)
+		assert.ErrorIs(t, err, did.ErrInvalidDID, identifier)
+	}
+}
+
+func TestResolveWebRefusesInternalHosts(t *testing.T) {
+	_, err := did.NewResolver().Resolve(context.Background(), "did:web:localhost%3A8080")
+	assert.ErrorIs(t, err, did.ErrResolutionFailed)
+	assert.ErrorContains(t, err, netguard.ErrForbiddenAddress.Error())
+}
+
+func TestJWKDIDRoundTrip(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwk, err := keys.NewJWK(pub)
+	require.NoError(t, err)
+	jwk.Kid = "ignored"
+
+	didJWK, err := did.JWKDID(jwk)
+	require.NoError(t, err)
+
+	document, err := did.NewResolver().Resolve(context.Background(), didJWK)
+	require.NoError(t, err)
+
+	key, err := document.AssertionMethods()[0].PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+}
+
+func TestWebDIDFromURL(t *testing.T) {
+	id, err := did.WebDIDFromURL("https://issuer.example.com:8443/base")
+	require.NoError(t, err)
+	assert.Equal(t, "did:web:issuer.example.com%3A8443", id)
+
+	_, err = did.WebDIDFromURL("not a url")
+	assert.ErrorIs(t, err, did.ErrInvalidDID)
+
+	t.Run("path segments are escaped and round-trip to the document URL", func(t *testing.T) {
+		id, err := did.WebDIDFromURL("https://node.example.com", "alice@finternet")
+		require.NoError(t, err)
+		assert.Equal(t, "did:web:node.example.com:alice%40finternet", id)
+
+		_, identifier, err := did.Parse(id)
+		require.NoError(t, err)
+		documentURL, err := did.WebDocumentURL(identifier)
+		require.NoError(t, err)
+		assert.Equal(t, "https://node.example.com/alice@finternet/did.json", documentURL)
+	})
+
+	t.Run("empty path segment", func(t *testing.T) {
+		_, err := did.WebDIDFromURL("https://node.example.com", "")
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+	})
+}
+
+func TestKeyDIDResolvesToItsKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	didKey, err := did.KeyDID(pub)
+	require.NoError(t, err)
+	assert.Equal(t, "did:key:z"+base58.Encode(append([]byte{0xed, 0x01}, pub...)), didKey)
+
+	document, err := did.NewResolver().Resolve(context.Background(), didKey)
+	require.NoError(t, err)
+	key, err := document.VerificationMethod[0].PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+}
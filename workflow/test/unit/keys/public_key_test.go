@@ -0,0 +1,182 @@
+package keys_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"app/src/keys"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pemSPKI encodes DER SubjectPublicKeyInfo bytes as a PEM public key
+func pemSPKI(der []byte) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// secp256k1SPKI encodes a secp256k1 key, which crypto/x509 cannot marshal
+func secp256k1SPKI(t *testing.T, key *secp256k1.PublicKey) string {
+	t.Helper()
+
+	curve, err := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+	require.NoError(t, err)
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+			Parameters: asn1.RawValue{FullBytes: curve},
+		},
+		PublicKey: asn1.BitString{Bytes: key.SerializeUncompressed(), BitLength: 8 * 65},
+	})
+	require.NoError(t, err)
+	return pemSPKI(der)
+}
+
+func TestParsePublicKeyAndEncodeMultibase(t *testing.T) {
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	k1Key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	edDER, err := x509.MarshalPKIXPublicKey(edKey)
+	require.NoError(t, err)
+	p256DER, err := x509.MarshalPKIXPublicKey(&p256Key.PublicKey)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		pem    string
+		key    crypto.PublicKey
+		prefix string
+	}{
+		{"Ed25519", pemSPKI(edDER), edKey, "z6Mk"},
+		{"P-256", pemSPKI(p256DER), &p256Key.PublicKey, "zDn"},
+		{"secp256k1", secp256k1SPKI(t, k1Key.PubKey()), k1Key.PubKey(), "zQ3s"},
+		{"RSA", pemSPKI(rsaDER), &rsaKey.PublicKey, "z4MX"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := keys.ParsePublicKey(tt.pem)
+			require.NoError(t, err)
+
+			encoded, err := keys.EncodeMultibaseKey(parsed)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encoded, tt.prefix), encoded)
+
+			// The multibase form parses back to the same key
+			decoded, err := keys.ParsePublicKey(encoded)
+			require.NoError(t, err)
+			again, err := keys.EncodeMultibaseKey(decoded)
+			require.NoError(t, err)
+			assert.Equal(t, encoded, again)
+
+			original, err := keys.EncodeMultibaseKey(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, original, encoded)
+		})
+	}
+}
+
+func TestEncodeMultibaseKeyRejectsUnsupportedKeys(t *testing.T) {
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	_, err = keys.EncodeMultibaseKey(&p384Key.PublicKey)
+	assert.ErrorIs(t, err, keys.ErrUnsupportedKey)
+}
+
+func TestParsePublicKeyRejectsInvalidInput(t *testing.T) {
+	for _, value := range []string{"", "not a key", "-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----"} {
+		_, err := keys.ParsePublicKey(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// rfc7638Key is the example RSA key of RFC 7638 section 3.1
+const rfc7638Key = `{
+	"kty": "RSA",
+	"n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	"e": "AQAB",
+	"alg": "RS256",
+	"kid": "2011-04-29"
+}`
+
+func TestNormalizePublicKey(t *testing.T) {
+	t.Run("RFC 7638 thumbprint", func(t *testing.T) {
+		normalized, err := keys.NormalizePublicKey(rfc7638Key)
+		require.NoError(t, err)
+		assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", normalized.Thumbprint)
+		assert.True(t, strings.HasPrefix(normalized.PEM, "-----BEGIN PUBLIC KEY-----"))
+	})
+
+	t.Run("every form of a key normalizes the same", func(t *testing.T) {
+		k1Key, err := secp256k1.GeneratePrivateKey()
+		require.NoError(t, err)
+		p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		edKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		for _, key := range []crypto.PublicKey{k1Key.PubKey(), &p256Key.PublicKey, edKey} {
+			encodedPEM, err := keys.MarshalPublicKeyPEM(key)
+			require.NoError(t, err)
+			multibase, err := keys.EncodeMultibaseKey(key)
+			require.NoError(t, err)
+			jwk, err := keys.NewJWK(key)
+			require.NoError(t, err)
+			jwkJSON, err := json.Marshal(jwk)
+			require.NoError(t, err)
+
+			expected, err := keys.Thumbprint(key)
+			require.NoError(t, err)
+			for _, value := range []string{encodedPEM, multibase, string(jwkJSON)} {
+				normalized, err := keys.NormalizePublicKey(value)
+				require.NoError(t, err, value)
+				assert.Equal(t, expected, normalized.Thumbprint)
+				assert.Equal(t, encodedPEM, normalized.PEM)
+			}
+		}
+	})
+
+	t.Run("weak and unsupported keys", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+		require.NoError(t, err)
+		_, err = keys.NormalizePublicKey(pemSPKI(der))
+		assert.ErrorIs(t, err, keys.ErrWeakKey)
+
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		der, err = x509.MarshalPKIXPublicKey(&p384Key.PublicKey)
+		require.NoError(t, err)
+		_, err = keys.NormalizePublicKey(pemSPKI(der))
+		assert.ErrorIs(t, err, keys.ErrUnsupportedKey)
+	})
+
+	t.Run("private JWK", func(t *testing.T) {
+		_, err := keys.NormalizePublicKey(`{"kty":"OKP","crv":"Ed25519","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A"}`)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package keys_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"app/src/keys"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignerRoundTrip(t *testing.T) {
+	p256, err := keys.GenerateSigningKey()
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k1, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		key interface{}
+		alg string
+	}{
+		"P-256":     {p256, "ES256"},
+		"Ed25519":   {edKey, "EdDSA"},
+		"secp256k1": {k1, keys.AlgES256K},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			signer, err := keys.NewSigner("did:web:issuer.example.com#key-1", tc.key)
+			require.NoError(t, err)
+			assert.Equal(t, tc.alg, signer.Algorithm())
+
+			compact, err := signer.Sign(jwt.MapClaims{"sub": "holder"}, map[string]interface{}{"typ": "JWT"})
+			require.NoError(t, err)
+
+			// Verify through the published JWK to check both encodings agree
+			jwk, err := signer.PublicJWK()
+			require.NoError(t, err)
+			assert.Equal(t, signer.KeyID(), jwk.Kid)
+
+			token, err := jwt.Parse(compact, func(token *jwt.Token) (interface{}, error) {
+				assert.Equal(t, signer.KeyID(), token.Header["kid"])
+				return jwk.PublicKey()
+			}, jwt.WithValidMethods([]string{tc.alg}))
+			require.NoError(t, err)
+			assert.True(t, token.Valid)
+		})
+	}
+}
+
+func TestNewSignerRejectsUnsupportedKeys(t *testing.T) {
+	_, err := keys.NewSigner("kid", "not a key")
+	assert.ErrorIs(t, err, keys.ErrUnsupportedKey)
+}
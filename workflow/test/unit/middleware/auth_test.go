@@ -0,0 +1,18 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"app/src/middleware"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthClaimsHasRole(t *testing.T) {
+	claims := &middleware.AuthClaims{}
+	claims.RealmAccess.Roles = []string{"offline_access", "admin"}
+
+	assert.True(t, claims.HasRole("admin"))
+	assert.False(t, claims.HasRole("auditor"))
+	assert.False(t, (&middleware.AuthClaims{}).HasRole("admin"))
+}
@@ -0,0 +1,144 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/middleware"
+	"app/src/model"
+	"app/src/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeDelegations returns the grants it holds for any principal and delegate
+type fakeDelegations struct {
+	repository.DelegationGrantRepository
+	grants []model.DelegationGrant
+}
+
+func (f *fakeDelegations) FindActive(_ context.Context, _ *gorm.DB, _, _ uuid.UUID, _ time.Time) ([]model.DelegationGrant, error) {
+	return f.grants, nil
+}
+
+// fakeDelegatedActions records the audited actions
+type fakeDelegatedActions struct {
+	repository.DelegatedActionRepository
+	actions []model.DelegatedAction
+}
+
+func (f *fakeDelegatedActions) Create(_ context.Context, _ *gorm.DB, action *model.DelegatedAction) error {
+	f.actions = append(f.actions, *action)
+	return nil
+}
+
+func TestActOnBehalfOf(t *testing.T) {
+	principalID := uuid.New()
+	delegateID := uuid.New()
+	grant := model.DelegationGrant{
+		GrantID:     uuid.New(),
+		PrincipalID: principalID,
+		DelegateID:  delegateID,
+		Scopes:      []string{constants.DelegationScopeCredentialsAdd},
+		ValidFrom:   time.Now().Add(-time.Hour),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	// newApp serves a route as the delegate, answering with the actor the handler acts for
+	newApp := func(grants []model.DelegationGrant, handlerErr error) (*fiber.App, *fakeDelegatedActions) {
+		actions := &fakeDelegatedActions{}
+		auth := middleware.NewAuthMiddleware(logrus.New(), nil, nil, nil, &fakeDelegations{grants: grants}, actions)
+
+		app := fiber.New()
+		app.Post("/credentials/add",
+			func(c *fiber.Ctx) error {
+				c.Locals("actorID", delegateID)
+				return c.Next()
+			},
+			auth.ActOnBehalfOf(constants.DelegationScopeCredentialsAdd),
+			func(c *fiber.Ctx) error {
+				if handlerErr != nil {
+					return handlerErr
+				}
+				return c.SendString(c.Locals("actorID").(uuid.UUID).String())
+			})
+		return app, actions
+	}
+
+	send := func(t *testing.T, app *fiber.App, headers map[string]string) (int, string) {
+		req := httptest.NewRequest(fiber.MethodPost, "/credentials/add", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		return resp.StatusCode, string(body[:n])
+	}
+
+	t.Run("without the header the caller acts for themselves", func(t *testing.T) {
+		app, actions := newApp(nil, nil)
+		status, body := send(t, app, nil)
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, delegateID.String(), body)
+		assert.Empty(t, actions.actions)
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		app, _ := newApp(nil, nil)
+		status, _ := send(t, app, map[string]string{constants.HTTPHeaderOnBehalfOf: "jane-doe"})
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("cannot be combined with an organization", func(t *testing.T) {
+		app, _ := newApp([]model.DelegationGrant{grant}, nil)
+		status, _ := send(t, app, map[string]string{
+			constants.HTTPHeaderOnBehalfOf:   principalID.String(),
+			constants.HTTPHeaderOrganization: uuid.NewString(),
+		})
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("no grant covers the scope", func(t *testing.T) {
+		other := grant
+		other.Scopes = []string{constants.DelegationScopeCredentialsList}
+		app, actions := newApp([]model.DelegationGrant{other}, nil)
+		status, _ := send(t, app, map[string]string{constants.HTTPHeaderOnBehalfOf: principalID.String()})
+		assert.Equal(t, fiber.StatusForbidden, status)
+		assert.Empty(t, actions.actions)
+	})
+
+	t.Run("acts for the principal and audits both identities", func(t *testing.T) {
+		app, actions := newApp([]model.DelegationGrant{grant}, nil)
+		status, body := send(t, app, map[string]string{constants.HTTPHeaderOnBehalfOf: principalID.String()})
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, principalID.String(), body)
+
+		require.Len(t, actions.actions, 1)
+		action := actions.actions[0]
+		assert.Equal(t, grant.GrantID, action.GrantID)
+		assert.Equal(t, principalID, action.PrincipalID)
+		assert.Equal(t, delegateID, action.DelegateID)
+		assert.Equal(t, constants.DelegationScopeCredentialsAdd, action.Scope)
+		assert.Equal(t, "/credentials/add", action.Path)
+		assert.Equal(t, fiber.StatusOK, action.StatusCode)
+	})
+
+	t.Run("failed requests are audited with their status", func(t *testing.T) {
+		app, actions := newApp([]model.DelegationGrant{grant}, fiber.NewError(fiber.StatusConflict, "duplicate"))
+		status, _ := send(t, app, map[string]string{constants.HTTPHeaderOnBehalfOf: principalID.String()})
+		assert.Equal(t, fiber.StatusConflict, status)
+
+		require.Len(t, actions.actions, 1)
+		assert.Equal(t, fiber.StatusConflict, actions.actions[0].StatusCode)
+	})
+}
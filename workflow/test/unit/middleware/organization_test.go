@@ -0,0 +1,113 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"app/src/constants"
+	"app/src/middleware"
+	"app/src/model"
+	"app/src/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeMembers knows the members of one organization
+type fakeMembers struct {
+	repository.OrganizationRepository
+	organizationID uuid.UUID
+	roles          map[uuid.UUID]string
+}
+
+func (f *fakeMembers) FindMember(_ context.Context, _ *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error) {
+	role, ok := f.roles[actorID]
+	if !ok || organizationID != f.organizationID {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationMemberNotFound)
+	}
+	return &model.OrganizationMember{OrganizationID: organizationID, ActorID: actorID, Role: role}, nil
+}
+
+func TestActAsOrganization(t *testing.T) {
+	organizationID := uuid.New()
+	viewerID := uuid.New()
+	operatorID := uuid.New()
+	outsiderID := uuid.New()
+	members := &fakeMembers{organizationID: organizationID, roles: map[uuid.UUID]string{
+		viewerID:   constants.OrganizationRoleViewer,
+		operatorID: constants.OrganizationRoleOperator,
+	}}
+	auth := middleware.NewAuthMiddleware(logrus.New(), nil, nil, members, nil, nil)
+
+	// send calls a route requiring the operator role as the caller, answering with the actor the
+	// handler acts for and the member behind it
+	send := func(t *testing.T, callerID uuid.UUID, header string) (int, string) {
+		app := fiber.New()
+		app.Post("/credentials/add",
+			func(c *fiber.Ctx) error {
+				c.Locals("actorID", callerID)
+				return c.Next()
+			},
+			auth.ActAsOrganization(constants.OrganizationRoleOperator),
+			func(c *fiber.Ctx) error {
+				body := c.Locals("actorID").(uuid.UUID).String()
+				if memberID, ok := c.Locals("memberID").(uuid.UUID); ok {
+					body += " " + memberID.String() + " " + c.Locals("organizationRole").(string)
+				}
+				return c.SendString(body)
+			})
+
+		req := httptest.NewRequest(fiber.MethodPost, "/credentials/add", nil)
+		if header != "" {
+			req.Header.Set(constants.HTTPHeaderOrganization, header)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body := make([]byte, 128)
+		n, _ := resp.Body.Read(body)
+		return resp.StatusCode, string(body[:n])
+	}
+
+	t.Run("without the header the caller acts for themselves", func(t *testing.T) {
+		status, body := send(t, outsiderID, "")
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, outsiderID.String(), body)
+	})
+
+	t.Run("malformed header", func(t *testing.T) {
+		status, _ := send(t, operatorID, "acme")
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("non-members are rejected", func(t *testing.T) {
+		status, _ := send(t, outsiderID, organizationID.String())
+		assert.Equal(t, fiber.StatusForbidden, status)
+	})
+
+	t.Run("members of other organizations are rejected", func(t *testing.T) {
+		status, _ := send(t, operatorID, uuid.NewString())
+		assert.Equal(t, fiber.StatusForbidden, status)
+	})
+
+	t.Run("members without the role are rejected", func(t *testing.T) {
+		status, _ := send(t, viewerID, organizationID.String())
+		assert.Equal(t, fiber.StatusForbidden, status)
+	})
+
+	t.Run("members with the role act for the organization", func(t *testing.T) {
+		status, body := send(t, operatorID, organizationID.String())
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, organizationID.String()+" "+operatorID.String()+" "+constants.OrganizationRoleOperator, body)
+	})
+
+	t.Run("the organization acts for itself", func(t *testing.T) {
+		status, body := send(t, organizationID, organizationID.String())
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, organizationID.String(), body)
+	})
+}
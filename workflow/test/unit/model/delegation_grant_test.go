@@ -0,0 +1,47 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegationGrantStatus(t *testing.T) {
+	now := time.Date(2025, 10, 23, 6, 25, 25, 0, time.UTC)
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		grant model.DelegationGrant
+		want  string
+	}{
+		{"scheduled before the window", model.DelegationGrant{ValidFrom: now.Add(time.Hour), ExpiresAt: now.Add(2 * time.Hour)}, constants.DelegationGrantStatusScheduled},
+		{"active from the start instant", model.DelegationGrant{ValidFrom: now, ExpiresAt: now.Add(time.Hour)}, constants.DelegationGrantStatusActive},
+		{"expired at the expiry instant", model.DelegationGrant{ValidFrom: now.Add(-time.Hour), ExpiresAt: now}, constants.DelegationGrantStatusExpired},
+		{"revocation takes precedence", model.DelegationGrant{ValidFrom: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute), RevokedAt: &revokedAt}, constants.DelegationGrantStatusRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.grant.Status(now))
+		})
+	}
+}
+
+func TestDelegationGrantAllows(t *testing.T) {
+	now := time.Date(2025, 10, 23, 6, 25, 25, 0, time.UTC)
+	grant := model.DelegationGrant{
+		Scopes:    []string{constants.DelegationScopeCredentialsAdd, constants.DelegationScopeDocumentsUpload},
+		ValidFrom: now.Add(-time.Hour),
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	assert.True(t, grant.Allows(constants.DelegationScopeCredentialsAdd, now))
+	assert.False(t, grant.Allows(constants.DelegationScopeProfileUpdate, now))
+	assert.False(t, grant.Allows(constants.DelegationScopeCredentialsAdd, now.Add(-2*time.Hour)))
+	assert.False(t, grant.Allows(constants.DelegationScopeCredentialsAdd, now.Add(time.Hour)))
+}
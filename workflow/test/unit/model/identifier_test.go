@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleasedIdentifierReservedAgainst(t *testing.T) {
+	now := time.Date(2025, 10, 23, 6, 25, 25, 0, time.UTC)
+	holder := uuid.New()
+	released := model.ReleasedIdentifier{
+		Identifier:  "alice@finternet",
+		EntityID:    holder,
+		ReleasedAt:  now.Add(-time.Hour),
+		AvailableAt: now,
+	}
+
+	tests := []struct {
+		name     string
+		claimant uuid.UUID
+		at       time.Time
+		want     bool
+	}{
+		{"others during cooling-off", uuid.New(), now.Add(-time.Nanosecond), true},
+		{"registration during cooling-off", uuid.Nil, now.Add(-time.Nanosecond), true},
+		{"former holder during cooling-off", holder, now.Add(-time.Nanosecond), false},
+		{"others from the available instant", uuid.New(), now, false},
+		{"others after cooling-off", uuid.New(), now.Add(time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, released.ReservedAgainst(tt.claimant, tt.at))
+		})
+	}
+}
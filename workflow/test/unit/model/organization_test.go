@@ -0,0 +1,67 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationRoleAtLeast(t *testing.T) {
+	assert.True(t, model.OrganizationRoleAtLeast(constants.OrganizationRoleOwner, constants.OrganizationRoleAdmin))
+	assert.True(t, model.OrganizationRoleAtLeast(constants.OrganizationRoleOperator, constants.OrganizationRoleOperator))
+	assert.True(t, model.OrganizationRoleAtLeast(constants.OrganizationRoleOperator, constants.OrganizationRoleViewer))
+	assert.False(t, model.OrganizationRoleAtLeast(constants.OrganizationRoleViewer, constants.OrganizationRoleOperator))
+	assert.False(t, model.OrganizationRoleAtLeast("auditor", constants.OrganizationRoleViewer))
+}
+
+func TestCanManageOrganizationRole(t *testing.T) {
+	tests := []struct {
+		manager string
+		role    string
+		want    bool
+	}{
+		{constants.OrganizationRoleOwner, constants.OrganizationRoleOwner, true},
+		{constants.OrganizationRoleOwner, constants.OrganizationRoleAdmin, true},
+		{constants.OrganizationRoleOwner, "auditor", false},
+		{constants.OrganizationRoleAdmin, constants.OrganizationRoleOperator, true},
+		{constants.OrganizationRoleAdmin, constants.OrganizationRoleViewer, true},
+		{constants.OrganizationRoleAdmin, constants.OrganizationRoleAdmin, false},
+		{constants.OrganizationRoleAdmin, constants.OrganizationRoleOwner, false},
+		{constants.OrganizationRoleOperator, constants.OrganizationRoleViewer, false},
+		{constants.OrganizationRoleViewer, constants.OrganizationRoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.manager+" manages "+tt.role, func(t *testing.T) {
+			assert.Equal(t, tt.want, model.CanManageOrganizationRole(tt.manager, tt.role))
+		})
+	}
+}
+
+func TestOrganizationInvitationStatus(t *testing.T) {
+	now := time.Date(2025, 10, 23, 6, 25, 25, 0, time.UTC)
+	before := now.Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		invitation model.OrganizationInvitation
+		want       string
+	}{
+		{"pending until expiry", model.OrganizationInvitation{ExpiresAt: now.Add(time.Minute)}, constants.OrganizationInvitationStatusPending},
+		{"expired at the expiry instant", model.OrganizationInvitation{ExpiresAt: now}, constants.OrganizationInvitationStatusExpired},
+		{"accepted", model.OrganizationInvitation{ExpiresAt: now.Add(time.Minute), AcceptedAt: &before}, constants.OrganizationInvitationStatusAccepted},
+		{"declined", model.OrganizationInvitation{ExpiresAt: now.Add(time.Minute), DeclinedAt: &before}, constants.OrganizationInvitationStatusDeclined},
+		{"revoked", model.OrganizationInvitation{ExpiresAt: now.Add(time.Minute), RevokedAt: &before}, constants.OrganizationInvitationStatusRevoked},
+		{"answer takes precedence over expiry", model.OrganizationInvitation{ExpiresAt: now.Add(-time.Minute), AcceptedAt: &before}, constants.OrganizationInvitationStatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.invitation.Status(now))
+		})
+	}
+}
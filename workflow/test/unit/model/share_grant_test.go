@@ -0,0 +1,49 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareGrantStatus(t *testing.T) {
+	now := time.Date(2025, 10, 23, 6, 25, 25, 0, time.UTC)
+	revokedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		grant model.ShareGrant
+		want  string
+	}{
+		{"active until expiry", model.ShareGrant{ExpiresAt: now.Add(time.Hour)}, constants.ShareGrantStatusActive},
+		{"expired at the expiry instant", model.ShareGrant{ExpiresAt: now}, constants.ShareGrantStatusExpired},
+		{"expired after expiry", model.ShareGrant{ExpiresAt: now.Add(-time.Minute)}, constants.ShareGrantStatusExpired},
+		{"revoked takes precedence over expiry", model.ShareGrant{ExpiresAt: now.Add(-time.Minute), RevokedAt: &revokedAt}, constants.ShareGrantStatusRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.grant.Status(now))
+		})
+	}
+}
+
+func TestShareGrantBeforeCreateLinksTokens(t *testing.T) {
+	tokenIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	grant := model.ShareGrant{
+		Tokens: []model.ShareGrantToken{{TokenID: tokenIDs[0]}, {TokenID: tokenIDs[1]}},
+	}
+
+	require.NoError(t, grant.BeforeCreate(nil))
+	assert.NotEqual(t, uuid.Nil, grant.GrantID)
+	for _, token := range grant.Tokens {
+		assert.Equal(t, grant.GrantID, token.GrantID)
+	}
+	assert.Equal(t, tokenIDs, grant.TokenIDs())
+}
@@ -0,0 +1,35 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationCodeStatus(t *testing.T) {
+	now := time.Date(2025, 10, 23, 6, 25, 25, 0, time.UTC)
+	consumedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		code model.VerificationCode
+		want string
+	}{
+		{"pending until expiry", model.VerificationCode{ExpiresAt: now.Add(time.Minute)}, constants.VerificationCodeStatusPending},
+		{"pending with attempts left", model.VerificationCode{ExpiresAt: now.Add(time.Minute), Attempts: constants.VerificationMaxAttempts - 1}, constants.VerificationCodeStatusPending},
+		{"exhausted after the last attempt", model.VerificationCode{ExpiresAt: now.Add(time.Minute), Attempts: constants.VerificationMaxAttempts}, constants.VerificationCodeStatusExhausted},
+		{"expired at the expiry instant", model.VerificationCode{ExpiresAt: now}, constants.VerificationCodeStatusExpired},
+		{"expiry takes precedence over attempts", model.VerificationCode{ExpiresAt: now.Add(-time.Minute), Attempts: constants.VerificationMaxAttempts}, constants.VerificationCodeStatusExpired},
+		{"consumed takes precedence over expiry", model.VerificationCode{ExpiresAt: now.Add(-time.Minute), ConsumedAt: &consumedAt}, constants.VerificationCodeStatusConsumed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.code.Status(now))
+		})
+	}
+}
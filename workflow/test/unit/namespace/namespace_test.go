@@ -0,0 +1,134 @@
+package namespace_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"app/src/constants"
+	"app/src/namespace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRules() *namespace.Rules {
+	return &namespace.Rules{
+		DefaultDomain: constants.DefaultIdentifierDomain,
+		LocalPattern:  regexp.MustCompile(constants.DefaultIdentifierPattern),
+	}
+}
+
+func TestParse(t *testing.T) {
+	rules := newRules()
+
+	tests := []struct {
+		name      string
+		raw       string
+		canonical string
+		err       error
+	}{
+		{"qualified", "alice@finternet", "alice@finternet", nil},
+		{"default domain", "alice", "alice@finternet", nil},
+		{"case folded", "Alice@ACME.Example", "alice@acme.example", nil},
+		{"fullwidth", "ａｌｉｃｅ", "alice@finternet", nil},
+		{"separators", "alice.b-c_d", "alice.b-c_d@finternet", nil},
+		{"non-Latin script", "алиса", "алиса@finternet", nil},
+		{"Japanese scripts mixed", "ひら漢字カタ", "ひら漢字カタ@finternet", nil},
+		{"whitespace", "alice smith", "", namespace.ErrInvalidIdentifier},
+		{"leading separator", ".alice", "", namespace.ErrInvalidIdentifier},
+		{"symbols", "alice!", "", namespace.ErrInvalidIdentifier},
+		{"empty local part", "@finternet", "", namespace.ErrInvalidIdentifier},
+		{"Latin and Cyrillic", "pаypal", "", namespace.ErrMixedScript},
+		{"invalid domain", "alice@acme_corp", "", namespace.ErrInvalidDomain},
+		{"empty domain", "alice@", "", namespace.ErrInvalidDomain},
+		{"too long", strings.Repeat("a", 60) + "@" + strings.Repeat("b.", 100) + "c", "", namespace.ErrInvalidIdentifier},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := rules.Parse(tt.raw)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.canonical, id.String())
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	rules := newRules()
+
+	assert.Equal(t, "alice@finternet", rules.Canonical("ALICE"))
+	assert.Equal(t, "not valid", rules.Canonical("not valid"), "unparsable input is looked up as given")
+}
+
+func TestLegacy(t *testing.T) {
+	rules := newRules()
+
+	id := rules.Legacy("ＡＬＩＣＥ")
+	assert.Equal(t, "alice@finternet", id.String())
+	assert.Equal(t, namespace.Skeleton("alice@finternet"), id.Skeleton)
+
+	legacy := rules.Legacy("Alice Smith@ACME")
+	assert.Equal(t, "alice smith@acme", legacy.String(), "identifiers predating the syntax are kept")
+
+	parsed, err := rules.Parse("Pay-Pal@Finternet")
+	require.NoError(t, err)
+	assert.Equal(t, parsed, rules.Legacy("Pay-Pal@Finternet"), "valid identifiers match what Parse returns")
+}
+
+func TestSkeletonConfusables(t *testing.T) {
+	rules := newRules()
+
+	skeleton := func(raw string) string {
+		id, err := rules.Parse(raw)
+		require.NoError(t, err)
+		return id.Skeleton
+	}
+
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"digit zero", "google", "g00gle"},
+		{"digit one", "paypal", "paypa1"},
+		{"rn and m", "modern", "rnodern"},
+		{"cl and d", "david", "clavicl"},
+		{"Cyrillic", "coop", "соор"},
+		{"Greek", "opa", "ορα"},
+		{"case", "Alice", "alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, skeleton(tt.a), skeleton(tt.b))
+		})
+	}
+
+	assert.NotEqual(t, skeleton("alice"), skeleton("alicia"))
+	assert.NotEqual(t, skeleton("alice.b"), skeleton("alice-b"))
+}
+
+func TestWordSkeleton(t *testing.T) {
+	admin := namespace.WordSkeleton("admin")
+
+	assert.Equal(t, admin, namespace.WordSkeleton("ADMIN"))
+	assert.Equal(t, admin, namespace.WordSkeleton("ad-min"))
+	assert.Equal(t, admin, namespace.WordSkeleton("a.d_m.i.n"))
+	assert.Equal(t, admin, namespace.WordSkeleton("аdmin"), "Cyrillic a")
+	assert.Equal(t, admin, namespace.WordSkeleton("adrnin"))
+	assert.NotEqual(t, admin, namespace.WordSkeleton("adman"))
+}
+
+func TestValidDomain(t *testing.T) {
+	assert.True(t, namespace.ValidDomain("finternet"))
+	assert.True(t, namespace.ValidDomain("acme.example"))
+	assert.True(t, namespace.ValidDomain("bank-1"))
+	assert.False(t, namespace.ValidDomain("Acme"))
+	assert.False(t, namespace.ValidDomain("-acme"))
+	assert.False(t, namespace.ValidDomain("acme..example"))
+	assert.False(t, namespace.ValidDomain(""))
+}
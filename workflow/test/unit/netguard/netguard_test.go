@@ -0,0 +1,68 @@
+package netguard_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"app/src/netguard"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.215.14", true},
+		{"2606:2800:21f:cb07:6820:80da:af6b:8b2c", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"::", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, netguard.IsPublic(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, netguard.CheckHost(ctx, "93.184.215.14"))
+	assert.ErrorIs(t, netguard.CheckHost(ctx, "127.0.0.1"), netguard.ErrForbiddenAddress)
+	assert.ErrorIs(t, netguard.CheckHost(ctx, "169.254.169.254"), netguard.ErrForbiddenAddress)
+	assert.ErrorIs(t, netguard.CheckHost(ctx, "localhost"), netguard.ErrForbiddenAddress)
+}
+
+func TestNewClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := netguard.NewClient(time.Second).Get(server.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, netguard.ErrForbiddenAddress)
+}
@@ -0,0 +1,122 @@
+package pex_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"app/src/pex"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const credentialJSON = `{
+	"iss": "did:web:issuer.example.com",
+	"vc": {
+		"type": ["VerifiableCredential", "VerifiedIdentityCredential"],
+		"credentialSubject": {"verificationLevel": "Tier1_Verified", "age": 34, "country": "IN"}
+	}
+}`
+
+func decode(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &v))
+	return v
+}
+
+func TestQuery(t *testing.T) {
+	document := decode(t, credentialJSON)
+
+	value, err := pex.Query(document, "$.vc.credentialSubject.country")
+	require.NoError(t, err)
+	assert.Equal(t, "IN", value)
+
+	value, err = pex.Query(document, "$['vc']['type'][1]")
+	require.NoError(t, err)
+	assert.Equal(t, "VerifiedIdentityCredential", value)
+
+	_, err = pex.Query(document, "$.vc.type[5]")
+	assert.ErrorIs(t, err, pex.ErrPathNotFound)
+
+	assert.Error(t, pex.ValidatePath("$..type"))
+	assert.Error(t, pex.ValidatePath("vc.type"))
+}
+
+func TestMatchFilter(t *testing.T) {
+	filter := func(raw string) map[string]interface{} {
+		return decode(t, raw).(map[string]interface{})
+	}
+
+	assert.True(t, pex.MatchFilter(filter(`{"type":"array","contains":{"const":"VerifiedIdentityCredential"}}`),
+		decode(t, `["VerifiableCredential","VerifiedIdentityCredential"]`)))
+	assert.False(t, pex.MatchFilter(filter(`{"contains":{"const":"DriverLicense"}}`),
+		decode(t, `["VerifiableCredential"]`)))
+	assert.True(t, pex.MatchFilter(filter(`{"type":"integer","minimum":18}`), 34.0))
+	assert.False(t, pex.MatchFilter(filter(`{"exclusiveMaximum":18}`), 34.0))
+	assert.True(t, pex.MatchFilter(filter(`{"enum":["IN","SG"]}`), "IN"))
+	assert.True(t, pex.MatchFilter(filter(`{"pattern":"^Tier[12]_"}`), "Tier1_Verified"))
+	assert.False(t, pex.MatchFilter(filter(`{"not":{"const":"IN"}}`), "IN"))
+}
+
+func TestInputDescriptorMatch(t *testing.T) {
+	descriptor := pex.InputDescriptor{
+		ID: "identity",
+		Constraints: pex.Constraints{Fields: []pex.Field{
+			{Path: []string{"$.vc.type"}, Filter: map[string]interface{}{"contains": map[string]interface{}{"const": "VerifiedIdentityCredential"}}},
+			{ID: "age", Path: []string{"$.credentialSubject.age", "$.vc.credentialSubject.age"}, Filter: map[string]interface{}{"minimum": 18.0}},
+			{Path: []string{"$.vc.credentialSubject.email"}, Optional: true},
+		}},
+	}
+
+	values, err := descriptor.Match(decode(t, credentialJSON))
+	require.NoError(t, err)
+	assert.Equal(t, 34.0, values["age"])
+	assert.NotContains(t, values, "$.vc.credentialSubject.email")
+
+	descriptor.Constraints.Fields[1].Filter = map[string]interface{}{"minimum": 40.0}
+	_, err = descriptor.Match(decode(t, credentialJSON))
+	assert.Error(t, err)
+}
+
+func TestInputDescriptorCheckHolderBinding(t *testing.T) {
+	descriptor := pex.InputDescriptor{ID: "identity"}
+
+	assert.NoError(t, descriptor.CheckHolderBinding("did:example:holder", "did:example:holder"))
+	assert.Error(t, descriptor.CheckHolderBinding("did:example:other", "did:example:holder"))
+	assert.Error(t, descriptor.CheckHolderBinding("", "did:example:holder"), "bearer credentials need an explicit opt-in")
+
+	descriptor.Constraints.AllowBearer = true
+	assert.NoError(t, descriptor.CheckHolderBinding("", "did:example:holder"))
+	assert.Error(t, descriptor.CheckHolderBinding("did:example:other", "did:example:holder"))
+}
+
+func TestDefinitionValidateAndCoverage(t *testing.T) {
+	definition := pex.PresentationDefinition{
+		ID: "kyc",
+		InputDescriptors: []pex.InputDescriptor{
+			{ID: "identity", Constraints: pex.Constraints{Fields: []pex.Field{{Path: []string{"$.vc.type"}}}}},
+			{ID: "address"},
+		},
+	}
+	require.NoError(t, definition.Validate())
+
+	submission := pex.PresentationSubmission{
+		DefinitionID:  "kyc",
+		DescriptorMap: []pex.Descriptor{{ID: "identity"}},
+	}
+	assert.Error(t, definition.CheckCoverage(&submission))
+
+	submission.DescriptorMap = append(submission.DescriptorMap, pex.Descriptor{ID: "address"})
+	assert.NoError(t, definition.CheckCoverage(&submission))
+
+	submission.DefinitionID = "other"
+	assert.Error(t, definition.CheckCoverage(&submission))
+
+	definition.InputDescriptors[1].ID = "identity"
+	assert.Error(t, definition.Validate())
+
+	definition.InputDescriptors[1].ID = "address"
+	definition.InputDescriptors[0].Constraints.Fields[0].Filter = map[string]interface{}{"format": "date"}
+	assert.Error(t, definition.Validate())
+}
@@ -0,0 +1,160 @@
+package queue_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+	"app/src/queue"
+	"app/src/repository"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestBackoff(t *testing.T) {
+	base, max := 10*time.Second, time.Minute
+
+	assert.Equal(t, 10*time.Second, queue.Backoff(base, max, 1))
+	assert.Equal(t, 20*time.Second, queue.Backoff(base, max, 2))
+	assert.Equal(t, 40*time.Second, queue.Backoff(base, max, 3))
+	assert.Equal(t, time.Minute, queue.Backoff(base, max, 4), "capped at max")
+	assert.Equal(t, time.Minute, queue.Backoff(base, max, 100), "no overflow for large attempts")
+}
+
+func TestPermanent(t *testing.T) {
+	cause := errors.New("credential not found")
+
+	assert.False(t, queue.IsPermanent(cause))
+	assert.True(t, queue.IsPermanent(queue.Permanent(cause)))
+	assert.True(t, queue.IsPermanent(fmt.Errorf("handler: %w", queue.Permanent(cause))), "detected through wrapping")
+	assert.ErrorIs(t, queue.Permanent(cause), cause)
+}
+
+// fakeConnPool lets the pool open claim transactions without a database
+type fakeConnPool struct{}
+
+// fakeTx is a transaction begun on fakeConnPool
+type fakeTx struct {
+	fakeConnPool
+}
+
+var errNoDatabase = errors.New("no database in unit tests")
+
+func (fakeConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (fakeConnPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{}, nil
+}
+
+func (*fakeTx) Commit() error   { return nil }
+func (*fakeTx) Rollback() error { return nil }
+
+// fakeJobs hands out one job and reports the completion of its attempt
+type fakeJobs struct {
+	repository.JobRepository
+	mu          sync.Mutex
+	job         *model.Job
+	claimedBy   string
+	completeErr error
+	completed   chan string
+}
+
+func (f *fakeJobs) ClaimNext(_ context.Context, _ *gorm.DB, _ []string, workerID string, now, _ time.Time) (*model.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.job == nil {
+		return nil, nil
+	}
+	job := f.job
+	f.job = nil
+	job.Status = constants.JobStatusRunning
+	job.Attempts++
+	job.LockedBy = &workerID
+	job.LockedAt = &now
+	f.claimedBy = workerID
+	return job, nil
+}
+
+func (f *fakeJobs) Complete(_ context.Context, _ *gorm.DB, _ *model.Job, workerID string) error {
+	f.completed <- workerID
+	return f.completeErr
+}
+
+func TestPoolRecordsOutcomeUnderLease(t *testing.T) {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{ConnPool: fakeConnPool{}})
+	require.NoError(t, err)
+
+	run := func(t *testing.T, completeErr error) (*fakeJobs, *model.Job, string, *test.Hook) {
+		t.Helper()
+		log, hook := test.NewNullLogger()
+		log.SetLevel(logrus.DebugLevel)
+		job := &model.Job{JobID: uuid.New(), Type: "test.job", MaxAttempts: 3}
+		jobs := &fakeJobs{job: job, completeErr: completeErr, completed: make(chan string, 1)}
+
+		pool := queue.NewPool(db, jobs, log, queue.Options{
+			Workers:      1,
+			PollInterval: 10 * time.Millisecond,
+			LeaseTimeout: time.Second,
+			BackoffBase:  time.Second,
+			BackoffMax:   time.Minute,
+		})
+		pool.Register("test.job", queue.HandlerFunc(func(context.Context, *model.Job) (map[string]interface{}, error) {
+			return map[string]interface{}{"ok": true}, nil
+		}))
+		pool.Start()
+
+		var workerID string
+		select {
+		case workerID = <-jobs.completed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("job outcome was not recorded")
+		}
+		require.NoError(t, pool.Stop(context.Background()))
+		return jobs, job, workerID, hook
+	}
+
+	t.Run("outcome is written for the claiming worker", func(t *testing.T) {
+		jobs, job, workerID, _ := run(t, nil)
+		assert.Equal(t, jobs.claimedBy, workerID)
+		assert.Equal(t, constants.JobStatusSucceeded, job.Status)
+		assert.Nil(t, job.LockedBy)
+	})
+
+	t.Run("lost lease discards the outcome", func(t *testing.T) {
+		_, _, _, hook := run(t, repository.ErrJobLeaseLost)
+		var warned bool
+		for _, entry := range hook.AllEntries() {
+			if entry.Level == logrus.WarnLevel {
+				warned = true
+			}
+			assert.NotEqual(t, logrus.ErrorLevel, entry.Level, entry.Message)
+		}
+		assert.True(t, warned)
+	})
+}
@@ -0,0 +1,94 @@
+package seal_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"app/src/seal"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSealer(t *testing.T) *seal.Sealer {
+	t.Helper()
+
+	key, err := seal.GenerateKey()
+	require.NoError(t, err)
+	sealer, err := seal.NewSealer(key)
+	require.NoError(t, err)
+	return sealer
+}
+
+func TestSealRoundTrip(t *testing.T) {
+	sealer := newSealer(t)
+	plaintext := []byte(`{"profile":{"email":"alice@example.com"}}`)
+
+	ciphertext, wrappedKey, err := sealer.Seal(plaintext, []byte("export-1"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "alice@example.com")
+
+	opened, err := sealer.Open(ciphertext, wrappedKey, []byte("export-1"))
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+}
+
+func TestSealUsesFreshDataKeys(t *testing.T) {
+	sealer := newSealer(t)
+
+	first, firstKey, err := sealer.Seal([]byte("same"), nil)
+	require.NoError(t, err)
+	second, secondKey, err := sealer.Seal([]byte("same"), nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.NotEqual(t, firstKey, secondKey)
+}
+
+func TestOpenRejects(t *testing.T) {
+	sealer := newSealer(t)
+	ciphertext, wrappedKey, err := sealer.Seal([]byte("bundle"), []byte("export-1"))
+	require.NoError(t, err)
+
+	t.Run("modified ciphertext", func(t *testing.T) {
+		tampered := append([]byte(nil), ciphertext...)
+		tampered[len(tampered)-1] ^= 1
+		_, err := sealer.Open(tampered, wrappedKey, []byte("export-1"))
+		assert.ErrorIs(t, err, seal.ErrOpen)
+	})
+
+	t.Run("other associated data", func(t *testing.T) {
+		_, err := sealer.Open(ciphertext, wrappedKey, []byte("export-2"))
+		assert.ErrorIs(t, err, seal.ErrOpen)
+	})
+
+	t.Run("other master key", func(t *testing.T) {
+		_, err := newSealer(t).Open(ciphertext, wrappedKey, []byte("export-1"))
+		assert.ErrorIs(t, err, seal.ErrOpen)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := sealer.Open(ciphertext[:4], wrappedKey, []byte("export-1"))
+		assert.ErrorIs(t, err, seal.ErrOpen)
+	})
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	key, err := seal.GenerateKey()
+	require.NoError(t, err)
+	valid := filepath.Join(dir, "valid.key")
+	require.NoError(t, os.WriteFile(valid, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+
+	loaded, err := seal.LoadKeyFile(valid)
+	require.NoError(t, err)
+	assert.Equal(t, key, loaded)
+
+	short := filepath.Join(dir, "short.key")
+	require.NoError(t, os.WriteFile(short, []byte(base64.StdEncoding.EncodeToString(key[:16])), 0o600))
+	_, err = seal.LoadKeyFile(short)
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"app/src/constants"
+	"app/src/model"
+	"app/src/queue"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDeletions records erasures and fails them with err
+type fakeDeletions struct {
+	service.DeletionService
+	err    error
+	erased []uuid.UUID
+}
+
+func (f *fakeDeletions) Erase(_ context.Context, deletionID uuid.UUID) error {
+	f.erased = append(f.erased, deletionID)
+	return f.err
+}
+
+func TestAccountErasureHandler(t *testing.T) {
+	deletionID := uuid.New()
+	job := &model.Job{Type: constants.JobTypeAccountErasure, ActorID: uuid.New(), ResourceID: &deletionID}
+
+	t.Run("erases the deletion", func(t *testing.T) {
+		deletions := &fakeDeletions{}
+		result, err := service.NewAccountErasureHandler(deletions).Handle(context.Background(), job)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{deletionID}, deletions.erased)
+		assert.Equal(t, deletionID.String(), result["deletionId"])
+	})
+
+	t.Run("job without deletion", func(t *testing.T) {
+		deletions := &fakeDeletions{}
+		_, err := service.NewAccountErasureHandler(deletions).Handle(context.Background(), &model.Job{ActorID: uuid.New()})
+		assert.True(t, queue.IsPermanent(err))
+		assert.Empty(t, deletions.erased)
+	})
+
+	t.Run("unknown deletion is not retried", func(t *testing.T) {
+		deletions := &fakeDeletions{err: fiber.NewError(fiber.StatusNotFound, constants.ErrAccountDeletionNotFound)}
+		_, err := service.NewAccountErasureHandler(deletions).Handle(context.Background(), job)
+		assert.True(t, queue.IsPermanent(err))
+	})
+
+	t.Run("other failures are retried", func(t *testing.T) {
+		deletions := &fakeDeletions{err: errors.New("storage unavailable")}
+		_, err := service.NewAccountErasureHandler(deletions).Handle(context.Background(), job)
+		require.Error(t, err)
+		assert.False(t, queue.IsPermanent(err))
+	})
+}
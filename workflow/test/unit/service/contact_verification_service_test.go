@@ -0,0 +1,320 @@
+package service_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"app/src/adapter"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeCodes keeps the verification messages sent to all actors in memory, oldest first
+type fakeCodes struct {
+	repository.VerificationCodeRepository
+	codes []*model.VerificationCode
+}
+
+func (f *fakeCodes) Create(_ context.Context, _ *gorm.DB, code *model.VerificationCode) error {
+	code.CodeID = uuid.New()
+	code.CreatedAt = time.Now()
+	f.codes = append(f.codes, code)
+	return nil
+}
+
+func (f *fakeCodes) FindLatest(_ context.Context, _ *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error) {
+	for i := len(f.codes) - 1; i >= 0; i-- {
+		if f.codes[i].ActorID == actorID && f.codes[i].Channel == channel {
+			return f.codes[i], nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrVerificationCodeNotFound)
+}
+
+func (f *fakeCodes) LockLatest(ctx context.Context, tx *gorm.DB, actorID uuid.UUID, channel string) (*model.VerificationCode, error) {
+	return f.FindLatest(ctx, tx, actorID, channel)
+}
+
+func (f *fakeCodes) CountSince(_ context.Context, _ *gorm.DB, actorID uuid.UUID, channel string, since time.Time) (int64, error) {
+	var count int64
+	for _, code := range f.codes {
+		if code.ActorID == actorID && code.Channel == channel && !code.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeCodes) Update(context.Context, *gorm.DB, *model.VerificationCode) error {
+	return nil
+}
+
+// age moves every message back in time, as if they had been sent earlier
+func (f *fakeCodes) age(d time.Duration) {
+	for _, code := range f.codes {
+		code.CreatedAt = code.CreatedAt.Add(-d)
+	}
+}
+
+// fakeSMS records the text messages it was asked to deliver
+type fakeSMS struct {
+	adapter.SMSSender
+	messages []string
+}
+
+func (f *fakeSMS) Send(_ context.Context, _, message string) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+var smsCode = regexp.MustCompile(`\d{6}`)
+
+// lastCode reads the one-time code from the last message sent
+func (f *fakeSMS) lastCode(t *testing.T) string {
+	t.Helper()
+	require.NotEmpty(t, f.messages)
+	return smsCode.FindString(f.messages[len(f.messages)-1])
+}
+
+// fakeEmailVerification reports whether the auth provider user followed the verification link
+type fakeEmailVerification struct {
+	service.AuthService
+	sent     []string
+	verified bool
+}
+
+func (f *fakeEmailVerification) SendEmailVerification(userID string) error {
+	f.sent = append(f.sent, userID)
+	return nil
+}
+
+func (f *fakeEmailVerification) IsEmailVerified(string) (bool, error) {
+	return f.verified, nil
+}
+
+// contactFixture is one actor with an unverified email address and phone number
+type contactFixture struct {
+	service service.ContactVerificationService
+	actor   *model.Actor
+	codes   *fakeCodes
+	sms     *fakeSMS
+	auth    *fakeEmailVerification
+}
+
+func newContactFixture(t *testing.T) *contactFixture {
+	t.Helper()
+	actor := &model.Actor{ActorID: uuid.New(), Email: "alice@example.com", PhoneNumber: utils.StringPtr("+14155550100")}
+	f := &contactFixture{actor: actor, codes: &fakeCodes{}, sms: &fakeSMS{}, auth: &fakeEmailVerification{}}
+	f.service = service.NewContactVerificationService(logrus.New(), newTransactionDB(t), validation.NewValidator(), f.auth, f.sms,
+		&fakeActors{actors: map[uuid.UUID]*model.Actor{actor.ActorID: actor}},
+		&fakeIntegrations{users: map[uuid.UUID]string{actor.ActorID: "keycloak-user"}},
+		f.codes)
+	return f
+}
+
+func (f *contactFixture) sendPhone(t *testing.T) error {
+	t.Helper()
+	return callAs(t, f.actor.ActorID, func(c *fiber.Ctx) error {
+		_, err := f.service.SendPhoneVerification(c)
+		return err
+	})
+}
+
+func (f *contactFixture) confirmPhone(t *testing.T, code string) error {
+	t.Helper()
+	return callAs(t, f.actor.ActorID, func(c *fiber.Ctx) error {
+		_, err := f.service.ConfirmPhoneVerification(c, &validation.ConfirmPhoneVerificationRequest{Code: code})
+		return err
+	})
+}
+
+// wrongCode returns a well-formed code other than code
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "111111"
+	}
+	return "000000"
+}
+
+func TestSendPhoneVerification(t *testing.T) {
+	t.Run("stores a digest of the code sent", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+
+		require.Len(t, f.codes.codes, 1)
+		code := f.codes.codes[0]
+		secret := f.sms.lastCode(t)
+		assert.Equal(t, *f.actor.PhoneNumber, code.Target)
+		require.NotNil(t, code.CodeHash)
+		assert.Equal(t, utils.HashSecret(secret), *code.CodeHash)
+		assert.WithinDuration(t, time.Now().Add(constants.VerificationCodeTTL*time.Minute), code.ExpiresAt, time.Minute)
+	})
+
+	t.Run("resends only after the interval", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+
+		assertFiberError(t, f.sendPhone(t), fiber.StatusTooManyRequests)
+		assert.Len(t, f.sms.messages, 1)
+
+		f.codes.age(constants.VerificationResendInterval * time.Second)
+		require.NoError(t, f.sendPhone(t))
+		assert.Len(t, f.sms.messages, 2)
+	})
+
+	t.Run("limits the messages sent per hour", func(t *testing.T) {
+		f := newContactFixture(t)
+		for i := 0; i < constants.VerificationMaxSendsPerHour; i++ {
+			require.NoError(t, f.sendPhone(t))
+			f.codes.age(constants.VerificationResendInterval * time.Second)
+		}
+		assertFiberError(t, f.sendPhone(t), fiber.StatusTooManyRequests)
+
+		f.codes.age(time.Hour)
+		require.NoError(t, f.sendPhone(t))
+	})
+
+	t.Run("without a phone number", func(t *testing.T) {
+		f := newContactFixture(t)
+		f.actor.PhoneNumber = nil
+		assertFiberError(t, f.sendPhone(t), fiber.StatusBadRequest)
+		assert.Empty(t, f.sms.messages)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		f := newContactFixture(t)
+		f.actor.PhoneVerified = true
+		assertFiberError(t, f.sendPhone(t), fiber.StatusConflict)
+	})
+}
+
+func TestConfirmPhoneVerification(t *testing.T) {
+	t.Run("the code verifies the number once", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+		secret := f.sms.lastCode(t)
+
+		require.NoError(t, f.confirmPhone(t, secret))
+		assert.True(t, f.actor.PhoneVerified)
+		assert.NotNil(t, f.actor.PhoneVerifiedAt)
+		assert.Equal(t, constants.VerificationCodeStatusConsumed, f.codes.codes[0].Status(time.Now()))
+
+		// A consumed code does not verify the number again once it is unverified
+		f.actor.PhoneVerified = false
+		assertFiberError(t, f.confirmPhone(t, secret), fiber.StatusBadRequest)
+		assert.False(t, f.actor.PhoneVerified)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+		f.codes.codes[0].ExpiresAt = time.Now().Add(-time.Second)
+
+		assertFiberError(t, f.confirmPhone(t, f.sms.lastCode(t)), fiber.StatusBadRequest)
+		assert.False(t, f.actor.PhoneVerified)
+	})
+
+	t.Run("incorrect codes are capped", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+		secret := f.sms.lastCode(t)
+
+		for attempt := 1; attempt <= constants.VerificationMaxAttempts; attempt++ {
+			assertFiberError(t, f.confirmPhone(t, wrongCode(secret)), fiber.StatusBadRequest)
+			assert.Equal(t, attempt, f.codes.codes[0].Attempts)
+		}
+
+		// Not even the right code is accepted once the attempts are exhausted
+		assertFiberError(t, f.confirmPhone(t, secret), fiber.StatusTooManyRequests)
+		assert.False(t, f.actor.PhoneVerified)
+
+		// A new code can be requested and starts with fresh attempts
+		f.codes.age(constants.VerificationResendInterval * time.Second)
+		require.NoError(t, f.sendPhone(t))
+		require.NoError(t, f.confirmPhone(t, f.sms.lastCode(t)))
+		assert.True(t, f.actor.PhoneVerified)
+	})
+
+	t.Run("a new code supersedes the previous one", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+		first := f.sms.lastCode(t)
+		f.codes.age(constants.VerificationResendInterval * time.Second)
+		require.NoError(t, f.sendPhone(t))
+
+		if first != f.sms.lastCode(t) {
+			assertFiberError(t, f.confirmPhone(t, first), fiber.StatusBadRequest)
+		}
+		require.NoError(t, f.confirmPhone(t, f.sms.lastCode(t)))
+	})
+
+	t.Run("code sent to a replaced number", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, f.sendPhone(t))
+		f.actor.PhoneNumber = utils.StringPtr("+14155550199")
+
+		assertFiberError(t, f.confirmPhone(t, f.sms.lastCode(t)), fiber.StatusBadRequest)
+		assert.False(t, f.actor.PhoneVerified)
+	})
+
+	t.Run("without a code sent", func(t *testing.T) {
+		f := newContactFixture(t)
+		assertFiberError(t, f.confirmPhone(t, "123456"), fiber.StatusBadRequest)
+	})
+}
+
+func TestEmailVerification(t *testing.T) {
+	check := func(t *testing.T, f *contactFixture) *model.Actor {
+		t.Helper()
+		var actor *model.Actor
+		err := callAs(t, f.actor.ActorID, func(c *fiber.Ctx) error {
+			var err error
+			actor, err = f.service.CheckEmailVerification(c)
+			return err
+		})
+		require.NoError(t, err)
+		return actor
+	}
+	send := func(t *testing.T, f *contactFixture) error {
+		t.Helper()
+		return callAs(t, f.actor.ActorID, func(c *fiber.Ctx) error {
+			_, err := f.service.SendEmailVerification(c)
+			return err
+		})
+	}
+
+	t.Run("verified once the link sent is followed", func(t *testing.T) {
+		f := newContactFixture(t)
+		f.auth.verified = true
+		assert.False(t, check(t, f).EmailVerified, "the provider flag alone proves nothing")
+
+		require.NoError(t, send(t, f))
+		assert.Equal(t, []string{"keycloak-user"}, f.auth.sent)
+		assertFiberError(t, send(t, f), fiber.StatusTooManyRequests)
+
+		assert.True(t, check(t, f).EmailVerified)
+		assert.Equal(t, constants.VerificationCodeStatusConsumed, f.codes.codes[0].Status(time.Now()))
+	})
+
+	t.Run("link sent to a replaced address", func(t *testing.T) {
+		f := newContactFixture(t)
+		require.NoError(t, send(t, f))
+		f.actor.Email = "alice@example.org"
+		f.auth.verified = true
+
+		assert.False(t, check(t, f).EmailVerified)
+	})
+}
@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/did"
+	"app/src/keys"
+	"app/src/service"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/mr-tron/base58"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDIDDocuments knows the DIDs of a fixed set of actors
+type fakeDIDDocuments struct {
+	service.DIDDocumentService
+	dids map[uuid.UUID][]string
+}
+
+func (f *fakeDIDDocuments) ActorDIDs(_ context.Context, actorID uuid.UUID) ([]string, error) {
+	return f.dids[actorID], nil
+}
+
+var credentialHolderID = uuid.New()
+
+func newCredentialJWTService() service.CredentialJWTService {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	didDocuments := &fakeDIDDocuments{dids: map[uuid.UUID][]string{
+		credentialHolderID: {"did:key:zHolder", "did:example:holder"},
+	}}
+	return service.NewCredentialJWTService(log, did.NewResolver(), didDocuments)
+}
+
+func didKeyFor(prefix, raw []byte) (string, string) {
+	identifier := "z" + base58.Encode(append(append([]byte{}, prefix...), raw...))
+	return "did:key:" + identifier, "did:key:" + identifier + "#" + identifier
+}
+
+func credentialClaims(issuer string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": issuer,
+		"sub": "did:example:holder",
+		"nbf": time.Now().Add(-time.Minute).Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"jti": "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5",
+		"vc": map[string]interface{}{
+			"@context":          []string{"https://www.w3.org/2018/credentials/v1"},
+			"type":              []string{"VerifiableCredential", "GovernmentID"},
+			"credentialSubject": map[string]interface{}{"id": "did:example:holder", "country": "US"},
+		},
+	}
+}
+
+func signCredential(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims, key interface{}) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	token.Header["typ"] = "vc+jwt"
+
+	compact, err := token.SignedString(key)
+	require.NoError(t, err)
+	return compact
+}
+
+func TestVerifyCredentialJWTEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	issuer, kid := didKeyFor([]byte{0xed, 0x01}, pub)
+
+	compact := signCredential(t, jwt.SigningMethodEdDSA, kid, credentialClaims(issuer), priv)
+
+	verified, err := newCredentialJWTService().Verify(context.Background(), compact)
+	require.NoError(t, err)
+	assert.Equal(t, issuer, verified.Issuer)
+	assert.Equal(t, "did:example:holder", verified.Subject)
+	assert.Equal(t, "EdDSA", verified.Algorithm)
+	require.NotNil(t, verified.ExpiresAt)
+	assert.NotNil(t, verified.Credential["credentialSubject"])
+}
+
+func TestVerifyCredentialJWTSecp256k1(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	issuer, kid := didKeyFor([]byte{0xe7, 0x01}, priv.PubKey().SerializeCompressed())
+
+	compact := signCredential(t, keys.ES256K, kid, credentialClaims(issuer), priv)
+
+	verified, err := newCredentialJWTService().Verify(context.Background(), compact)
+	require.NoError(t, err)
+	assert.Equal(t, keys.AlgES256K, verified.Algorithm)
+}
+
+func TestVerifyCredentialJWTRejects(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	issuer, kid := didKeyFor([]byte{0xed, 0x01}, pub)
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherIssuer, _ := didKeyFor([]byte{0xed, 0x01}, otherPub)
+
+	expired := credentialClaims(issuer)
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	issuerMismatch := credentialClaims(issuer)
+	issuerMismatch["vc"].(map[string]interface{})["issuer"] = otherIssuer
+
+	tests := map[string]string{
+		"expired credential":       signCredential(t, jwt.SigningMethodEdDSA, kid, expired, priv),
+		"vc issuer differs":        signCredential(t, jwt.SigningMethodEdDSA, kid, issuerMismatch, priv),
+		"key of another DID":       signCredential(t, jwt.SigningMethodEdDSA, kid, credentialClaims(otherIssuer), priv),
+		"signature by another key": signCredential(t, jwt.SigningMethodEdDSA, kid, credentialClaims(issuer), ed25519.NewKeyFromSeed(make([]byte, 32))),
+	}
+
+	verifier := newCredentialJWTService()
+	for name, compact := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), compact)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifyCredentialJWTHeldBy(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	issuer, kid := didKeyFor([]byte{0xed, 0x01}, pub)
+	verifier := newCredentialJWTService()
+
+	t.Run("subject is a DID of the holder", func(t *testing.T) {
+		compact := signCredential(t, jwt.SigningMethodEdDSA, kid, credentialClaims(issuer), priv)
+		verified, err := verifier.VerifyHeldBy(context.Background(), compact, credentialHolderID)
+		require.NoError(t, err)
+		assert.Equal(t, "did:example:holder", verified.SubjectDID())
+	})
+
+	t.Run("subject is somebody else", func(t *testing.T) {
+		compact := signCredential(t, jwt.SigningMethodEdDSA, kid, credentialClaims(issuer), priv)
+		_, err := verifier.VerifyHeldBy(context.Background(), compact, uuid.New())
+		var fiberErr *fiber.Error
+		require.ErrorAs(t, err, &fiberErr)
+		assert.Equal(t, fiber.StatusUnprocessableEntity, fiberErr.Code)
+		assert.Equal(t, constants.ErrCredentialSubjectNotHolder, fiberErr.Message)
+	})
+
+	t.Run("subject only in the credential", func(t *testing.T) {
+		claims := credentialClaims(issuer)
+		delete(claims, "sub")
+		claims["vc"].(map[string]interface{})["credentialSubject"] = map[string]interface{}{"id": "did:example:other"}
+		compact := signCredential(t, jwt.SigningMethodEdDSA, kid, claims, priv)
+		_, err := verifier.VerifyHeldBy(context.Background(), compact, credentialHolderID)
+		assert.Error(t, err)
+	})
+
+	t.Run("bearer credential", func(t *testing.T) {
+		claims := credentialClaims(issuer)
+		delete(claims, "sub")
+		claims["vc"].(map[string]interface{})["credentialSubject"] = map[string]interface{}{"country": "US"}
+		compact := signCredential(t, jwt.SigningMethodEdDSA, kid, claims, priv)
+		verified, err := verifier.VerifyHeldBy(context.Background(), compact, uuid.New())
+		require.NoError(t, err)
+		assert.Empty(t, verified.SubjectDID())
+	})
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"app/src/constants"
+	"app/src/model"
+	"app/src/queue"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExports records builds and failures, failing builds with err
+type fakeExports struct {
+	service.ExportService
+	err    error
+	built  []uuid.UUID
+	failed []uuid.UUID
+}
+
+func (f *fakeExports) Build(_ context.Context, exportID uuid.UUID) error {
+	f.built = append(f.built, exportID)
+	return f.err
+}
+
+func (f *fakeExports) Fail(_ context.Context, exportID uuid.UUID, _ error) error {
+	f.failed = append(f.failed, exportID)
+	return nil
+}
+
+func TestDataExportHandler(t *testing.T) {
+	exportID := uuid.New()
+	newJob := func(attempts int) *model.Job {
+		return &model.Job{Type: constants.JobTypeDataExport, ActorID: uuid.New(), ResourceID: &exportID, Attempts: attempts, MaxAttempts: 3}
+	}
+
+	t.Run("builds the export", func(t *testing.T) {
+		exports := &fakeExports{}
+		result, err := service.NewDataExportHandler(exports).Handle(context.Background(), newJob(1))
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{exportID}, exports.built)
+		assert.Equal(t, exportID.String(), result["exportId"])
+		assert.Empty(t, exports.failed)
+	})
+
+	t.Run("job without export", func(t *testing.T) {
+		exports := &fakeExports{}
+		_, err := service.NewDataExportHandler(exports).Handle(context.Background(), &model.Job{ActorID: uuid.New()})
+		assert.True(t, queue.IsPermanent(err))
+		assert.Empty(t, exports.built)
+	})
+
+	t.Run("unknown export is not retried", func(t *testing.T) {
+		exports := &fakeExports{err: fiber.NewError(fiber.StatusNotFound, constants.ErrDataExportNotFound)}
+		_, err := service.NewDataExportHandler(exports).Handle(context.Background(), newJob(1))
+		assert.True(t, queue.IsPermanent(err))
+		assert.Empty(t, exports.failed)
+	})
+
+	t.Run("failures are retried while attempts remain", func(t *testing.T) {
+		exports := &fakeExports{err: errors.New("auth provider unavailable")}
+		_, err := service.NewDataExportHandler(exports).Handle(context.Background(), newJob(2))
+		require.Error(t, err)
+		assert.False(t, queue.IsPermanent(err))
+		assert.Empty(t, exports.failed)
+	})
+
+	t.Run("last failed attempt marks the export failed", func(t *testing.T) {
+		exports := &fakeExports{err: errors.New("auth provider unavailable")}
+		_, err := service.NewDataExportHandler(exports).Handle(context.Background(), newJob(3))
+		require.Error(t, err)
+		assert.Equal(t, []uuid.UUID{exportID}, exports.failed)
+	})
+}
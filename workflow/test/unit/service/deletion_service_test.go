@@ -0,0 +1,388 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"app/src/adapter"
+	"app/src/config"
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeAccountDeletions keeps account deletions in memory
+type fakeAccountDeletions struct {
+	repository.AccountDeletionRepository
+	deletions map[uuid.UUID]*model.AccountDeletion
+}
+
+func newFakeAccountDeletions(deletions ...*model.AccountDeletion) *fakeAccountDeletions {
+	f := &fakeAccountDeletions{deletions: map[uuid.UUID]*model.AccountDeletion{}}
+	for _, deletion := range deletions {
+		f.deletions[deletion.DeletionID] = deletion
+	}
+	return f
+}
+
+func (f *fakeAccountDeletions) Create(_ context.Context, _ *gorm.DB, deletion *model.AccountDeletion) error {
+	deletion.DeletionID = uuid.New()
+	deletion.CreatedAt = time.Now()
+	f.deletions[deletion.DeletionID] = deletion
+	return nil
+}
+
+func (f *fakeAccountDeletions) FindByID(_ context.Context, _ *gorm.DB, deletionID uuid.UUID) (*model.AccountDeletion, error) {
+	deletion, ok := f.deletions[deletionID]
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrAccountDeletionNotFound)
+	}
+	// A copy, so that changes of a rolled back transaction are not seen by later calls
+	found := *deletion
+	return &found, nil
+}
+
+func (f *fakeAccountDeletions) LockByID(ctx context.Context, tx *gorm.DB, deletionID uuid.UUID) (*model.AccountDeletion, error) {
+	return f.FindByID(ctx, tx, deletionID)
+}
+
+func (f *fakeAccountDeletions) Complete(_ context.Context, _ *gorm.DB, deletion *model.AccountDeletion) error {
+	completed := *deletion
+	completed.Status = constants.AccountDeletionStatusCompleted
+	f.deletions[deletion.DeletionID] = &completed
+	return nil
+}
+
+// fakeJobQueue records enqueued jobs
+type fakeJobQueue struct {
+	service.JobService
+	jobs []*model.Job
+}
+
+func (f *fakeJobQueue) Enqueue(_ context.Context, _ *gorm.DB, job *model.Job) error {
+	f.jobs = append(f.jobs, job)
+	return nil
+}
+
+// fakeWebhooks records published events
+type fakeWebhooks struct {
+	service.WebhookService
+	events []service.WebhookEvent
+}
+
+func (f *fakeWebhooks) Publish(_ context.Context, _ *gorm.DB, event service.WebhookEvent) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestDeletionCertificate(t *testing.T) {
+	actorID := uuid.New()
+	actors := &fakeActors{actors: map[uuid.UUID]*model.Actor{
+		actorID: {ActorID: actorID, Email: "alice@example.com", DID: "did:web:example.com:alice"},
+	}}
+
+	newService := func(t *testing.T, deletions *fakeAccountDeletions) service.DeletionService {
+		t.Helper()
+		return service.NewDeletionService(&config.Config{ErasureGraceDays: 30}, logrus.New(), newTransactionDB(t),
+			validation.NewValidator(), nil, &fakeAuth{}, &fakeJobQueue{}, &fakeWebhooks{}, &fakeResolver{}, nil,
+			actors, nil, nil, deletions, nil, nil)
+	}
+	getCertificate := func(t *testing.T, deletions service.DeletionService, deletionID uuid.UUID, token string) (*model.AccountDeletion, error) {
+		t.Helper()
+		var deletion *model.AccountDeletion
+		err := callAs(t, uuid.Nil, func(c *fiber.Ctx) error {
+			var err error
+			deletion, err = deletions.GetCertificate(c, &validation.DeletionCertificateRequest{DeletionID: deletionID.String(), CertificateToken: token})
+			return err
+		})
+		return deletion, err
+	}
+
+	t.Run("request returns a token stored only as a digest", func(t *testing.T) {
+		deletions := newFakeAccountDeletions()
+		var deletion *model.AccountDeletion
+		var token string
+		err := callAs(t, actorID, func(c *fiber.Ctx) error {
+			var err error
+			deletion, token, err = newService(t, deletions).RequestDeletion(c, &validation.RequestDeletionRequest{Password: "secret"})
+			return err
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, token)
+		require.NotNil(t, deletion.CertificateTokenHash)
+		assert.Equal(t, utils.HashSecret(token), *deletion.CertificateTokenHash)
+	})
+
+	certificate := "erasure-certificate"
+	tokenHash := utils.HashSecret("certificate-token")
+	completed := &model.AccountDeletion{
+		DeletionID:           uuid.New(),
+		ActorID:              actorID,
+		Status:               constants.AccountDeletionStatusCompleted,
+		Certificate:          &certificate,
+		CertificateTokenHash: &tokenHash,
+	}
+	pending := &model.AccountDeletion{
+		DeletionID:           uuid.New(),
+		ActorID:              actorID,
+		Status:               constants.AccountDeletionStatusPending,
+		CertificateTokenHash: &tokenHash,
+	}
+	legacy := &model.AccountDeletion{
+		DeletionID:  uuid.New(),
+		ActorID:     actorID,
+		Status:      constants.AccountDeletionStatusCompleted,
+		Certificate: &certificate,
+	}
+	deletions := newService(t, newFakeAccountDeletions(completed, pending, legacy))
+
+	t.Run("certificate token", func(t *testing.T) {
+		deletion, err := getCertificate(t, deletions, completed.DeletionID, "certificate-token")
+		require.NoError(t, err)
+		assert.Equal(t, certificate, *deletion.Certificate)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		_, err := getCertificate(t, deletions, completed.DeletionID, "guessed")
+		assertFiberError(t, err, fiber.StatusNotFound)
+	})
+
+	t.Run("deletion requested without a token", func(t *testing.T) {
+		_, err := getCertificate(t, deletions, legacy.DeletionID, "certificate-token")
+		assertFiberError(t, err, fiber.StatusNotFound)
+	})
+
+	t.Run("deletion not completed", func(t *testing.T) {
+		_, err := getCertificate(t, deletions, pending.DeletionID, "certificate-token")
+		assertFiberError(t, err, fiber.StatusNotFound)
+	})
+}
+
+// fakeIntegrations knows the auth provider user of every actor
+type fakeIntegrations struct {
+	repository.ActorIntegrationRepository
+	users map[uuid.UUID]string
+}
+
+func (f *fakeIntegrations) FindByActorIDAndProvider(_ context.Context, _ *gorm.DB, actorID interface{}, _ string) (*model.ActorIntegration, error) {
+	userID, ok := f.users[actorID.(uuid.UUID)]
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+	}
+	return &model.ActorIntegration{ExternalUserID: userID}, nil
+}
+
+// fakeHolds reports whether every actor is under a retention hold
+type fakeHolds struct {
+	repository.RetentionHoldRepository
+	held bool
+}
+
+func (f *fakeHolds) HasActive(context.Context, *gorm.DB, uuid.UUID, time.Time) (bool, error) {
+	return f.held, nil
+}
+
+// fakeErasure holds one stored document per actor and reports fixed deletion counts
+type fakeErasure struct {
+	repository.ErasureRepository
+	pseudonymized []string
+	retainedGone  bool
+}
+
+func (f *fakeErasure) FindDataExports(context.Context, *gorm.DB, uuid.UUID) ([]model.DataExport, error) {
+	return nil, nil
+}
+
+func (f *fakeErasure) FindDocuments(_ context.Context, _ *gorm.DB, actorID uuid.UUID) ([]model.Document, error) {
+	return []model.Document{{StoragePath: "documents/" + actorID.String()}}, nil
+}
+
+func (f *fakeErasure) DeleteAccountData(context.Context, *gorm.DB, uuid.UUID, string) (map[string]int64, error) {
+	return map[string]int64{"integrations": 1, "webhooks": 2}, nil
+}
+
+func (f *fakeErasure) DeleteRetainedData(context.Context, *gorm.DB, uuid.UUID) (map[string]int64, error) {
+	f.retainedGone = true
+	return map[string]int64{"credentials": 3, "actors": 1}, nil
+}
+
+func (f *fakeErasure) PseudonymizeActor(_ context.Context, _ *gorm.DB, _ uuid.UUID, email string) error {
+	f.pseudonymized = append(f.pseudonymized, email)
+	return nil
+}
+
+// fakeStorage records deleted files and signs download URLs; every file exists
+type fakeStorage struct {
+	adapter.StorageProvider
+	deleted []string
+}
+
+func (f *fakeStorage) CreateProvider() (adapter.StorageProvider, error) {
+	return f, nil
+}
+
+func (f *fakeStorage) Exists(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeStorage) Delete(_ context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func (f *fakeStorage) PresignedURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return "https://storage.example.com/" + key, nil
+}
+
+func TestEraseAccount(t *testing.T) {
+	key, err := keys.GenerateSigningKey()
+	require.NoError(t, err)
+	signer, err := keys.NewSigner("platform-key", key)
+	require.NoError(t, err)
+
+	type fixture struct {
+		deletions   *fakeAccountDeletions
+		auth        *fakeAuth
+		identifiers *fakeIdentifiers
+		erasure     *fakeErasure
+		storage     *fakeStorage
+		resolver    *fakeResolver
+		service     service.DeletionService
+		deletionID  uuid.UUID
+		actorID     uuid.UUID
+	}
+	setup := func(t *testing.T, held bool) *fixture {
+		t.Helper()
+		actorID := uuid.New()
+		f := &fixture{
+			deletions: newFakeAccountDeletions(&model.AccountDeletion{
+				DeletionID:  uuid.New(),
+				ActorID:     actorID,
+				Status:      constants.AccountDeletionStatusPending,
+				EffectiveAt: time.Now().Add(-time.Minute),
+				CreatedAt:   time.Now().AddDate(0, 0, -30),
+			}),
+			auth: &fakeAuth{},
+			identifiers: newFakeIdentifiers(
+				model.Identifier{Identifier: "alice@finternet", EntityType: constants.EntityTypeActor, EntityID: actorID, IsPrimary: true},
+				model.Identifier{Identifier: "alice-business@finternet", EntityType: constants.EntityTypeActor, EntityID: actorID},
+			),
+			erasure:  &fakeErasure{},
+			storage:  &fakeStorage{},
+			resolver: &fakeResolver{},
+			actorID:  actorID,
+		}
+		for id := range f.deletions.deletions {
+			f.deletionID = id
+		}
+		actors := &fakeActors{actors: map[uuid.UUID]*model.Actor{
+			actorID: {ActorID: actorID, Email: "alice@example.com", DID: "did:web:example.com:alice"},
+		}}
+		f.service = service.NewDeletionService(&config.Config{IssuerURL: "https://id.example.com", ReleaseCoolingOff: 30},
+			logrus.New(), newTransactionDB(t), validation.NewValidator(), signer, f.auth, &fakeJobQueue{}, &fakeWebhooks{},
+			f.resolver, adapter.NewLazyStorage(adapter.NewStorageFactory(f.storage)), actors,
+			&fakeIntegrations{users: map[uuid.UUID]string{actorID: "keycloak-user"}}, f.identifiers, f.deletions,
+			&fakeHolds{held: held}, f.erasure)
+		return f
+	}
+	certificateClaims := func(t *testing.T, deletion *model.AccountDeletion) *service.ErasureCertificateClaims {
+		t.Helper()
+		require.NotNil(t, deletion.Certificate)
+		claims := &service.ErasureCertificateClaims{}
+		_, _, err := jwt.NewParser().ParseUnverified(*deletion.Certificate, claims)
+		require.NoError(t, err)
+		return claims
+	}
+
+	t.Run("deletes the account", func(t *testing.T) {
+		f := setup(t, false)
+		before := time.Now().UTC()
+		require.NoError(t, f.service.Erase(context.Background(), f.deletionID))
+
+		deletion := f.deletions.deletions[f.deletionID]
+		assert.Equal(t, constants.AccountDeletionStatusCompleted, deletion.Status)
+		assert.False(t, deletion.Pseudonymized)
+		assert.Equal(t, []string{"keycloak-user"}, f.auth.deleted)
+		assert.Equal(t, []string{"documents/" + f.actorID.String()}, f.storage.deleted)
+		assert.True(t, f.erasure.retainedGone)
+		assert.Empty(t, f.erasure.pseudonymized)
+		assert.Equal(t, []uuid.UUID{f.actorID}, f.resolver.invalidatedActors)
+
+		want := map[string]int64{"identifiers": 2, "integrations": 1, "webhooks": 2, "storedFiles": 1, "credentials": 3, "actors": 1}
+		for category, count := range want {
+			assert.Equal(t, count, deletion.Summary[category], category)
+		}
+		assert.Len(t, deletion.Summary, len(want))
+
+		claims := certificateClaims(t, deletion)
+		assert.Equal(t, want, claims.Erased)
+		assert.Equal(t, "did:web:example.com:alice", claims.Subject)
+		assert.Equal(t, f.deletionID.String(), claims.ID)
+		assert.False(t, claims.Pseudonymized)
+
+		t.Run("released identifiers are reserved for the cooling-off period", func(t *testing.T) {
+			require.Len(t, f.identifiers.released, 2)
+			assert.Empty(t, f.identifiers.identifiers)
+			for _, released := range f.identifiers.released {
+				assert.Equal(t, f.actorID, released.EntityID)
+				assert.False(t, released.ReleasedAt.Before(before))
+				assert.Equal(t, released.ReleasedAt.AddDate(0, 0, 30), released.AvailableAt)
+				assert.True(t, released.ReservedAgainst(uuid.New(), released.AvailableAt.Add(-time.Second)))
+			}
+		})
+	})
+
+	t.Run("hold pseudonymizes instead of deleting", func(t *testing.T) {
+		f := setup(t, true)
+		require.NoError(t, f.service.Erase(context.Background(), f.deletionID))
+
+		deletion := f.deletions.deletions[f.deletionID]
+		assert.True(t, deletion.Pseudonymized)
+		assert.Equal(t, []string{"erased-" + f.actorID.String() + "@" + constants.ErasedEmailDomain}, f.erasure.pseudonymized)
+		assert.False(t, f.erasure.retainedGone)
+		assert.Empty(t, f.storage.deleted, "documents under hold are kept")
+
+		want := map[string]int64{"identifiers": 2, "integrations": 1, "webhooks": 2}
+		assert.Len(t, deletion.Summary, len(want))
+		assert.True(t, certificateClaims(t, deletion).Pseudonymized)
+	})
+
+	t.Run("failure after deleting the auth user is retried", func(t *testing.T) {
+		f := setup(t, false)
+		f.identifiers.listErr = errors.New("connection reset")
+
+		require.Error(t, f.service.Erase(context.Background(), f.deletionID))
+		deletion := f.deletions.deletions[f.deletionID]
+		assert.Equal(t, constants.AccountDeletionStatusPending, deletion.Status, "the deletion stays pending")
+		assert.Nil(t, deletion.Certificate)
+		assert.Empty(t, f.resolver.invalidatedActors)
+
+		f.identifiers.listErr = nil
+		require.NoError(t, f.service.Erase(context.Background(), f.deletionID))
+		deletion = f.deletions.deletions[f.deletionID]
+		assert.Equal(t, constants.AccountDeletionStatusCompleted, deletion.Status)
+		assert.Equal(t, []string{"keycloak-user", "keycloak-user"}, f.auth.deleted, "deleting the auth user again succeeds")
+		assert.NotNil(t, deletion.Certificate)
+	})
+
+	t.Run("completed deletion is not erased again", func(t *testing.T) {
+		f := setup(t, false)
+		require.NoError(t, f.service.Erase(context.Background(), f.deletionID))
+		require.NoError(t, f.service.Erase(context.Background(), f.deletionID))
+		assert.Len(t, f.auth.deleted, 1)
+	})
+}
@@ -0,0 +1,78 @@
+package service_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"app/src/did"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildActorDIDDocument(t *testing.T) {
+	const webDID = "did:web:node.example.com:alice"
+
+	previous, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	current, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encode := func(key interface{}) string {
+		t.Helper()
+		pem, err := keys.MarshalPublicKeyPEM(key)
+		require.NoError(t, err)
+		return pem
+	}
+	currentDID, err := did.KeyDID(&current.PublicKey)
+	require.NoError(t, err)
+
+	rotatedAt := time.Now().Add(-time.Hour)
+	actor := &model.Actor{ActorID: uuid.New(), DID: currentDID}
+	history := []model.ActorKey{
+		{PublicKey: encode(previous), ValidFrom: rotatedAt.Add(-time.Hour), ValidUntil: &rotatedAt},
+		{PublicKey: encode(&current.PublicKey), ValidFrom: rotatedAt},
+	}
+	services := []model.DIDService{{Fragment: "linked-domain", Type: "LinkedDomains", Endpoint: "https://alice.example.com"}}
+
+	document, err := service.BuildActorDIDDocument(webDID, actor, history, services)
+	require.NoError(t, err)
+
+	assert.Equal(t, webDID, document.ID)
+	assert.Equal(t, []string{currentDID}, document.AlsoKnownAs)
+	require.Len(t, document.VerificationMethod, 2)
+
+	t.Run("only the current key is authorized", func(t *testing.T) {
+		methods := document.AuthenticationMethods()
+		require.Len(t, methods, 1)
+		key, err := methods[0].PublicKey()
+		require.NoError(t, err)
+		assert.True(t, current.PublicKey.Equal(key))
+		assert.Len(t, document.AssertionMethods(), 1)
+	})
+
+	t.Run("retired keys stay resolvable", func(t *testing.T) {
+		thumbprint, err := keys.Thumbprint(previous)
+		require.NoError(t, err)
+		method, err := document.FindVerificationMethod("#" + thumbprint)
+		require.NoError(t, err)
+		assert.False(t, document.IsAuthenticationMethod(method))
+		key, err := method.PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, previous, key)
+	})
+
+	t.Run("services are published under the DID", func(t *testing.T) {
+		require.Len(t, document.Service, 1)
+		assert.Equal(t, webDID+"#linked-domain", document.Service[0].ID)
+		assert.Equal(t, "https://alice.example.com", document.Service[0].ServiceEndpoint)
+	})
+}
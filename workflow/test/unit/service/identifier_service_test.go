@@ -0,0 +1,382 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+// fakeConnPool lets services open transactions without a database. The fake repositories never
+// send it a statement.
+type fakeConnPool struct{}
+
+// fakeTx is a transaction begun on fakeConnPool
+type fakeTx struct {
+	fakeConnPool
+}
+
+var errNoDatabase = errors.New("no database in unit tests")
+
+func (fakeConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func (fakeConnPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{}, nil
+}
+
+func (*fakeTx) Commit() error   { return nil }
+func (*fakeTx) Rollback() error { return nil }
+
+func newTransactionDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{ConnPool: fakeConnPool{}})
+	require.NoError(t, err)
+	return db
+}
+
+// callAs runs fn in a request authenticated as actorID
+func callAs(t *testing.T, actorID uuid.UUID, fn func(c *fiber.Ctx) error) error {
+	t.Helper()
+	var fnErr error
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals("actorID", actorID)
+		fnErr = fn(c)
+		return nil
+	})
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err)
+	return fnErr
+}
+
+// fakeIdentifiers keeps the identifiers of all actors in memory
+type fakeIdentifiers struct {
+	repository.IdentifierRepository
+	identifiers map[string]*model.Identifier
+	released    []*model.ReleasedIdentifier
+	findErr     error
+	listErr     error
+}
+
+func newFakeIdentifiers(identifiers ...model.Identifier) *fakeIdentifiers {
+	f := &fakeIdentifiers{identifiers: map[string]*model.Identifier{}}
+	for i := range identifiers {
+		f.identifiers[identifiers[i].Identifier] = &identifiers[i]
+	}
+	return f
+}
+
+func (f *fakeIdentifiers) FindByValue(_ context.Context, _ *gorm.DB, value string) (*model.Identifier, error) {
+	if f.findErr != nil {
+		return nil, f.findErr
+	}
+	identifier, ok := f.identifiers[value]
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrResourceNotFound)
+	}
+	return identifier, nil
+}
+
+func (f *fakeIdentifiers) FindByActorID(_ context.Context, _ *gorm.DB, actorID uuid.UUID) (*model.Identifier, error) {
+	for _, identifier := range f.identifiers {
+		if identifier.EntityID == actorID && identifier.IsPrimary {
+			return identifier, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeIdentifiers) ListByActorID(_ context.Context, _ *gorm.DB, actorID uuid.UUID) ([]model.Identifier, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	var identifiers []model.Identifier
+	for _, identifier := range f.identifiers {
+		if identifier.EntityID == actorID {
+			identifiers = append(identifiers, *identifier)
+		}
+	}
+	return identifiers, nil
+}
+
+func (f *fakeIdentifiers) SetPrimary(_ context.Context, _ *gorm.DB, actorID uuid.UUID, value string) error {
+	target, ok := f.identifiers[value]
+	if !ok || target.EntityID != actorID {
+		return fiber.NewError(fiber.StatusNotFound, constants.ErrIdentifierNotFound)
+	}
+	for _, identifier := range f.identifiers {
+		if identifier.EntityID == actorID {
+			identifier.IsPrimary = identifier == target
+		}
+	}
+	return nil
+}
+
+func (f *fakeIdentifiers) Release(_ context.Context, _ *gorm.DB, identifier *model.Identifier, releasedAt, availableAt time.Time) (*model.ReleasedIdentifier, error) {
+	delete(f.identifiers, identifier.Identifier)
+	released := &model.ReleasedIdentifier{
+		Identifier:  identifier.Identifier,
+		EntityType:  identifier.EntityType,
+		EntityID:    identifier.EntityID,
+		ReleasedAt:  releasedAt,
+		AvailableAt: availableAt,
+	}
+	f.released = append(f.released, released)
+	return released, nil
+}
+
+// fakeActors knows a fixed set of actors
+type fakeActors struct {
+	repository.ActorRepository
+	actors map[uuid.UUID]*model.Actor
+}
+
+func (f *fakeActors) find(actorID uuid.UUID) (*model.Actor, error) {
+	actor, ok := f.actors[actorID]
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrActorNotFound)
+	}
+	return actor, nil
+}
+
+func (f *fakeActors) LockByID(_ context.Context, _ *gorm.DB, actorID uuid.UUID) (*model.Actor, error) {
+	return f.find(actorID)
+}
+
+func (f *fakeActors) FindByID(_ context.Context, _ *gorm.DB, actorID uuid.UUID) (*model.Actor, error) {
+	return f.find(actorID)
+}
+
+func (f *fakeActors) Update(context.Context, *gorm.DB, *model.Actor) error {
+	return nil
+}
+
+func (f *fakeActors) FindByEmail(_ context.Context, _ *gorm.DB, email string) (*model.Actor, error) {
+	for _, actor := range f.actors {
+		if actor.Email == email {
+			return actor, nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrActorNotFound)
+}
+
+// fakeNamespaces lowercases identifiers as their canonical form
+type fakeNamespaces struct {
+	service.NamespaceService
+}
+
+func (fakeNamespaces) Canonical(identifier string) string {
+	return strings.ToLower(identifier)
+}
+
+// fakeResolver records the identifiers and actors whose resolutions were invalidated
+type fakeResolver struct {
+	service.ResolverService
+	invalidated       []string
+	invalidatedActors []uuid.UUID
+}
+
+func (f *fakeResolver) InvalidateIdentifiers(identifiers ...string) {
+	f.invalidated = append(f.invalidated, identifiers...)
+}
+
+func (f *fakeResolver) InvalidateActor(actorID uuid.UUID) {
+	f.invalidatedActors = append(f.invalidatedActors, actorID)
+}
+
+func TestIdentifierService(t *testing.T) {
+	actorID, otherID := uuid.New(), uuid.New()
+	actors := &fakeActors{actors: map[uuid.UUID]*model.Actor{
+		actorID: {ActorID: actorID},
+		otherID: {ActorID: otherID},
+	}}
+	cfg := &config.Config{ReleaseCoolingOff: 30}
+
+	newService := func(identifiers *fakeIdentifiers, resolver *fakeResolver) service.IdentifierService {
+		return service.NewIdentifierService(cfg, logrus.New(), newTransactionDB(t), validation.NewValidator(),
+			fakeNamespaces{}, resolver, actors, identifiers)
+	}
+	newIdentifiers := func() *fakeIdentifiers {
+		return newFakeIdentifiers(
+			model.Identifier{Identifier: "alice@finternet", EntityType: constants.EntityTypeActor, EntityID: actorID, IsPrimary: true},
+			model.Identifier{Identifier: "alice-business@finternet", EntityType: constants.EntityTypeActor, EntityID: actorID},
+			model.Identifier{Identifier: "bob@finternet", EntityType: constants.EntityTypeActor, EntityID: otherID, IsPrimary: true},
+		)
+	}
+
+	t.Run("set primary", func(t *testing.T) {
+		identifiers := newIdentifiers()
+		var primary *model.Identifier
+		err := callAs(t, actorID, func(c *fiber.Ctx) (err error) {
+			primary, err = newService(identifiers, &fakeResolver{}).SetPrimary(c, &validation.IdentifierRequest{Identifier: "Alice-Business@finternet"})
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "alice-business@finternet", primary.Identifier)
+		assert.False(t, identifiers.identifiers["alice@finternet"].IsPrimary)
+	})
+
+	t.Run("set primary to another actor's identifier", func(t *testing.T) {
+		identifiers := newIdentifiers()
+		err := callAs(t, actorID, func(c *fiber.Ctx) error {
+			_, err := newService(identifiers, &fakeResolver{}).SetPrimary(c, &validation.IdentifierRequest{Identifier: "bob@finternet"})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusNotFound)
+		assert.True(t, identifiers.identifiers["bob@finternet"].IsPrimary)
+		assert.True(t, identifiers.identifiers["alice@finternet"].IsPrimary)
+	})
+
+	t.Run("remove alias", func(t *testing.T) {
+		identifiers, resolver := newIdentifiers(), &fakeResolver{}
+		var released *model.ReleasedIdentifier
+		err := callAs(t, actorID, func(c *fiber.Ctx) (err error) {
+			released, err = newService(identifiers, resolver).RemoveIdentifier(c, &validation.IdentifierRequest{Identifier: "alice-business@finternet"})
+			return err
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, identifiers.identifiers, "alice-business@finternet")
+		assert.Equal(t, actorID, released.EntityID)
+		assert.Equal(t, released.ReleasedAt.AddDate(0, 0, cfg.ReleaseCoolingOff), released.AvailableAt)
+		assert.Equal(t, []string{"alice-business@finternet"}, resolver.invalidated)
+	})
+
+	t.Run("remove primary", func(t *testing.T) {
+		identifiers := newIdentifiers()
+		err := callAs(t, actorID, func(c *fiber.Ctx) error {
+			_, err := newService(identifiers, &fakeResolver{}).RemoveIdentifier(c, &validation.IdentifierRequest{Identifier: "alice@finternet"})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusBadRequest)
+		assert.Contains(t, identifiers.identifiers, "alice@finternet")
+	})
+
+	t.Run("remove another actor's identifier", func(t *testing.T) {
+		identifiers := newIdentifiers()
+		err := callAs(t, actorID, func(c *fiber.Ctx) error {
+			_, err := newService(identifiers, &fakeResolver{}).RemoveIdentifier(c, &validation.IdentifierRequest{Identifier: "bob@finternet"})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusNotFound)
+		assert.Contains(t, identifiers.identifiers, "bob@finternet")
+		assert.Empty(t, identifiers.released)
+	})
+
+	t.Run("remove unknown identifier", func(t *testing.T) {
+		err := callAs(t, actorID, func(c *fiber.Ctx) error {
+			_, err := newService(newIdentifiers(), &fakeResolver{}).RemoveIdentifier(c, &validation.IdentifierRequest{Identifier: "carol@finternet"})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusNotFound)
+	})
+}
+
+// fakeAuth records the emails it was asked to log in with
+type fakeAuth struct {
+	service.AuthService
+	logins  []string
+	deleted []string
+}
+
+func (f *fakeAuth) Login(username, _ string) (*service.AuthTokenResponse, error) {
+	f.logins = append(f.logins, username)
+	return &service.AuthTokenResponse{}, nil
+}
+
+// DeleteUser succeeds for users already deleted, like the auth provider
+func (f *fakeAuth) DeleteUser(userID string) error {
+	f.deleted = append(f.deleted, userID)
+	return nil
+}
+
+func TestLoginWithAlias(t *testing.T) {
+	actorID := uuid.New()
+	actors := &fakeActors{actors: map[uuid.UUID]*model.Actor{
+		actorID: {ActorID: actorID, Email: "alice@example.com"},
+	}}
+	identifiers := newFakeIdentifiers(
+		model.Identifier{Identifier: "alice@finternet", EntityType: constants.EntityTypeActor, EntityID: actorID, IsPrimary: true},
+		model.Identifier{Identifier: "alice-business@finternet", EntityType: constants.EntityTypeActor, EntityID: actorID},
+	)
+
+	login := func(t *testing.T, username string) (*fakeAuth, error) {
+		t.Helper()
+		auth := &fakeAuth{}
+		actorService := service.NewActorService(&config.Config{}, logrus.New(), newTransactionDB(t), validation.NewValidator(),
+			auth, nil, fakeNamespaces{}, actors, identifiers, nil, nil, nil)
+		err := callAs(t, uuid.Nil, func(c *fiber.Ctx) error {
+			req := &validation.ApiRequest_LoginRequest{Request: validation.LoginRequest{Username: username, Password: "secret"}}
+			_, err := actorService.Login(c, req)
+			return err
+		})
+		return auth, err
+	}
+
+	t.Run("non-primary alias", func(t *testing.T) {
+		auth, err := login(t, "Alice-Business@finternet")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice@example.com"}, auth.logins)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		auth, err := login(t, "alice@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice@example.com"}, auth.logins)
+	})
+
+	t.Run("unknown username", func(t *testing.T) {
+		auth, err := login(t, "mallory@finternet")
+		assertFiberError(t, err, fiber.StatusUnauthorized)
+		assert.Empty(t, auth.logins)
+	})
+
+	t.Run("identifier lookup failure is not reported as invalid credentials", func(t *testing.T) {
+		identifiers.findErr = errors.New("connection refused")
+		defer func() { identifiers.findErr = nil }()
+
+		auth, err := login(t, "alice@example.com")
+		require.Error(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, utils.ErrorStatus(err))
+		assert.Empty(t, auth.logins)
+	})
+}
+
+func assertFiberError(t *testing.T, err error, code int) {
+	t.Helper()
+	var fiberErr *fiber.Error
+	require.ErrorAs(t, err, &fiberErr)
+	assert.Equal(t, code, fiberErr.Code)
+}
@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/service"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLoginProof(t *testing.T) {
+	master, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := keys.NewSigner(rotationActorDID+"#key-1", master)
+	require.NoError(t, err)
+
+	now := time.Now()
+	proof := func(t *testing.T, typ string, claims service.LoginProofClaims) string {
+		t.Helper()
+		compact, err := signer.Sign(claims, map[string]interface{}{"typ": typ})
+		require.NoError(t, err)
+		return compact
+	}
+	claims := func(issuedAt time.Time, subject, nonce string) service.LoginProofClaims {
+		return service.LoginProofClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:  subject,
+				Audience: jwt.ClaimStrings{rotationAudience},
+				IssuedAt: jwt.NewNumericDate(issuedAt),
+			},
+			Nonce: nonce,
+		}
+	}
+
+	t.Run("valid proof", func(t *testing.T) {
+		compact := proof(t, constants.LoginProofJWTType, claims(now, rotationActorDID, "nonce-1"))
+		nonce, err := service.VerifyLoginProof(&master.PublicKey, compact, rotationActorDID, rotationAudience, now)
+		require.NoError(t, err)
+		assert.Equal(t, "nonce-1", nonce)
+	})
+
+	t.Run("other actor", func(t *testing.T) {
+		compact := proof(t, constants.LoginProofJWTType, claims(now, "did:key:zOther", "nonce-1"))
+		_, err := service.VerifyLoginProof(&master.PublicKey, compact, rotationActorDID, rotationAudience, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing nonce", func(t *testing.T) {
+		compact := proof(t, constants.LoginProofJWTType, claims(now, rotationActorDID, ""))
+		_, err := service.VerifyLoginProof(&master.PublicKey, compact, rotationActorDID, rotationAudience, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("registration proof", func(t *testing.T) {
+		compact := proof(t, constants.RegistrationProofJWTType, claims(now, rotationActorDID, "nonce-1"))
+		_, err := service.VerifyLoginProof(&master.PublicKey, compact, rotationActorDID, rotationAudience, now)
+		assert.Error(t, err)
+	})
+
+	t.Run("stale proof", func(t *testing.T) {
+		compact := proof(t, constants.LoginProofJWTType, claims(now.Add(-time.Hour), rotationActorDID, "nonce-1"))
+		_, err := service.VerifyLoginProof(&master.PublicKey, compact, rotationActorDID, rotationAudience, now)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/service"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rotationAudience = "https://wallet.example.com"
+	rotationActorDID = "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+)
+
+func TestVerifyKeyRotationProof(t *testing.T) {
+	current, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := keys.NewSigner(rotationActorDID+"#key-1", current)
+	require.NoError(t, err)
+
+	newKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	thumbprint, err := keys.Thumbprint(newKey)
+	require.NoError(t, err)
+
+	now := time.Now()
+	proof := func(t *testing.T, typ string, claims service.KeyRotationClaims) string {
+		t.Helper()
+		compact, err := signer.Sign(claims, map[string]interface{}{"typ": typ})
+		require.NoError(t, err)
+		return compact
+	}
+	claims := func(issuedAt time.Time, thumbprint string) service.KeyRotationClaims {
+		return service.KeyRotationClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:  rotationActorDID,
+				Audience: jwt.ClaimStrings{rotationAudience},
+				IssuedAt: jwt.NewNumericDate(issuedAt),
+			},
+			NewKeyThumbprint: thumbprint,
+		}
+	}
+
+	t.Run("valid proof", func(t *testing.T) {
+		compact := proof(t, constants.KeyRotationProofJWTType, claims(now, thumbprint))
+		assert.NoError(t, service.VerifyKeyRotationProof(&current.PublicKey, compact, rotationActorDID, rotationAudience, thumbprint, now))
+	})
+
+	t.Run("different new key", func(t *testing.T) {
+		compact := proof(t, constants.KeyRotationProofJWTType, claims(now, "other"))
+		assert.Error(t, service.VerifyKeyRotationProof(&current.PublicKey, compact, rotationActorDID, rotationAudience, thumbprint, now))
+	})
+
+	t.Run("stale proof", func(t *testing.T) {
+		compact := proof(t, constants.KeyRotationProofJWTType, claims(now.Add(-time.Hour), thumbprint))
+		assert.Error(t, service.VerifyKeyRotationProof(&current.PublicKey, compact, rotationActorDID, rotationAudience, thumbprint, now))
+	})
+
+	t.Run("wrong typ", func(t *testing.T) {
+		compact := proof(t, "JWT", claims(now, thumbprint))
+		assert.Error(t, service.VerifyKeyRotationProof(&current.PublicKey, compact, rotationActorDID, rotationAudience, thumbprint, now))
+	})
+
+	t.Run("other actor", func(t *testing.T) {
+		compact := proof(t, constants.KeyRotationProofJWTType, claims(now, thumbprint))
+		assert.Error(t, service.VerifyKeyRotationProof(&current.PublicKey, compact, "did:key:zOther", rotationAudience, thumbprint, now))
+	})
+
+	t.Run("not signed by the current key", func(t *testing.T) {
+		other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		compact := proof(t, constants.KeyRotationProofJWTType, claims(now, thumbprint))
+		assert.Error(t, service.VerifyKeyRotationProof(&other.PublicKey, compact, rotationActorDID, rotationAudience, thumbprint, now))
+	})
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"app/src/config"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/namespace"
+	"app/src/repository"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeStoredIdentifiers keeps identifier and released identifier rows keyed by value, like the
+// tables whose primary key they are
+type fakeStoredIdentifiers struct {
+	repository.IdentifierRepository
+	identifiers map[string]string
+	released    map[string]string
+	enforced    bool
+}
+
+func listAfter(rows map[string]string, after string, limit int) []string {
+	var values []string
+	for value := range rows {
+		if value > after {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	if len(values) > limit {
+		values = values[:limit]
+	}
+	return values
+}
+
+func updateCanonical(rows map[string]string, identifier, canonical, skeleton string) error {
+	if _, ok := rows[canonical]; ok && canonical != identifier {
+		return fiber.NewError(fiber.StatusConflict, constants.ErrUniversalIdentifierAlreadyInUse)
+	}
+	delete(rows, identifier)
+	rows[canonical] = skeleton
+	return nil
+}
+
+func (f *fakeStoredIdentifiers) ListAfter(_ context.Context, _ *gorm.DB, after string, limit int) ([]model.Identifier, error) {
+	var identifiers []model.Identifier
+	for _, value := range listAfter(f.identifiers, after, limit) {
+		identifiers = append(identifiers, model.Identifier{Identifier: value, Skeleton: f.identifiers[value]})
+	}
+	return identifiers, nil
+}
+
+func (f *fakeStoredIdentifiers) ListReleasedAfter(_ context.Context, _ *gorm.DB, after string, limit int) ([]model.ReleasedIdentifier, error) {
+	var released []model.ReleasedIdentifier
+	for _, value := range listAfter(f.released, after, limit) {
+		released = append(released, model.ReleasedIdentifier{Identifier: value, Skeleton: f.released[value]})
+	}
+	return released, nil
+}
+
+func (f *fakeStoredIdentifiers) UpdateCanonical(_ context.Context, _ *gorm.DB, identifier, canonical, skeleton string) error {
+	return updateCanonical(f.identifiers, identifier, canonical, skeleton)
+}
+
+func (f *fakeStoredIdentifiers) UpdateReleasedCanonical(_ context.Context, _ *gorm.DB, identifier, canonical, skeleton string) error {
+	return updateCanonical(f.released, identifier, canonical, skeleton)
+}
+
+func (f *fakeStoredIdentifiers) EnforceSkeletons(context.Context, *gorm.DB) error {
+	f.enforced = true
+	return nil
+}
+
+func TestBackfillIdentifiers(t *testing.T) {
+	cfg := &config.Config{IdentifierDomain: "acme.example", IdentifierPattern: constants.DefaultIdentifierPattern}
+	newService := func(stored *fakeStoredIdentifiers) service.NamespaceService {
+		return service.NewNamespaceService(cfg, logrus.New(), nil, validation.NewValidator(), nil, stored, nil)
+	}
+
+	t.Run("canonicalizes with the configured domain and enforces skeletons", func(t *testing.T) {
+		current := namespace.Skeleton("carol@acme.example")
+		stored := &fakeStoredIdentifiers{
+			identifiers: map[string]string{"Alice": "", "BOB@Finternet": "", "carol@acme.example": current},
+			released:    map[string]string{"Dave": ""},
+		}
+
+		result, err := newService(stored).BackfillIdentifiers(context.Background(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Scanned)
+		assert.Equal(t, 3, result.Converted)
+		assert.Equal(t, 1, result.Unchanged)
+		assert.Empty(t, result.Failed)
+		assert.True(t, result.Enforced)
+		assert.True(t, stored.enforced)
+
+		assert.Equal(t, map[string]string{
+			"alice@acme.example": namespace.Skeleton("alice@acme.example"),
+			"bob@finternet":      namespace.Skeleton("bob@finternet"),
+			"carol@acme.example": current,
+		}, stored.identifiers)
+		assert.Equal(t, map[string]string{"dave@acme.example": namespace.Skeleton("dave@acme.example")}, stored.released)
+
+		// Running again finds nothing left to do
+		result, err = newService(stored).BackfillIdentifiers(context.Background(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Converted)
+		assert.Equal(t, 4, result.Unchanged)
+	})
+
+	t.Run("look-alikes are reported and skeletons not enforced", func(t *testing.T) {
+		// The skeleton folds what lowercasing leaves apart: "rn" looks like "m"
+		require.Equal(t, namespace.Skeleton("rnary@acme.example"), namespace.Skeleton("mary@acme.example"))
+		stored := &fakeStoredIdentifiers{
+			identifiers: map[string]string{"Mary": "", "rnary": "", "ALICE": "", "alice@acme.example": namespace.Skeleton("alice@acme.example")},
+			released:    map[string]string{},
+		}
+
+		result, err := newService(stored).BackfillIdentifiers(context.Background(), false)
+		require.NoError(t, err)
+		assert.False(t, result.Enforced)
+		assert.False(t, stored.enforced)
+
+		failed := map[string]string{}
+		for _, failure := range result.Failed {
+			failed[failure.Identifier] = failure.Reason
+		}
+		assert.Equal(t, map[string]string{
+			"ALICE":              constants.ErrUniversalIdentifierAlreadyInUse,
+			"alice@acme.example": "looks like ALICE",
+			"rnary":              "looks like Mary",
+		}, failed)
+		assert.Contains(t, stored.identifiers, "ALICE", "identifiers merging into another are left as they are")
+		assert.Contains(t, stored.identifiers, "rnary", "look-alikes are left as they are")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		stored := &fakeStoredIdentifiers{identifiers: map[string]string{"Alice": ""}, released: map[string]string{}}
+
+		result, err := newService(stored).BackfillIdentifiers(context.Background(), true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Converted)
+		assert.Equal(t, map[string]string{"Alice": ""}, stored.identifiers)
+		assert.False(t, stored.enforced)
+	})
+}
@@ -0,0 +1,409 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"app/src/config"
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/response"
+	"app/src/service"
+	"app/src/utils"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testIssuerURL = "https://issuer.example.com"
+
+// fakeOffers keeps credential offers in memory
+type fakeOffers struct {
+	repository.CredentialOfferRepository
+	offers []*model.CredentialOffer
+}
+
+func (f *fakeOffers) Create(_ context.Context, _ *gorm.DB, offer *model.CredentialOffer) error {
+	offer.OfferID = uuid.New()
+	f.offers = append(f.offers, offer)
+	return nil
+}
+
+func (f *fakeOffers) LockByPreAuthorizedCodeHash(_ context.Context, _ *gorm.DB, codeHash string) (*model.CredentialOffer, error) {
+	for _, offer := range f.offers {
+		if offer.PreAuthorizedCodeHash == codeHash {
+			return offer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOffers) FindByAccessTokenHash(_ context.Context, _ *gorm.DB, tokenHash string) (*model.CredentialOffer, error) {
+	for _, offer := range f.offers {
+		if offer.AccessTokenHash != nil && *offer.AccessTokenHash == tokenHash {
+			return offer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOffers) LockByAccessTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*model.CredentialOffer, error) {
+	return f.FindByAccessTokenHash(ctx, tx, tokenHash)
+}
+
+func (f *fakeOffers) Update(context.Context, *gorm.DB, *model.CredentialOffer) error {
+	return nil
+}
+
+// fakeVerifiedTypes reports the same verified credential types for every account
+type fakeVerifiedTypes struct {
+	repository.CredentialsRepository
+}
+
+func (fakeVerifiedTypes) FindVerifiedTypes(context.Context, *gorm.DB, uuid.UUID) ([]string, error) {
+	return []string{"passport"}, nil
+}
+
+// oid4vciFixture is an issuer with one actor, and a wallet key to request credentials with
+type oid4vciFixture struct {
+	service service.OID4VCIService
+	offers  *fakeOffers
+	actorID uuid.UUID
+	wallet  *ecdsa.PrivateKey
+}
+
+func newOID4VCIFixture(t *testing.T) *oid4vciFixture {
+	t.Helper()
+	issuerKey, err := keys.GenerateSigningKey()
+	require.NoError(t, err)
+	signer, err := keys.NewSigner(testIssuerURL+"#key-1", issuerKey)
+	require.NoError(t, err)
+	wallet, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	actorID := uuid.New()
+	actors := &fakeActors{actors: map[uuid.UUID]*model.Actor{
+		actorID: {ActorID: actorID, Email: "alice@example.com", EntityType: constants.EntityTypeActor},
+	}}
+	offers := &fakeOffers{}
+	oid4vci, err := service.NewOID4VCIService(logrus.New(), newTransactionDB(t), validation.NewValidator(),
+		&config.Config{IssuerURL: testIssuerURL}, signer, nil, offers, actors, fakeVerifiedTypes{})
+	require.NoError(t, err)
+
+	return &oid4vciFixture{service: oid4vci, offers: offers, actorID: actorID, wallet: wallet}
+}
+
+func (f *oid4vciFixture) createOffer(t *testing.T, req *validation.CreateCredentialOfferRequest) *response.CredentialOfferResponse {
+	t.Helper()
+	var offer *response.CredentialOfferResponse
+	err := callAs(t, f.actorID, func(c *fiber.Ctx) error {
+		var err error
+		offer, err = f.service.CreateOffer(c, req)
+		return err
+	})
+	require.NoError(t, err)
+	return offer
+}
+
+func (f *oid4vciFixture) exchange(t *testing.T, code, txCode string) (*response.OID4VCITokenResponse, error) {
+	t.Helper()
+	var token *response.OID4VCITokenResponse
+	err := callAs(t, uuid.Nil, func(c *fiber.Ctx) error {
+		var err error
+		token, err = f.service.ExchangeToken(c, &validation.OID4VCITokenRequest{
+			GrantType:         constants.OID4VCIGrantPreAuthorizedCode,
+			PreAuthorizedCode: code,
+			TxCode:            txCode,
+		})
+		return err
+	})
+	return token, err
+}
+
+// redeem creates an offer and exchanges its code for an access token
+func (f *oid4vciFixture) redeem(t *testing.T) *response.OID4VCITokenResponse {
+	t.Helper()
+	offer := f.createOffer(t, &validation.CreateCredentialOfferRequest{})
+	token, err := f.exchange(t, offer.CredentialOffer.Grants.PreAuthorizedCode.PreAuthorizedCode, "")
+	require.NoError(t, err)
+	return token
+}
+
+func (f *oid4vciFixture) issue(t *testing.T, accessToken string, req *validation.OID4VCICredentialRequest) (*response.OID4VCICredentialResponse, error) {
+	t.Helper()
+	var credential *response.OID4VCICredentialResponse
+	err := callAs(t, uuid.Nil, func(c *fiber.Ctx) error {
+		var err error
+		credential, err = f.service.IssueCredential(c, accessToken, req)
+		return err
+	})
+	return credential, err
+}
+
+// proof signs a key proof for nonce with the wallet key, embedding its public key as jwk header
+func (f *oid4vciFixture) proof(t *testing.T, nonce string) *validation.OID4VCIProof {
+	t.Helper()
+	jwk, err := keys.NewJWK(&f.wallet.PublicKey)
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud":   testIssuerURL,
+		"iat":   time.Now().Unix(),
+		"nonce": nonce,
+	})
+	token.Header["typ"] = constants.OID4VCIProofJWTType
+	token.Header["jwk"] = jwk
+	signed, err := token.SignedString(f.wallet)
+	require.NoError(t, err)
+	return &validation.OID4VCIProof{ProofType: constants.OID4VCIProofTypeJWT, JWT: signed}
+}
+
+func (f *oid4vciFixture) request(t *testing.T, nonce string) *validation.OID4VCICredentialRequest {
+	t.Helper()
+	return &validation.OID4VCICredentialRequest{
+		CredentialConfigurationID: constants.CredentialConfigVerifiedIdentity,
+		Proof:                     f.proof(t, nonce),
+	}
+}
+
+func assertProtocolError(t *testing.T, err error, code string) *service.ProtocolError {
+	t.Helper()
+	var protocolErr *service.ProtocolError
+	require.ErrorAs(t, err, &protocolErr)
+	assert.Equal(t, code, protocolErr.Code)
+	return protocolErr
+}
+
+func TestOID4VCIExchangeToken(t *testing.T) {
+	t.Run("code is redeemed once", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		offer := f.createOffer(t, &validation.CreateCredentialOfferRequest{})
+		code := offer.CredentialOffer.Grants.PreAuthorizedCode.PreAuthorizedCode
+
+		token, err := f.exchange(t, code, "")
+		require.NoError(t, err)
+		assert.NotEmpty(t, token.AccessToken)
+		assert.NotEmpty(t, token.CNonce)
+		assert.Equal(t, constants.CredentialOfferStatusRedeemed, f.offers.offers[0].Status)
+
+		_, err = f.exchange(t, code, "")
+		assertProtocolError(t, err, constants.OAuthErrInvalidGrant)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		offer := f.createOffer(t, &validation.CreateCredentialOfferRequest{})
+		f.offers.offers[0].ExpiresAt = time.Now().Add(-time.Second)
+
+		_, err := f.exchange(t, offer.CredentialOffer.Grants.PreAuthorizedCode.PreAuthorizedCode, "")
+		assertProtocolError(t, err, constants.OAuthErrInvalidGrant)
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		_, err := f.exchange(t, "unknown", "")
+		assertProtocolError(t, err, constants.OAuthErrInvalidGrant)
+	})
+
+	t.Run("transaction code", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		offer := f.createOffer(t, &validation.CreateCredentialOfferRequest{RequireTxCode: true})
+		require.Len(t, offer.TxCode, constants.OID4VCITxCodeLength)
+
+		token, err := f.exchange(t, offer.CredentialOffer.Grants.PreAuthorizedCode.PreAuthorizedCode, offer.TxCode)
+		require.NoError(t, err)
+		assert.NotEmpty(t, token.AccessToken)
+	})
+
+	t.Run("failed transaction code attempts revoke the offer", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		offer := f.createOffer(t, &validation.CreateCredentialOfferRequest{RequireTxCode: true})
+		code := offer.CredentialOffer.Grants.PreAuthorizedCode.PreAuthorizedCode
+		wrong := "000000"
+		if offer.TxCode == wrong {
+			wrong = "111111"
+		}
+
+		for attempt := 1; attempt <= constants.OID4VCIMaxTxCodeAttempts; attempt++ {
+			_, err := f.exchange(t, code, wrong)
+			assertProtocolError(t, err, constants.OAuthErrInvalidGrant)
+			assert.Equal(t, attempt, f.offers.offers[0].TxCodeAttempts)
+		}
+		assert.Equal(t, constants.CredentialOfferStatusRevoked, f.offers.offers[0].Status)
+
+		_, err := f.exchange(t, code, offer.TxCode)
+		assertProtocolError(t, err, constants.OAuthErrInvalidGrant)
+	})
+
+	t.Run("unsupported grant type", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		err := callAs(t, uuid.Nil, func(c *fiber.Ctx) error {
+			_, err := f.service.ExchangeToken(c, &validation.OID4VCITokenRequest{GrantType: "authorization_code", PreAuthorizedCode: "code"})
+			return err
+		})
+		assertProtocolError(t, err, constants.OAuthErrUnsupportedGrantType)
+	})
+}
+
+func TestOID4VCIIssueCredential(t *testing.T) {
+	t.Run("issues a credential bound to the proof key", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		token := f.redeem(t)
+
+		issued, err := f.issue(t, token.AccessToken, f.request(t, token.CNonce))
+		require.NoError(t, err)
+		assert.NotEqual(t, token.CNonce, issued.CNonce, "the nonce is rotated")
+
+		claims := jwt.MapClaims{}
+		_, _, err = jwt.NewParser().ParseUnverified(issued.Credential, claims)
+		require.NoError(t, err)
+		subject, err := claims.GetSubject()
+		require.NoError(t, err)
+		assert.Contains(t, subject, "did:jwk:")
+
+		t.Run("nonce cannot be reused", func(t *testing.T) {
+			_, err := f.issue(t, token.AccessToken, f.request(t, token.CNonce))
+			protocolErr := assertProtocolError(t, err, constants.OAuthErrInvalidNonce)
+			require.NotNil(t, protocolErr.CNonce)
+			assert.Equal(t, *f.offers.offers[0].CNonce, *protocolErr.CNonce)
+		})
+	})
+
+	t.Run("invalid nonce returns a fresh one to retry with", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		token := f.redeem(t)
+
+		_, err := f.issue(t, token.AccessToken, f.request(t, "stale"))
+		protocolErr := assertProtocolError(t, err, constants.OAuthErrInvalidNonce)
+		require.NotNil(t, protocolErr.CNonce)
+		assert.NotEqual(t, token.CNonce, *protocolErr.CNonce)
+
+		_, err = f.issue(t, token.AccessToken, f.request(t, *protocolErr.CNonce))
+		require.NoError(t, err)
+	})
+
+	t.Run("expired nonce", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		token := f.redeem(t)
+		expired := time.Now().Add(-time.Second)
+		f.offers.offers[0].CNonceExpiresAt = &expired
+
+		_, err := f.issue(t, token.AccessToken, f.request(t, token.CNonce))
+		assertProtocolError(t, err, constants.OAuthErrInvalidNonce)
+	})
+
+	t.Run("invalid proof consumes the nonce", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		token := f.redeem(t)
+		req := f.request(t, token.CNonce)
+		req.Proof.JWT += "tampered"
+
+		_, err := f.issue(t, token.AccessToken, req)
+		assertProtocolError(t, err, constants.OAuthErrInvalidProof)
+		assert.NotEqual(t, token.CNonce, *f.offers.offers[0].CNonce)
+	})
+
+	t.Run("expired access token", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		token := f.redeem(t)
+		expired := time.Now().Add(-time.Second)
+		f.offers.offers[0].AccessTokenExpiresAt = &expired
+
+		_, err := f.issue(t, token.AccessToken, f.request(t, token.CNonce))
+		assertProtocolError(t, err, constants.OAuthErrInvalidToken)
+		assert.Equal(t, token.CNonce, *f.offers.offers[0].CNonce, "rejected before the nonce is used")
+	})
+
+	t.Run("unknown access token", func(t *testing.T) {
+		f := newOID4VCIFixture(t)
+		_, err := f.issue(t, "unknown", f.request(t, "nonce"))
+		assertProtocolError(t, err, constants.OAuthErrInvalidToken)
+	})
+}
+
+func TestOID4VCIRequestedConfiguration(t *testing.T) {
+	tests := []struct {
+		name string
+		req  validation.OID4VCICredentialRequest
+		code string
+	}{
+		{
+			name: "offered configuration",
+			req:  validation.OID4VCICredentialRequest{CredentialConfigurationID: constants.CredentialConfigVerifiedIdentity},
+		},
+		{
+			name: "configuration not offered",
+			req:  validation.OID4VCICredentialRequest{CredentialConfigurationID: "UniversityDegree_jwt_vc_json"},
+			code: constants.OAuthErrUnsupportedCredentialType,
+		},
+		{
+			name: "format",
+			req:  validation.OID4VCICredentialRequest{Format: constants.OID4VCIFormatJWTVCJSON},
+		},
+		{
+			name: "format and offered type",
+			req: validation.OID4VCICredentialRequest{
+				Format:               constants.OID4VCIFormatJWTVCJSON,
+				CredentialDefinition: &validation.OID4VCICredentialDefinition{Type: []string{"VerifiableCredential", constants.CredentialTypeVerifiedIdentity}},
+			},
+		},
+		{
+			name: "format and other type",
+			req: validation.OID4VCICredentialRequest{
+				Format:               constants.OID4VCIFormatJWTVCJSON,
+				CredentialDefinition: &validation.OID4VCICredentialDefinition{Type: []string{"VerifiableCredential", "UniversityDegreeCredential"}},
+			},
+			code: constants.OAuthErrUnsupportedCredentialType,
+		},
+		{
+			name: "unsupported format",
+			req:  validation.OID4VCICredentialRequest{Format: "ldp_vc"},
+			code: constants.OAuthErrUnsupportedCredentialFormat,
+		},
+		{
+			name: "neither configuration nor format",
+			req:  validation.OID4VCICredentialRequest{},
+			code: constants.OAuthErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newOID4VCIFixture(t)
+			token := f.redeem(t)
+			req := tt.req
+			req.Proof = f.proof(t, token.CNonce)
+
+			issued, err := f.issue(t, token.AccessToken, &req)
+			if tt.code == "" {
+				require.NoError(t, err)
+				assert.Equal(t, constants.OID4VCIFormatJWTVCJSON, issued.Format)
+				return
+			}
+			assertProtocolError(t, err, tt.code)
+		})
+	}
+}
+
+// Digests are what the offer stores, never the codes themselves
+func TestOID4VCIOfferStoresDigests(t *testing.T) {
+	f := newOID4VCIFixture(t)
+	offer := f.createOffer(t, &validation.CreateCredentialOfferRequest{RequireTxCode: true})
+
+	stored := f.offers.offers[0]
+	assert.Equal(t, utils.HashSecret(offer.CredentialOffer.Grants.PreAuthorizedCode.PreAuthorizedCode), stored.PreAuthorizedCodeHash)
+	require.NotNil(t, stored.TxCodeHash)
+	assert.Equal(t, utils.HashSecret(offer.TxCode), *stored.TxCodeHash)
+}
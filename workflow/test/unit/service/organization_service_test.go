@@ -0,0 +1,380 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeOrganizations keeps the members and invitations of all organizations in memory
+type fakeOrganizations struct {
+	repository.OrganizationRepository
+	members     []*model.OrganizationMember
+	invitations []*model.OrganizationInvitation
+}
+
+func (f *fakeOrganizations) CreateMember(_ context.Context, _ *gorm.DB, member *model.OrganizationMember) error {
+	if _, err := f.LockMember(context.Background(), nil, member.OrganizationID, member.ActorID); err == nil {
+		return fiber.NewError(fiber.StatusConflict, constants.ErrAlreadyOrganizationMember)
+	}
+	f.members = append(f.members, member)
+	return nil
+}
+
+func (f *fakeOrganizations) FindMember(ctx context.Context, tx *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error) {
+	return f.LockMember(ctx, tx, organizationID, actorID)
+}
+
+func (f *fakeOrganizations) LockMember(_ context.Context, _ *gorm.DB, organizationID, actorID uuid.UUID) (*model.OrganizationMember, error) {
+	for _, member := range f.members {
+		if member.OrganizationID == organizationID && member.ActorID == actorID {
+			return member, nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationMemberNotFound)
+}
+
+func (f *fakeOrganizations) LockMembersWithRole(_ context.Context, _ *gorm.DB, organizationID uuid.UUID, role string) ([]model.OrganizationMember, error) {
+	var members []model.OrganizationMember
+	for _, member := range f.members {
+		if member.OrganizationID == organizationID && member.Role == role {
+			members = append(members, *member)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakeOrganizations) UpdateMember(context.Context, *gorm.DB, *model.OrganizationMember) error {
+	return nil
+}
+
+func (f *fakeOrganizations) DeleteMember(_ context.Context, _ *gorm.DB, member *model.OrganizationMember) error {
+	for i, existing := range f.members {
+		if existing.OrganizationID == member.OrganizationID && existing.ActorID == member.ActorID {
+			f.members = append(f.members[:i], f.members[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *fakeOrganizations) CreateInvitation(_ context.Context, _ *gorm.DB, invitation *model.OrganizationInvitation) error {
+	invitation.InvitationID = uuid.New()
+	f.invitations = append(f.invitations, invitation)
+	return nil
+}
+
+func (f *fakeOrganizations) LockInvitation(_ context.Context, _ *gorm.DB, invitationID uuid.UUID) (*model.OrganizationInvitation, error) {
+	for _, invitation := range f.invitations {
+		if invitation.InvitationID == invitationID {
+			return invitation, nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrOrganizationInvitationNotFound)
+}
+
+func (f *fakeOrganizations) ExistsPendingInvitation(_ context.Context, _ *gorm.DB, organizationID, inviteeID uuid.UUID, at time.Time) (bool, error) {
+	for _, invitation := range f.invitations {
+		if invitation.OrganizationID == organizationID && invitation.InviteeID == inviteeID &&
+			invitation.Status(at) == constants.OrganizationInvitationStatusPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeOrganizations) UpdateInvitation(context.Context, *gorm.DB, *model.OrganizationInvitation) error {
+	return nil
+}
+
+// organizationFixture is one organization with an Individual actor for every role and one outsider
+type organizationFixture struct {
+	service        service.OrganizationService
+	orgs           *fakeOrganizations
+	organizationID uuid.UUID
+	members        map[string]uuid.UUID
+	outsiderID     uuid.UUID
+}
+
+func newOrganizationFixture(t *testing.T) *organizationFixture {
+	t.Helper()
+	f := &organizationFixture{
+		orgs:           &fakeOrganizations{},
+		organizationID: uuid.New(),
+		members:        map[string]uuid.UUID{},
+		outsiderID:     uuid.New(),
+	}
+	actors := &fakeActors{actors: map[uuid.UUID]*model.Actor{
+		f.organizationID: {ActorID: f.organizationID, EntityType: constants.EntityTypeBusiness},
+		f.outsiderID:     {ActorID: f.outsiderID, EntityType: constants.EntityTypeIndividual},
+	}}
+	for _, role := range []string{
+		constants.OrganizationRoleOwner, constants.OrganizationRoleAdmin,
+		constants.OrganizationRoleOperator, constants.OrganizationRoleViewer,
+	} {
+		actorID := uuid.New()
+		f.members[role] = actorID
+		actors.actors[actorID] = &model.Actor{ActorID: actorID, EntityType: constants.EntityTypeIndividual}
+		f.orgs.members = append(f.orgs.members, &model.OrganizationMember{
+			OrganizationID: f.organizationID, ActorID: actorID, Role: role,
+		})
+	}
+	identifiers := newFakeIdentifiers(
+		model.Identifier{Identifier: "acme@finternet", EntityType: constants.EntityTypeActor, EntityID: f.organizationID, IsPrimary: true},
+		model.Identifier{Identifier: "bob@finternet", EntityType: constants.EntityTypeActor, EntityID: f.outsiderID, IsPrimary: true},
+	)
+
+	f.service = service.NewOrganizationService(logrus.New(), newTransactionDB(t), validation.NewValidator(),
+		fakeNamespaces{}, actors, identifiers, f.orgs)
+	return f
+}
+
+// asMember runs fn as the member holding role, acting for the organization
+func (f *organizationFixture) asMember(t *testing.T, role string, fn func(c *fiber.Ctx) error) error {
+	t.Helper()
+	return callAs(t, f.organizationID, func(c *fiber.Ctx) error {
+		c.Locals("memberID", f.members[role])
+		c.Locals("organizationRole", role)
+		return fn(c)
+	})
+}
+
+func (f *organizationFixture) invite(t *testing.T, role string) *model.OrganizationInvitation {
+	t.Helper()
+	var invitation *model.OrganizationInvitation
+	err := callAs(t, f.organizationID, func(c *fiber.Ctx) error {
+		var err error
+		invitation, err = f.service.Invite(c, &validation.CreateOrganizationInvitationRequest{Identifier: "Bob@finternet", Role: role})
+		return err
+	})
+	require.NoError(t, err)
+	return invitation
+}
+
+func (f *organizationFixture) accept(t *testing.T, actorID uuid.UUID, invitation *model.OrganizationInvitation) (*model.OrganizationMember, error) {
+	t.Helper()
+	var member *model.OrganizationMember
+	err := callAs(t, actorID, func(c *fiber.Ctx) error {
+		var err error
+		member, err = f.service.AcceptInvitation(c, &validation.OrganizationInvitationRequest{InvitationID: invitation.InvitationID.String()})
+		return err
+	})
+	return member, err
+}
+
+func (f *organizationFixture) revoke(t *testing.T, role string, invitation *model.OrganizationInvitation) error {
+	t.Helper()
+	return f.asMember(t, role, func(c *fiber.Ctx) error {
+		_, err := f.service.RevokeInvitation(c, &validation.OrganizationInvitationRequest{InvitationID: invitation.InvitationID.String()})
+		return err
+	})
+}
+
+func (f *organizationFixture) updateRole(t *testing.T, role string, actorID uuid.UUID, newRole string) error {
+	t.Helper()
+	return f.asMember(t, role, func(c *fiber.Ctx) error {
+		_, err := f.service.UpdateMemberRole(c, &validation.UpdateOrganizationMemberRequest{ActorID: actorID.String(), Role: newRole})
+		return err
+	})
+}
+
+func (f *organizationFixture) remove(t *testing.T, role string, actorID uuid.UUID) error {
+	t.Helper()
+	return f.asMember(t, role, func(c *fiber.Ctx) error {
+		return f.service.RemoveMember(c, &validation.OrganizationMemberRequest{ActorID: actorID.String()})
+	})
+}
+
+func (f *organizationFixture) role(t *testing.T, actorID uuid.UUID) string {
+	t.Helper()
+	member, err := f.orgs.FindMember(context.Background(), nil, f.organizationID, actorID)
+	require.NoError(t, err)
+	return member.Role
+}
+
+func TestOrganizationInvitations(t *testing.T) {
+	t.Run("invite by identifier", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+		assert.Equal(t, f.outsiderID, invitation.InviteeID)
+		assert.Equal(t, "bob@finternet", invitation.InviteeIdentifier)
+		assert.Equal(t, "acme@finternet", invitation.OrganizationIdentifier)
+		assert.Equal(t, constants.OrganizationInvitationStatusPending, invitation.Status(time.Now()))
+
+		err := callAs(t, f.organizationID, func(c *fiber.Ctx) error {
+			_, err := f.service.Invite(c, &validation.CreateOrganizationInvitationRequest{Identifier: "bob@finternet", Role: constants.OrganizationRoleViewer})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusConflict)
+	})
+
+	t.Run("admins cannot invite admins", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		err := f.asMember(t, constants.OrganizationRoleAdmin, func(c *fiber.Ctx) error {
+			_, err := f.service.Invite(c, &validation.CreateOrganizationInvitationRequest{Identifier: "bob@finternet", Role: constants.OrganizationRoleAdmin})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusForbidden)
+		assert.Empty(t, f.orgs.invitations)
+	})
+
+	t.Run("accept makes the invitee a member once", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+
+		member, err := f.accept(t, f.outsiderID, invitation)
+		require.NoError(t, err)
+		assert.Equal(t, f.organizationID, member.OrganizationID)
+		assert.Equal(t, constants.OrganizationRoleOperator, member.Role)
+		assert.Equal(t, &invitation.InvitationID, member.InvitationID)
+		assert.Equal(t, constants.OrganizationRoleOperator, f.role(t, f.outsiderID))
+		assert.Equal(t, constants.OrganizationInvitationStatusAccepted, invitation.Status(time.Now()))
+
+		_, err = f.accept(t, f.outsiderID, invitation)
+		assertFiberError(t, err, fiber.StatusConflict)
+	})
+
+	t.Run("only the invitee can accept", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+
+		_, err := f.accept(t, f.members[constants.OrganizationRoleViewer], invitation)
+		assertFiberError(t, err, fiber.StatusNotFound)
+		assert.Nil(t, invitation.AcceptedAt)
+	})
+
+	t.Run("expired invitation", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+		invitation.ExpiresAt = time.Now().Add(-time.Second)
+
+		_, err := f.accept(t, f.outsiderID, invitation)
+		assertFiberError(t, err, fiber.StatusConflict)
+	})
+
+	t.Run("declined invitation cannot be accepted", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+
+		err := callAs(t, f.outsiderID, func(c *fiber.Ctx) error {
+			_, err := f.service.DeclineInvitation(c, &validation.OrganizationInvitationRequest{InvitationID: invitation.InvitationID.String()})
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, constants.OrganizationInvitationStatusDeclined, invitation.Status(time.Now()))
+
+		_, err = f.accept(t, f.outsiderID, invitation)
+		assertFiberError(t, err, fiber.StatusConflict)
+		assert.Len(t, f.orgs.members, 4)
+	})
+
+	t.Run("revoked invitation cannot be accepted", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+
+		require.NoError(t, f.revoke(t, constants.OrganizationRoleAdmin, invitation))
+		assert.Equal(t, constants.OrganizationInvitationStatusRevoked, invitation.Status(time.Now()))
+
+		_, err := f.accept(t, f.outsiderID, invitation)
+		assertFiberError(t, err, fiber.StatusConflict)
+		assertFiberError(t, f.revoke(t, constants.OrganizationRoleAdmin, invitation), fiber.StatusConflict)
+	})
+
+	t.Run("admins cannot revoke admin invitations", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleAdmin)
+
+		assertFiberError(t, f.revoke(t, constants.OrganizationRoleAdmin, invitation), fiber.StatusForbidden)
+		require.NoError(t, f.revoke(t, constants.OrganizationRoleOwner, invitation))
+	})
+
+	t.Run("invitations of other organizations are not found", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		invitation := f.invite(t, constants.OrganizationRoleOperator)
+		invitation.OrganizationID = uuid.New()
+
+		assertFiberError(t, f.revoke(t, constants.OrganizationRoleOwner, invitation), fiber.StatusNotFound)
+		assert.Nil(t, invitation.RevokedAt)
+	})
+}
+
+func TestOrganizationMemberRoles(t *testing.T) {
+	t.Run("admins move members below their own role", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		viewerID := f.members[constants.OrganizationRoleViewer]
+
+		require.NoError(t, f.updateRole(t, constants.OrganizationRoleAdmin, viewerID, constants.OrganizationRoleOperator))
+		assert.Equal(t, constants.OrganizationRoleOperator, f.role(t, viewerID))
+
+		assertFiberError(t, f.updateRole(t, constants.OrganizationRoleAdmin, viewerID, constants.OrganizationRoleAdmin), fiber.StatusForbidden)
+		assertFiberError(t, f.updateRole(t, constants.OrganizationRoleAdmin, f.members[constants.OrganizationRoleOwner], constants.OrganizationRoleViewer), fiber.StatusForbidden)
+		assert.Equal(t, constants.OrganizationRoleOperator, f.role(t, viewerID))
+	})
+
+	t.Run("operators cannot change roles", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		err := f.updateRole(t, constants.OrganizationRoleOperator, f.members[constants.OrganizationRoleViewer], constants.OrganizationRoleOperator)
+		assertFiberError(t, err, fiber.StatusForbidden)
+	})
+
+	t.Run("the last owner cannot be demoted", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		ownerID := f.members[constants.OrganizationRoleOwner]
+
+		assertFiberError(t, f.updateRole(t, constants.OrganizationRoleOwner, ownerID, constants.OrganizationRoleAdmin), fiber.StatusConflict)
+		err := callAs(t, f.organizationID, func(c *fiber.Ctx) error {
+			_, err := f.service.UpdateMemberRole(c, &validation.UpdateOrganizationMemberRequest{ActorID: ownerID.String(), Role: constants.OrganizationRoleAdmin})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusConflict)
+		assert.Equal(t, constants.OrganizationRoleOwner, f.role(t, ownerID))
+
+		// Once another member is an owner the first one can step down
+		adminID := f.members[constants.OrganizationRoleAdmin]
+		require.NoError(t, f.updateRole(t, constants.OrganizationRoleOwner, adminID, constants.OrganizationRoleOwner))
+		require.NoError(t, f.updateRole(t, constants.OrganizationRoleOwner, ownerID, constants.OrganizationRoleAdmin))
+		assert.Equal(t, constants.OrganizationRoleAdmin, f.role(t, ownerID))
+	})
+
+	t.Run("the last owner cannot be removed or leave", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		ownerID := f.members[constants.OrganizationRoleOwner]
+
+		assertFiberError(t, f.remove(t, constants.OrganizationRoleOwner, ownerID), fiber.StatusConflict)
+		err := callAs(t, ownerID, func(c *fiber.Ctx) error {
+			return f.service.LeaveOrganization(c, &validation.OrganizationRequest{OrganizationID: f.organizationID.String()})
+		})
+		assertFiberError(t, err, fiber.StatusConflict)
+		assert.Equal(t, constants.OrganizationRoleOwner, f.role(t, ownerID))
+	})
+
+	t.Run("remove members", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		assertFiberError(t, f.remove(t, constants.OrganizationRoleAdmin, f.members[constants.OrganizationRoleAdmin]), fiber.StatusForbidden)
+		require.NoError(t, f.remove(t, constants.OrganizationRoleAdmin, f.members[constants.OrganizationRoleOperator]))
+		assert.Len(t, f.orgs.members, 3)
+		assertFiberError(t, f.remove(t, constants.OrganizationRoleAdmin, f.members[constants.OrganizationRoleOperator]), fiber.StatusNotFound)
+	})
+
+	t.Run("only Business actors have members", func(t *testing.T) {
+		f := newOrganizationFixture(t)
+		err := callAs(t, f.outsiderID, func(c *fiber.Ctx) error {
+			_, err := f.service.ListMembers(c)
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusForbidden)
+	})
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/model"
+	"app/src/queue"
+	"app/src/repository"
+	"app/src/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakePresentations holds one presentation request and records callback status updates
+type fakePresentations struct {
+	repository.PresentationRequestRepository
+	request  *model.PresentationRequest
+	statuses []string
+}
+
+func (f *fakePresentations) FindByID(_ context.Context, _ *gorm.DB, requestID uuid.UUID) (*model.PresentationRequest, error) {
+	if f.request == nil || f.request.RequestID != requestID {
+		return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrPresentationRequestNotFound)
+	}
+	return f.request, nil
+}
+
+func (f *fakePresentations) UpdateCallbackStatus(_ context.Context, _ *gorm.DB, _ uuid.UUID, status string) error {
+	f.statuses = append(f.statuses, status)
+	return nil
+}
+
+func TestPresentationCallbackHandler(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	completedAt := time.Now()
+	secret := "callback-secret"
+	request := &model.PresentationRequest{
+		RequestID:      uuid.New(),
+		ActorID:        uuid.New(),
+		CallbackURL:    &server.URL,
+		CallbackSecret: &secret,
+		Status:         constants.PresentationStatusVerified,
+		CompletedAt:    &completedAt,
+	}
+	job := &model.Job{Type: constants.JobTypePresentationCallback, ActorID: request.ActorID, ResourceID: &request.RequestID, Attempts: 1, MaxAttempts: 5}
+
+	t.Run("internal callback address is refused without retry", func(t *testing.T) {
+		presentations := &fakePresentations{request: request}
+		_, err := service.NewPresentationCallbackHandler(logrus.New(), nil, presentations).Handle(context.Background(), job)
+		require.Error(t, err)
+		assert.True(t, queue.IsPermanent(err))
+		assert.Equal(t, []string{constants.CallbackStatusFailed}, presentations.statuses)
+		assert.Zero(t, received)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		presentations := &fakePresentations{}
+		_, err := service.NewPresentationCallbackHandler(logrus.New(), nil, presentations).Handle(context.Background(), job)
+		assert.True(t, queue.IsPermanent(err))
+		assert.Empty(t, presentations.statuses)
+	})
+
+	t.Run("job without request", func(t *testing.T) {
+		presentations := &fakePresentations{request: request}
+		_, err := service.NewPresentationCallbackHandler(logrus.New(), nil, presentations).Handle(context.Background(), &model.Job{ActorID: request.ActorID})
+		assert.True(t, queue.IsPermanent(err))
+	})
+}
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/keys"
+	"app/src/service"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registrationBody = `{"id":"api.actor.create","ver":"5.0.0","request":{"email":"actor@example.com","firstName":"John","proof":"ignored"}}`
+
+func TestRegistrationPayloadHash(t *testing.T) {
+	hash, err := service.RegistrationPayloadHash([]byte(registrationBody))
+	require.NoError(t, err)
+
+	t.Run("ignores formatting, member order, envelope and proof", func(t *testing.T) {
+		reordered := `{"request": {"proof": "other", "firstName": "John", "email": "actor@example.com"}, "id": "api.actor.other"}`
+		other, err := service.RegistrationPayloadHash([]byte(reordered))
+		require.NoError(t, err)
+		assert.Equal(t, hash, other)
+	})
+
+	t.Run("changes with the payload", func(t *testing.T) {
+		changed := `{"request":{"email":"mallory@example.com","firstName":"John"}}`
+		other, err := service.RegistrationPayloadHash([]byte(changed))
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, other)
+	})
+
+	t.Run("missing request", func(t *testing.T) {
+		_, err := service.RegistrationPayloadHash([]byte(`{"id":"api.actor.create"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyRegistrationProof(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	secp256k1Key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	payloadHash, err := service.RegistrationPayloadHash([]byte(registrationBody))
+	require.NoError(t, err)
+	now := time.Now()
+
+	claims := func(issuedAt time.Time, nonce, payloadHash string) service.RegistrationProofClaims {
+		return service.RegistrationProofClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Audience: jwt.ClaimStrings{rotationAudience},
+				IssuedAt: jwt.NewNumericDate(issuedAt),
+			},
+			Nonce:       nonce,
+			PayloadHash: payloadHash,
+		}
+	}
+
+	for name, private := range map[string]crypto.PrivateKey{
+		"RSA":       rsaKey,
+		"P-256":     p256Key,
+		"secp256k1": secp256k1Key,
+		"Ed25519":   ed25519Key,
+	} {
+		t.Run(name, func(t *testing.T) {
+			signer, err := keys.NewSigner("", private)
+			require.NoError(t, err)
+
+			// Verify against the key as registration stores it
+			encoded, err := keys.MarshalPublicKeyPEM(signer.PublicKey())
+			require.NoError(t, err)
+			masterKey, err := keys.NormalizePublicKey(encoded)
+			require.NoError(t, err)
+
+			proof := func(t *testing.T, typ string, claims service.RegistrationProofClaims) string {
+				t.Helper()
+				compact, err := signer.Sign(claims, map[string]interface{}{"typ": typ})
+				require.NoError(t, err)
+				return compact
+			}
+
+			t.Run("valid proof", func(t *testing.T) {
+				compact := proof(t, constants.RegistrationProofJWTType, claims(now, "nonce-1", payloadHash))
+				nonce, err := service.VerifyRegistrationProof(masterKey.Key, compact, rotationAudience, payloadHash, now)
+				require.NoError(t, err)
+				assert.Equal(t, "nonce-1", nonce)
+			})
+
+			t.Run("different payload", func(t *testing.T) {
+				compact := proof(t, constants.RegistrationProofJWTType, claims(now, "nonce-1", "other"))
+				_, err := service.VerifyRegistrationProof(masterKey.Key, compact, rotationAudience, payloadHash, now)
+				assert.Error(t, err)
+			})
+
+			t.Run("missing nonce", func(t *testing.T) {
+				compact := proof(t, constants.RegistrationProofJWTType, claims(now, "", payloadHash))
+				_, err := service.VerifyRegistrationProof(masterKey.Key, compact, rotationAudience, payloadHash, now)
+				assert.Error(t, err)
+			})
+
+			t.Run("stale proof", func(t *testing.T) {
+				compact := proof(t, constants.RegistrationProofJWTType, claims(now.Add(-time.Hour), "nonce-1", payloadHash))
+				_, err := service.VerifyRegistrationProof(masterKey.Key, compact, rotationAudience, payloadHash, now)
+				assert.Error(t, err)
+			})
+
+			t.Run("wrong typ", func(t *testing.T) {
+				compact := proof(t, constants.KeyRotationProofJWTType, claims(now, "nonce-1", payloadHash))
+				_, err := service.VerifyRegistrationProof(masterKey.Key, compact, rotationAudience, payloadHash, now)
+				assert.Error(t, err)
+			})
+		})
+	}
+
+	t.Run("not signed by the master key", func(t *testing.T) {
+		signer, err := keys.NewSigner("", p256Key)
+		require.NoError(t, err)
+		compact, err := signer.Sign(claims(now, "nonce-1", payloadHash), map[string]interface{}{"typ": constants.RegistrationProofJWTType})
+		require.NoError(t, err)
+		_, err = service.VerifyRegistrationProof(ed25519Key.Public(), compact, rotationAudience, payloadHash, now)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,372 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"app/src/adapter"
+	"app/src/constants"
+	"app/src/model"
+	"app/src/repository"
+	"app/src/service"
+	"app/src/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeShareGrants keeps share grants in memory
+type fakeShareGrants struct {
+	repository.ShareGrantRepository
+	grants []*model.ShareGrant
+}
+
+func (f *fakeShareGrants) Create(_ context.Context, _ *gorm.DB, grant *model.ShareGrant) error {
+	grant.GrantID = uuid.New()
+	for i := range grant.Tokens {
+		grant.Tokens[i].GrantID = grant.GrantID
+	}
+	f.grants = append(f.grants, grant)
+	return nil
+}
+
+func (f *fakeShareGrants) find(match func(*model.ShareGrant) bool) (*model.ShareGrant, error) {
+	for _, grant := range f.grants {
+		if match(grant) {
+			return grant, nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrShareGrantNotFound)
+}
+
+func (f *fakeShareGrants) LockByIDForOwner(_ context.Context, _ *gorm.DB, grantID, ownerID uuid.UUID) (*model.ShareGrant, error) {
+	return f.find(func(grant *model.ShareGrant) bool {
+		return grant.GrantID == grantID && grant.OwnerID == ownerID
+	})
+}
+
+func (f *fakeShareGrants) FindActiveForGrantee(_ context.Context, _ *gorm.DB, grantID, granteeID uuid.UUID, at time.Time) (*model.ShareGrant, error) {
+	return f.find(func(grant *model.ShareGrant) bool {
+		return grant.GrantID == grantID && grant.GranteeID == granteeID && grant.RevokedAt == nil && grant.ExpiresAt.After(at)
+	})
+}
+
+func (f *fakeShareGrants) Revoke(_ context.Context, _ *gorm.DB, grantID uuid.UUID, revokedAt time.Time) error {
+	for _, grant := range f.grants {
+		if grant.GrantID == grantID && grant.RevokedAt == nil {
+			grant.RevokedAt = &revokedAt
+			return nil
+		}
+	}
+	return fiber.NewError(fiber.StatusConflict, constants.ErrShareGrantAlreadyRevoked)
+}
+
+// fakeShareAccessLog records the accesses to shared data
+type fakeShareAccessLog struct {
+	repository.ShareAccessLogRepository
+	entries []model.ShareAccessLog
+}
+
+func (f *fakeShareAccessLog) Create(_ context.Context, _ *gorm.DB, entry *model.ShareAccessLog) error {
+	f.entries = append(f.entries, *entry)
+	return nil
+}
+
+// fakeTokens knows the credentials of all accounts
+type fakeTokens struct {
+	repository.CredentialsRepository
+	tokens []model.Token
+}
+
+func (f *fakeTokens) FindByIDForAccount(_ context.Context, _ *gorm.DB, tokenID, accountID uuid.UUID) (*model.Token, error) {
+	for i := range f.tokens {
+		if f.tokens[i].TokenID == tokenID && f.tokens[i].AccountID == accountID {
+			return &f.tokens[i], nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrCredentialNotFound)
+}
+
+func (f *fakeTokens) FindByIDsForAccount(_ context.Context, _ *gorm.DB, tokenIDs []uuid.UUID, accountID uuid.UUID) ([]model.Token, error) {
+	var tokens []model.Token
+	for _, token := range f.tokens {
+		if token.AccountID == accountID && slices.Contains(tokenIDs, token.TokenID) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// fakeDocuments knows the documents of all accounts
+type fakeDocuments struct {
+	repository.DocumentRepository
+	documents []model.Document
+}
+
+func (f *fakeDocuments) FindByIDForAccount(_ context.Context, _ *gorm.DB, documentID, accountID uuid.UUID) (*model.Document, error) {
+	for i := range f.documents {
+		if f.documents[i].DocumentID == documentID && f.documents[i].AccountID == accountID {
+			return &f.documents[i], nil
+		}
+	}
+	return nil, fiber.NewError(fiber.StatusNotFound, constants.ErrDocumentNotFound)
+}
+
+// shareFixture is an owner sharing one of two credentials, each with its own document, with a
+// grantee. A third actor knows the grant ID but was not granted anything.
+type shareFixture struct {
+	service  service.ShareService
+	grants   *fakeShareGrants
+	log      *fakeShareAccessLog
+	ownerID  uuid.UUID
+	grantee  uuid.UUID
+	stranger uuid.UUID
+	shared   model.Token
+	private  model.Token
+	grant    *model.ShareGrant
+}
+
+func newShareFixture(t *testing.T) *shareFixture {
+	t.Helper()
+	f := &shareFixture{
+		grants:   &fakeShareGrants{},
+		log:      &fakeShareAccessLog{},
+		ownerID:  uuid.New(),
+		grantee:  uuid.New(),
+		stranger: uuid.New(),
+	}
+	credential := func(storagePath string) (model.Token, model.Document) {
+		document := model.Document{DocumentID: uuid.New(), AccountID: f.ownerID, StoragePath: storagePath}
+		token := model.Token{TokenID: uuid.New(), AccountID: f.ownerID}
+		token.Documents = []model.TokenDocument{{TokenID: token.TokenID, DocumentID: document.DocumentID}}
+		return token, document
+	}
+	var sharedDocument, privateDocument model.Document
+	f.shared, sharedDocument = credential("owner/passport.pdf")
+	f.private, privateDocument = credential("owner/payslip.pdf")
+
+	identifiers := newFakeIdentifiers(
+		model.Identifier{Identifier: "alice@finternet", EntityType: constants.EntityTypeActor, EntityID: f.ownerID, IsPrimary: true},
+		model.Identifier{Identifier: "acme-bank@finternet", EntityType: constants.EntityTypeActor, EntityID: f.grantee, IsPrimary: true},
+	)
+	f.service = service.NewShareService(logrus.New(), newTransactionDB(t), validation.NewValidator(), f.grants, f.log,
+		&fakeTokens{tokens: []model.Token{f.shared, f.private}},
+		&fakeDocuments{documents: []model.Document{sharedDocument, privateDocument}},
+		identifiers, fakeNamespaces{}, adapter.NewLazyStorage(adapter.NewStorageFactory(&fakeStorage{})))
+
+	err := callAs(t, f.ownerID, func(c *fiber.Ctx) error {
+		var err error
+		f.grant, err = f.service.CreateGrant(c, &validation.CreateShareGrantRequest{
+			Grantee:       "Acme-Bank@finternet",
+			CredentialIDs: []string{f.shared.TokenID.String()},
+			ExpiresAt:     time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+			Purpose:       "Mortgage application KYC",
+		})
+		return err
+	})
+	require.NoError(t, err)
+	return f
+}
+
+func (f *shareFixture) list(t *testing.T, actorID uuid.UUID) ([]model.Token, error) {
+	t.Helper()
+	var tokens []model.Token
+	err := callAs(t, actorID, func(c *fiber.Ctx) error {
+		var err error
+		_, tokens, err = f.service.ListSharedCredentials(c, &validation.ShareGrantIDRequest{GrantID: f.grant.GrantID.String()})
+		return err
+	})
+	return tokens, err
+}
+
+func (f *shareFixture) credential(t *testing.T, actorID uuid.UUID, token model.Token) error {
+	t.Helper()
+	return callAs(t, actorID, func(c *fiber.Ctx) error {
+		_, err := f.service.GetSharedCredential(c, &validation.SharedCredentialRequest{
+			GrantID: f.grant.GrantID.String(), CredentialID: token.TokenID.String(),
+		})
+		return err
+	})
+}
+
+func (f *shareFixture) document(t *testing.T, actorID uuid.UUID, token model.Token, documentID uuid.UUID) (*service.SharedDocument, error) {
+	t.Helper()
+	var document *service.SharedDocument
+	err := callAs(t, actorID, func(c *fiber.Ctx) error {
+		var err error
+		document, err = f.service.GetSharedDocument(c, &validation.SharedDocumentRequest{
+			SharedCredentialRequest: validation.SharedCredentialRequest{GrantID: f.grant.GrantID.String(), CredentialID: token.TokenID.String()},
+			DocumentID:              documentID.String(),
+		})
+		return err
+	})
+	return document, err
+}
+
+// assertNoAccess checks that no read of the grant succeeds for the actor, and none is logged
+func (f *shareFixture) assertNoAccess(t *testing.T, actorID uuid.UUID) {
+	t.Helper()
+	_, err := f.list(t, actorID)
+	assertFiberError(t, err, fiber.StatusNotFound)
+	assertFiberError(t, f.credential(t, actorID, f.shared), fiber.StatusNotFound)
+	_, err = f.document(t, actorID, f.shared, f.shared.Documents[0].DocumentID)
+	assertFiberError(t, err, fiber.StatusNotFound)
+	assert.Empty(t, f.log.entries)
+}
+
+func TestShareGrantReads(t *testing.T) {
+	t.Run("every read of the grantee is logged", func(t *testing.T) {
+		f := newShareFixture(t)
+		documentID := f.shared.Documents[0].DocumentID
+
+		tokens, err := f.list(t, f.grantee)
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+		assert.Equal(t, f.shared.TokenID, tokens[0].TokenID)
+
+		require.NoError(t, f.credential(t, f.grantee, f.shared))
+
+		document, err := f.document(t, f.grantee, f.shared, documentID)
+		require.NoError(t, err)
+		assert.Equal(t, "https://storage.example.com/owner/passport.pdf", document.DownloadURL)
+
+		require.Len(t, f.log.entries, 3)
+		for _, entry := range f.log.entries {
+			assert.Equal(t, f.grant.GrantID, entry.GrantID)
+			assert.Equal(t, f.ownerID, entry.OwnerID)
+			assert.Equal(t, f.grantee, entry.GranteeID)
+		}
+		assert.Equal(t, constants.ShareAccessList, f.log.entries[0].Action)
+		assert.Nil(t, f.log.entries[0].TokenID)
+		assert.Equal(t, constants.ShareAccessCredential, f.log.entries[1].Action)
+		assert.Equal(t, &f.shared.TokenID, f.log.entries[1].TokenID)
+		assert.Equal(t, constants.ShareAccessDocument, f.log.entries[2].Action)
+		assert.Equal(t, &f.shared.TokenID, f.log.entries[2].TokenID)
+		assert.Equal(t, &documentID, f.log.entries[2].DocumentID)
+	})
+
+	t.Run("credentials and documents outside the grant", func(t *testing.T) {
+		f := newShareFixture(t)
+
+		assertFiberError(t, f.credential(t, f.grantee, f.private), fiber.StatusNotFound)
+
+		// A document of another credential cannot be reached through the shared one, nor directly
+		_, err := f.document(t, f.grantee, f.shared, f.private.Documents[0].DocumentID)
+		assertFiberError(t, err, fiber.StatusNotFound)
+		_, err = f.document(t, f.grantee, f.private, f.private.Documents[0].DocumentID)
+		assertFiberError(t, err, fiber.StatusNotFound)
+
+		assert.Empty(t, f.log.entries)
+	})
+
+	t.Run("actors other than the grantee", func(t *testing.T) {
+		f := newShareFixture(t)
+		f.assertNoAccess(t, f.stranger)
+		f.assertNoAccess(t, f.ownerID)
+	})
+
+	t.Run("expired grant", func(t *testing.T) {
+		f := newShareFixture(t)
+		f.grant.ExpiresAt = time.Now().Add(-time.Second)
+		f.assertNoAccess(t, f.grantee)
+	})
+
+	t.Run("revoked grant", func(t *testing.T) {
+		f := newShareFixture(t)
+		revoke := func() error {
+			return callAs(t, f.ownerID, func(c *fiber.Ctx) error {
+				_, err := f.service.RevokeGrant(c, &validation.ShareGrantIDRequest{GrantID: f.grant.GrantID.String()})
+				return err
+			})
+		}
+
+		require.NoError(t, revoke())
+		f.assertNoAccess(t, f.grantee)
+		assertFiberError(t, revoke(), fiber.StatusConflict)
+	})
+
+	t.Run("only the owner revokes", func(t *testing.T) {
+		f := newShareFixture(t)
+		err := callAs(t, f.grantee, func(c *fiber.Ctx) error {
+			_, err := f.service.RevokeGrant(c, &validation.ShareGrantIDRequest{GrantID: f.grant.GrantID.String()})
+			return err
+		})
+		assertFiberError(t, err, fiber.StatusNotFound)
+		assert.Nil(t, f.grant.RevokedAt)
+	})
+}
+
+func TestCreateShareGrant(t *testing.T) {
+	f := newShareFixture(t)
+	assert.Equal(t, f.grantee, f.grant.GranteeID)
+	assert.Equal(t, "acme-bank@finternet", f.grant.GranteeIdentifier)
+	assert.Equal(t, "alice@finternet", f.grant.OwnerIdentifier)
+
+	create := func(actorID uuid.UUID, req validation.CreateShareGrantRequest) error {
+		if req.ExpiresAt == "" {
+			req.ExpiresAt = time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		}
+		return callAs(t, actorID, func(c *fiber.Ctx) error {
+			_, err := f.service.CreateGrant(c, &req)
+			return err
+		})
+	}
+
+	tests := []struct {
+		name    string
+		actorID uuid.UUID
+		req     validation.CreateShareGrantRequest
+		code    int
+	}{
+		{
+			name:    "credentials of another account",
+			actorID: f.stranger,
+			req:     validation.CreateShareGrantRequest{Grantee: "acme-bank@finternet", CredentialIDs: []string{f.shared.TokenID.String()}},
+			code:    fiber.StatusNotFound,
+		},
+		{
+			name:    "with themselves",
+			actorID: f.ownerID,
+			req:     validation.CreateShareGrantRequest{Grantee: "alice@finternet", CredentialIDs: []string{f.shared.TokenID.String()}},
+			code:    fiber.StatusBadRequest,
+		},
+		{
+			name:    "unknown grantee",
+			actorID: f.ownerID,
+			req:     validation.CreateShareGrantRequest{Grantee: "nobody@finternet", CredentialIDs: []string{f.shared.TokenID.String()}},
+			code:    fiber.StatusNotFound,
+		},
+		{
+			name:    "beyond the maximum duration",
+			actorID: f.ownerID,
+			req: validation.CreateShareGrantRequest{
+				Grantee:       "acme-bank@finternet",
+				CredentialIDs: []string{f.shared.TokenID.String()},
+				ExpiresAt:     time.Now().AddDate(0, 0, constants.MaxShareDuration+1).UTC().Format(time.RFC3339),
+			},
+			code: fiber.StatusBadRequest,
+		},
+		{
+			name:    "already expired",
+			actorID: f.ownerID,
+			req: validation.CreateShareGrantRequest{
+				Grantee:       "acme-bank@finternet",
+				CredentialIDs: []string{f.shared.TokenID.String()},
+				ExpiresAt:     time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+			},
+			code: fiber.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertFiberError(t, create(tt.actorID, tt.req), tt.code)
+			assert.Len(t, f.grants.grants, 1)
+		})
+	}
+}
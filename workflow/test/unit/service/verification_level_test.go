@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"app/src/constants"
+	"app/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultVerificationRules(t *testing.T) {
+	rules := service.DefaultVerificationRules
+	require.NoError(t, rules.Validate())
+
+	tests := []struct {
+		name       string
+		entityType string
+		verified   []string
+		want       string
+	}{
+		{"no credentials", constants.EntityTypeIndividual, nil, constants.VerificationLevelUnverified},
+		{"government ID only", constants.EntityTypeIndividual, []string{"GovernmentID"}, constants.VerificationLevelUnverified},
+		{"government ID and address", constants.EntityTypeIndividual, []string{"ProofOfAddress", "GovernmentID"}, constants.VerificationLevelVerified},
+		{"business registration", constants.EntityTypeBusiness, []string{"BusinessRegistration"}, constants.VerificationLevelVerified},
+		{"institution attested business", constants.EntityTypeBusiness, []string{"BusinessRegistration", "InstitutionAttestation"}, constants.VerificationLevelInstitutionVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rules.Evaluate(tt.entityType, "US", tt.verified))
+		})
+	}
+}
+
+func TestCountryRulesOverrideDefaults(t *testing.T) {
+	rules := service.VerificationRuleSet{
+		{EntityType: constants.EntityTypeIndividual, Level: constants.VerificationLevelVerified, RequiredTypes: []string{"GovernmentID", "ProofOfAddress"}},
+		{EntityType: constants.EntityTypeIndividual, Country: "IN", Level: constants.VerificationLevelVerified, RequiredTypes: []string{"Aadhaar"}},
+	}
+
+	assert.Equal(t, constants.VerificationLevelVerified, rules.Evaluate(constants.EntityTypeIndividual, "in", []string{"Aadhaar"}))
+	assert.Equal(t, constants.VerificationLevelUnverified, rules.Evaluate(constants.EntityTypeIndividual, "IN", []string{"GovernmentID", "ProofOfAddress"}))
+	assert.Equal(t, constants.VerificationLevelVerified, rules.Evaluate(constants.EntityTypeIndividual, "DE", []string{"GovernmentID", "ProofOfAddress"}))
+}
+
+func TestLoadVerificationRules(t *testing.T) {
+	t.Run("defaults without a file", func(t *testing.T) {
+		rules, err := service.LoadVerificationRules("")
+		require.NoError(t, err)
+		assert.Equal(t, service.DefaultVerificationRules, rules)
+	})
+
+	t.Run("rejects unknown level", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		content := `[{"entityType":"Individual","level":"Tier9","requiredTypes":["GovernmentID"]}]`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		_, err := service.LoadVerificationRules(path)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"testing"
+
+	"app/src/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"sorts keys", `{"b":1,"a":{"d":true,"c":null}}`, `{"a":{"c":null,"d":true},"b":1}`},
+		{"drops whitespace", "{ \"a\" : [ 1 , 2 ] }", `{"a":[1,2]}`},
+		{"keeps html characters", `{"a":"<b>&"}`, `{"a":"<b>&"}`},
+		{"normalizes numbers", `{"a":1.0,"b":1e3,"c":-0.5}`, `{"a":1,"b":1000,"c":-0.5}`},
+		{"keeps array order", `["b","a"]`, `["b","a"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := utils.CanonicalJSON([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := utils.CanonicalJSON([]byte(`{"a":`))
+		assert.Error(t, err)
+	})
+}
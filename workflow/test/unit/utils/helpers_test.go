@@ -0,0 +1,21 @@
+package utils_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"app/src/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", utils.Truncate("short", 10))
+	assert.Equal(t, "exact", utils.Truncate("exact", 5))
+	assert.Equal(t, "trunc", utils.Truncate("truncated", 5))
+
+	// "é" is two bytes, so cutting after the first byte drops it entirely
+	truncated := utils.Truncate("café", 4)
+	assert.Equal(t, "caf", truncated)
+	assert.True(t, utf8.ValidString(truncated))
+}
@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"app/src/constants"
+	"app/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 10, 23, 6, 25, 25, 191000000, time.UTC)
+	id := uuid.New()
+
+	cursor, err := utils.DecodeCursor(utils.EncodeCursor(createdAt, id))
+	require.NoError(t, err)
+	require.NotNil(t, cursor)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, id, cursor.ID)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	t.Run("empty cursor starts from the first page", func(t *testing.T) {
+		cursor, err := utils.DecodeCursor("")
+		require.NoError(t, err)
+		assert.Nil(t, cursor)
+	})
+
+	t.Run("malformed cursor is rejected", func(t *testing.T) {
+		for _, value := range []string{"not-base64!", "bm8tc2VwYXJhdG9y", "MjAyNXxub3QtYS11dWlk"} {
+			_, err := utils.DecodeCursor(value)
+			assert.Error(t, err, value)
+		}
+	})
+}
+
+func TestPageLimit(t *testing.T) {
+	assert.Equal(t, constants.DefaultPageSize, utils.PageLimit(0))
+	assert.Equal(t, 5, utils.PageLimit(5))
+	assert.Equal(t, constants.MaxPageSize, utils.PageLimit(constants.MaxPageSize+1))
+}
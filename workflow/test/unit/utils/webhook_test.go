@@ -0,0 +1,31 @@
+package utils_test
+
+import (
+	"testing"
+
+	"app/src/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"type":"document.uploaded"}`)
+
+	t.Run("signs the timestamp and body with the secret", func(t *testing.T) {
+		// printf '%s' '1700000000.{"type":"document.uploaded"}' | openssl dgst -sha256 -hmac whsec_test
+		expected := "sha256=436d1470e55d8370c07ace0fe6e4d00da7d4f33ec8ecd4ca0c4415452de4fb02"
+		assert.Equal(t, expected, utils.WebhookSignature("whsec_test", 1700000000, body))
+	})
+
+	t.Run("timestamp is covered by the signature", func(t *testing.T) {
+		assert.NotEqual(t,
+			utils.WebhookSignature("whsec_test", 1700000000, body),
+			utils.WebhookSignature("whsec_test", 1700000001, body))
+	})
+
+	t.Run("secret is covered by the signature", func(t *testing.T) {
+		assert.NotEqual(t,
+			utils.WebhookSignature("whsec_test", 1700000000, body),
+			utils.WebhookSignature("whsec_other", 1700000000, body))
+	})
+}